@@ -14,6 +14,11 @@ type (
 		App     `yaml:"app"`
 		Storage `yaml:"storage"`
 		GRPC    `yaml:"grpc"`
+		TLS     `yaml:"tls"`
+
+		// MigratePlaintext однократная миграция записей, сохранённых до включения сквозного шифрования
+		// (задаётся только флагом запуска --migrate-plaintext).
+		MigratePlaintext bool `yaml:"-"`
 	}
 
 	// App информация о приложении.
@@ -32,6 +37,19 @@ type (
 		Address string `env-required:"true" yaml:"address" env:"GRPC_ADDRESS"`
 		Port    string `env-required:"true" yaml:"port"    env:"GRPC_PORT"`
 	}
+
+	// TLS настройки защищённого соединения.
+	//
+	// CAFile - центр сертификации для проверки сервера (пусто - системные корневые сертификаты),
+	// CertFile/KeyFile - сертификат клиента для взаимного TLS. Передача без шифрования
+	// возможна только при явно включённом Insecure.
+	TLS struct {
+		CAFile     string `yaml:"ca_file"     env:"TLS_CA_FILE"`
+		CertFile   string `yaml:"cert_file"   env:"TLS_CERT_FILE"`
+		KeyFile    string `yaml:"key_file"    env:"TLS_KEY_FILE"`
+		ServerName string `yaml:"server_name" env:"TLS_SERVER_NAME"`
+		Insecure   bool   `yaml:"insecure"    env:"TLS_INSECURE"`
+	}
 )
 
 // New создаёт объект Config.
@@ -39,7 +57,9 @@ func New() (*Config, error) {
 	cfg := &Config{}
 
 	var cfgFile string
+	var migrate bool
 	flag.StringVarP(&cfgFile, "config", "c", "", "path to config file (*.yaml)")
+	flag.BoolVar(&migrate, "migrate-plaintext", false, "after login, encrypt records saved before end-to-end encryption")
 	flag.Parse()
 
 	err := cleanenv.ReadConfig(cfgFile, cfg)
@@ -52,5 +72,7 @@ func New() (*Config, error) {
 		return nil, err
 	}
 
+	cfg.MigratePlaintext = migrate
+
 	return cfg, nil
 }
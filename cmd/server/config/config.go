@@ -2,6 +2,7 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -9,13 +10,26 @@ import (
 	flag "github.com/spf13/pflag"
 )
 
+// Хранилища данных сервера (Storage.Driver).
+const (
+	StoragePostgres = "postgres"
+	StorageMemory   = "memory"
+)
+
 type (
 	// Config основная конфигурация.
 	Config struct {
-		App   `yaml:"app"`
-		PG    `yaml:"postgres"`
-		GRPC  `yaml:"grpc"`
-		Token `yaml:"token"`
+		App        `yaml:"app"`
+		Storage    `yaml:"storage"`
+		PG         `yaml:"postgres"`
+		GRPC       `yaml:"grpc"`
+		TLS        `yaml:"tls"`
+		Token      `yaml:"token"`
+		Password   `yaml:"password"`
+		Hash       `yaml:"hash"`
+		Lockout    `yaml:"lockout"`
+		Encryption `yaml:"encryption"`
+		Binary     `yaml:"binary"`
 	}
 
 	// App информация о приложении.
@@ -24,22 +38,111 @@ type (
 		Version string `yaml:"version" env:"APP_VERSION"`
 	}
 
+	// Storage выбор хранилища данных сервера.
+	//
+	// Driver - StoragePostgres (БД Postgres, секция postgres) или StorageMemory (в оперативной памяти,
+	// данные теряются при остановке сервера - для локальной разработки и демонстрации).
+	Storage struct {
+		Driver string `env-default:"postgres" yaml:"driver" env:"STORAGE_DRIVER"`
+	}
+
 	// PG подключение к БД Postgres.
+	//
+	// Схему БД обновляет команда migrate; AutoMigrate - применять миграции при запуске сервера.
+	// QueryTimeout - максимальное время запроса к БД, ExportTimeout - время на выгрузку всех данных
+	// пользователя; оба срока отсчитываются в пределах запроса клиента (его отмена прерывает запрос к БД).
 	PG struct {
-		MaxOpen      int    `yaml:"pool_max"      env:"PG_POOL_MAX"`
-		ConnAttempts int    `yaml:"conn_attempts" env:"PG_CONN_ATTEMPTS"`
-		URL          string `env-required:"true"  env:"PG_URL"`
+		MaxOpen       int           `yaml:"pool_max"       env:"PG_POOL_MAX"`
+		ConnAttempts  int           `yaml:"conn_attempts"  env:"PG_CONN_ATTEMPTS"`
+		URL           string        `env:"PG_URL"`
+		AutoMigrate   bool          `yaml:"auto_migrate"   env:"PG_AUTO_MIGRATE"`
+		QueryTimeout  time.Duration `env-default:"1s"  yaml:"query_timeout"  env:"PG_QUERY_TIMEOUT"`
+		ExportTimeout time.Duration `env-default:"10s" yaml:"export_timeout" env:"PG_EXPORT_TIMEOUT"`
 	}
 
 	// GRPC настройки gRPC.
+	//
+	// ShutdownTimeout - время на завершение выполняющихся запросов при остановке сервера,
+	// по его истечении оставшиеся запросы прерываются. Reflection - описание API для инструментов
+	// отладки (grpcurl, grpcui), в рабочем окружении не включается.
 	GRPC struct {
-		Port string `yaml:"port" env:"GRPC_PORT"`
+		Port            string        `yaml:"port" env:"GRPC_PORT"`
+		ShutdownTimeout time.Duration `env-default:"10s" yaml:"shutdown_timeout" env:"GRPC_SHUTDOWN_TIMEOUT"`
+		Reflection      bool          `yaml:"reflection" env:"GRPC_REFLECTION"`
+	}
+
+	// TLS настройки защищённого соединения.
+	//
+	// Без сертификата и ключа сервер не запускается, если явно не включён Insecure (передача без шифрования).
+	// Если задан ClientCAFile - включается взаимный TLS (клиенты обязаны предъявить сертификат).
+	TLS struct {
+		CertFile     string `yaml:"cert_file"      env:"TLS_CERT_FILE"`
+		KeyFile      string `yaml:"key_file"       env:"TLS_KEY_FILE"`
+		ClientCAFile string `yaml:"client_ca_file" env:"TLS_CLIENT_CA_FILE"`
+		Insecure     bool   `yaml:"insecure"       env:"TLS_INSECURE"`
 	}
 
-	// Token настройки для формирования токена.
+	// Token настройки для формирования токенов.
+	//
+	// AccessDuration - время действия access-токена, RefreshDuration - время действия сессии
+	// (refresh-токена) без обновления.
 	Token struct {
-		Key            string        `env-required:"true"                        env:"TOKEN_KEY"`
-		AccessDuration time.Duration `env-required:"true" yaml:"access_duration" env:"TOKEN_ACCESS_DURATION"`
+		Key             string        `env-required:"true"                         env:"TOKEN_KEY"`
+		AccessDuration  time.Duration `env-required:"true" yaml:"access_duration"  env:"TOKEN_ACCESS_DURATION"`
+		RefreshDuration time.Duration `env-default:"720h"  yaml:"refresh_duration" env:"TOKEN_REFRESH_DURATION"`
+	}
+
+	// Password правила смены паролей пользователей.
+	//
+	// HistorySize - количество последних паролей (включая текущий), которые нельзя использовать повторно,
+	// MaxAge - срок действия пароля, после которого вход возможен только со сменой пароля (0 - без ограничения).
+	Password struct {
+		HistorySize int           `env-default:"5" yaml:"history_size" env:"PASSWORD_HISTORY_SIZE"`
+		MaxAge      time.Duration `env-default:"0" yaml:"max_age"      env:"PASSWORD_MAX_AGE"`
+	}
+
+	// Hash параметры хеширования паролей (Argon2id).
+	//
+	// Memory - объём памяти (КиБ, не меньше 8 на каждый поток), Time - количество проходов (не меньше 1),
+	// Threads - степень параллелизма (не меньше 1).
+	// Pepper - секрет сервера, добавляемый к паролю перед хешированием (не хранится в БД; смена pepper
+	// делает недействительными все пароли). При изменении параметров хеши пересчитываются при входе.
+	Hash struct {
+		Memory  uint32 `env-default:"65536" yaml:"memory"  env:"HASH_MEMORY"`
+		Time    uint32 `env-default:"3"     yaml:"time"    env:"HASH_TIME"`
+		Threads uint8  `env-default:"4"     yaml:"threads" env:"HASH_THREADS"`
+		Pepper  string `env:"HASH_PEPPER"`
+	}
+
+	// Lockout правила блокировки входа после неудачных попыток.
+	//
+	// Неудачи считаются по логину (MaxFailures) и по адресу клиента (PeerMaxFailures, 0 - без ограничения).
+	// По достижении порога вход блокируется на Base, каждая следующая неудача удваивает срок (не более Max).
+	// Если неудач не было дольше Window, счёт начинается заново.
+	Lockout struct {
+		MaxFailures     int           `env-default:"5"   yaml:"max_failures"      env:"LOCKOUT_MAX_FAILURES"`
+		PeerMaxFailures int           `env-default:"20"  yaml:"peer_max_failures" env:"LOCKOUT_PEER_MAX_FAILURES"`
+		Base            time.Duration `env-default:"1m"  yaml:"base"              env:"LOCKOUT_BASE"`
+		Max             time.Duration `env-default:"1h"  yaml:"max"               env:"LOCKOUT_MAX"`
+		Window          time.Duration `env-default:"24h" yaml:"window"            env:"LOCKOUT_WINDOW"`
+	}
+
+	// Encryption настройки шифрования хранимых данных (если ключи не заданы - данные хранятся как есть).
+	// Применяется только к хранилищу Postgres: с хранилищем в памяти ключи задавать нельзя.
+	//
+	// Ключи шифрования ключей (KEK) задаются записями "id:base64(32 байта)": в файле KeysFile
+	// по одной на строку, либо в переменной окружения ENCRYPTION_KEYS через запятую.
+	Encryption struct {
+		KeysFile string `yaml:"keys_file" env:"ENCRYPTION_KEYS_FILE"`
+		Keys     string `env:"ENCRYPTION_KEYS"`
+	}
+
+	// Binary настройки хранения файлов.
+	//
+	// MaxSize - максимальный размер файла (в байтах, до шифрования на клиенте). Файл принимается в память
+	// сервера целиком и хранится в БД одним значением, поэтому предел не следует делать большим.
+	Binary struct {
+		MaxSize int `env-default:"1048576" yaml:"max_size" env:"BINARY_MAX_SIZE"`
 	}
 )
 
@@ -61,5 +164,30 @@ func New() (*Config, error) {
 		return nil, err
 	}
 
+	switch cfg.Storage.Driver {
+	case StoragePostgres:
+		if cfg.PG.URL == "" {
+			return nil, errors.New("config error: PG_URL is required for postgres storage")
+		}
+	case StorageMemory:
+		// шифровать нечего: данные в памяти не сохраняются
+		if cfg.Encryption.KeysFile != "" || cfg.Encryption.Keys != "" {
+			return nil, errors.New("config error: encryption keys are not supported by memory storage")
+		}
+	default:
+		return nil, fmt.Errorf("config error: unknown storage driver %q", cfg.Storage.Driver)
+	}
+
+	if cfg.Hash.Time < 1 || cfg.Hash.Threads < 1 {
+		return nil, errors.New("config error: hash time and threads must be positive")
+	}
+	if cfg.Hash.Memory < 8*uint32(cfg.Hash.Threads) {
+		return nil, errors.New("config error: hash memory must be at least 8 KiB per thread")
+	}
+
+	if cfg.Binary.MaxSize <= 0 {
+		return nil, errors.New("config error: binary max size must be positive")
+	}
+
 	return cfg, nil
 }
@@ -1,12 +1,20 @@
 package main
 
 import (
+	"errors"
+	"fmt"
 	"log"
+	"os"
+
+	flag "github.com/spf13/pflag"
 
 	"github.com/PaulYakow/gophkeeper/cmd/server/config"
 	"github.com/PaulYakow/gophkeeper/internal/server/app"
 )
 
+// buildTime время сборки сервера (RFC 3339), задаётся при сборке: -ldflags "-X main.buildTime=...".
+var buildTime string
+
 func main() {
 	// Configuration
 	cfg, err := config.New()
@@ -14,6 +22,35 @@ func main() {
 		log.Fatalf("server config error: %s", err)
 	}
 
-	srv := app.New(cfg)
-	srv.Run()
+	// Schema migrations (migrate status | up | down N)
+	if args := flag.Args(); len(args) > 0 && args[0] == "migrate" {
+		err = app.Migrate(cfg, args[1:], os.Stdout)
+		if errors.Is(err, app.ErrMigrateUsage) {
+			fmt.Fprint(os.Stderr, app.MigrateUsage)
+			os.Exit(2)
+		}
+		if err != nil {
+			log.Fatalf("migrate error: %s", err)
+		}
+		return
+	}
+
+	// Encryption of records stored before encryption at rest (encrypt status | seal)
+	if args := flag.Args(); len(args) > 0 && args[0] == "encrypt" {
+		err = app.Encrypt(cfg, args[1:], os.Stdout)
+		if errors.Is(err, app.ErrEncryptUsage) {
+			fmt.Fprint(os.Stderr, app.EncryptUsage)
+			os.Exit(2)
+		}
+		if err != nil {
+			log.Fatalf("encrypt error: %s", err)
+		}
+		return
+	}
+
+	srv := app.New(cfg, buildTime)
+	if err = srv.Run(); err != nil {
+		log.Printf("server error: %s", err)
+		os.Exit(1)
+	}
 }
@@ -2,12 +2,16 @@
 package app
 
 import (
+	"fmt"
+
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 
 	"github.com/PaulYakow/gophkeeper/cmd/client/config"
 	"github.com/PaulYakow/gophkeeper/internal/client/controller"
 	"github.com/PaulYakow/gophkeeper/internal/client/views"
+	"github.com/PaulYakow/gophkeeper/internal/utils/tlsconfig"
 	"github.com/PaulYakow/gophkeeper/pkg/logger"
 )
 
@@ -27,15 +31,24 @@ func New(cfg *config.Config) (a *App) {
 		logger: logger.New(cfg.App.Name),
 	}
 
-	var err error
+	creds, err := a.credentials()
+	if err != nil {
+		a.logger.Fatal(fmt.Errorf("credentials: %w", err))
+	}
+
+	session := controller.NewSession()
 
 	target := cfg.GRPC.Address + ":" + cfg.GRPC.Port
-	a.conn, err = grpc.Dial(target, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	a.conn, err = grpc.Dial(target,
+		grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(controller.ErrorsUnaryInterceptor, session.UnaryInterceptor),
+		grpc.WithChainStreamInterceptor(controller.ErrorsStreamInterceptor, session.StreamInterceptor),
+	)
 	if err != nil {
 		a.logger.Fatal(err)
 	}
 
-	a.ctrl = controller.New(a.conn)
+	a.ctrl = controller.New(a.conn, cfg.Storage.Path, session)
 	a.view = views.New(a.ctrl, cfg)
 
 	return
@@ -47,3 +60,18 @@ func (a *App) Run() {
 
 	a.view.Run()
 }
+
+// Формирование параметров защиты соединения (TLS/mTLS, без шифрования - только при явном Insecure).
+func (a *App) credentials() (credentials.TransportCredentials, error) {
+	if a.config.TLS.Insecure {
+		a.logger.Warn("TLS disabled (insecure mode): data is transferred in plaintext")
+		return insecure.NewCredentials(), nil
+	}
+
+	cfg, err := tlsconfig.Client(a.config.TLS.CAFile, a.config.TLS.CertFile, a.config.TLS.KeyFile, a.config.TLS.ServerName)
+	if err != nil {
+		return nil, err
+	}
+
+	return credentials.NewTLS(cfg), nil
+}
@@ -0,0 +1,174 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	pb "github.com/PaulYakow/gophkeeper/proto"
+)
+
+// AccountClient обеспечивает экспорт данных и удаление учётной записи пользователя.
+type AccountClient struct {
+	conn    *grpc.ClientConn
+	keys    *Keys
+	cache   *Cache
+	session *Session
+}
+
+// NewAccountClient создаёт объект AccountClient.
+func NewAccountClient(conn *grpc.ClientConn, keys *Keys, cache *Cache, session *Session) *AccountClient {
+	return &AccountClient{
+		conn:    conn,
+		keys:    keys,
+		cache:   cache,
+		session: session,
+	}
+}
+
+// ExportAccount выгружает с сервера все данные пользователя и сохраняет их в каталог dir.
+//
+// Поля записей и содержимое файлов расшифровываются, поэтому архив (JSON) содержит данные в открытом
+// виде и доступен только владельцу (0600). Возвращает путь к сохранённому архиву.
+func (c *AccountClient) ExportAccount(ctx context.Context, token string, dir string) (string, error) {
+	client := pb.NewUserClient(c.conn)
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(streamTimeout))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	stream, err := client.ExportAccount(ctx, &pb.ExportAccountRequest{})
+	if err != nil {
+		return "", err
+	}
+
+	var data []byte
+	for {
+		resp, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", err
+		}
+
+		data = append(data, resp.GetChunk()...)
+	}
+
+	var account entity.AccountDTO
+	if err = json.Unmarshal(data, &account); err != nil {
+		return "", fmt.Errorf("export account: %w", err)
+	}
+
+	if err = c.openAccount(&account); err != nil {
+		return "", err
+	}
+
+	if data, err = json.MarshalIndent(account, "", "  "); err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(dir, "export-"+account.ExportedAt.Format("20060102-150405")+".json")
+	if err = writePrivateFile(path, data); err != nil {
+		return "", fmt.Errorf("save export: %w", err)
+	}
+
+	return path, nil
+}
+
+// DeleteAccount удаляет учётную запись пользователя вместе со всеми данными (требуется текущий пароль).
+//
+// Если подключён второй фактор, требуется код аутентификатора (code).
+// После удаления на сервере удаляются сохранённая сессия и локальный кеш пользователя.
+func (c *AccountClient) DeleteAccount(ctx context.Context, token, password, code string) error {
+	client := pb.NewUserClient(c.conn)
+	req := &pb.DeleteAccountRequest{
+		Password: password,
+		Code:     code,
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	if _, err := client.DeleteAccount(ctx, req); err != nil {
+		return err
+	}
+
+	c.session.clear()
+
+	// учётной записи уже нет - ошибка удаления кеша не меняет результат
+	_ = c.cache.purge()
+
+	return nil
+}
+
+// openAccount расшифровывает на месте все записи архива.
+func (c *AccountClient) openAccount(account *entity.AccountDTO) error {
+	for i := range account.Pairs {
+		pair := &account.Pairs[i]
+		if err := c.keys.open(&pair.Login, &pair.Password, &pair.Metadata); err != nil {
+			return err
+		}
+	}
+
+	for i := range account.Cards {
+		card := &account.Cards[i]
+		if err := c.keys.open(&card.CardHolder, &card.Number, &card.ExpirationDate, &card.Metadata); err != nil {
+			return err
+		}
+	}
+
+	for i := range account.Notes {
+		note := &account.Notes[i]
+		if err := c.keys.open(&note.Note, &note.Metadata); err != nil {
+			return err
+		}
+	}
+
+	for i := range account.Binaries {
+		binary := &account.Binaries[i]
+		if err := c.keys.open(&binary.Filename, &binary.Metadata); err != nil {
+			return err
+		}
+
+		data, err := c.keys.openBytes(binary.Data)
+		if err != nil {
+			return err
+		}
+		binary.Data = data
+	}
+
+	for i := range account.OTPs {
+		item := &account.OTPs[i]
+		if err := c.keys.open(&item.Secret, &item.Issuer, &item.Metadata); err != nil {
+			return err
+		}
+	}
+
+	for i := range account.Folders {
+		if err := c.keys.open(&account.Folders[i].Name); err != nil {
+			return err
+		}
+	}
+
+	for i := range account.Tags {
+		if err := c.keys.open(&account.Tags[i].Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -2,28 +2,49 @@ package controller
 
 import (
 	"context"
+	"errors"
+	"net/url"
+	"strconv"
 	"time"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 
+	"github.com/PaulYakow/gophkeeper/internal/entity"
 	pb "github.com/PaulYakow/gophkeeper/proto"
 )
 
+var (
+	// ErrSessionExpired ошибка восстановления сессии, срок действия которой истёк.
+	ErrSessionExpired = errors.New("session expired: sign in required")
+	// ErrSecondFactorRequired пароль принят, вход завершается кодом аутентификатора (VerifySecondFactor).
+	ErrSecondFactorRequired = errors.New("one-time code required")
+	// ErrNoChallenge попытка завершить вход кодом без незавершённого входа.
+	ErrNoChallenge = errors.New("no login awaiting one-time code")
+)
+
 // UserClient обеспечивает регистрацию/аутентификацию пользователя.
 type UserClient struct {
-	conn *grpc.ClientConn
+	conn    *grpc.ClientConn
+	session *Session
+
+	// токен незавершённого входа (ожидается код аутентификатора)
+	challenge string
 }
 
 // NewUserClient создаёт объект UserClient.
-func NewUserClient(conn *grpc.ClientConn) *UserClient {
+//
+// Полученные от сервера токены сохраняются в session.
+func NewUserClient(conn *grpc.ClientConn, session *Session) *UserClient {
 	return &UserClient{
-		conn: conn,
+		conn:    conn,
+		session: session,
 	}
 }
 
 // Register регистрация пользователя с заданными логином и паролем.
 // Возвращает ошибку если пользователь с таким логином уже существует.
-func (c *UserClient) Register(ctx context.Context, login, password string) (string, error) {
+func (c *UserClient) Register(ctx context.Context, login, password string) error {
 	client := pb.NewUserClient(c.conn)
 	req := &pb.RegisterRequest{
 		Login:    login,
@@ -35,14 +56,18 @@ func (c *UserClient) Register(ctx context.Context, login, password string) (stri
 
 	resp, err := client.Register(ctx, req)
 	if err != nil {
-		return "", err
+		return err
 	}
 
-	return resp.GetToken(), nil
+	c.session.set(resp.GetToken(), resp.GetRefreshToken(), resp.GetExpiresIn())
+	return nil
 }
 
 // Login аутентификация пользователя по переданным логину и паролю.
-func (c *UserClient) Login(ctx context.Context, login, password string) (string, error) {
+//
+// Если у пользователя подключён второй фактор, возвращает ErrSecondFactorRequired:
+// вход завершается кодом аутентификатора (VerifySecondFactor).
+func (c *UserClient) Login(ctx context.Context, login, password string) error {
 	client := pb.NewUserClient(c.conn)
 	req := &pb.LoginRequest{
 		Login:    login,
@@ -54,8 +79,221 @@ func (c *UserClient) Login(ctx context.Context, login, password string) (string,
 
 	resp, err := client.Login(ctx, req)
 	if err != nil {
-		return "", err
+		return err
+	}
+
+	if resp.GetChallenge() != "" {
+		c.challenge = resp.GetChallenge()
+		return ErrSecondFactorRequired
+	}
+
+	c.session.set(resp.GetToken(), resp.GetRefreshToken(), resp.GetExpiresIn())
+	return nil
+}
+
+// VerifySecondFactor завершает вход (после ErrSecondFactorRequired) кодом аутентификатора.
+func (c *UserClient) VerifySecondFactor(ctx context.Context, code string) error {
+	if c.challenge == "" {
+		return ErrNoChallenge
+	}
+
+	client := pb.NewUserClient(c.conn)
+	req := &pb.VerifySecondFactorRequest{
+		Challenge: c.challenge,
+		Code:      code,
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	resp, err := client.VerifySecondFactor(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	c.challenge = ""
+	c.session.set(resp.GetToken(), resp.GetRefreshToken(), resp.GetExpiresIn())
+	return nil
+}
+
+// ChangePassword смена пароля пользователя (требуется текущий пароль).
+//
+// Сервер отзывает все сессии пользователя и открывает новую, её токены сохраняются в session.
+// Если подключён второй фактор, требуется код аутентификатора (code).
+func (c *UserClient) ChangePassword(ctx context.Context, login, password, newPassword, code string) error {
+	client := pb.NewUserClient(c.conn)
+	req := &pb.ChangePasswordRequest{
+		Login:       login,
+		Password:    password,
+		NewPassword: newPassword,
+		Code:        code,
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	resp, err := client.ChangePassword(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	c.session.set(resp.GetToken(), resp.GetRefreshToken(), resp.GetExpiresIn())
+	return nil
+}
+
+// SecurityEvents возвращает события безопасности учётной записи (блокировки входа), от новых к старым.
+func (c *UserClient) SecurityEvents(ctx context.Context, token string) ([]entity.SecurityEventDTO, error) {
+	client := pb.NewUserClient(c.conn)
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	resp, err := client.SecurityEvents(ctx, &pb.SecurityEventsRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]entity.SecurityEventDTO, 0, len(resp.GetEvents()))
+	for _, event := range resp.GetEvents() {
+		events = append(events, entity.SecurityEventDTO{
+			Kind:        event.GetKind(),
+			Peer:        event.GetPeer(),
+			LockedUntil: time.Unix(event.GetLockedUntil(), 0),
+			CreatedAt:   time.Unix(event.GetCreatedAt(), 0),
+		})
+	}
+
+	return events, nil
+}
+
+// EnrollTOTP подключение (или замена) аутентификатора: возвращает параметры нового секрета.
+//
+// Требуется текущий пароль и код действующего аутентификатора (если второй фактор уже подключён).
+// Секрет начинает действовать после подтверждения кодом (ConfirmTOTP).
+func (c *UserClient) EnrollTOTP(ctx context.Context, token, password, code string) (entity.TOTPEnrollmentDTO, error) {
+	client := pb.NewUserClient(c.conn)
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	resp, err := client.EnrollTOTP(ctx, &pb.EnrollTOTPRequest{Password: password, Code: code})
+	if err != nil {
+		return entity.TOTPEnrollmentDTO{}, err
+	}
+
+	return entity.TOTPEnrollmentDTO{
+		Secret:    resp.GetSecret(),
+		Algorithm: resp.GetAlgorithm(),
+		Digits:    int(resp.GetDigits()),
+		Period:    int(resp.GetPeriod()),
+	}, nil
+}
+
+// ConfirmTOTP подтверждение подключаемого аутентификатора кодом.
+func (c *UserClient) ConfirmTOTP(ctx context.Context, token, code string) error {
+	client := pb.NewUserClient(c.conn)
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := client.ConfirmTOTP(ctx, &pb.ConfirmTOTPRequest{Code: code})
+	return err
+}
+
+// DisableTOTP отключение второго фактора (требуется код действующего аутентификатора).
+func (c *UserClient) DisableTOTP(ctx context.Context, token, code string) error {
+	client := pb.NewUserClient(c.conn)
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := client.DisableTOTP(ctx, &pb.DisableTOTPRequest{Code: code})
+	return err
+}
+
+// TOTPURI формирует ссылку otpauth:// для добавления аутентификатора пользователя login (например, по QR-коду).
+func TOTPURI(issuer, login string, enrollment entity.TOTPEnrollmentDTO) string {
+	params := url.Values{}
+	params.Set("secret", enrollment.Secret)
+	params.Set("issuer", issuer)
+	params.Set("algorithm", enrollment.Algorithm)
+	params.Set("digits", strconv.Itoa(enrollment.Digits))
+	params.Set("period", strconv.Itoa(enrollment.Period))
+
+	label := url.PathEscape(issuer + ":" + login)
+	return "otpauth://totp/" + label + "?" + params.Encode()
+}
+
+// IsLocked проверяет, отклонён ли вход из-за блокировки после серии неудачных попыток.
+func IsLocked(err error) bool {
+	return errors.Is(err, ErrTooManyAttempts)
+}
+
+// IsPasswordExpired проверяет, отклонён ли вход из-за истёкшего срока действия пароля (пароль необходимо сменить).
+func IsPasswordExpired(err error) bool {
+	return errors.Is(err, ErrPasswordExpired)
+}
+
+// Logout завершает текущую сессию на сервере и удаляет её токены.
+//
+// Токены удаляются и при ошибке запроса (например, если сервер недоступен).
+func (c *UserClient) Logout(ctx context.Context) error {
+	defer c.session.clear()
+
+	if c.session.Token() == "" {
+		return nil
 	}
 
-	return resp.GetToken(), nil
+	client := pb.NewUserClient(c.conn)
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": c.session.Token()})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := client.Logout(ctx, &pb.LogoutRequest{})
+	return err
+}
+
+// Resume восстанавливает сохранённую сессию (вход без ввода пароля).
+//
+// Ключ шифрования данных остаётся заблокированным до ввода мастер-пароля (Keys.Unlock с логином
+// Session.Login).
+//
+// Токены сохранённой сессии сразу обновляются, что проверяет сессию на сервере. Если сервер отклонил
+// сессию, она удаляется и возвращается ошибка. Если сервер недоступен, сессия восстанавливается
+// только пока не истёк access-токен (возвращается ошибка недоступности, данные доступны из кеша),
+// иначе возвращается ErrSessionExpired.
+func (c *UserClient) Resume(ctx context.Context) error {
+	if err := c.session.restore(); err != nil {
+		return err
+	}
+
+	err := c.session.refreshNow(ctx, c.conn)
+	switch {
+	case err == nil:
+		return nil
+	case IsUnavailable(err) && c.session.valid():
+		return err
+	case IsUnavailable(err):
+		// сохранённая сессия остаётся - её можно будет восстановить при доступном сервере
+		c.session.forget(false)
+		return ErrSessionExpired
+	default:
+		c.session.forget(true)
+		return err
+	}
 }
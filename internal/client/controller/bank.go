@@ -13,21 +13,45 @@ import (
 
 // BankClient обеспечивает обмен данными о банковских картах пользователя.
 type BankClient struct {
-	conn *grpc.ClientConn
+	conn  *grpc.ClientConn
+	keys  *Keys
+	cache *Cache
 }
 
 // NewBankClient создаёт объект BankClient.
-func NewBankClient(conn *grpc.ClientConn) *BankClient {
+func NewBankClient(conn *grpc.ClientConn, keys *Keys, cache *Cache) *BankClient {
 	return &BankClient{
-		conn: conn,
+		conn:  conn,
+		keys:  keys,
+		cache: cache,
 	}
 }
 
 // ViewAllCards запрашивает информацию обо всех имеющихся картах текущего пользователя.
+//
+// Список загружается постранично. При недоступности сервера возвращает данные из локального кеша
+// вместе с ошибкой *OfflineError.
 func (c *BankClient) ViewAllCards(ctx context.Context, token string) ([]entity.BankDTO, error) {
+	out, err := listAll(func(page entity.PageRequest) ([]entity.BankDTO, string, error) {
+		return c.ListCards(ctx, token, page)
+	})
+	if err != nil {
+		return fromCache[entity.BankDTO](c.cache, cardsKind, err)
+	}
+
+	// ошибка сохранения кеша не влияет на результат запроса
+	_ = c.cache.save(cardsKind, out)
+
+	return out, nil
+}
+
+// ListCards запрашивает страницу списка банковских карт пользователя.
+//
+// Возвращает записи страницы и токен следующей страницы (пустой - страница последняя).
+func (c *BankClient) ListCards(ctx context.Context, token string, page entity.PageRequest) ([]entity.BankDTO, string, error) {
 	client := pb.NewBankClient(c.conn)
 	req := &pb.GetAllCardsRequest{
-		Token: "",
+		Page: pageToMsg(page),
 	}
 
 	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
@@ -38,19 +62,110 @@ func (c *BankClient) ViewAllCards(ctx context.Context, token string) ([]entity.B
 
 	resp, err := client.GetAll(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	out := make([]entity.BankDTO, len(resp.Cards))
 	for i, card := range resp.GetCards() {
-		out[i] = entity.BankDTO{
-			ID:             int(card.GetId()),
-			CardHolder:     card.GetCardHolder(),
-			Number:         card.GetNumber(),
-			ExpirationDate: card.GetExpirationDate(),
-			Metadata:       card.GetMetadata(),
+		if out[i], err = cardFromMsg(c.keys, card); err != nil {
+			return nil, "", err
 		}
 	}
 
-	return out, nil
+	return out, resp.GetNextPageToken(), nil
+}
+
+// CreateCard сохраняет новую банковскую карту пользователя.
+//
+// Возвращает id созданной записи.
+func (c *BankClient) CreateCard(ctx context.Context, token string, card entity.BankDTO) (int, error) {
+	if err := c.keys.seal(&card.CardHolder, &card.Number, &card.ExpirationDate, &card.Metadata); err != nil {
+		return 0, err
+	}
+
+	client := pb.NewBankClient(c.conn)
+	req := &pb.CreateCardRequest{
+		Card: &pb.CardMsg{
+			CardHolder:     card.CardHolder,
+			Number:         card.Number,
+			ExpirationDate: card.ExpirationDate,
+			Metadata:       card.Metadata,
+			FolderId:       int64(card.FolderID),
+			TagIds:         tagIDsToMsg(card.TagIDs),
+		},
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	resp, err := client.Create(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(resp.GetId()), nil
+}
+
+// UpdateCard изменяет существующую банковскую карту пользователя.
+func (c *BankClient) UpdateCard(ctx context.Context, token string, card entity.BankDTO) error {
+	if err := c.keys.seal(&card.CardHolder, &card.Number, &card.ExpirationDate, &card.Metadata); err != nil {
+		return err
+	}
+
+	client := pb.NewBankClient(c.conn)
+	req := &pb.UpdateCardRequest{
+		Card: &pb.CardMsg{
+			Id:             int64(card.ID),
+			CardHolder:     card.CardHolder,
+			Number:         card.Number,
+			ExpirationDate: card.ExpirationDate,
+			Metadata:       card.Metadata,
+			FolderId:       int64(card.FolderID),
+			TagIds:         tagIDsToMsg(card.TagIDs),
+		},
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := client.Update(ctx, req)
+	return err
+}
+
+// DeleteCard удаляет банковскую карту пользователя по её id.
+func (c *BankClient) DeleteCard(ctx context.Context, token string, id int) error {
+	client := pb.NewBankClient(c.conn)
+	req := &pb.DeleteCardRequest{
+		Id: int64(id),
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := client.Delete(ctx, req)
+	return err
+}
+
+// cardFromMsg преобразует сообщение сервера в объект банковской карты с расшифрованными полями.
+func cardFromMsg(keys *Keys, msg *pb.CardMsg) (entity.BankDTO, error) {
+	card := entity.BankDTO{
+		ID:             int(msg.GetId()),
+		CardHolder:     msg.GetCardHolder(),
+		Number:         msg.GetNumber(),
+		ExpirationDate: msg.GetExpirationDate(),
+		Metadata:       msg.GetMetadata(),
+		Labels:         labelsFromMsg(msg.GetFolderId(), msg.GetTagIds()),
+	}
+
+	err := keys.open(&card.CardHolder, &card.Number, &card.ExpirationDate, &card.Metadata)
+	return card, err
 }
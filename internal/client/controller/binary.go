@@ -0,0 +1,349 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	pb "github.com/PaulYakow/gophkeeper/proto"
+)
+
+const (
+	// chunkSize размер части файла, передаваемой одним сообщением потока.
+	chunkSize = 64 * 1024
+	// streamTimeout время на передачу файла целиком.
+	streamTimeout = 30 * time.Second
+	// maxNameAttempts количество номеров, перебираемых для имени сохраняемого файла, если оно занято.
+	maxNameAttempts = 100
+)
+
+// MaxUploadSize предельный размер загружаемого файла, проверяемый клиентом до чтения файла в память.
+//
+// Это защита от случайного выбора огромного файла; допустимый размер задаёт сервер и обычно он меньше.
+const MaxUploadSize = 256 << 20
+
+// BinaryClient обеспечивает обмен бинарными данными (файлами) пользователя.
+type BinaryClient struct {
+	conn  *grpc.ClientConn
+	keys  *Keys
+	cache *Cache
+}
+
+// NewBinaryClient создаёт объект BinaryClient.
+func NewBinaryClient(conn *grpc.ClientConn, keys *Keys, cache *Cache) *BinaryClient {
+	return &BinaryClient{
+		conn:  conn,
+		keys:  keys,
+		cache: cache,
+	}
+}
+
+// ViewAllBinaries запрашивает описания всех файлов пользователя (без содержимого).
+//
+// Список загружается постранично. При недоступности сервера возвращает данные из локального кеша
+// вместе с ошибкой *OfflineError.
+func (c *BinaryClient) ViewAllBinaries(ctx context.Context, token string) ([]entity.BinaryDTO, error) {
+	out, err := listAll(func(page entity.PageRequest) ([]entity.BinaryDTO, string, error) {
+		return c.ListBinaries(ctx, token, page)
+	})
+	if err != nil {
+		return fromCache[entity.BinaryDTO](c.cache, binariesKind, err)
+	}
+
+	// ошибка сохранения кеша не влияет на результат запроса
+	_ = c.cache.save(binariesKind, out)
+
+	return out, nil
+}
+
+// ListBinaries запрашивает страницу списка описаний файлов пользователя (без содержимого).
+//
+// Возвращает записи страницы и токен следующей страницы (пустой - страница последняя).
+func (c *BinaryClient) ListBinaries(ctx context.Context, token string, page entity.PageRequest) ([]entity.BinaryDTO, string, error) {
+	client := pb.NewBinaryClient(c.conn)
+	req := &pb.GetAllBinariesRequest{
+		Page: pageToMsg(page),
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	resp, err := client.GetAll(ctx, req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	out := make([]entity.BinaryDTO, len(resp.Binaries))
+	for i, binary := range resp.GetBinaries() {
+		if out[i], err = binaryFromMsg(c.keys, binary); err != nil {
+			return nil, "", err
+		}
+	}
+
+	return out, resp.GetNextPageToken(), nil
+}
+
+// UploadBinary загружает на сервер файл, расположенный по пути path, с метаинформацией meta
+// в папку и с метками labels.
+//
+// Файл шифруется целиком, поэтому читается в память полностью. Допустимый размер задаёт сервер:
+// больший файл он отклоняет (ErrTooLarge). Файл больше MaxUploadSize отклоняется без чтения (ErrTooLarge).
+// Возвращает id созданной записи.
+func (c *BinaryClient) UploadBinary(ctx context.Context, token, path, meta string, labels entity.Labels) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, fmt.Errorf("open file: %w", err)
+	}
+	defer file.Close()
+
+	stat, err := file.Stat()
+	if err != nil {
+		return 0, fmt.Errorf("open file: %w", err)
+	}
+	if stat.Size() > MaxUploadSize {
+		return 0, ErrTooLarge
+	}
+
+	// размер файла мог измениться после проверки (или не определяться для специальных файлов)
+	data, err := io.ReadAll(io.LimitReader(file, MaxUploadSize+1))
+	if err != nil {
+		return 0, fmt.Errorf("read file: %w", err)
+	}
+	if len(data) > MaxUploadSize {
+		return 0, ErrTooLarge
+	}
+
+	return c.upload(ctx, token, filepath.Base(path), meta, labels, data)
+}
+
+// upload шифрует и загружает на сервер файл с именем filename и содержимым data.
+//
+// Возвращает id созданной записи.
+func (c *BinaryClient) upload(ctx context.Context, token, filename, meta string, labels entity.Labels, data []byte) (int, error) {
+	if err := c.keys.seal(&filename, &meta); err != nil {
+		return 0, err
+	}
+
+	// сервер хранит шифротекст, поэтому размер файла сообщается ему отдельно
+	size := int64(len(data))
+
+	data, err := c.keys.sealBytes(data)
+	if err != nil {
+		return 0, err
+	}
+
+	client := pb.NewBinaryClient(c.conn)
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(streamTimeout))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	stream, err := client.Upload(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	err = stream.Send(&pb.UploadBinaryRequest{
+		Data: &pb.UploadBinaryRequest_Info{
+			Info: &pb.BinaryMsg{
+				Filename: filename,
+				Size:     size,
+				Metadata: meta,
+				FolderId: int64(labels.FolderID),
+				TagIds:   tagIDsToMsg(labels.TagIDs),
+			},
+		},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	for len(data) > 0 {
+		n := chunkSize
+		if n > len(data) {
+			n = len(data)
+		}
+
+		err = stream.Send(&pb.UploadBinaryRequest{
+			Data: &pb.UploadBinaryRequest_Chunk{Chunk: data[:n]},
+		})
+		if err != nil {
+			break
+		}
+		data = data[n:]
+	}
+
+	// при ошибке отправки причину возвращает CloseAndRecv
+	resp, err := stream.CloseAndRecv()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(resp.GetId()), nil
+}
+
+// DownloadBinary загружает с сервера файл с заданным id и сохраняет его в каталог dir.
+//
+// Существующие файлы не заменяются: если имя занято, к нему добавляется номер ("name (1).ext").
+// Возвращает путь к сохранённому файлу.
+func (c *BinaryClient) DownloadBinary(ctx context.Context, token string, id int, dir string) (string, error) {
+	info, data, err := c.download(ctx, token, id)
+	if err != nil {
+		return "", err
+	}
+
+	filename := info.GetFilename()
+	if err = c.keys.open(&filename); err != nil {
+		return "", err
+	}
+
+	if data, err = c.keys.openBytes(data); err != nil {
+		return "", err
+	}
+
+	// имя приходит с сервера: от него берётся только последний элемент, чтобы файл не попал за пределы dir
+	name := filepath.Base(filename)
+	if name == "." || name == ".." || name == string(filepath.Separator) {
+		return "", ErrInvalidFilename
+	}
+
+	if err = os.MkdirAll(dir, 0o700); err != nil {
+		return "", fmt.Errorf("create dir: %w", err)
+	}
+
+	// файл записывается во временный и получает своё имя только после успешной записи
+	tmp, err := os.CreateTemp(dir, ".download-*")
+	if err != nil {
+		return "", fmt.Errorf("create file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if _, err = tmp.Write(data); err != nil {
+		return "", fmt.Errorf("write file: %w", err)
+	}
+
+	if err = tmp.Close(); err != nil {
+		return "", fmt.Errorf("close file: %w", err)
+	}
+
+	return linkUnique(tmp.Name(), dir, name)
+}
+
+// linkUnique создаёт в каталоге dir ссылку с именем name на файл src, не заменяя существующие файлы.
+//
+// Если имя занято, к нему добавляется номер: "name (1).ext", "name (2).ext" и т.д.
+// Возвращает путь к созданной ссылке.
+func linkUnique(src, dir, name string) (string, error) {
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+	if base == "" {
+		base, ext = name, ""
+	}
+
+	for i := 0; i <= maxNameAttempts; i++ {
+		candidate := name
+		if i > 0 {
+			candidate = fmt.Sprintf("%s (%d)%s", base, i, ext)
+		}
+
+		// в отличие от os.Rename, ссылка не создаётся поверх существующего файла
+		path := filepath.Join(dir, candidate)
+		err := os.Link(src, path)
+		if err == nil {
+			return path, nil
+		}
+		if !errors.Is(err, fs.ErrExist) {
+			return "", fmt.Errorf("save file: %w", err)
+		}
+	}
+
+	return "", fmt.Errorf("save file: too many files named %q", name)
+}
+
+// download получает с сервера описание и содержимое файла с заданным id (в зашифрованном виде).
+func (c *BinaryClient) download(ctx context.Context, token string, id int) (*pb.BinaryMsg, []byte, error) {
+	client := pb.NewBinaryClient(c.conn)
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(streamTimeout))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	stream, err := client.Download(ctx, &pb.DownloadBinaryRequest{Id: int64(id)})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	resp, err := stream.Recv()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	info := resp.GetInfo()
+	if info == nil {
+		return nil, nil, errors.New("download binary: missing file info")
+	}
+
+	var data []byte
+	for {
+		resp, err = stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		data = append(data, resp.GetChunk()...)
+	}
+
+	return info, data, nil
+}
+
+// DeleteBinary удаляет файл пользователя по его id.
+func (c *BinaryClient) DeleteBinary(ctx context.Context, token string, id int) error {
+	client := pb.NewBinaryClient(c.conn)
+	req := &pb.DeleteBinaryRequest{
+		Id: int64(id),
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := client.Delete(ctx, req)
+	return err
+}
+
+// binaryFromMsg преобразует сообщение сервера в объект описания файла с расшифрованными полями.
+func binaryFromMsg(keys *Keys, msg *pb.BinaryMsg) (entity.BinaryDTO, error) {
+	binary := entity.BinaryDTO{
+		ID:       int(msg.GetId()),
+		Filename: msg.GetFilename(),
+		Size:     msg.GetSize(),
+		Metadata: msg.GetMetadata(),
+		Labels:   labelsFromMsg(msg.GetFolderId(), msg.GetTagIds()),
+	}
+
+	err := keys.open(&binary.Filename, &binary.Metadata)
+	return binary, err
+}
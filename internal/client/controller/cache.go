@@ -0,0 +1,200 @@
+package controller
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Типы данных, сохраняемых в локальном кеше.
+const (
+	pairsKind    = "pairs"
+	cardsKind    = "cards"
+	notesKind    = "notes"
+	binariesKind = "binaries"
+	otpsKind     = "otps"
+	foldersKind  = "folders"
+	tagsKind     = "tags"
+)
+
+// OfflineError возвращается вместе с данными из локального кеша, если сервер недоступен.
+type OfflineError struct {
+	// SavedAt время последнего успешного получения данных от сервера.
+	SavedAt time.Time
+	Err     error
+}
+
+func (e *OfflineError) Error() string {
+	return fmt.Sprintf("server unavailable, showing data cached at %s: %s",
+		e.SavedAt.Format(time.RFC822), e.Err.Error())
+}
+
+func (e *OfflineError) Unwrap() error {
+	return e.Err
+}
+
+// Cache зашифрованный локальный кеш данных пользователя (каталог storage.path из конфигурации).
+//
+// Кеш заполняется при каждом успешном получении списков от сервера и используется
+// только для чтения, когда сервер недоступен. Содержимое шифруется ключом пользователя (Keys),
+// каталог определяется логином, поэтому кеши разных пользователей не пересекаются.
+// Значение nil означает, что кеш отключён.
+type Cache struct {
+	dir  string
+	keys *Keys
+}
+
+type cacheEntry struct {
+	SavedAt time.Time       `json:"saved_at"`
+	Items   json.RawMessage `json:"items"`
+}
+
+// NewCache создаёт объект Cache в каталоге dir.
+func NewCache(dir string, keys *Keys) *Cache {
+	return &Cache{
+		dir:  dir,
+		keys: keys,
+	}
+}
+
+// save сохраняет список items типа kind в кеш.
+func (c *Cache) save(kind string, items any) error {
+	if c == nil {
+		return nil
+	}
+
+	raw, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(cacheEntry{SavedAt: time.Now(), Items: raw})
+	if err != nil {
+		return err
+	}
+
+	if data, err = c.keys.sealBytes(data); err != nil {
+		return err
+	}
+
+	path, err := c.path(kind)
+	if err != nil {
+		return err
+	}
+
+	return writePrivateFile(path, data)
+}
+
+// load загружает из кеша список типа kind в items и возвращает время его сохранения.
+func (c *Cache) load(kind string, items any) (time.Time, error) {
+	if c == nil {
+		return time.Time{}, errors.New("cache disabled")
+	}
+
+	path, err := c.path(kind)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if data, err = c.keys.openBytes(data); err != nil {
+		return time.Time{}, err
+	}
+
+	var entry cacheEntry
+	if err = json.Unmarshal(data, &entry); err != nil {
+		return time.Time{}, err
+	}
+
+	return entry.SavedAt, json.Unmarshal(entry.Items, items)
+}
+
+// purge удаляет кеш текущего пользователя.
+func (c *Cache) purge() error {
+	if c == nil {
+		return nil
+	}
+
+	path, err := c.path(syncKind)
+	if err != nil {
+		return err
+	}
+
+	return os.RemoveAll(filepath.Dir(path))
+}
+
+func (c *Cache) path(kind string) (string, error) {
+	owner, err := c.keys.owner()
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256([]byte(owner))
+	return filepath.Join(c.dir, "cache", hex.EncodeToString(sum[:8]), kind+".bin"), nil
+}
+
+// fromCache возвращает данные типа kind из кеша, если ошибка запроса вызвана недоступностью сервера.
+//
+// В этом случае вместе с данными возвращается *OfflineError, иначе - исходная ошибка.
+func fromCache[T any](c *Cache, kind string, err error) ([]T, error) {
+	if !IsUnavailable(err) {
+		return nil, err
+	}
+
+	var items []T
+	savedAt, errLoad := c.load(kind, &items)
+	if errLoad != nil {
+		return nil, err
+	}
+
+	return items, &OfflineError{SavedAt: savedAt, Err: err}
+}
+
+// writePrivateFile записывает data в файл path, доступный только владельцу (каталоги создаются при необходимости).
+//
+// Запись выполняется во временный файл с последующим переименованием - файл не повреждается при сбое.
+func writePrivateFile(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	// временный файл создаётся с правами 0600
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	if _, err = tmp.Write(data); err != nil {
+		return err
+	}
+
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+// IsUnavailable проверяет, вызвана ли ошибка запроса недоступностью сервера.
+func IsUnavailable(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return true
+	default:
+		return false
+	}
+}
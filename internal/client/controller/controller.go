@@ -7,19 +7,45 @@ import (
 
 // Controller обеспечивает обмен клиента данными с gRPC-сервером.
 type Controller struct {
-	Auth  *UserClient
-	Pairs *PairsClient
-	Cards *BankClient
-	Notes *TextClient
-	Token string
+	Auth     *UserClient
+	Pairs    *PairsClient
+	Cards    *BankClient
+	Notes    *TextClient
+	Binaries *BinaryClient
+	OTPs     *OTPClient
+	Sync     *SyncClient
+	Search   *SearchClient
+	Labels   *LabelsClient
+	Account  *AccountClient
+	Info     *InfoClient
+	Keys     *Keys
+	Session  *Session
 }
 
 // New создаёт объект Controller.
-func New(conn *grpc.ClientConn) *Controller {
+//
+// storagePath - каталог локального хранилища клиента (зашифрованный кеш данных и сохранённая сессия).
+// session - токены пользователя (её перехватчики должны быть подключены к conn).
+func New(conn *grpc.ClientConn, storagePath string, session *Session) *Controller {
+	keys := &Keys{}
+	cache := NewCache(storagePath, keys)
+	session.persist(NewSessionStore(storagePath), keys)
+
+	sync := NewSyncClient(conn, keys, cache)
+
 	return &Controller{
-		Auth:  NewUserClient(conn),
-		Pairs: NewPairsClient(conn),
-		Cards: NewBankClient(conn),
-		Notes: NewTextClient(conn),
+		Auth:     NewUserClient(conn, session),
+		Pairs:    NewPairsClient(conn, keys, cache),
+		Cards:    NewBankClient(conn, keys, cache),
+		Notes:    NewTextClient(conn, keys, cache),
+		Binaries: NewBinaryClient(conn, keys, cache),
+		OTPs:     NewOTPClient(conn, keys, cache),
+		Sync:     sync,
+		Search:   NewSearchClient(sync),
+		Labels:   NewLabelsClient(conn, keys, cache),
+		Account:  NewAccountClient(conn, keys, cache, session),
+		Info:     NewInfoClient(conn),
+		Keys:     keys,
+		Session:  session,
 	}
 }
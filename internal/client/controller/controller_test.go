@@ -2,19 +2,35 @@ package controller_test
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/json"
 	"errors"
+	"io"
 	"log"
 	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 
 	"github.com/PaulYakow/gophkeeper/internal/client/controller"
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	serverController "github.com/PaulYakow/gophkeeper/internal/server/controller"
 	"github.com/PaulYakow/gophkeeper/internal/server/mocks"
+	"github.com/PaulYakow/gophkeeper/internal/utils/encryption"
 	pb "github.com/PaulYakow/gophkeeper/proto"
 )
 
@@ -25,36 +41,172 @@ var (
 
 type mockUserServer struct {
 	pb.UnimplementedUserServer
-	auth *mocks.MockIAuthorizationService
+	auth    *mocks.MockIAuthorizationService
+	account *mocks.MockIAccountService
 }
 
 func (s *mockUserServer) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
 	var resp pb.RegisterResponse
-	token, err := s.auth.RegisterUser(req.GetLogin(), req.GetPassword())
+	tokens, err := s.auth.RegisterUser(ctx, req.GetLogin(), req.GetPassword())
 	if err != nil {
 		return nil, err
 	}
 
-	resp.Token = token
+	resp.Token = tokens.AccessToken
+	resp.RefreshToken = tokens.RefreshToken
+	resp.ExpiresIn = int64(tokens.ExpiresIn.Seconds())
 	return &resp, nil
 }
 
 func (s *mockUserServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
 	var resp pb.LoginResponse
-	token, err := s.auth.LoginUser(req.GetLogin(), req.GetPassword())
+	tokens, err := s.auth.LoginUser(ctx, req.GetLogin(), req.GetPassword(), "")
 	if err != nil {
 		return nil, err
 	}
 
-	resp.Token = token
+	resp.Token = tokens.AccessToken
+	resp.RefreshToken = tokens.RefreshToken
+	resp.ExpiresIn = int64(tokens.ExpiresIn.Seconds())
+	resp.Challenge = tokens.Challenge
 	return &resp, nil
 }
 
+func (s *mockUserServer) VerifySecondFactor(ctx context.Context, req *pb.VerifySecondFactorRequest) (*pb.VerifySecondFactorResponse, error) {
+	var resp pb.VerifySecondFactorResponse
+	tokens, err := s.auth.VerifySecondFactor(ctx, req.GetChallenge(), req.GetCode(), "")
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Token = tokens.AccessToken
+	resp.RefreshToken = tokens.RefreshToken
+	resp.ExpiresIn = int64(tokens.ExpiresIn.Seconds())
+	return &resp, nil
+}
+
+func (s *mockUserServer) EnrollTOTP(ctx context.Context, req *pb.EnrollTOTPRequest) (*pb.EnrollTOTPResponse, error) {
+	enrollment, err := s.auth.EnrollTOTP(ctx, 1, req.GetPassword(), req.GetCode(), "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.EnrollTOTPResponse{
+		Secret:    enrollment.Secret,
+		Algorithm: enrollment.Algorithm,
+		Digits:    int32(enrollment.Digits),
+		Period:    int32(enrollment.Period),
+	}, nil
+}
+
+func (s *mockUserServer) ConfirmTOTP(ctx context.Context, req *pb.ConfirmTOTPRequest) (*pb.ConfirmTOTPResponse, error) {
+	if err := s.auth.ConfirmTOTP(ctx, 1, req.GetCode()); err != nil {
+		return nil, err
+	}
+
+	return &pb.ConfirmTOTPResponse{}, nil
+}
+
+func (s *mockUserServer) DisableTOTP(ctx context.Context, req *pb.DisableTOTPRequest) (*pb.DisableTOTPResponse, error) {
+	if err := s.auth.DisableTOTP(ctx, 1, req.GetCode()); err != nil {
+		return nil, err
+	}
+
+	return &pb.DisableTOTPResponse{}, nil
+}
+
+func (s *mockUserServer) Refresh(ctx context.Context, req *pb.RefreshRequest) (*pb.RefreshResponse, error) {
+	var resp pb.RefreshResponse
+	tokens, err := s.auth.Refresh(ctx, req.GetRefreshToken())
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Token = tokens.AccessToken
+	resp.RefreshToken = tokens.RefreshToken
+	resp.ExpiresIn = int64(tokens.ExpiresIn.Seconds())
+	return &resp, nil
+}
+
+func (s *mockUserServer) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*pb.ChangePasswordResponse, error) {
+	var resp pb.ChangePasswordResponse
+	tokens, err := s.auth.ChangePassword(ctx, req.GetLogin(), req.GetPassword(), req.GetNewPassword(), req.GetCode(), "")
+	if err != nil {
+		return nil, err
+	}
+
+	resp.Token = tokens.AccessToken
+	resp.RefreshToken = tokens.RefreshToken
+	resp.ExpiresIn = int64(tokens.ExpiresIn.Seconds())
+	return &resp, nil
+}
+
+func (s *mockUserServer) ExportAccount(req *pb.ExportAccountRequest, stream pb.User_ExportAccountServer) error {
+	account, err := s.account.ExportAccount(stream.Context(), 1)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(account)
+	if err != nil {
+		return err
+	}
+
+	// архив передаётся двумя частями
+	half := len(data) / 2
+	for _, chunk := range [][]byte{data[:half], data[half:]} {
+		if err = stream.Send(&pb.ExportAccountResponse{Chunk: chunk}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *mockUserServer) DeleteAccount(ctx context.Context, req *pb.DeleteAccountRequest) (*pb.DeleteAccountResponse, error) {
+	if err := s.account.DeleteAccount(ctx, 1, req.GetPassword(), req.GetCode(), ""); err != nil {
+		return nil, err
+	}
+
+	return &pb.DeleteAccountResponse{}, nil
+}
+
+func (s *mockUserServer) SecurityEvents(ctx context.Context, req *pb.SecurityEventsRequest) (*pb.SecurityEventsResponse, error) {
+	events, err := s.auth.SecurityEvents(ctx, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp pb.SecurityEventsResponse
+	for _, event := range events {
+		resp.Events = append(resp.Events, &pb.SecurityEvent{
+			Kind:        event.Kind,
+			Peer:        event.Peer,
+			CreatedAt:   event.CreatedAt.Unix(),
+			LockedUntil: event.LockedUntil.Unix(),
+		})
+	}
+
+	return &resp, nil
+}
+
+func (s *mockUserServer) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if err := s.auth.Logout(ctx, strings.Join(md.Get("token"), "")); err != nil {
+		return nil, err
+	}
+
+	return &pb.LogoutResponse{}, nil
+}
+
 func dialer() func(context.Context, string) (net.Conn, error) {
 	listener := bufconn.Listen(1024 * 1024)
 
 	server := grpc.NewServer()
-	srv = &mockUserServer{auth: mocks.NewMockIAuthorizationService(ctrl)}
+	srv = &mockUserServer{
+		auth:    mocks.NewMockIAuthorizationService(ctrl),
+		account: mocks.NewMockIAccountService(ctrl),
+	}
 	pb.RegisterUserServer(server, srv)
 
 	go func() {
@@ -68,6 +220,16 @@ func dialer() func(context.Context, string) (net.Conn, error) {
 	}
 }
 
+// reasonError ошибка сервера со статусом code и причиной reason (как её возвращает серверный контроллер).
+func reasonError(code codes.Code, reason pb.ErrorReason) error {
+	st, err := status.New(code, reason.String()).WithDetails(&errdetails.ErrorInfo{Reason: reason.String(), Domain: "gophkeeper"})
+	if err != nil {
+		panic(err)
+	}
+
+	return st.Err()
+}
+
 func mockHelper(t testing.TB) {
 	t.Helper()
 	ctrl = gomock.NewController(t)
@@ -78,31 +240,44 @@ func TestRegister(t *testing.T) {
 	defer ctrl.Finish()
 
 	ctx := context.Background()
-	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(dialer()), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(dialer()),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(controller.ErrorsUnaryInterceptor),
+	)
 	if err != nil {
 		t.Fatalf("Failed to dial bufnet: %v", err)
 	}
 	defer conn.Close()
 
-	client := controller.NewUserClient(conn)
+	session := controller.NewSession()
+	client := controller.NewUserClient(conn, session)
 
 	login, password := "user", "password"
-	token := "token"
+	tokens := entity.TokensDTO{AccessToken: "token", RefreshToken: "session.secret", ExpiresIn: 15 * time.Minute}
 
 	t.Run("proper register", func(t *testing.T) {
-		srv.auth.EXPECT().RegisterUser(login, password).Return(token, nil)
-		resp, err := client.Register(ctx, login, password)
-		require.Equal(t, token, resp)
+		srv.auth.EXPECT().RegisterUser(gomock.Any(), login, password).Return(tokens, nil)
+		err := client.Register(ctx, login, password)
 		require.NoError(t, err)
+		require.Equal(t, tokens.AccessToken, session.Token())
+		require.Equal(t, tokens.RefreshToken, session.RefreshToken())
 	})
 
 	t.Run("fail register", func(t *testing.T) {
 		errFail := errors.New("fail")
-		srv.auth.EXPECT().RegisterUser(login, password).Return("", errFail)
-		resp, err := client.Register(ctx, login, password)
-		require.Empty(t, resp)
+		srv.auth.EXPECT().RegisterUser(gomock.Any(), login, password).Return(entity.TokensDTO{}, errFail)
+		err := client.Register(ctx, login, password)
 		require.Error(t, err)
 	})
+
+	t.Run("login exists", func(t *testing.T) {
+		srv.auth.EXPECT().RegisterUser(gomock.Any(), login, password).
+			Return(entity.TokensDTO{}, reasonError(codes.AlreadyExists, pb.ErrorReason_USER_EXISTS))
+		err := client.Register(ctx, login, password)
+		require.ErrorIs(t, err, controller.ErrLoginExists)
+		require.Equal(t, codes.AlreadyExists, status.Code(err))
+	})
 }
 
 func TestLogin(t *testing.T) {
@@ -110,29 +285,1111 @@ func TestLogin(t *testing.T) {
 	defer ctrl.Finish()
 
 	ctx := context.Background()
-	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(dialer()), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(dialer()),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(controller.ErrorsUnaryInterceptor),
+	)
 	if err != nil {
 		t.Fatalf("Failed to dial bufnet: %v", err)
 	}
 	defer conn.Close()
 
-	client := controller.NewUserClient(conn)
+	session := controller.NewSession()
+	client := controller.NewUserClient(conn, session)
 
 	login, password := "user", "password"
-	token := "token"
+	tokens := entity.TokensDTO{AccessToken: "token", RefreshToken: "session.secret", ExpiresIn: 15 * time.Minute}
 
 	t.Run("proper login", func(t *testing.T) {
-		srv.auth.EXPECT().LoginUser(login, password).Return(token, nil)
-		resp, err := client.Login(ctx, login, password)
-		require.Equal(t, token, resp)
+		srv.auth.EXPECT().LoginUser(gomock.Any(), login, password, "").Return(tokens, nil)
+		err := client.Login(ctx, login, password)
 		require.NoError(t, err)
+		require.Equal(t, tokens.AccessToken, session.Token())
+		require.Equal(t, tokens.RefreshToken, session.RefreshToken())
 	})
 
 	t.Run("fail login", func(t *testing.T) {
 		errFail := errors.New("fail")
-		srv.auth.EXPECT().LoginUser(login, password).Return("", errFail)
-		resp, err := client.Login(ctx, login, password)
-		require.Empty(t, resp)
+		srv.auth.EXPECT().LoginUser(gomock.Any(), login, password, "").Return(entity.TokensDTO{}, errFail)
+		err := client.Login(ctx, login, password)
+		require.Error(t, err)
+		require.False(t, controller.IsLocked(err))
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		srv.auth.EXPECT().LoginUser(gomock.Any(), login, password, "").
+			Return(entity.TokensDTO{}, reasonError(codes.Unauthenticated, pb.ErrorReason_INVALID_CREDENTIALS))
+		err := client.Login(ctx, login, password)
+		require.ErrorIs(t, err, controller.ErrInvalidCredentials)
+		require.Equal(t, controller.ErrInvalidCredentials.Error(), err.Error())
+	})
+
+	t.Run("login locked", func(t *testing.T) {
+		srv.auth.EXPECT().LoginUser(gomock.Any(), login, password, "").
+			Return(entity.TokensDTO{}, reasonError(codes.ResourceExhausted, pb.ErrorReason_TOO_MANY_ATTEMPTS))
+		err := client.Login(ctx, login, password)
+		require.True(t, controller.IsLocked(err))
+	})
+
+	t.Run("security events", func(t *testing.T) {
+		now := time.Now().Truncate(time.Second)
+		events := []entity.SecurityEventDTO{{
+			Kind:        entity.SecurityEventLoginLocked,
+			Peer:        "10.0.0.1",
+			LockedUntil: now.Add(time.Minute),
+			CreatedAt:   now,
+		}}
+		srv.auth.EXPECT().SecurityEvents(gomock.Any(), 1).Return(events, nil)
+		got, err := client.SecurityEvents(ctx, "")
+		require.NoError(t, err)
+		require.Len(t, got, 1)
+		require.Equal(t, events[0].Peer, got[0].Peer)
+		require.True(t, events[0].LockedUntil.Equal(got[0].LockedUntil))
+		require.True(t, events[0].CreatedAt.Equal(got[0].CreatedAt))
+	})
+}
+
+func TestSecondFactor(t *testing.T) {
+	mockHelper(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(dialer()),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(controller.ErrorsUnaryInterceptor),
+	)
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	session := controller.NewSession()
+	client := controller.NewUserClient(conn, session)
+
+	login, password := "user", "password"
+	tokens := entity.TokensDTO{AccessToken: "token", RefreshToken: "session.secret", ExpiresIn: 15 * time.Minute}
+
+	t.Run("code without challenge", func(t *testing.T) {
+		require.ErrorIs(t, client.VerifySecondFactor(ctx, "123456"), controller.ErrNoChallenge)
+	})
+
+	t.Run("login requires code", func(t *testing.T) {
+		srv.auth.EXPECT().LoginUser(gomock.Any(), login, password, "").Return(entity.TokensDTO{Challenge: "challenge.secret"}, nil)
+		err := client.Login(ctx, login, password)
+		require.ErrorIs(t, err, controller.ErrSecondFactorRequired)
+		require.Empty(t, session.Token())
+	})
+
+	t.Run("wrong code", func(t *testing.T) {
+		srv.auth.EXPECT().VerifySecondFactor(gomock.Any(), "challenge.secret", "000000", "").
+			Return(entity.TokensDTO{}, reasonError(codes.Unauthenticated, pb.ErrorReason_INVALID_CODE))
+		require.ErrorIs(t, client.VerifySecondFactor(ctx, "000000"), controller.ErrInvalidCode)
+		require.Empty(t, session.Token())
+	})
+
+	t.Run("proper code", func(t *testing.T) {
+		srv.auth.EXPECT().VerifySecondFactor(gomock.Any(), "challenge.secret", "123456", "").Return(tokens, nil)
+		require.NoError(t, client.VerifySecondFactor(ctx, "123456"))
+		require.Equal(t, tokens.AccessToken, session.Token())
+		require.Equal(t, tokens.RefreshToken, session.RefreshToken())
+
+		// незавершённый вход использован
+		require.ErrorIs(t, client.VerifySecondFactor(ctx, "123456"), controller.ErrNoChallenge)
+	})
+
+	t.Run("enroll", func(t *testing.T) {
+		enrollment := entity.TOTPEnrollmentDTO{Secret: "JBSWY3DPEHPK3PXP", Algorithm: "SHA1", Digits: 6, Period: 30}
+		srv.auth.EXPECT().EnrollTOTP(gomock.Any(), 1, "password", "654321", "").Return(enrollment, nil)
+		got, err := client.EnrollTOTP(ctx, session.Token(), "password", "654321")
+		require.NoError(t, err)
+		require.Equal(t, enrollment, got)
+		require.Equal(t,
+			"otpauth://totp/GophKeeper:user?algorithm=SHA1&digits=6&issuer=GophKeeper&period=30&secret=JBSWY3DPEHPK3PXP",
+			controller.TOTPURI("GophKeeper", login, got))
+
+		srv.auth.EXPECT().ConfirmTOTP(gomock.Any(), 1, "123456").Return(nil)
+		require.NoError(t, client.ConfirmTOTP(ctx, session.Token(), "123456"))
+	})
+
+	t.Run("disable", func(t *testing.T) {
+		srv.auth.EXPECT().DisableTOTP(gomock.Any(), 1, "654321").Return(nil)
+		require.NoError(t, client.DisableTOTP(ctx, session.Token(), "654321"))
+	})
+}
+
+func TestChangePassword(t *testing.T) {
+	mockHelper(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(dialer()),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(controller.ErrorsUnaryInterceptor),
+	)
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	session := controller.NewSession()
+	client := controller.NewUserClient(conn, session)
+
+	login, password, newPassword := "user", "password", "new_password"
+	tokens := entity.TokensDTO{AccessToken: "token", RefreshToken: "session.secret", ExpiresIn: 15 * time.Minute}
+
+	t.Run("login with expired password", func(t *testing.T) {
+		srv.auth.EXPECT().LoginUser(gomock.Any(), login, password, "").
+			Return(entity.TokensDTO{}, reasonError(codes.FailedPrecondition, pb.ErrorReason_PASSWORD_EXPIRED))
+		err := client.Login(ctx, login, password)
+		require.True(t, controller.IsPasswordExpired(err))
+		require.Empty(t, session.Token())
+	})
+
+	t.Run("change password", func(t *testing.T) {
+		srv.auth.EXPECT().ChangePassword(gomock.Any(), login, password, newPassword, "", "").Return(tokens, nil)
+		require.NoError(t, client.ChangePassword(ctx, login, password, newPassword, ""))
+		require.Equal(t, tokens.AccessToken, session.Token())
+	})
+
+	t.Run("fail change password", func(t *testing.T) {
+		srv.auth.EXPECT().ChangePassword(gomock.Any(), login, password, newPassword, "", "").Return(entity.TokensDTO{}, errors.New("fail"))
+		err := client.ChangePassword(ctx, login, password, newPassword, "")
+		require.Error(t, err)
+		require.False(t, controller.IsPasswordExpired(err))
+	})
+}
+
+func TestSession(t *testing.T) {
+	mockHelper(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	session := controller.NewSession()
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(dialer()),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(session.UnaryInterceptor),
+	)
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	client := controller.NewUserClient(conn, session)
+
+	login, password := "user", "password"
+
+	// access-токен истекает раньше запаса обновления - перед запросом токены обновляются
+	expiring := entity.TokensDTO{AccessToken: "old_token", RefreshToken: "session.old", ExpiresIn: time.Second}
+	refreshed := entity.TokensDTO{AccessToken: "new_token", RefreshToken: "session.new", ExpiresIn: 15 * time.Minute}
+
+	srv.auth.EXPECT().LoginUser(gomock.Any(), login, password, "").Return(expiring, nil)
+	require.NoError(t, client.Login(ctx, login, password))
+
+	t.Run("refresh before request", func(t *testing.T) {
+		srv.auth.EXPECT().Refresh(gomock.Any(), expiring.RefreshToken).Return(refreshed, nil)
+		srv.auth.EXPECT().Logout(gomock.Any(), refreshed.AccessToken).Return(nil)
+		require.NoError(t, client.Logout(ctx))
+		require.Empty(t, session.Token())
+		require.Empty(t, session.RefreshToken())
+	})
+}
+
+func TestResume(t *testing.T) {
+	mockHelper(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(dialer()), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	dir := t.TempDir()
+	login, password := "user", "password"
+	tokens := entity.TokensDTO{AccessToken: "token", RefreshToken: "session.secret", ExpiresIn: 15 * time.Minute}
+	refreshed := entity.TokensDTO{AccessToken: "new_token", RefreshToken: "session.new", ExpiresIn: 15 * time.Minute}
+
+	first := controller.New(conn, dir, controller.NewSession())
+	srv.auth.EXPECT().LoginUser(gomock.Any(), login, password, "").Return(tokens, nil)
+	require.NoError(t, first.Auth.Login(ctx, login, password))
+	require.NoError(t, first.Keys.Unlock(login, "master"))
+	require.NoError(t, first.Session.Save())
+
+	t.Run("session file is private", func(t *testing.T) {
+		info, err := os.Stat(filepath.Join(dir, "session.bin"))
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+		data, err := os.ReadFile(filepath.Join(dir, "session.bin"))
+		require.NoError(t, err)
+		require.NotContains(t, string(data), tokens.RefreshToken)
+	})
+
+	t.Run("resume valid session", func(t *testing.T) {
+		next := controller.New(conn, dir, controller.NewSession())
+		srv.auth.EXPECT().Refresh(gomock.Any(), tokens.RefreshToken).Return(refreshed, nil)
+		require.NoError(t, next.Auth.Resume(ctx))
+		require.Equal(t, refreshed.AccessToken, next.Session.Token())
+		require.Equal(t, login, next.Session.Login())
+
+		// ключ шифрования данных не сохраняется - до ввода мастер-пароля данные недоступны
+		_, err := next.Pairs.CreatePair(ctx, next.Session.Token(), entity.PairDTO{Login: "l", Password: "p"})
+		require.ErrorIs(t, err, controller.ErrLocked)
+
+		require.NoError(t, next.Keys.Unlock(next.Session.Login(), "master"))
+		_, err = next.Pairs.CreatePair(ctx, next.Session.Token(), entity.PairDTO{Login: "l", Password: "p"})
+		require.NotErrorIs(t, err, controller.ErrLocked)
+	})
+
+	t.Run("session file has no data key", func(t *testing.T) {
+		raw, err := os.ReadFile(filepath.Join(dir, "device.key"))
+		require.NoError(t, err)
+		c, err := encryption.NewCipher(raw)
+		require.NoError(t, err)
+
+		data, err := os.ReadFile(filepath.Join(dir, "session.bin"))
+		require.NoError(t, err)
+		plain, err := c.Decrypt(data)
+		require.NoError(t, err)
+
+		var stored map[string]any
+		require.NoError(t, json.Unmarshal(plain, &stored))
+		require.Equal(t, login, stored["login"])
+		require.NotContains(t, stored, "key")
+	})
+
+	t.Run("rejected session is removed", func(t *testing.T) {
+		next := controller.New(conn, dir, controller.NewSession())
+		srv.auth.EXPECT().Refresh(gomock.Any(), refreshed.RefreshToken).Return(entity.TokensDTO{}, errors.New("session revoked"))
+		require.Error(t, next.Auth.Resume(ctx))
+		require.Empty(t, next.Session.Token())
+
+		require.ErrorIs(t, next.Auth.Resume(ctx), controller.ErrNoSession)
+	})
+}
+
+func TestAccount(t *testing.T) {
+	mockHelper(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(dialer()),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(controller.ErrorsUnaryInterceptor),
+	)
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	dir := t.TempDir()
+	login, master := "user", "master"
+	tokens := entity.TokensDTO{AccessToken: "token", RefreshToken: "session.secret", ExpiresIn: 15 * time.Minute}
+
+	client := controller.New(conn, dir, controller.NewSession())
+	srv.auth.EXPECT().LoginUser(gomock.Any(), login, "password", "").Return(tokens, nil)
+	require.NoError(t, client.Auth.Login(ctx, login, "password"))
+	require.NoError(t, client.Keys.Unlock(login, master))
+	require.NoError(t, client.Session.Save())
+
+	// сервер хранит поля записей зашифрованными ключом пользователя
+	c, err := encryption.NewCipher(encryption.DeriveKey(login, master))
+	require.NoError(t, err)
+	seal := func(plain string) string {
+		out, err := c.EncryptString(plain)
+		require.NoError(t, err)
+		return out
+	}
+	data, err := c.Encrypt([]byte("file content"))
+	require.NoError(t, err)
+
+	t.Run("export decrypted archive", func(t *testing.T) {
+		srv.account.EXPECT().ExportAccount(gomock.Any(), 1).Return(entity.AccountDTO{
+			Login:      login,
+			ExportedAt: time.Now(),
+			Pairs:      []entity.PairDTO{{ID: 1, Login: seal("site"), Password: seal("secret"), Metadata: seal("")}},
+			Binaries:   []entity.BinaryDTO{{ID: 2, Filename: seal("file.txt"), Size: 12, Data: data, Metadata: seal("")}},
+		}, nil)
+
+		path, err := client.Account.ExportAccount(ctx, client.Session.Token(), dir)
+		require.NoError(t, err)
+
+		info, err := os.Stat(path)
+		require.NoError(t, err)
+		require.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+		raw, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		var account entity.AccountDTO
+		require.NoError(t, json.Unmarshal(raw, &account))
+		require.Len(t, account.Pairs, 1)
+		require.Equal(t, "secret", account.Pairs[0].Password)
+		require.Len(t, account.Binaries, 1)
+		require.Equal(t, "file.txt", account.Binaries[0].Filename)
+		require.Equal(t, []byte("file content"), account.Binaries[0].Data)
+	})
+
+	t.Run("delete with wrong password", func(t *testing.T) {
+		srv.account.EXPECT().DeleteAccount(gomock.Any(), 1, "wrong", "", "").Return(reasonError(codes.PermissionDenied, pb.ErrorReason_PASSWORD_MISMATCH))
+		require.ErrorIs(t, client.Account.DeleteAccount(ctx, client.Session.Token(), "wrong", ""), controller.ErrPasswordMismatch)
+		require.NotEmpty(t, client.Session.Token())
+	})
+
+	t.Run("delete account", func(t *testing.T) {
+		srv.account.EXPECT().DeleteAccount(gomock.Any(), 1, "password", "123456", "").Return(nil)
+		require.NoError(t, client.Account.DeleteAccount(ctx, client.Session.Token(), "password", "123456"))
+		require.Empty(t, client.Session.Token())
+
+		// сохранённая сессия удалена
+		_, err := os.Stat(filepath.Join(dir, "session.bin"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+type mockBinaryServer struct {
+	pb.UnimplementedBinaryServer
+	info    *pb.BinaryMsg
+	data    []byte
+	deleted []int64
+	// limit размер принимаемых данных (0 - без ограничения)
+	limit int
+}
+
+func (s *mockBinaryServer) Delete(ctx context.Context, req *pb.DeleteBinaryRequest) (*pb.DeleteBinaryResponse, error) {
+	s.deleted = append(s.deleted, req.GetId())
+	return &pb.DeleteBinaryResponse{}, nil
+}
+
+func (s *mockBinaryServer) Upload(stream pb.Binary_UploadServer) error {
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if info := req.GetInfo(); info != nil {
+			s.info, s.data = info, nil
+		}
+		if s.limit > 0 && len(s.data)+len(req.GetChunk()) > s.limit {
+			return reasonError(codes.ResourceExhausted, pb.ErrorReason_BINARY_TOO_LARGE)
+		}
+		s.data = append(s.data, req.GetChunk()...)
+	}
+
+	return stream.SendAndClose(&pb.UploadBinaryResponse{Id: 1})
+}
+
+func (s *mockBinaryServer) Download(req *pb.DownloadBinaryRequest, stream pb.Binary_DownloadServer) error {
+	err := stream.Send(&pb.DownloadBinaryResponse{Data: &pb.DownloadBinaryResponse_Info{Info: s.info}})
+	if err != nil {
+		return err
+	}
+
+	half := len(s.data) / 2
+	for _, chunk := range [][]byte{s.data[:half], s.data[half:]} {
+		err = stream.Send(&pb.DownloadBinaryResponse{Data: &pb.DownloadBinaryResponse_Chunk{Chunk: chunk}})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func TestBinaryUploadDownload(t *testing.T) {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	binarySrv := &mockBinaryServer{}
+	pb.RegisterBinaryServer(server, binarySrv)
+
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			log.Fatal(err)
+		}
+	}()
+	defer server.Stop()
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStreamInterceptor(controller.ErrorsStreamInterceptor))
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	keys := &controller.Keys{}
+	require.NoError(t, keys.Unlock("user", "master"))
+	client := controller.NewBinaryClient(conn, keys, nil)
+
+	data := make([]byte, 200*1024)
+	_, err = rand.Read(data)
+	require.NoError(t, err)
+
+	src := filepath.Join(t.TempDir(), "source.bin")
+	require.NoError(t, os.WriteFile(src, data, 0o600))
+
+	t.Run("upload file", func(t *testing.T) {
+		id, err := client.UploadBinary(ctx, "token", src, "meta", entity.Labels{})
+		require.NoError(t, err)
+		require.Equal(t, 1, id)
+	})
+
+	t.Run("server receives only ciphertext", func(t *testing.T) {
+		require.True(t, encryption.IsEncrypted(binarySrv.info.GetFilename()))
+		require.True(t, encryption.IsEncrypted(binarySrv.info.GetMetadata()))
+		require.True(t, encryption.IsEncryptedBytes(binarySrv.data))
+		require.Len(t, binarySrv.data, len(data)+encryption.Overhead)
+		require.Equal(t, int64(len(data)), binarySrv.info.GetSize())
+	})
+
+	t.Run("download file", func(t *testing.T) {
+		dir := t.TempDir()
+		path, err := client.DownloadBinary(ctx, "token", 1, dir)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(dir, "source.bin"), path)
+
+		got, err := os.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, data, got)
+	})
+
+	t.Run("download does not replace existing file", func(t *testing.T) {
+		dir := t.TempDir()
+		existing := filepath.Join(dir, "source.bin")
+		require.NoError(t, os.WriteFile(existing, []byte("local"), 0o600))
+
+		path, err := client.DownloadBinary(ctx, "token", 1, dir)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(dir, "source (1).bin"), path)
+
+		path, err = client.DownloadBinary(ctx, "token", 1, dir)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(dir, "source (2).bin"), path)
+
+		got, err := os.ReadFile(existing)
+		require.NoError(t, err)
+		require.Equal(t, []byte("local"), got)
+
+		got, err = os.ReadFile(path)
+		require.NoError(t, err)
+		require.Equal(t, data, got)
+
+		// временные файлы не остаются
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		require.Len(t, entries, 3)
+	})
+
+	t.Run("download keeps file inside dir", func(t *testing.T) {
+		info := binarySrv.info
+		defer func() { binarySrv.info = info }()
+
+		c, err := encryption.NewCipher(encryption.DeriveKey("user", "master"))
+		require.NoError(t, err)
+
+		for filename, want := range map[string]error{
+			"../evil":     nil,
+			"/tmp/evil":   nil,
+			"..":          controller.ErrInvalidFilename,
+			"":            controller.ErrInvalidFilename,
+			"/":           controller.ErrInvalidFilename,
+			"nested/../.": controller.ErrInvalidFilename,
+		} {
+			sealed, err := c.EncryptString(filename)
+			require.NoError(t, err)
+			binarySrv.info = &pb.BinaryMsg{Id: info.GetId(), Filename: sealed, Metadata: info.GetMetadata()}
+
+			dir := t.TempDir()
+			path, err := client.DownloadBinary(ctx, "token", 1, dir)
+			require.ErrorIs(t, err, want, filename)
+			if want == nil {
+				require.Equal(t, filepath.Join(dir, "evil"), path)
+			}
+		}
+	})
+
+	t.Run("download with wrong master password", func(t *testing.T) {
+		other := &controller.Keys{}
+		require.NoError(t, other.Unlock("user", "wrong"))
+		_, err := controller.NewBinaryClient(conn, other, nil).DownloadBinary(ctx, "token", 1, t.TempDir())
+		require.ErrorIs(t, err, encryption.ErrKeyMismatch)
+	})
+
+	t.Run("upload with locked vault", func(t *testing.T) {
+		_, err := controller.NewBinaryClient(conn, &controller.Keys{}, nil).UploadBinary(ctx, "token", src, "", entity.Labels{})
+		require.ErrorIs(t, err, controller.ErrLocked)
+	})
+
+	t.Run("upload file larger than server limit", func(t *testing.T) {
+		info, stored := binarySrv.info, binarySrv.data
+		binarySrv.limit = len(data)
+		defer func() { binarySrv.info, binarySrv.data, binarySrv.limit = info, stored, 0 }()
+
+		_, err := client.UploadBinary(ctx, "token", src, "", entity.Labels{})
+		require.ErrorIs(t, err, controller.ErrTooLarge)
+	})
+
+	t.Run("upload file larger than client limit", func(t *testing.T) {
+		large := filepath.Join(t.TempDir(), "large.bin")
+		f, err := os.Create(large)
+		require.NoError(t, err)
+		require.NoError(t, f.Truncate(controller.MaxUploadSize+1))
+		require.NoError(t, f.Close())
+
+		info := binarySrv.info
+		_, err = client.UploadBinary(ctx, "token", large, "", entity.Labels{})
+		require.ErrorIs(t, err, controller.ErrTooLarge)
+		require.Same(t, info, binarySrv.info)
+	})
+
+	t.Run("upload not exist file", func(t *testing.T) {
+		_, err := client.UploadBinary(ctx, "token", filepath.Join(t.TempDir(), "none"), "", entity.Labels{})
+		require.Error(t, err)
+	})
+}
+
+type mockPairServer struct {
+	pb.UnimplementedPairServer
+	pairs []*pb.PairMsg
+}
+
+// GetAll отдаёт по одной записи на страницу (токен - номер следующей записи).
+func (s *mockPairServer) GetAll(ctx context.Context, req *pb.GetAllPairsRequest) (*pb.GetAllPairsResponse, error) {
+	i, _ := strconv.Atoi(req.GetPage().GetToken())
+	if i >= len(s.pairs) {
+		return &pb.GetAllPairsResponse{}, nil
+	}
+
+	resp := &pb.GetAllPairsResponse{Pairs: s.pairs[i : i+1]}
+	if i+1 < len(s.pairs) {
+		resp.NextPageToken = strconv.Itoa(i + 1)
+	}
+	return resp, nil
+}
+
+func (s *mockPairServer) Update(ctx context.Context, req *pb.UpdatePairRequest) (*pb.UpdatePairResponse, error) {
+	s.pairs = append(s.pairs, req.GetPair())
+	return &pb.UpdatePairResponse{}, nil
+}
+
+func TestMigratePlaintext(t *testing.T) {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+
+	encrypted, err := encryptString("user", "master", "encrypted")
+	require.NoError(t, err)
+
+	syncSrv := &mockSyncServer{responses: map[int64]*pb.SyncResponse{
+		0: {
+			Revision: 3,
+			Full:     true,
+			Pairs:    []*pb.PairMsg{{Id: 1, Login: "legacy", Password: encrypted}, {Id: 2, Login: encrypted}},
+			Binaries: []*pb.BinaryMsg{{Id: 7, Filename: encrypted}},
+		},
+	}}
+	pairSrv := &mockPairServer{}
+	// файл до включения шифрования, случайно начинающийся с признака шифротекста
+	binarySrv := &mockBinaryServer{info: &pb.BinaryMsg{Id: 7, Filename: "old.txt"}, data: []byte("GK legacy file")}
+	pb.RegisterSyncServer(server, syncSrv)
+	pb.RegisterPairServer(server, pairSrv)
+	pb.RegisterBinaryServer(server, binarySrv)
+
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(controller.ErrorsUnaryInterceptor))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := controller.New(conn, t.TempDir(), controller.NewSession())
+	require.NoError(t, client.Keys.Unlock("user", "master"))
+
+	t.Run("plaintext from server rejected", func(t *testing.T) {
+		require.ErrorIs(t, client.Sync.Sync(ctx, "token"), encryption.ErrNotEncrypted)
+	})
+
+	t.Run("migration encrypts legacy records", func(t *testing.T) {
+		migrated, err := client.MigratePlaintext(ctx, "token")
+		require.NoError(t, err)
+		require.Equal(t, 2, migrated)
+
+		// пара с открытым логином сохранена заново, зашифрованная - не изменялась
+		require.Len(t, pairSrv.pairs, 1)
+		updated := pairSrv.pairs[0]
+		require.Equal(t, int64(1), updated.GetId())
+		require.True(t, encryption.IsEncrypted(updated.GetLogin()))
+		login, err := encryptionOpen("user", "master", updated.GetLogin())
+		require.NoError(t, err)
+		require.Equal(t, "legacy", login)
+		password, err := encryptionOpen("user", "master", updated.GetPassword())
+		require.NoError(t, err)
+		require.Equal(t, "encrypted", password)
+
+		// файл загружен заново в зашифрованном виде, прежняя запись удалена
+		require.True(t, encryption.IsEncrypted(binarySrv.info.GetFilename()))
+		c, err := encryption.NewCipher(encryption.DeriveKey("user", "master"))
+		require.NoError(t, err)
+		data, err := c.Decrypt(binarySrv.data)
+		require.NoError(t, err)
+		require.Equal(t, []byte("GK legacy file"), data)
+		require.Equal(t, []int64{7}, binarySrv.deleted)
+	})
+
+	t.Run("plaintext rejected after migration", func(t *testing.T) {
+		require.ErrorIs(t, client.Sync.Sync(ctx, "token"), encryption.ErrNotEncrypted)
+	})
+}
+
+// encryptionOpen расшифровывает значение, зашифрованное клиентом.
+func encryptionOpen(login, master, value string) (string, error) {
+	c, err := encryption.NewCipher(encryption.DeriveKey(login, master))
+	if err != nil {
+		return "", err
+	}
+
+	return c.DecryptString(value)
+}
+
+func TestOfflineCache(t *testing.T) {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+
+	keys := &controller.Keys{}
+	require.NoError(t, keys.Unlock("user", "master"))
+
+	login, err := encryptString("user", "master", "login")
+	require.NoError(t, err)
+	second, err := encryptString("user", "master", "second")
+	require.NoError(t, err)
+	pb.RegisterPairServer(server, &mockPairServer{pairs: []*pb.PairMsg{{Id: 1, Login: login}, {Id: 2, Login: second}}})
+
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	dir := t.TempDir()
+	client := controller.NewPairsClient(conn, keys, controller.NewCache(dir, keys))
+
+	t.Run("list page", func(t *testing.T) {
+		pairs, next, err := client.ListPairs(ctx, "token", entity.PageRequest{Size: 1})
+		require.NoError(t, err)
+		require.Len(t, pairs, 1)
+		require.Equal(t, "login", pairs[0].Login)
+		require.Equal(t, "1", next)
+	})
+
+	t.Run("online fills cache", func(t *testing.T) {
+		pairs, err := client.ViewAllPairs(ctx, "token")
+		require.NoError(t, err)
+		require.Len(t, pairs, 2)
+		require.Equal(t, "login", pairs[0].Login)
+		require.Equal(t, "second", pairs[1].Login)
+	})
+
+	t.Run("cache is encrypted", func(t *testing.T) {
+		err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			require.True(t, encryption.IsEncryptedBytes(data))
+			require.NotContains(t, string(data), "login")
+			return nil
+		})
+		require.NoError(t, err)
+	})
+
+	server.Stop()
+
+	t.Run("offline serves cache", func(t *testing.T) {
+		pairs, err := client.ViewAllPairs(ctx, "token")
+
+		var offline *controller.OfflineError
+		require.ErrorAs(t, err, &offline)
+		require.True(t, controller.IsUnavailable(offline.Err))
+		require.WithinDuration(t, time.Now(), offline.SavedAt, time.Minute)
+		require.Len(t, pairs, 2)
+		require.Equal(t, "login", pairs[0].Login)
+	})
+
+	t.Run("offline with another master password", func(t *testing.T) {
+		other := &controller.Keys{}
+		require.NoError(t, other.Unlock("user", "wrong"))
+
+		_, err := controller.NewPairsClient(conn, other, controller.NewCache(dir, other)).ViewAllPairs(ctx, "token")
 		require.Error(t, err)
+
+		var offline *controller.OfflineError
+		require.False(t, errors.As(err, &offline))
 	})
 }
+
+type mockSyncServer struct {
+	pb.UnimplementedSyncServer
+	responses map[int64]*pb.SyncResponse
+	requests  []int64
+}
+
+func (s *mockSyncServer) Sync(ctx context.Context, req *pb.SyncRequest) (*pb.SyncResponse, error) {
+	s.requests = append(s.requests, req.GetSince())
+	return s.responses[req.GetSince()], nil
+}
+
+func TestSync(t *testing.T) {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+
+	keys := &controller.Keys{}
+	require.NoError(t, keys.Unlock("user", "master"))
+
+	first, err := encryptString("user", "master", "first")
+	require.NoError(t, err)
+	second, err := encryptString("user", "master", "second")
+	require.NoError(t, err)
+	changed, err := encryptString("user", "master", "changed")
+	require.NoError(t, err)
+
+	syncSrv := &mockSyncServer{responses: map[int64]*pb.SyncResponse{
+		0: {
+			Revision: 3,
+			Full:     true,
+			Pairs:    []*pb.PairMsg{{Id: 2, Login: second}, {Id: 1, Login: first}},
+			Notes:    []*pb.NoteMsg{{Id: 5, Note: first}},
+		},
+		3: {
+			Revision: 5,
+			Pairs:    []*pb.PairMsg{{Id: 2, Login: changed}},
+			Deleted:  []*pb.TombstoneMsg{{Kind: "pair", Id: 1}},
+		},
+		5: {Revision: 5},
+	}}
+	pb.RegisterSyncServer(server, syncSrv)
+
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	dir := t.TempDir()
+	client := controller.NewSyncClient(conn, keys, controller.NewCache(dir, keys))
+
+	t.Run("initial full sync", func(t *testing.T) {
+		require.NoError(t, client.Sync(ctx, "token"))
+		require.Equal(t, int64(3), client.Revision())
+
+		pairs := client.Pairs()
+		require.Len(t, pairs, 2)
+		require.Equal(t, "first", pairs[0].Login)
+		require.Equal(t, "second", pairs[1].Login)
+		require.Len(t, client.Notes(), 1)
+	})
+
+	t.Run("delta applied", func(t *testing.T) {
+		require.NoError(t, client.Sync(ctx, "token"))
+		require.Equal(t, int64(5), client.Revision())
+
+		pairs := client.Pairs()
+		require.Len(t, pairs, 1)
+		require.Equal(t, 2, pairs[0].ID)
+		require.Equal(t, "changed", pairs[0].Login)
+		require.Len(t, client.Notes(), 1)
+	})
+
+	t.Run("cursor restored from cache", func(t *testing.T) {
+		restored := controller.NewSyncClient(conn, keys, controller.NewCache(dir, keys))
+		require.NoError(t, restored.Sync(ctx, "token"))
+		require.Equal(t, []int64{0, 3, 5}, syncSrv.requests)
+		require.Equal(t, client.Pairs(), restored.Pairs())
+	})
+
+	server.Stop()
+
+	t.Run("offline keeps state", func(t *testing.T) {
+		err := client.Sync(ctx, "token")
+
+		var offline *controller.OfflineError
+		require.ErrorAs(t, err, &offline)
+		require.Equal(t, int64(5), client.Revision())
+		require.Len(t, client.Pairs(), 1)
+	})
+
+	t.Run("locked keys", func(t *testing.T) {
+		locked := controller.NewSyncClient(conn, &controller.Keys{}, nil)
+		require.ErrorIs(t, locked.Sync(ctx, "token"), controller.ErrLocked)
+	})
+}
+
+func TestSearch(t *testing.T) {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+
+	keys := &controller.Keys{}
+	require.NoError(t, keys.Unlock("user", "master"))
+
+	note, err := encryptString("user", "master", "VPN settings")
+	require.NoError(t, err)
+	metadata, err := encryptString("user", "master", "office vpn")
+	require.NoError(t, err)
+
+	pb.RegisterSyncServer(server, &mockSyncServer{responses: map[int64]*pb.SyncResponse{
+		0: {
+			Revision: 2,
+			Full:     true,
+			Pairs:    []*pb.PairMsg{{Id: 2, Metadata: metadata}},
+			Notes:    []*pb.NoteMsg{{Id: 5, Note: note}},
+		},
+	}})
+
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(controller.ErrorsUnaryInterceptor))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	sync := controller.NewSyncClient(conn, keys, controller.NewCache(t.TempDir(), keys))
+	require.NoError(t, sync.Sync(ctx, "token"))
+	client := controller.NewSearchClient(sync)
+
+	t.Run("local hits", func(t *testing.T) {
+		hits, err := client.Search("vpn", 0)
+		require.NoError(t, err)
+		require.Equal(t, []entity.SearchHitDTO{
+			{Kind: "note", ID: 5, Rank: 1, Snippet: "«VPN» settings"},
+			{Kind: "pair", ID: 2, Rank: 0.5, Snippet: "office «vpn»"},
+		}, hits)
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		hits, err := client.Search("vpn", 1)
+		require.NoError(t, err)
+		require.Len(t, hits, 1)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		_, err := client.Search(" ", 0)
+		require.ErrorIs(t, err, controller.ErrInvalidQuery)
+		_, err = client.Search(strings.Repeat("a", 257), 0)
+		require.ErrorIs(t, err, controller.ErrInvalidQuery)
+	})
+}
+
+type mockLabelsServer struct {
+	pb.UnimplementedLabelsServer
+	folders []*pb.FolderMsg
+	tags    []*pb.TagMsg
+}
+
+func (s *mockLabelsServer) ListFolders(ctx context.Context, req *pb.ListFoldersRequest) (*pb.ListFoldersResponse, error) {
+	return &pb.ListFoldersResponse{Folders: s.folders}, nil
+}
+
+func (s *mockLabelsServer) CreateFolder(ctx context.Context, req *pb.CreateFolderRequest) (*pb.CreateFolderResponse, error) {
+	if req.GetFolder().GetName() == "" {
+		return nil, reasonError(codes.InvalidArgument, pb.ErrorReason_INVALID_LABEL)
+	}
+	folder := &pb.FolderMsg{
+		Id:       int64(len(s.folders) + 1),
+		ParentId: req.GetFolder().GetParentId(),
+		Name:     req.GetFolder().GetName(),
+	}
+	s.folders = append(s.folders, folder)
+	return &pb.CreateFolderResponse{Id: folder.Id}, nil
+}
+
+func (s *mockLabelsServer) ListTags(ctx context.Context, req *pb.ListTagsRequest) (*pb.ListTagsResponse, error) {
+	return &pb.ListTagsResponse{Tags: s.tags}, nil
+}
+
+func (s *mockLabelsServer) CreateTag(ctx context.Context, req *pb.CreateTagRequest) (*pb.CreateTagResponse, error) {
+	tag := &pb.TagMsg{Id: int64(len(s.tags) + 1), Name: req.GetTag().GetName()}
+	s.tags = append(s.tags, tag)
+	return &pb.CreateTagResponse{Id: tag.Id}, nil
+}
+
+func (s *mockLabelsServer) RenameTag(ctx context.Context, req *pb.RenameTagRequest) (*pb.RenameTagResponse, error) {
+	for _, tag := range s.tags {
+		if tag.Id == req.GetId() {
+			tag.Name = req.GetName()
+			return &pb.RenameTagResponse{}, nil
+		}
+	}
+	return nil, reasonError(codes.NotFound, pb.ErrorReason_NOT_FOUND)
+}
+
+func TestLabels(t *testing.T) {
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+
+	labels := &mockLabelsServer{}
+	pb.RegisterLabelsServer(server, labels)
+
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			log.Fatal(err)
+		}
+	}()
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(controller.ErrorsUnaryInterceptor))
+	require.NoError(t, err)
+	defer conn.Close()
+
+	keys := &controller.Keys{}
+	require.NoError(t, keys.Unlock("user", "master"))
+	client := controller.NewLabelsClient(conn, keys, controller.NewCache(t.TempDir(), keys))
+
+	t.Run("names are encrypted on the wire", func(t *testing.T) {
+		workID, err := client.CreateFolder(ctx, "token", entity.FolderDTO{Name: "work"})
+		require.NoError(t, err)
+		_, err = client.CreateFolder(ctx, "token", entity.FolderDTO{ParentID: workID, Name: "vpn"})
+		require.NoError(t, err)
+		tagID, err := client.CreateTag(ctx, "token", entity.TagDTO{Name: "office"})
+		require.NoError(t, err)
+		require.NoError(t, client.RenameTag(ctx, "token", tagID, "home"))
+
+		require.Len(t, labels.folders, 2)
+		require.NotContains(t, labels.folders[0].Name, "work")
+		require.NotContains(t, labels.tags[0].Name, "home")
+	})
+
+	t.Run("names are decrypted on list", func(t *testing.T) {
+		folders, err := client.ListFolders(ctx, "token")
+		require.NoError(t, err)
+		require.Equal(t, []entity.FolderDTO{{ID: 1, Name: "work"}, {ID: 2, ParentID: 1, Name: "vpn"}}, folders)
+
+		tags, err := client.ListTags(ctx, "token")
+		require.NoError(t, err)
+		require.Equal(t, []entity.TagDTO{{ID: 1, Name: "home"}}, tags)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		require.ErrorIs(t, client.RenameTag(ctx, "token", 9, "x"), controller.ErrNotFound)
+
+		locked := controller.NewLabelsClient(conn, &controller.Keys{}, nil)
+		_, err := locked.CreateTag(ctx, "token", entity.TagDTO{Name: "x"})
+		require.ErrorIs(t, err, controller.ErrLocked)
+	})
+
+	server.Stop()
+
+	t.Run("offline returns cached labels", func(t *testing.T) {
+		folders, err := client.ListFolders(ctx, "token")
+		var offline *controller.OfflineError
+		require.ErrorAs(t, err, &offline)
+		require.Len(t, folders, 2)
+		require.Equal(t, "vpn", folders[1].Name)
+
+		tags, err := client.ListTags(ctx, "token")
+		require.ErrorAs(t, err, &offline)
+		require.Len(t, tags, 1)
+	})
+}
+
+// encryptString шифрует значение так же, как это делает клиент перед отправкой на сервер.
+func encryptString(login, master, value string) (string, error) {
+	c, err := encryption.NewCipher(encryption.DeriveKey(login, master))
+	if err != nil {
+		return "", err
+	}
+
+	return c.EncryptString(value)
+}
+
+func TestInfo(t *testing.T) {
+	info := entity.ServerInfoDTO{
+		Version:   "1.2.3",
+		BuildTime: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+		Features:  []string{"sync", "otp"},
+	}
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	pb.RegisterInfoServer(server, serverController.NewInfoServer(info))
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			log.Fatal(err)
+		}
+	}()
+	defer server.Stop()
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := controller.NewInfoClient(conn)
+	require.Equal(t, "bufnet", client.Target())
+
+	got, err := client.Info(ctx)
+	require.NoError(t, err)
+	require.Equal(t, info.Version, got.Version)
+	require.True(t, info.BuildTime.Equal(got.BuildTime))
+	require.Equal(t, info.Features, got.Features)
+	require.Equal(t, connectivity.Ready, client.State())
+}
@@ -0,0 +1,168 @@
+package controller
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/PaulYakow/gophkeeper/proto"
+)
+
+// Ошибки запросов к серверу с известной причиной (сопоставляются через errors.Is).
+var (
+	// ErrLoginExists логин уже занят.
+	ErrLoginExists = errors.New("login already exists")
+	// ErrInvalidCredentials неверный логин или пароль.
+	ErrInvalidCredentials = errors.New("invalid login or password")
+	// ErrPasswordMismatch неверный текущий пароль (подтверждение операции).
+	ErrPasswordMismatch = errors.New("wrong password")
+	// ErrPasswordExpired срок действия пароля истёк, пароль необходимо сменить.
+	ErrPasswordExpired = errors.New("password expired: choose a new password")
+	// ErrPasswordReused новый пароль недавно использовался.
+	ErrPasswordReused = errors.New("password was used recently: choose another one")
+	// ErrTooManyAttempts вход заблокирован после серии неудачных попыток.
+	ErrTooManyAttempts = errors.New("too many failed attempts: try again later")
+	// ErrInvalidCode неверный или уже использованный код аутентификатора.
+	ErrInvalidCode = errors.New("invalid one-time code")
+	// ErrChallengeExpired незавершённый вход истёк - нужно снова ввести логин и пароль.
+	ErrChallengeExpired = errors.New("sign in expired: enter login and password again")
+	// ErrTOTPNotEnrolled аутентификатор не подключён.
+	ErrTOTPNotEnrolled = errors.New("authenticator is not enrolled")
+	// ErrNotFound запись не найдена (например, удалена на другом устройстве).
+	ErrNotFound = errors.New("record not found")
+	// ErrTooLarge файл превышает допустимый размер.
+	ErrTooLarge = errors.New("file is too large")
+	// ErrInvalidFilename сервер вернул имя файла, которое нельзя использовать для сохранения.
+	ErrInvalidFilename = errors.New("invalid file name")
+	// ErrInvalidOTP некорректные параметры одноразового пароля.
+	ErrInvalidOTP = errors.New("invalid one-time password parameters")
+	// ErrInvalidPage некорректные параметры страницы списка (например, токен устарел).
+	ErrInvalidPage = errors.New("invalid list page request")
+	// ErrInvalidQuery некорректный поисковый запрос (пустой или слишком длинный).
+	ErrInvalidQuery = errors.New("invalid search query: enter words to find")
+	// ErrInvalidLabel некорректная папка или метка (например, пустое название).
+	ErrInvalidLabel = errors.New("invalid folder or tag: enter a name")
+	// ErrServerInternal внутренняя ошибка сервера.
+	ErrServerInternal = errors.New("server error: try again later")
+)
+
+// reasonErrors соответствие причин ошибок сервера (proto/errors.proto) ошибкам клиента.
+var reasonErrors = map[pb.ErrorReason]error{
+	pb.ErrorReason_USER_EXISTS:            ErrLoginExists,
+	pb.ErrorReason_NOT_FOUND:              ErrNotFound,
+	pb.ErrorReason_INVALID_CREDENTIALS:    ErrInvalidCredentials,
+	pb.ErrorReason_PASSWORD_MISMATCH:      ErrPasswordMismatch,
+	pb.ErrorReason_PASSWORD_EXPIRED:       ErrPasswordExpired,
+	pb.ErrorReason_PASSWORD_REUSED:        ErrPasswordReused,
+	pb.ErrorReason_TOO_MANY_ATTEMPTS:      ErrTooManyAttempts,
+	pb.ErrorReason_SECOND_FACTOR_REQUIRED: ErrSecondFactorRequired,
+	pb.ErrorReason_INVALID_CODE:           ErrInvalidCode,
+	pb.ErrorReason_INVALID_CHALLENGE:      ErrChallengeExpired,
+	pb.ErrorReason_TOTP_NOT_ENROLLED:      ErrTOTPNotEnrolled,
+	pb.ErrorReason_TOKEN_MISSING:          ErrSessionExpired,
+	pb.ErrorReason_TOKEN_EXPIRED:          ErrSessionExpired,
+	pb.ErrorReason_SESSION_INVALID:        ErrSessionExpired,
+	pb.ErrorReason_BINARY_TOO_LARGE:       ErrTooLarge,
+	pb.ErrorReason_INVALID_OTP:            ErrInvalidOTP,
+	pb.ErrorReason_INVALID_PAGE:           ErrInvalidPage,
+	pb.ErrorReason_INVALID_LABEL:          ErrInvalidLabel,
+	pb.ErrorReason_INTERNAL:               ErrServerInternal,
+}
+
+// ServerError ошибка запроса к серверу с известной причиной.
+//
+// errors.Is сопоставляет её с ошибками пакета (ErrLoginExists, ...), статус gRPC сохраняется
+// (status.Code возвращает код ответа сервера).
+type ServerError struct {
+	Reason pb.ErrorReason
+	err    error
+	status *status.Status
+}
+
+// Error возвращает описание ошибки для пользователя.
+func (e *ServerError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap возвращает ошибку пакета, соответствующую причине.
+func (e *ServerError) Unwrap() error {
+	return e.err
+}
+
+// GRPCStatus возвращает статус ответа сервера.
+func (e *ServerError) GRPCStatus() *status.Status {
+	return e.status
+}
+
+// typedError преобразует статус gRPC с причиной (errdetails.ErrorInfo) в ServerError.
+//
+// Ошибки без известной причины (в том числе недоступность сервера) возвращаются как есть.
+func typedError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	st, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+
+	for _, detail := range st.Details() {
+		info, ok := detail.(*errdetails.ErrorInfo)
+		if !ok {
+			continue
+		}
+
+		reason := pb.ErrorReason(pb.ErrorReason_value[info.GetReason()])
+		if known, ok := reasonErrors[reason]; ok {
+			return &ServerError{Reason: reason, err: known, status: st}
+		}
+	}
+
+	return err
+}
+
+// ErrorsUnaryInterceptor преобразует ошибки запросов к серверу в ошибки пакета (ServerError).
+func ErrorsUnaryInterceptor(ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	return typedError(invoker(ctx, method, req, reply, cc, opts...))
+}
+
+// ErrorsStreamInterceptor преобразует ошибки потоковых запросов к серверу в ошибки пакета (ServerError).
+func ErrorsStreamInterceptor(ctx context.Context,
+	desc *grpc.StreamDesc,
+	cc *grpc.ClientConn,
+	method string,
+	streamer grpc.Streamer,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	stream, err := streamer(ctx, desc, cc, method, opts...)
+	if err != nil {
+		return nil, typedError(err)
+	}
+
+	return &typedStream{stream}, nil
+}
+
+// typedStream преобразует ошибки потока в ошибки пакета.
+type typedStream struct {
+	grpc.ClientStream
+}
+
+// SendMsg отправляет сообщение потока.
+func (s *typedStream) SendMsg(m interface{}) error {
+	return typedError(s.ClientStream.SendMsg(m))
+}
+
+// RecvMsg получает сообщение потока (io.EOF - конец потока - возвращается как есть).
+func (s *typedStream) RecvMsg(m interface{}) error {
+	return typedError(s.ClientStream.RecvMsg(m))
+}
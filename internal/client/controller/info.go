@@ -0,0 +1,57 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	pb "github.com/PaulYakow/gophkeeper/proto"
+)
+
+// InfoClient обеспечивает получение информации о сервере и состояния соединения с ним.
+type InfoClient struct {
+	conn *grpc.ClientConn
+}
+
+// NewInfoClient создаёт объект InfoClient.
+func NewInfoClient(conn *grpc.ClientConn) *InfoClient {
+	return &InfoClient{
+		conn: conn,
+	}
+}
+
+// Info получает с сервера его версию, время сборки и поддерживаемые возможности (вход не требуется).
+func (c *InfoClient) Info(ctx context.Context) (entity.ServerInfoDTO, error) {
+	resp, err := pb.NewInfoClient(c.conn).Info(ctx, &pb.InfoRequest{})
+	if err != nil {
+		return entity.ServerInfoDTO{}, err
+	}
+
+	info := entity.ServerInfoDTO{
+		Version:  resp.GetVersion(),
+		Features: resp.GetFeatures(),
+	}
+	if resp.GetBuildTime() != 0 {
+		info.BuildTime = time.Unix(resp.GetBuildTime(), 0)
+	}
+
+	return info, nil
+}
+
+// Target возвращает адрес сервера.
+func (c *InfoClient) Target() string {
+	return c.conn.Target()
+}
+
+// State возвращает текущее состояние соединения с сервером.
+func (c *InfoClient) State() connectivity.State {
+	return c.conn.GetState()
+}
+
+// WaitForStateChange ожидает изменения состояния соединения (относительно state) или отмены ctx.
+func (c *InfoClient) WaitForStateChange(ctx context.Context, state connectivity.State) bool {
+	return c.conn.WaitForStateChange(ctx, state)
+}
@@ -0,0 +1,170 @@
+package controller
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/PaulYakow/gophkeeper/internal/utils/encryption"
+)
+
+// ErrLocked ошибка обращения к данным до ввода мастер-пароля.
+var ErrLocked = errors.New("vault is locked: master password required")
+
+// Keys хранит ключ сквозного шифрования данных пользователя.
+//
+// Ключ выводится из мастер-пароля при входе и никогда не передаётся на сервер:
+// все поля записей шифруются перед отправкой и расшифровываются после получения.
+// Значения без шифротекста отклоняются, кроме как на время миграции (MigratePlaintext).
+type Keys struct {
+	mu     sync.RWMutex
+	cipher *encryption.Cipher
+	login  string
+	legacy bool
+}
+
+// Unlock выводит ключ шифрования из логина и мастер-пароля пользователя.
+func (k *Keys) Unlock(login, masterPassword string) error {
+	c, err := encryption.NewCipher(encryption.DeriveKey(login, masterPassword))
+	if err != nil {
+		return err
+	}
+
+	k.mu.Lock()
+	k.cipher, k.login = c, login
+	k.mu.Unlock()
+
+	return nil
+}
+
+// Lock удаляет ключ шифрования из памяти (при выходе пользователя).
+func (k *Keys) Lock() {
+	k.mu.Lock()
+	k.cipher, k.login, k.legacy = nil, "", false
+	k.mu.Unlock()
+}
+
+// Login возвращает логин пользователя, для которого выведен ключ (пустая строка - ключа нет).
+func (k *Keys) Login() string {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	return k.login
+}
+
+// allowPlaintext разрешает (on) или снова запрещает принимать значения, сохранённые до включения шифрования.
+func (k *Keys) allowPlaintext(on bool) {
+	k.mu.Lock()
+	k.legacy = on
+	k.mu.Unlock()
+}
+
+func (k *Keys) get() (*encryption.Cipher, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	if k.cipher == nil {
+		return nil, ErrLocked
+	}
+
+	return k.cipher, nil
+}
+
+// owner возвращает логин пользователя, для которого выведен ключ.
+func (k *Keys) owner() (string, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	if k.cipher == nil {
+		return "", ErrLocked
+	}
+
+	return k.login, nil
+}
+
+// seal шифрует переданные поля записи на месте.
+func (k *Keys) seal(fields ...*string) error {
+	c, err := k.get()
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		if *field, err = c.EncryptString(*field); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// open расшифровывает переданные поля записи на месте.
+func (k *Keys) open(fields ...*string) error {
+	_, err := k.openLegacy(fields...)
+	return err
+}
+
+// openLegacy расшифровывает переданные поля записи на месте и сообщает, были ли среди них
+// значения без шифротекста (принимаются только на время миграции).
+func (k *Keys) openLegacy(fields ...*string) (bool, error) {
+	c, legacy, err := k.getLegacy()
+	if err != nil {
+		return false, err
+	}
+
+	var found bool
+	for _, field := range fields {
+		if !legacy {
+			if *field, err = c.DecryptString(*field); err != nil {
+				return false, err
+			}
+			continue
+		}
+
+		var plain bool
+		if *field, plain, err = c.DecryptStringLegacy(*field); err != nil {
+			return false, err
+		}
+		found = found || plain
+	}
+
+	return found, nil
+}
+
+func (k *Keys) sealBytes(data []byte) ([]byte, error) {
+	c, err := k.get()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Encrypt(data)
+}
+
+func (k *Keys) openBytes(data []byte) ([]byte, error) {
+	data, _, err := k.openBytesLegacy(data)
+	return data, err
+}
+
+func (k *Keys) openBytesLegacy(data []byte) ([]byte, bool, error) {
+	c, legacy, err := k.getLegacy()
+	if err != nil {
+		return nil, false, err
+	}
+
+	if !legacy {
+		data, err = c.Decrypt(data)
+		return data, false, err
+	}
+
+	return c.DecryptLegacy(data)
+}
+
+func (k *Keys) getLegacy() (*encryption.Cipher, bool, error) {
+	k.mu.RLock()
+	defer k.mu.RUnlock()
+
+	if k.cipher == nil {
+		return nil, false, ErrLocked
+	}
+
+	return k.cipher, k.legacy, nil
+}
@@ -0,0 +1,262 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	pb "github.com/PaulYakow/gophkeeper/proto"
+)
+
+// LabelsClient обеспечивает обмен данными о папках и метках записей пользователя.
+//
+// Названия папок и меток шифруются ключом пользователя, как и поля записей.
+type LabelsClient struct {
+	conn  *grpc.ClientConn
+	keys  *Keys
+	cache *Cache
+}
+
+// NewLabelsClient создаёт объект LabelsClient.
+func NewLabelsClient(conn *grpc.ClientConn, keys *Keys, cache *Cache) *LabelsClient {
+	return &LabelsClient{
+		conn:  conn,
+		keys:  keys,
+		cache: cache,
+	}
+}
+
+// ListFolders запрашивает все папки пользователя (дерево задаётся ParentID).
+//
+// При недоступности сервера возвращает папки из локального кеша вместе с ошибкой *OfflineError.
+func (c *LabelsClient) ListFolders(ctx context.Context, token string) ([]entity.FolderDTO, error) {
+	client := pb.NewLabelsClient(c.conn)
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	resp, err := client.ListFolders(ctx, &pb.ListFoldersRequest{})
+	if err != nil {
+		return fromCache[entity.FolderDTO](c.cache, foldersKind, err)
+	}
+
+	out := make([]entity.FolderDTO, len(resp.GetFolders()))
+	for i, msg := range resp.GetFolders() {
+		out[i] = entity.FolderDTO{
+			ID:       int(msg.GetId()),
+			ParentID: int(msg.GetParentId()),
+			Name:     msg.GetName(),
+		}
+		if err = c.keys.open(&out[i].Name); err != nil {
+			return nil, err
+		}
+	}
+
+	// ошибка сохранения кеша не влияет на результат запроса
+	_ = c.cache.save(foldersKind, out)
+
+	return out, nil
+}
+
+// CreateFolder создаёт новую папку пользователя (ParentID - родительская папка, 0 - верхний уровень).
+//
+// Возвращает id созданной папки.
+func (c *LabelsClient) CreateFolder(ctx context.Context, token string, folder entity.FolderDTO) (int, error) {
+	if err := c.keys.seal(&folder.Name); err != nil {
+		return 0, err
+	}
+
+	client := pb.NewLabelsClient(c.conn)
+	req := &pb.CreateFolderRequest{
+		Folder: &pb.FolderMsg{
+			ParentId: int64(folder.ParentID),
+			Name:     folder.Name,
+		},
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	resp, err := client.CreateFolder(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(resp.GetId()), nil
+}
+
+// RenameFolder изменяет название папки пользователя.
+func (c *LabelsClient) RenameFolder(ctx context.Context, token string, id int, name string) error {
+	if err := c.keys.seal(&name); err != nil {
+		return err
+	}
+
+	client := pb.NewLabelsClient(c.conn)
+	req := &pb.RenameFolderRequest{
+		Id:   int64(id),
+		Name: name,
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := client.RenameFolder(ctx, req)
+	return err
+}
+
+// DeleteFolder удаляет папку пользователя вместе с вложенными папками.
+//
+// Записи удалённых папок переносятся сервером в родительскую папку удалённой.
+func (c *LabelsClient) DeleteFolder(ctx context.Context, token string, id int) error {
+	client := pb.NewLabelsClient(c.conn)
+	req := &pb.DeleteFolderRequest{
+		Id: int64(id),
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := client.DeleteFolder(ctx, req)
+	return err
+}
+
+// ListTags запрашивает все метки пользователя.
+//
+// При недоступности сервера возвращает метки из локального кеша вместе с ошибкой *OfflineError.
+func (c *LabelsClient) ListTags(ctx context.Context, token string) ([]entity.TagDTO, error) {
+	client := pb.NewLabelsClient(c.conn)
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	resp, err := client.ListTags(ctx, &pb.ListTagsRequest{})
+	if err != nil {
+		return fromCache[entity.TagDTO](c.cache, tagsKind, err)
+	}
+
+	out := make([]entity.TagDTO, len(resp.GetTags()))
+	for i, msg := range resp.GetTags() {
+		out[i] = entity.TagDTO{
+			ID:   int(msg.GetId()),
+			Name: msg.GetName(),
+		}
+		if err = c.keys.open(&out[i].Name); err != nil {
+			return nil, err
+		}
+	}
+
+	// ошибка сохранения кеша не влияет на результат запроса
+	_ = c.cache.save(tagsKind, out)
+
+	return out, nil
+}
+
+// CreateTag создаёт новую метку пользователя.
+//
+// Возвращает id созданной метки.
+func (c *LabelsClient) CreateTag(ctx context.Context, token string, tag entity.TagDTO) (int, error) {
+	if err := c.keys.seal(&tag.Name); err != nil {
+		return 0, err
+	}
+
+	client := pb.NewLabelsClient(c.conn)
+	req := &pb.CreateTagRequest{
+		Tag: &pb.TagMsg{
+			Name: tag.Name,
+		},
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	resp, err := client.CreateTag(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(resp.GetId()), nil
+}
+
+// RenameTag изменяет название метки пользователя.
+func (c *LabelsClient) RenameTag(ctx context.Context, token string, id int, name string) error {
+	if err := c.keys.seal(&name); err != nil {
+		return err
+	}
+
+	client := pb.NewLabelsClient(c.conn)
+	req := &pb.RenameTagRequest{
+		Id:   int64(id),
+		Name: name,
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := client.RenameTag(ctx, req)
+	return err
+}
+
+// DeleteTag удаляет метку пользователя (сервер снимает её со всех записей).
+func (c *LabelsClient) DeleteTag(ctx context.Context, token string, id int) error {
+	client := pb.NewLabelsClient(c.conn)
+	req := &pb.DeleteTagRequest{
+		Id: int64(id),
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := client.DeleteTag(ctx, req)
+	return err
+}
+
+// labelsFromMsg преобразует папку и метки записи из сообщения сервера.
+func labelsFromMsg(folderID int64, tagIDs []int64) entity.Labels {
+	labels := entity.Labels{FolderID: int(folderID)}
+	for _, id := range tagIDs {
+		labels.TagIDs = append(labels.TagIDs, int(id))
+	}
+
+	return labels
+}
+
+// tagIDsToMsg преобразует метки записи для сообщения сервера.
+func tagIDsToMsg(ids entity.IDs) []int64 {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	out := make([]int64, len(ids))
+	for i, id := range ids {
+		out[i] = int64(id)
+	}
+
+	return out
+}
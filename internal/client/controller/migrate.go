@@ -0,0 +1,127 @@
+package controller
+
+import (
+	"context"
+)
+
+// MigratePlaintext однократно шифрует записи пользователя, сохранённые до включения сквозного шифрования
+// (запускается флагом клиента --migrate-plaintext).
+//
+// Только на время миграции значения без шифротекста принимаются как открытые: все записи запрашиваются
+// с сервера, записи с такими значениями сохраняются заново в зашифрованном виде. Файл загружается повторно
+// (получает новый id), прежняя запись удаляется. Возвращает количество зашифрованных записей.
+func (c *Controller) MigratePlaintext(ctx context.Context, token string) (int, error) {
+	c.Keys.allowPlaintext(true)
+	defer c.Keys.allowPlaintext(false)
+
+	resp, err := c.Sync.fetch(ctx, token, 0)
+	if err != nil {
+		return 0, err
+	}
+
+	var migrated int
+	migrate := func(plain bool, err error, save func() error) error {
+		if err != nil || !plain {
+			return err
+		}
+		if err = save(); err != nil {
+			return err
+		}
+		migrated++
+		return nil
+	}
+
+	for _, msg := range resp.GetPairs() {
+		plain, err := hasPlaintext(c.Keys, msg.GetLogin(), msg.GetPassword(), msg.GetMetadata())
+		err = migrate(plain, err, func() error {
+			pair, err := pairFromMsg(c.Keys, msg)
+			if err != nil {
+				return err
+			}
+			return c.Pairs.UpdatePair(ctx, token, pair)
+		})
+		if err != nil {
+			return migrated, err
+		}
+	}
+
+	for _, msg := range resp.GetCards() {
+		plain, err := hasPlaintext(c.Keys, msg.GetCardHolder(), msg.GetNumber(), msg.GetExpirationDate(), msg.GetMetadata())
+		err = migrate(plain, err, func() error {
+			card, err := cardFromMsg(c.Keys, msg)
+			if err != nil {
+				return err
+			}
+			return c.Cards.UpdateCard(ctx, token, card)
+		})
+		if err != nil {
+			return migrated, err
+		}
+	}
+
+	for _, msg := range resp.GetNotes() {
+		plain, err := hasPlaintext(c.Keys, msg.GetNote(), msg.GetMetadata())
+		err = migrate(plain, err, func() error {
+			note, err := noteFromMsg(c.Keys, msg)
+			if err != nil {
+				return err
+			}
+			return c.Notes.UpdateNote(ctx, token, note)
+		})
+		if err != nil {
+			return migrated, err
+		}
+	}
+
+	for _, msg := range resp.GetOtps() {
+		plain, err := hasPlaintext(c.Keys, msg.GetSecret(), msg.GetIssuer(), msg.GetMetadata())
+		err = migrate(plain, err, func() error {
+			item, err := otpFromMsg(c.Keys, msg)
+			if err != nil {
+				return err
+			}
+			return c.OTPs.UpdateOTP(ctx, token, item)
+		})
+		if err != nil {
+			return migrated, err
+		}
+	}
+
+	// содержимое файлов в сообщениях синхронизации не передаётся, поэтому каждый файл загружается
+	for _, msg := range resp.GetBinaries() {
+		info, data, err := c.Binaries.download(ctx, token, int(msg.GetId()))
+		if err != nil {
+			return migrated, err
+		}
+
+		filename, meta := info.GetFilename(), info.GetMetadata()
+		plainInfo, err := c.Keys.openLegacy(&filename, &meta)
+		if err != nil {
+			return migrated, err
+		}
+
+		data, plainData, err := c.Keys.openBytesLegacy(data)
+		err = migrate(plainInfo || plainData, err, func() error {
+			labels := labelsFromMsg(info.GetFolderId(), info.GetTagIds())
+			if _, err := c.Binaries.upload(ctx, token, filename, meta, labels, data); err != nil {
+				return err
+			}
+			return c.Binaries.DeleteBinary(ctx, token, int(msg.GetId()))
+		})
+		if err != nil {
+			return migrated, err
+		}
+	}
+
+	return migrated, nil
+}
+
+// hasPlaintext проверяет, есть ли среди значений полей записи сохранённые без шифрования.
+func hasPlaintext(keys *Keys, values ...string) (bool, error) {
+	fields := make([]*string, len(values))
+	for i := range values {
+		fields[i] = &values[i]
+	}
+
+	return keys.openLegacy(fields...)
+}
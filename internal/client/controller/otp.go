@@ -0,0 +1,191 @@
+package controller
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	"github.com/PaulYakow/gophkeeper/internal/utils/otp"
+	pb "github.com/PaulYakow/gophkeeper/proto"
+)
+
+// OTPClient обеспечивает обмен данными об одноразовых паролях (TOTP/HOTP) пользователя.
+//
+// Шифруются секрет, издатель и метаинформация. Тип, алгоритм, количество цифр, период и счётчик
+// передаются открыто: это стандартные параметры, по которым секрет и коды не восстановить, а сервер
+// по ним заполняет значения по умолчанию и проверяет корректность записи (счётчик HOTP при этом
+// раскрывает серверу количество использованных кодов).
+type OTPClient struct {
+	conn  *grpc.ClientConn
+	keys  *Keys
+	cache *Cache
+}
+
+// NewOTPClient создаёт объект OTPClient.
+func NewOTPClient(conn *grpc.ClientConn, keys *Keys, cache *Cache) *OTPClient {
+	return &OTPClient{
+		conn:  conn,
+		keys:  keys,
+		cache: cache,
+	}
+}
+
+// ViewAllOTPs запрашивает информацию обо всех одноразовых паролях пользователя.
+//
+// Список загружается постранично. При недоступности сервера возвращает данные из локального кеша
+// вместе с ошибкой *OfflineError.
+func (c *OTPClient) ViewAllOTPs(ctx context.Context, token string) ([]entity.OTPDTO, error) {
+	out, err := listAll(func(page entity.PageRequest) ([]entity.OTPDTO, string, error) {
+		return c.ListOTPs(ctx, token, page)
+	})
+	if err != nil {
+		return fromCache[entity.OTPDTO](c.cache, otpsKind, err)
+	}
+
+	// ошибка сохранения кеша не влияет на результат запроса
+	_ = c.cache.save(otpsKind, out)
+
+	return out, nil
+}
+
+// ListOTPs запрашивает страницу списка одноразовых паролей пользователя.
+//
+// Возвращает записи страницы и токен следующей страницы (пустой - страница последняя).
+func (c *OTPClient) ListOTPs(ctx context.Context, token string, page entity.PageRequest) ([]entity.OTPDTO, string, error) {
+	client := pb.NewOTPClient(c.conn)
+	req := &pb.GetAllOTPsRequest{
+		Page: pageToMsg(page),
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	resp, err := client.GetAll(ctx, req)
+	if err != nil {
+		return nil, "", err
+	}
+
+	out := make([]entity.OTPDTO, len(resp.Otps))
+	for i, item := range resp.GetOtps() {
+		if out[i], err = otpFromMsg(c.keys, item); err != nil {
+			return nil, "", err
+		}
+	}
+
+	return out, resp.GetNextPageToken(), nil
+}
+
+// CreateOTP сохраняет новый одноразовый пароль пользователя.
+//
+// Возвращает id созданной записи.
+func (c *OTPClient) CreateOTP(ctx context.Context, token string, item entity.OTPDTO) (int, error) {
+	// секрет передаётся зашифрованным, поэтому проверить его может только клиент
+	if _, err := otp.DecodeSecret(item.Secret); err != nil {
+		return 0, err
+	}
+
+	if err := c.keys.seal(&item.Secret, &item.Issuer, &item.Metadata); err != nil {
+		return 0, err
+	}
+
+	client := pb.NewOTPClient(c.conn)
+	req := &pb.CreateOTPRequest{
+		Otp: otpToMsg(item),
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	resp, err := client.Create(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(resp.GetId()), nil
+}
+
+// UpdateOTP изменяет существующий одноразовый пароль пользователя (в том числе счётчик HOTP).
+func (c *OTPClient) UpdateOTP(ctx context.Context, token string, item entity.OTPDTO) error {
+	if _, err := otp.DecodeSecret(item.Secret); err != nil {
+		return err
+	}
+
+	if err := c.keys.seal(&item.Secret, &item.Issuer, &item.Metadata); err != nil {
+		return err
+	}
+
+	client := pb.NewOTPClient(c.conn)
+	req := &pb.UpdateOTPRequest{
+		Otp: otpToMsg(item),
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := client.Update(ctx, req)
+	return err
+}
+
+// DeleteOTP удаляет одноразовый пароль пользователя по его id.
+func (c *OTPClient) DeleteOTP(ctx context.Context, token string, id int) error {
+	client := pb.NewOTPClient(c.conn)
+	req := &pb.DeleteOTPRequest{
+		Id: int64(id),
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := client.Delete(ctx, req)
+	return err
+}
+
+func otpToMsg(item entity.OTPDTO) *pb.OTPMsg {
+	return &pb.OTPMsg{
+		Id:        int64(item.ID),
+		Kind:      item.Kind,
+		Secret:    item.Secret,
+		Algorithm: item.Algorithm,
+		Digits:    int32(item.Digits),
+		Period:    int32(item.Period),
+		Counter:   item.Counter,
+		Issuer:    item.Issuer,
+		Metadata:  item.Metadata,
+		FolderId:  int64(item.FolderID),
+		TagIds:    tagIDsToMsg(item.TagIDs),
+	}
+}
+
+// otpFromMsg преобразует сообщение сервера в объект одноразового пароля с расшифрованными полями.
+func otpFromMsg(keys *Keys, msg *pb.OTPMsg) (entity.OTPDTO, error) {
+	item := entity.OTPDTO{
+		ID:        int(msg.GetId()),
+		Kind:      msg.GetKind(),
+		Secret:    msg.GetSecret(),
+		Algorithm: msg.GetAlgorithm(),
+		Digits:    int(msg.GetDigits()),
+		Period:    int(msg.GetPeriod()),
+		Counter:   msg.GetCounter(),
+		Issuer:    msg.GetIssuer(),
+		Metadata:  msg.GetMetadata(),
+		Labels:    labelsFromMsg(msg.GetFolderId(), msg.GetTagIds()),
+	}
+
+	err := keys.open(&item.Secret, &item.Issuer, &item.Metadata)
+	return item, err
+}
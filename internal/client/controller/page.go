@@ -0,0 +1,48 @@
+package controller
+
+import (
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	pb "github.com/PaulYakow/gophkeeper/proto"
+)
+
+// pageToMsg преобразует запрос страницы списка в сообщение сервера.
+func pageToMsg(page entity.PageRequest) *pb.PageRequest {
+	msg := &pb.PageRequest{
+		Size:  int32(page.Size),
+		Token: page.Token,
+		Order: pb.SortOrder(page.Order),
+	}
+	if !page.CreatedFrom.IsZero() {
+		msg.CreatedFrom = page.CreatedFrom.UnixNano()
+	}
+	if !page.CreatedTo.IsZero() {
+		msg.CreatedTo = page.CreatedTo.UnixNano()
+	}
+	if page.Filter.FolderID != nil {
+		folderID := int64(*page.Filter.FolderID)
+		msg.FolderId = &folderID
+	}
+	msg.TagId = int64(page.Filter.TagID)
+
+	return msg
+}
+
+// listAll запрашивает все страницы списка (list - запрос одной страницы) и объединяет их.
+func listAll[T any](list func(page entity.PageRequest) ([]T, string, error)) ([]T, error) {
+	var (
+		out  []T
+		page entity.PageRequest
+	)
+	for {
+		items, next, err := list(page)
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, items...)
+		if next == "" {
+			return out, nil
+		}
+		page.Token = next
+	}
+}
@@ -13,21 +13,45 @@ import (
 
 // PairsClient обеспечивает обмен данными о сохранённых парах логин/пароль пользователя.
 type PairsClient struct {
-	conn *grpc.ClientConn
+	conn  *grpc.ClientConn
+	keys  *Keys
+	cache *Cache
 }
 
 // NewPairsClient создаёт объект PairsClient.
-func NewPairsClient(conn *grpc.ClientConn) *PairsClient {
+func NewPairsClient(conn *grpc.ClientConn, keys *Keys, cache *Cache) *PairsClient {
 	return &PairsClient{
-		conn: conn,
+		conn:  conn,
+		keys:  keys,
+		cache: cache,
 	}
 }
 
 // ViewAllPairs запрашивает информацию обо всех имеющихся парах логин/пароль пользователя.
+//
+// Список загружается постранично. При недоступности сервера возвращает данные из локального кеша
+// вместе с ошибкой *OfflineError.
 func (c *PairsClient) ViewAllPairs(ctx context.Context, token string) ([]entity.PairDTO, error) {
+	out, err := listAll(func(page entity.PageRequest) ([]entity.PairDTO, string, error) {
+		return c.ListPairs(ctx, token, page)
+	})
+	if err != nil {
+		return fromCache[entity.PairDTO](c.cache, pairsKind, err)
+	}
+
+	// ошибка сохранения кеша не влияет на результат запроса
+	_ = c.cache.save(pairsKind, out)
+
+	return out, nil
+}
+
+// ListPairs запрашивает страницу списка пар логин/пароль пользователя.
+//
+// Возвращает записи страницы и токен следующей страницы (пустой - страница последняя).
+func (c *PairsClient) ListPairs(ctx context.Context, token string, page entity.PageRequest) ([]entity.PairDTO, string, error) {
 	client := pb.NewPairClient(c.conn)
 	req := &pb.GetAllPairsRequest{
-		Token: "",
+		Page: pageToMsg(page),
 	}
 
 	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
@@ -38,18 +62,107 @@ func (c *PairsClient) ViewAllPairs(ctx context.Context, token string) ([]entity.
 
 	resp, err := client.GetAll(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	out := make([]entity.PairDTO, len(resp.Pairs))
 	for i, pair := range resp.GetPairs() {
-		out[i] = entity.PairDTO{
-			ID:       int(pair.GetId()),
-			Login:    pair.GetLogin(),
-			Password: pair.GetPassword(),
-			Metadata: pair.GetMetadata(),
+		if out[i], err = pairFromMsg(c.keys, pair); err != nil {
+			return nil, "", err
 		}
 	}
 
-	return out, nil
+	return out, resp.GetNextPageToken(), nil
+}
+
+// CreatePair сохраняет новую пару логин/пароль пользователя.
+//
+// Возвращает id созданной записи.
+func (c *PairsClient) CreatePair(ctx context.Context, token string, pair entity.PairDTO) (int, error) {
+	if err := c.keys.seal(&pair.Login, &pair.Password, &pair.Metadata); err != nil {
+		return 0, err
+	}
+
+	client := pb.NewPairClient(c.conn)
+	req := &pb.CreatePairRequest{
+		Pair: &pb.PairMsg{
+			Login:    pair.Login,
+			Password: pair.Password,
+			Metadata: pair.Metadata,
+			FolderId: int64(pair.FolderID),
+			TagIds:   tagIDsToMsg(pair.TagIDs),
+		},
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	resp, err := client.Create(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(resp.GetId()), nil
+}
+
+// UpdatePair изменяет существующую пару логин/пароль пользователя.
+func (c *PairsClient) UpdatePair(ctx context.Context, token string, pair entity.PairDTO) error {
+	if err := c.keys.seal(&pair.Login, &pair.Password, &pair.Metadata); err != nil {
+		return err
+	}
+
+	client := pb.NewPairClient(c.conn)
+	req := &pb.UpdatePairRequest{
+		Pair: &pb.PairMsg{
+			Id:       int64(pair.ID),
+			Login:    pair.Login,
+			Password: pair.Password,
+			Metadata: pair.Metadata,
+			FolderId: int64(pair.FolderID),
+			TagIds:   tagIDsToMsg(pair.TagIDs),
+		},
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := client.Update(ctx, req)
+	return err
+}
+
+// DeletePair удаляет пару логин/пароль пользователя по её id.
+func (c *PairsClient) DeletePair(ctx context.Context, token string, id int) error {
+	client := pb.NewPairClient(c.conn)
+	req := &pb.DeletePairRequest{
+		Id: int64(id),
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := client.Delete(ctx, req)
+	return err
+}
+
+// pairFromMsg преобразует сообщение сервера в объект пары логин/пароль с расшифрованными полями.
+func pairFromMsg(keys *Keys, msg *pb.PairMsg) (entity.PairDTO, error) {
+	pair := entity.PairDTO{
+		ID:       int(msg.GetId()),
+		Login:    msg.GetLogin(),
+		Password: msg.GetPassword(),
+		Metadata: msg.GetMetadata(),
+		Labels:   labelsFromMsg(msg.GetFolderId(), msg.GetTagIds()),
+	}
+
+	err := keys.open(&pair.Login, &pair.Password, &pair.Metadata)
+	return pair, err
 }
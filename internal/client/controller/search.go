@@ -0,0 +1,98 @@
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	"github.com/PaulYakow/gophkeeper/internal/utils/textsearch"
+)
+
+// maxSearchQueryLen максимальная длина поискового запроса (в символах).
+const maxSearchQueryLen = 256
+
+// SearchClient обеспечивает полнотекстовый поиск по заметкам и метаинформации данных пользователя.
+//
+// Данные шифруются на клиенте (сквозное шифрование), поэтому сервер искать по ним не может: поиск выполняется
+// по расшифрованной локальной копии (SyncClient), и поисковый запрос не покидает клиента.
+type SearchClient struct {
+	sync *SyncClient
+}
+
+// NewSearchClient создаёт объект SearchClient.
+func NewSearchClient(sync *SyncClient) *SearchClient {
+	return &SearchClient{
+		sync: sync,
+	}
+}
+
+// Search находит в локальной копии записи, соответствующие запросу query (не более limit, 0 - без ограничения),
+// в порядке убывания ранга.
+//
+// Возвращает ErrInvalidQuery при пустом или слишком длинном запросе.
+func (c *SearchClient) Search(query string, limit int) ([]entity.SearchHitDTO, error) {
+	query = strings.TrimSpace(query)
+	switch {
+	case textsearch.Parse(query).Empty():
+		return nil, fmt.Errorf("%w: empty query", ErrInvalidQuery)
+	case utf8.RuneCountInString(query) > maxSearchQueryLen:
+		return nil, fmt.Errorf("%w: query too long", ErrInvalidQuery)
+	}
+
+	hits := c.sync.Search(query)
+	if limit > 0 && len(hits) > limit {
+		hits = hits[:limit]
+	}
+
+	return hits, nil
+}
+
+// Search находит в локальном состоянии записи, соответствующие запросу query, в порядке убывания ранга.
+//
+// Правила поиска - пакет textsearch, заметка весомее метаинформации.
+func (c *SyncClient) Search(query string) []entity.SearchHitDTO {
+	q := textsearch.Parse(query)
+	if q.Empty() {
+		return nil
+	}
+
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	var hits []entity.SearchHitDTO
+	match := func(kind string, id int, fields ...string) {
+		if rank, snippet, ok := q.Match(fields...); ok {
+			hits = append(hits, entity.SearchHitDTO{Kind: kind, ID: id, Rank: rank, Snippet: snippet})
+		}
+	}
+
+	for id, pair := range c.state.Pairs {
+		match(entity.PairKind, id, "", pair.Metadata)
+	}
+	for id, card := range c.state.Cards {
+		match(entity.CardKind, id, "", card.Metadata)
+	}
+	for id, note := range c.state.Notes {
+		match(entity.NoteKind, id, note.Note, note.Metadata)
+	}
+	for id, binary := range c.state.Binaries {
+		match(entity.BinaryKind, id, "", binary.Metadata)
+	}
+	for id, item := range c.state.OTPs {
+		match(entity.OTPKind, id, "", item.Metadata)
+	}
+
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].Rank != hits[j].Rank {
+			return hits[i].Rank > hits[j].Rank
+		}
+		if hits[i].Kind != hits[j].Kind {
+			return hits[i].Kind < hits[j].Kind
+		}
+		return hits[i].ID < hits[j].ID
+	})
+
+	return hits
+}
@@ -0,0 +1,286 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	pb "github.com/PaulYakow/gophkeeper/proto"
+)
+
+// refreshMargin запас времени до истечения access-токена, при котором токены обновляются заранее.
+const refreshMargin = 30 * time.Second
+
+// Session токены текущей сессии пользователя.
+//
+// Access-токен короткоживущий: перехватчики запросов (UnaryInterceptor, StreamInterceptor)
+// обновляют токены по refresh-токену незадолго до его истечения и подставляют в метаданные
+// запроса актуальный access-токен.
+//
+// Если задано хранилище (SessionStore), сессия сохраняется на диск после ввода мастер-пароля (Save)
+// и при каждом обновлении токенов, а при запуске клиента восстанавливается (UserClient.Resume).
+// Ключ шифрования данных не сохраняется: после восстановления сессии нужно снова ввести мастер-пароль.
+type Session struct {
+	mu        sync.Mutex
+	login     string
+	access    string
+	refresh   string
+	expiresAt time.Time
+
+	store *SessionStore
+	keys  *Keys
+}
+
+// NewSession создаёт пустую сессию (пользователь не аутентифицирован).
+func NewSession() *Session {
+	return &Session{}
+}
+
+// Token возвращает текущий access-токен.
+func (s *Session) Token() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.access
+}
+
+// Login возвращает логин пользователя восстановленной сессии (для повторного ввода мастер-пароля).
+func (s *Session) Login() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.login
+}
+
+// RefreshToken возвращает текущий refresh-токен.
+func (s *Session) RefreshToken() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.refresh
+}
+
+// Save сохраняет токены сессии в хранилище (вызывается после ввода мастер-пароля).
+func (s *Session) Save() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.save()
+}
+
+// persist подключает хранилище сессии и ключи шифрования, удаляемые вместе с ней.
+func (s *Session) persist(store *SessionStore, keys *Keys) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.store, s.keys = store, keys
+}
+
+// save сохраняет текущее состояние сессии (вызывается под блокировкой).
+func (s *Session) save() error {
+	if s.store == nil || s.refresh == "" {
+		return nil
+	}
+
+	// до ввода мастер-пароля (после восстановления сессии) логин берётся из сохранённой сессии
+	if login := s.keys.Login(); login != "" {
+		s.login = login
+	}
+	if s.login == "" {
+		return ErrLocked
+	}
+
+	return s.store.save(storedSession{
+		Login:     s.login,
+		Access:    s.access,
+		Refresh:   s.refresh,
+		ExpiresAt: s.expiresAt,
+	})
+}
+
+// restore загружает сессию из хранилища (ключ шифрования данных остаётся заблокированным).
+func (s *Session) restore() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.store == nil {
+		return ErrNoSession
+	}
+
+	stored, err := s.store.load()
+	if err != nil {
+		return err
+	}
+
+	s.login = stored.Login
+	s.access = stored.Access
+	s.refresh = stored.Refresh
+	s.expiresAt = stored.ExpiresAt
+
+	return nil
+}
+
+// forget удаляет восстановленную сессию из памяти вместе с ключом шифрования данных.
+//
+// discard - удалить и сохранённую в хранилище сессию.
+func (s *Session) forget(discard bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.login = ""
+	s.access = ""
+	s.refresh = ""
+	s.expiresAt = time.Time{}
+	s.keys.Lock()
+
+	if discard {
+		_ = s.store.remove()
+	}
+}
+
+// valid проверяет, что access-токен ещё не истёк.
+func (s *Session) valid() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.access != "" && time.Now().Before(s.expiresAt)
+}
+
+// set сохраняет токены, полученные от сервера (expiresIn - время действия access-токена в секундах).
+func (s *Session) set(access, refresh string, expiresIn int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.access = access
+	s.refresh = refresh
+	s.expiresAt = time.Now().Add(time.Duration(expiresIn) * time.Second)
+}
+
+// clear удаляет токены сессии (в том числе сохранённые в хранилище).
+func (s *Session) clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.login = ""
+	s.access = ""
+	s.refresh = ""
+	s.expiresAt = time.Time{}
+
+	// при ошибке удаления сохранённая сессия будет отклонена сервером (сессия отозвана)
+	_ = s.store.remove()
+}
+
+// UnaryInterceptor подставляет в запрос актуальный access-токен (при необходимости обновляя его).
+func (s *Session) UnaryInterceptor(ctx context.Context,
+	method string,
+	req, reply interface{},
+	cc *grpc.ClientConn,
+	invoker grpc.UnaryInvoker,
+	opts ...grpc.CallOption,
+) error {
+	ctx, err := s.authorize(ctx, cc)
+	if err != nil {
+		return err
+	}
+
+	return invoker(ctx, method, req, reply, cc, opts...)
+}
+
+// StreamInterceptor подставляет в потоковый запрос актуальный access-токен (при необходимости обновляя его).
+func (s *Session) StreamInterceptor(ctx context.Context,
+	desc *grpc.StreamDesc,
+	cc *grpc.ClientConn,
+	method string,
+	streamer grpc.Streamer,
+	opts ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	ctx, err := s.authorize(ctx, cc)
+	if err != nil {
+		return nil, err
+	}
+
+	return streamer(ctx, desc, cc, method, opts...)
+}
+
+// authorize заменяет токен в метаданных запроса на актуальный.
+//
+// Запросы без токена (регистрация, вход, обновление токенов) передаются как есть.
+func (s *Session) authorize(ctx context.Context, cc *grpc.ClientConn) (context.Context, error) {
+	md, ok := metadata.FromOutgoingContext(ctx)
+	if !ok || len(md.Get("token")) == 0 {
+		return ctx, nil
+	}
+
+	token, err := s.fresh(ctx, cc)
+	if err != nil {
+		return nil, err
+	}
+
+	md = md.Copy()
+	md.Set("token", token)
+	return metadata.NewOutgoingContext(ctx, md), nil
+}
+
+// fresh возвращает access-токен, обновляя токены, если он скоро истечёт.
+func (s *Session) fresh(ctx context.Context, cc *grpc.ClientConn) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.refresh == "" || time.Until(s.expiresAt) > refreshMargin {
+		return s.access, nil
+	}
+
+	err := s.renew(ctx, cc)
+	if err != nil {
+		if IsUnavailable(err) {
+			// сервер недоступен - запрос завершится той же ошибкой
+			return s.access, nil
+		}
+		return "", fmt.Errorf("refresh session: %w", err)
+	}
+
+	return s.access, nil
+}
+
+// refreshNow обновляет токены сессии независимо от срока действия access-токена.
+func (s *Session) refreshNow(ctx context.Context, cc *grpc.ClientConn) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.renew(ctx, cc)
+}
+
+// renew обновляет токены сессии по refresh-токену (вызывается под блокировкой).
+//
+// Новые токены сохраняются в хранилище: прежний refresh-токен после обновления недействителен.
+func (s *Session) renew(ctx context.Context, cc *grpc.ClientConn) error {
+	client := pb.NewUserClient(cc)
+	req := &pb.RefreshRequest{
+		RefreshToken: s.refresh,
+	}
+
+	// запрос обновления - без токена в метаданных
+	ctx, cancel := context.WithDeadline(metadata.NewOutgoingContext(ctx, metadata.MD{}), time.Now().Add(time.Second))
+	defer cancel()
+
+	resp, err := client.Refresh(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	s.access = resp.GetToken()
+	s.refresh = resp.GetRefreshToken()
+	s.expiresAt = time.Now().Add(time.Duration(resp.GetExpiresIn()) * time.Second)
+
+	// прежний refresh-токен уже недействителен: если новое состояние не сохранено, сохранённая
+	// сессия удаляется (её использование отозвало бы сессию) и при следующем запуске потребуется вход
+	if err = s.save(); err != nil {
+		_ = s.store.remove()
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package controller
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/PaulYakow/gophkeeper/internal/utils/encryption"
+)
+
+const (
+	// sessionFile файл сохранённой сессии в каталоге хранилища.
+	sessionFile = "session.bin"
+	// deviceKeyFile файл ключа шифрования сохранённой сессии в каталоге хранилища.
+	deviceKeyFile = "device.key"
+	// deviceKeySize размер ключа шифрования сохранённой сессии (в байтах).
+	deviceKeySize = 32
+)
+
+// ErrNoSession ошибка восстановления сессии, если сохранённой сессии нет.
+var ErrNoSession = errors.New("no saved session")
+
+// SessionStore хранилище сессии пользователя на диске (каталог storage.path из конфигурации).
+//
+// Сохраняются только логин и токены сессии, что позволяет входить без ввода пароля, пока сессия
+// действительна. Ключ шифрования данных на диск не записывается: после восстановления сессии
+// мастер-пароль вводится снова. Файл сессии шифруется случайным ключом устройства, который
+// создаётся при первом сохранении; оба файла доступны только владельцу (0600), файл с более
+// широкими правами доступа не загружается. Значение nil означает, что сессия не сохраняется.
+type SessionStore struct {
+	dir string
+}
+
+// storedSession сохраняемое состояние сессии.
+type storedSession struct {
+	Login     string    `json:"login"`
+	Access    string    `json:"access"`
+	Refresh   string    `json:"refresh"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// NewSessionStore создаёт объект SessionStore в каталоге dir.
+func NewSessionStore(dir string) *SessionStore {
+	return &SessionStore{
+		dir: dir,
+	}
+}
+
+// save шифрует и сохраняет сессию.
+func (s *SessionStore) save(session storedSession) error {
+	if s == nil {
+		return nil
+	}
+
+	c, err := s.cipher()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		return err
+	}
+
+	if data, err = c.Encrypt(data); err != nil {
+		return err
+	}
+
+	return writePrivateFile(filepath.Join(s.dir, sessionFile), data)
+}
+
+// load загружает и расшифровывает сохранённую сессию (ErrNoSession - сессия не сохранялась).
+func (s *SessionStore) load() (storedSession, error) {
+	var session storedSession
+	if s == nil {
+		return session, ErrNoSession
+	}
+
+	data, err := readPrivateFile(filepath.Join(s.dir, sessionFile))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return session, ErrNoSession
+		}
+		return session, err
+	}
+
+	c, err := s.cipher()
+	if err != nil {
+		return session, err
+	}
+
+	if data, err = c.Decrypt(data); err != nil {
+		return session, err
+	}
+
+	return session, json.Unmarshal(data, &session)
+}
+
+// remove удаляет сохранённую сессию.
+func (s *SessionStore) remove() error {
+	if s == nil {
+		return nil
+	}
+
+	err := os.Remove(filepath.Join(s.dir, sessionFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+// cipher возвращает шифр на ключе устройства (ключ создаётся при первом обращении).
+func (s *SessionStore) cipher() (*encryption.Cipher, error) {
+	path := filepath.Join(s.dir, deviceKeyFile)
+
+	key, err := readPrivateFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		key = make([]byte, deviceKeySize)
+		if _, err = rand.Read(key); err != nil {
+			return nil, err
+		}
+		err = writePrivateFile(path, key)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return encryption.NewCipher(key)
+}
+
+// readPrivateFile читает файл, доступный только владельцу.
+func readPrivateFile(path string) ([]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if info.Mode().Perm()&0o077 != 0 {
+		return nil, fmt.Errorf("insecure permissions %s on %s", info.Mode().Perm(), path)
+	}
+
+	return os.ReadFile(path)
+}
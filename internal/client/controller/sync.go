@@ -0,0 +1,294 @@
+package controller
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	pb "github.com/PaulYakow/gophkeeper/proto"
+)
+
+// syncKind тип данных локального кеша, в котором хранится синхронизированное состояние.
+const syncKind = "sync"
+
+// SyncClient обеспечивает инкрементальную синхронизацию данных пользователя с сервером.
+//
+// Клиент хранит локальное состояние (все записи пользователя) и курсор - последнюю полученную
+// ревизию сервера. При каждой синхронизации запрашиваются только изменения после курсора,
+// которые применяются к состоянию. Состояние вместе с курсором сохраняется в локальный кеш,
+// поэтому после перезапуска клиента данные целиком повторно не загружаются.
+type SyncClient struct {
+	conn  *grpc.ClientConn
+	keys  *Keys
+	cache *Cache
+
+	mu      sync.RWMutex
+	owner   string
+	savedAt time.Time
+	state   syncState
+}
+
+// syncState локальное состояние данных пользователя.
+type syncState struct {
+	Revision int64                    `json:"revision"`
+	Pairs    map[int]entity.PairDTO   `json:"pairs"`
+	Cards    map[int]entity.BankDTO   `json:"cards"`
+	Notes    map[int]entity.TextDTO   `json:"notes"`
+	Binaries map[int]entity.BinaryDTO `json:"binaries"`
+	OTPs     map[int]entity.OTPDTO    `json:"otps"`
+}
+
+func newSyncState() syncState {
+	return syncState{
+		Pairs:    make(map[int]entity.PairDTO),
+		Cards:    make(map[int]entity.BankDTO),
+		Notes:    make(map[int]entity.TextDTO),
+		Binaries: make(map[int]entity.BinaryDTO),
+		OTPs:     make(map[int]entity.OTPDTO),
+	}
+}
+
+// NewSyncClient создаёт объект SyncClient.
+func NewSyncClient(conn *grpc.ClientConn, keys *Keys, cache *Cache) *SyncClient {
+	return &SyncClient{
+		conn:  conn,
+		keys:  keys,
+		cache: cache,
+		state: newSyncState(),
+	}
+}
+
+// Sync запрашивает изменения после последней известной ревизии и применяет их к локальному состоянию.
+//
+// При недоступности сервера состояние остаётся прежним (загруженным из локального кеша),
+// а возвращается ошибка *OfflineError со временем последней синхронизации.
+func (c *SyncClient) Sync(ctx context.Context, token string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := c.restore(); err != nil {
+		return err
+	}
+
+	resp, err := c.fetch(ctx, token, c.state.Revision)
+	if err != nil {
+		if IsUnavailable(err) && !c.savedAt.IsZero() {
+			return &OfflineError{SavedAt: c.savedAt, Err: err}
+		}
+		return err
+	}
+
+	// изменения применяются к копии - при ошибке расшифровки состояние и курсор не меняются
+	state, err := c.apply(resp)
+	if err != nil {
+		return err
+	}
+
+	c.state = state
+	c.savedAt = time.Now()
+
+	// ошибка сохранения кеша не влияет на результат синхронизации
+	_ = c.cache.save(syncKind, c.state)
+
+	return nil
+}
+
+// fetch запрашивает у сервера изменения после ревизии since (0 - все записи) без расшифровки.
+func (c *SyncClient) fetch(ctx context.Context, token string, since int64) (*pb.SyncResponse, error) {
+	client := pb.NewSyncClient(c.conn)
+	req := &pb.SyncRequest{
+		Since: since,
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	return client.Sync(ctx, req)
+}
+
+// Revision возвращает курсор синхронизации - последнюю полученную ревизию сервера.
+func (c *SyncClient) Revision() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.state.Revision
+}
+
+// Reset очищает локальное состояние в памяти (например, при выходе пользователя).
+func (c *SyncClient) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.owner = ""
+	c.savedAt = time.Time{}
+	c.state = newSyncState()
+}
+
+// Pairs возвращает пары логин/пароль из локального состояния (по возрастанию id).
+func (c *SyncClient) Pairs() []entity.PairDTO {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return sortedValues(c.state.Pairs)
+}
+
+// Cards возвращает банковские карты из локального состояния (по возрастанию id).
+func (c *SyncClient) Cards() []entity.BankDTO {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return sortedValues(c.state.Cards)
+}
+
+// Notes возвращает заметки из локального состояния (по возрастанию id).
+func (c *SyncClient) Notes() []entity.TextDTO {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return sortedValues(c.state.Notes)
+}
+
+// Binaries возвращает описания файлов из локального состояния (по возрастанию id).
+func (c *SyncClient) Binaries() []entity.BinaryDTO {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return sortedValues(c.state.Binaries)
+}
+
+// OTPs возвращает одноразовые пароли из локального состояния (по возрастанию id).
+func (c *SyncClient) OTPs() []entity.OTPDTO {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return sortedValues(c.state.OTPs)
+}
+
+// restore загружает состояние текущего пользователя из локального кеша (при смене пользователя).
+func (c *SyncClient) restore() error {
+	owner, err := c.keys.owner()
+	if err != nil {
+		return err
+	}
+
+	if owner == c.owner {
+		return nil
+	}
+
+	c.owner = owner
+	c.savedAt = time.Time{}
+	c.state = newSyncState()
+
+	state := newSyncState()
+	savedAt, err := c.cache.load(syncKind, &state)
+	if err != nil {
+		// кеша нет или он повреждён - состояние будет получено с сервера целиком
+		return nil
+	}
+
+	c.state = state
+	c.savedAt = savedAt
+
+	return nil
+}
+
+// apply применяет полученные изменения к копии локального состояния.
+func (c *SyncClient) apply(resp *pb.SyncResponse) (syncState, error) {
+	state := newSyncState()
+	if !resp.GetFull() {
+		copyMap(state.Pairs, c.state.Pairs)
+		copyMap(state.Cards, c.state.Cards)
+		copyMap(state.Notes, c.state.Notes)
+		copyMap(state.Binaries, c.state.Binaries)
+		copyMap(state.OTPs, c.state.OTPs)
+	}
+
+	for _, msg := range resp.GetPairs() {
+		pair, err := pairFromMsg(c.keys, msg)
+		if err != nil {
+			return state, err
+		}
+		state.Pairs[pair.ID] = pair
+	}
+
+	for _, msg := range resp.GetCards() {
+		card, err := cardFromMsg(c.keys, msg)
+		if err != nil {
+			return state, err
+		}
+		state.Cards[card.ID] = card
+	}
+
+	for _, msg := range resp.GetNotes() {
+		note, err := noteFromMsg(c.keys, msg)
+		if err != nil {
+			return state, err
+		}
+		state.Notes[note.ID] = note
+	}
+
+	for _, msg := range resp.GetBinaries() {
+		binary, err := binaryFromMsg(c.keys, msg)
+		if err != nil {
+			return state, err
+		}
+		state.Binaries[binary.ID] = binary
+	}
+
+	for _, msg := range resp.GetOtps() {
+		item, err := otpFromMsg(c.keys, msg)
+		if err != nil {
+			return state, err
+		}
+		state.OTPs[item.ID] = item
+	}
+
+	for _, deleted := range resp.GetDeleted() {
+		id := int(deleted.GetId())
+		switch deleted.GetKind() {
+		case entity.PairKind:
+			delete(state.Pairs, id)
+		case entity.CardKind:
+			delete(state.Cards, id)
+		case entity.NoteKind:
+			delete(state.Notes, id)
+		case entity.BinaryKind:
+			delete(state.Binaries, id)
+		case entity.OTPKind:
+			delete(state.OTPs, id)
+		}
+	}
+
+	state.Revision = resp.GetRevision()
+
+	return state, nil
+}
+
+func copyMap[T any](dst, src map[int]T) {
+	for id, item := range src {
+		dst[id] = item
+	}
+}
+
+func sortedValues[T any](m map[int]T) []T {
+	ids := make([]int, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	out := make([]T, len(ids))
+	for i, id := range ids {
+		out[i] = m[id]
+	}
+
+	return out
+}
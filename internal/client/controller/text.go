@@ -13,21 +13,45 @@ import (
 
 // TextClient обеспечивает обмен данными о сохранённых заметках пользователя.
 type TextClient struct {
-	conn *grpc.ClientConn
+	conn  *grpc.ClientConn
+	keys  *Keys
+	cache *Cache
 }
 
 // NewTextClient создаёт объект TextClient.
-func NewTextClient(conn *grpc.ClientConn) *TextClient {
+func NewTextClient(conn *grpc.ClientConn, keys *Keys, cache *Cache) *TextClient {
 	return &TextClient{
-		conn: conn,
+		conn:  conn,
+		keys:  keys,
+		cache: cache,
 	}
 }
 
 // ViewAllNotes запрашивает информацию обо всех имеющихся заметках пользователя.
+//
+// Список загружается постранично. При недоступности сервера возвращает данные из локального кеша
+// вместе с ошибкой *OfflineError.
 func (c *TextClient) ViewAllNotes(ctx context.Context, token string) ([]entity.TextDTO, error) {
+	out, err := listAll(func(page entity.PageRequest) ([]entity.TextDTO, string, error) {
+		return c.ListNotes(ctx, token, page)
+	})
+	if err != nil {
+		return fromCache[entity.TextDTO](c.cache, notesKind, err)
+	}
+
+	// ошибка сохранения кеша не влияет на результат запроса
+	_ = c.cache.save(notesKind, out)
+
+	return out, nil
+}
+
+// ListNotes запрашивает страницу списка заметок пользователя.
+//
+// Возвращает записи страницы и токен следующей страницы (пустой - страница последняя).
+func (c *TextClient) ListNotes(ctx context.Context, token string, page entity.PageRequest) ([]entity.TextDTO, string, error) {
 	client := pb.NewTextClient(c.conn)
 	req := &pb.GetAllNotesRequest{
-		Token: "",
+		Page: pageToMsg(page),
 	}
 
 	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
@@ -38,17 +62,104 @@ func (c *TextClient) ViewAllNotes(ctx context.Context, token string) ([]entity.T
 
 	resp, err := client.GetAll(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, "", err
 	}
 
 	out := make([]entity.TextDTO, len(resp.Notes))
 	for i, note := range resp.GetNotes() {
-		out[i] = entity.TextDTO{
-			ID:       int(note.GetId()),
-			Note:     note.GetNote(),
-			Metadata: note.GetMetadata(),
+		if out[i], err = noteFromMsg(c.keys, note); err != nil {
+			return nil, "", err
 		}
 	}
 
-	return out, nil
+	return out, resp.GetNextPageToken(), nil
+}
+
+// CreateNote сохраняет новую заметку пользователя.
+//
+// Возвращает id созданной записи.
+func (c *TextClient) CreateNote(ctx context.Context, token string, note entity.TextDTO) (int, error) {
+	if err := c.keys.seal(&note.Note, &note.Metadata); err != nil {
+		return 0, err
+	}
+
+	client := pb.NewTextClient(c.conn)
+	req := &pb.CreateNoteRequest{
+		Note: &pb.NoteMsg{
+			Note:     note.Note,
+			Metadata: note.Metadata,
+			FolderId: int64(note.FolderID),
+			TagIds:   tagIDsToMsg(note.TagIDs),
+		},
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	resp, err := client.Create(ctx, req)
+	if err != nil {
+		return 0, err
+	}
+
+	return int(resp.GetId()), nil
+}
+
+// UpdateNote изменяет существующую заметку пользователя.
+func (c *TextClient) UpdateNote(ctx context.Context, token string, note entity.TextDTO) error {
+	if err := c.keys.seal(&note.Note, &note.Metadata); err != nil {
+		return err
+	}
+
+	client := pb.NewTextClient(c.conn)
+	req := &pb.UpdateNoteRequest{
+		Note: &pb.NoteMsg{
+			Id:       int64(note.ID),
+			Note:     note.Note,
+			Metadata: note.Metadata,
+			FolderId: int64(note.FolderID),
+			TagIds:   tagIDsToMsg(note.TagIDs),
+		},
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := client.Update(ctx, req)
+	return err
+}
+
+// DeleteNote удаляет заметку пользователя по её id.
+func (c *TextClient) DeleteNote(ctx context.Context, token string, id int) error {
+	client := pb.NewTextClient(c.conn)
+	req := &pb.DeleteNoteRequest{
+		Id: int64(id),
+	}
+
+	ctx, cancel := context.WithDeadline(ctx, time.Now().Add(time.Second))
+	defer cancel()
+
+	md := metadata.New(map[string]string{"token": token})
+	ctx = metadata.NewOutgoingContext(ctx, md)
+
+	_, err := client.Delete(ctx, req)
+	return err
+}
+
+// noteFromMsg преобразует сообщение сервера в объект заметки с расшифрованными полями.
+func noteFromMsg(keys *Keys, msg *pb.NoteMsg) (entity.TextDTO, error) {
+	note := entity.TextDTO{
+		ID:       int(msg.GetId()),
+		Note:     msg.GetNote(),
+		Metadata: msg.GetMetadata(),
+		Labels:   labelsFromMsg(msg.GetFolderId(), msg.GetTagIds()),
+	}
+
+	err := keys.open(&note.Note, &note.Metadata)
+	return note, err
 }
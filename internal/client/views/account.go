@@ -0,0 +1,58 @@
+package views
+
+import (
+	"context"
+
+	"github.com/rivo/tview"
+)
+
+// Экспорт всех данных пользователя в каталог хранилища клиента (архив в открытом виде).
+func (v *View) exportAccount() {
+	path, err := v.ctrl.Account.ExportAccount(context.Background(), v.ctrl.Session.Token(), v.cfg.Storage.Path)
+	if err != nil {
+		v.callRequestFail(err, v.switchToUnitsMenu)
+		return
+	}
+
+	v.switchToUnitsMenu()
+	v.setHeader("Resources\nall data exported to " + path)
+}
+
+// Форма удаления учётной записи: удаление выполняется только после ввода текущего пароля
+// (и кода аутентификатора, если подключён второй фактор).
+func (v *View) callDeleteAccountForm() {
+	var password, code string
+
+	v.tui.editForm.Clear(true)
+
+	v.tui.editForm.AddPasswordField("password", "", 20, '*', func(text string) {
+		password = text
+	})
+
+	// код аутентификатора требуется, только если подключён второй фактор
+	v.tui.editForm.AddInputField("one-time code (if enabled)", "", 10, tview.InputFieldInteger, func(text string) {
+		code = text
+	})
+
+	v.tui.editForm.AddButton("Delete", func() {
+		err := v.ctrl.Account.DeleteAccount(context.Background(), v.ctrl.Session.Token(), password, code)
+		if err != nil {
+			v.callRequestFail(err, v.switchToUnitsMenu)
+			return
+		}
+
+		v.ctrl.Keys.Lock()
+		v.ctrl.Sync.Reset()
+		v.resetSearch()
+		v.resetLabels()
+		v.switchToMainMenu()
+		v.setHeader("Main menu\naccount and all its data deleted")
+	})
+
+	v.tui.editForm.AddButton("Cancel", func() {
+		v.switchToUnitsMenu()
+	})
+
+	v.setHeader("Delete account and all its data (irreversible)")
+	v.tui.body.SwitchToPage(editForm)
+}
@@ -0,0 +1,212 @@
+package views
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+)
+
+const (
+	binariesPage   = "binaries"
+	binariesHeader = "Binary (u - upload, s - save to dir, d - delete, o - order, ESC - exit)"
+)
+
+func (v *View) createBinariesPage() {
+	v.tui.binariesList = tview.NewList().ShowSecondaryText(false)
+	v.tui.binaryInfo = tview.NewTextView()
+
+	v.tui.binariesPage = tview.NewFlex().
+		AddItem(v.tui.binariesList, 0, 1, true).
+		AddItem(v.tui.binaryInfo, 0, 3, false)
+
+	v.tui.binariesPage.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if v.blockOffline(event, "usd", v.switchToBinariesPage) {
+			return nil
+		}
+
+		switch {
+		case event.Key() == tcell.KeyEscape:
+			v.switchToUnitsMenu()
+		case event.Rune() == 'u':
+			v.callUploadForm()
+			return nil
+		case event.Rune() == 's':
+			if binary, ok := v.selectedBinary(); ok {
+				v.callSaveForm(binary)
+			}
+			return nil
+		case event.Rune() == 'o':
+			v.binariesPaging.toggleOrder()
+			v.switchToBinariesPage()
+			return nil
+		case event.Rune() == 'd':
+			if binary, ok := v.selectedBinary(); ok {
+				v.callDeleteAsk(func() error {
+					return v.ctrl.Binaries.DeleteBinary(context.Background(), v.ctrl.Session.Token(), binary.ID)
+				}, v.switchToBinariesPage)
+			}
+			return nil
+		}
+		return event
+	})
+
+	v.tui.binariesList.SetSelectedFunc(func(index int, name string, secondName string, shortcut rune) {
+		if binary, ok := v.selectedBinary(); ok {
+			v.setBinaryInfo(binary)
+		}
+	})
+
+	v.tui.binariesList.SetChangedFunc(func(index int, name string, secondName string, shortcut rune) {
+		v.moreBinaries(index)
+	})
+
+	v.tui.body.AddPage(binariesPage, v.tui.binariesPage, true, false)
+}
+
+func (v *View) getBinariesList() error {
+	binaries, err := firstPage(v, &v.binariesPaging, v.listBinaries, v.ctrl.Sync.Binaries, func(binary entity.BinaryDTO) entity.Labels {
+		return binary.Labels
+	})
+	if err != nil {
+		return err
+	}
+
+	v.binaries = nil
+	v.tui.binariesList.Clear()
+	v.appendBinaries(binaries)
+
+	return nil
+}
+
+// listBinaries запрос страницы списка описаний файлов.
+func (v *View) listBinaries(page entity.PageRequest) ([]entity.BinaryDTO, string, error) {
+	return v.ctrl.Binaries.ListBinaries(context.Background(), v.ctrl.Session.Token(), page)
+}
+
+// appendBinaries добавляет записи в конец списка.
+func (v *View) appendBinaries(binaries []entity.BinaryDTO) {
+	v.binaries = append(v.binaries, binaries...)
+	for _, binary := range binaries {
+		v.tui.binariesList.AddItem(strconv.Itoa(binary.ID)+" "+binary.Filename, "", ' ', nil)
+	}
+}
+
+// moreBinaries загружает следующую страницу, когда выбран последний элемент списка.
+func (v *View) moreBinaries(index int) {
+	if index < len(v.binaries)-1 {
+		return
+	}
+
+	binaries, err := nextPage(&v.binariesPaging, v.listBinaries)
+	if err != nil {
+		v.callRequestFail(err, v.switchToBinariesPage)
+		return
+	}
+
+	v.appendBinaries(binaries)
+}
+
+func (v *View) setBinaryInfo(binary entity.BinaryDTO) {
+	var sb strings.Builder
+
+	v.tui.binaryInfo.Clear()
+	sb.WriteString(binary.Filename)
+	sb.WriteString("\n")
+	sb.WriteString(strconv.FormatInt(binary.Size, 10))
+	sb.WriteString(" bytes\n")
+	sb.WriteString(binary.Metadata)
+	sb.WriteString("\n")
+	sb.WriteString(v.labelsText(binary.Labels))
+
+	v.tui.binaryInfo.SetText(sb.String())
+}
+
+func (v *View) selectedBinary() (entity.BinaryDTO, bool) {
+	index := v.tui.binariesList.GetCurrentItem()
+	if index < 0 || index >= len(v.binaries) {
+		return entity.BinaryDTO{}, false
+	}
+
+	return v.binaries[index], true
+}
+
+// Форма загрузки локального файла на сервер.
+func (v *View) callUploadForm() {
+	var path, metadata string
+	labels := v.filterLabels()
+
+	v.tui.editForm.Clear(true)
+	v.tui.editForm.AddInputField("file path", "", 60, nil, func(text string) {
+		path = text
+	})
+
+	v.tui.editForm.AddTextArea("metadata", "", 60, 4, 0, func(text string) {
+		metadata = text
+	})
+
+	resolveTags := v.addLabelFields(&labels)
+
+	v.tui.editForm.AddButton("Upload", func() {
+		if err := resolveTags(); err != nil {
+			v.callRequestFail(err, v.switchToBinariesPage)
+			return
+		}
+
+		if _, err := v.ctrl.Binaries.UploadBinary(context.Background(), v.ctrl.Session.Token(), path, metadata, labels); err != nil {
+			v.callRequestFail(err, v.switchToBinariesPage)
+			return
+		}
+
+		v.switchToBinariesPage()
+	})
+
+	v.tui.editForm.AddButton("Cancel", func() {
+		v.switchToBinariesPage()
+	})
+
+	v.setHeader("Upload file")
+	v.tui.body.SwitchToPage(editForm)
+}
+
+// Форма сохранения выбранного файла в локальный каталог (по умолчанию - каталог хранилища из конфигурации).
+func (v *View) callSaveForm(binary entity.BinaryDTO) {
+	dir := v.cfg.Storage.Path
+
+	v.tui.editForm.Clear(true)
+	v.tui.editForm.AddInputField("directory", dir, 60, nil, func(text string) {
+		dir = text
+	})
+
+	v.tui.editForm.AddButton("Save", func() {
+		path, err := v.ctrl.Binaries.DownloadBinary(context.Background(), v.ctrl.Session.Token(), binary.ID, dir)
+		if err != nil {
+			v.callRequestFail(err, v.switchToBinariesPage)
+			return
+		}
+
+		v.switchToBinariesPage()
+		v.tui.binaryInfo.SetText("saved to " + path)
+	})
+
+	v.tui.editForm.AddButton("Cancel", func() {
+		v.switchToBinariesPage()
+	})
+
+	v.setHeader("Save " + binary.Filename)
+	v.tui.body.SwitchToPage(editForm)
+}
+
+func (v *View) switchToBinariesPage() {
+	if err := v.getBinariesList(); err != nil {
+		v.callRequestFail(err, v.switchToUnitsMenu)
+		return
+	}
+
+	v.setListHeader(binariesHeader + " [" + v.binariesPaging.orderTitle() + "]" + v.filterTitle())
+	v.tui.body.SwitchToPage(binariesPage)
+}
@@ -3,11 +3,15 @@ package views
 
 import (
 	"context"
+	"errors"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/status"
 
 	"github.com/PaulYakow/gophkeeper/cmd/client/config"
 	"github.com/PaulYakow/gophkeeper/internal/client/controller"
@@ -15,15 +19,26 @@ import (
 )
 
 const (
-	mainMenu     = "main"
-	unitsMenu    = "units"
-	pairsPage    = "pairs"
-	cardsPage    = "cards"
-	notesPage    = "notes"
-	signForm     = "sign"
-	registerFail = "login exist"
+	mainMenu    = "main"
+	unitsMenu   = "units"
+	pairsPage   = "pairs"
+	cardsPage   = "cards"
+	notesPage   = "notes"
+	signForm    = "sign"
+	editForm    = "edit"
+	requestFail = "request fail"
+	deleteAsk   = "delete ask"
 )
 
+const (
+	pairsHeader = "Pairs (n - new, e - edit, d - delete, o - order, ESC - exit)"
+	cardsHeader = "Cards (n - new, e - edit, d - delete, o - order, ESC - exit)"
+	notesHeader = "Notes (n - new, e - edit, d - delete, o - order, ESC - exit)"
+)
+
+// serverInfoTimeout время ожидания информации о сервере.
+const serverInfoTimeout = 5 * time.Second
+
 type Sign int
 
 const (
@@ -39,6 +54,8 @@ type ui struct {
 	body   *tview.Pages
 	footer *tview.Flex
 
+	serverInfo *tview.TextView
+
 	mainMenu *tview.List
 
 	unitsMenu *tview.List
@@ -55,8 +72,29 @@ type ui struct {
 	notesList *tview.List
 	noteInfo  *tview.TextView
 
+	binariesPage *tview.Flex
+	binariesList *tview.List
+	binaryInfo   *tview.TextView
+
+	otpsPage *tview.Flex
+	otpsList *tview.List
+	otpInfo  *tview.TextView
+
+	searchPage  *tview.Flex
+	searchInput *tview.InputField
+	searchList  *tview.List
+
+	foldersTree *tview.TreeView
+	tagsList    *tview.List
+
 	signForm     *tview.Form
-	registerFail *tview.Modal
+	passwordForm *tview.Form
+	securityPage *tview.TextView
+	codeForm     *tview.Form
+
+	editForm    *tview.Form
+	requestFail *tview.Modal
+	deleteAsk   *tview.Modal
 }
 
 // View обеспечивает взаимодействие TUI и Controller'a
@@ -64,6 +102,31 @@ type View struct {
 	ctrl *controller.Controller
 	cfg  *config.Config
 	tui  *ui
+
+	// последние полученные от сервера списки (для редактирования/удаления выбранного элемента)
+	pairs    []entity.PairDTO
+	cards    []entity.BankDTO
+	notes    []entity.TextDTO
+	binaries []entity.BinaryDTO
+	otps     []entity.OTPDTO
+
+	// постраничная загрузка списков
+	pairsPaging    listPaging
+	cardsPaging    listPaging
+	notesPaging    listPaging
+	binariesPaging listPaging
+	otpsPaging     listPaging
+
+	// результаты последнего поиска
+	searchHits []entity.SearchHitDTO
+
+	// папки и метки пользователя (метки - по названию) и отбор записей списков по ним
+	folders []entity.FolderDTO
+	tags    []entity.TagDTO
+	filter  entity.LabelFilter
+
+	// время сохранения отображаемой локальной копии данных (нулевое - данные получены от сервера)
+	cachedAt time.Time
 }
 
 // New создаёт объект View.
@@ -75,34 +138,92 @@ func New(ctrl *controller.Controller, cfg *config.Config) (v *View) {
 			Application: tview.NewApplication(),
 			body:        tview.NewPages(),
 			signForm:    tview.NewForm(),
+			editForm:    tview.NewForm(),
 		},
 	}
 
 	v.createHeader()
 
 	v.createMainMenu()
-	v.createRegisterFail()
+	v.createRequestFail()
+	v.createDeleteAsk()
+	v.createPasswordForm()
+	v.createSecurityPage()
+	v.createCodeForm()
 	v.createUnitsMenu()
 	v.createPairsPage()
 	v.createCardsPage()
 	v.createNotesPage()
+	v.createBinariesPage()
+	v.createOTPsPage()
+	v.createSearchPage()
+	v.createFoldersPage()
+	v.createTagsPage()
 
 	v.createFooter()
 	v.createRoot()
 
+	v.resumeSession()
+
 	v.tui.EnableMouse(true)
 	return
 }
 
+// Восстановление сохранённой сессии: при действительной сессии запрашивается мастер-пароль,
+// если сессия истекла или отклонена сервером - форма входа.
+func (v *View) resumeSession() {
+	err := v.ctrl.Auth.Resume(context.Background())
+	switch {
+	case err == nil:
+		v.callUnlockForm(false)
+	case controller.IsUnavailable(err):
+		v.callUnlockForm(true)
+	case errors.Is(err, controller.ErrNoSession):
+		// сессия не сохранялась - остаётся основное меню
+	default:
+		v.tui.signForm.Clear(true)
+		v.callSignForm(login)
+		v.setHeader("Login")
+		v.tui.body.SwitchToPage(signForm)
+	}
+}
+
+// Форма ввода мастер-пароля для восстановленной сессии (ключ шифрования данных не сохраняется на диске).
+func (v *View) callUnlockForm(offline bool) {
+	var masterPassword string
+
+	v.tui.signForm.Clear(true)
+
+	v.tui.signForm.AddPasswordField("master password", "", 20, '*', func(password string) {
+		masterPassword = password
+	})
+
+	v.tui.signForm.AddButton("OK", func() {
+		v.signIn(v.ctrl.Session.Login(), masterPassword, offline)
+	})
+
+	v.tui.signForm.AddButton("Cancel", func() {
+		// сессия завершается - при следующем запуске потребуется вход
+		_ = v.ctrl.Auth.Logout(context.Background())
+		v.switchToMainMenu()
+	})
+
+	v.setHeader("Unlock " + v.ctrl.Session.Login())
+	v.tui.body.SwitchToPage(signForm)
+}
+
 // Run запускает TUI клиента.
 func (v *View) Run() {
+	go v.runOTPTicker()
+	go v.runServerInfo()
+
 	if err := v.tui.SetRoot(v.tui.root, true).Run(); err != nil {
 		panic(err)
 	}
 }
 
 func (v *View) callSignForm(signType Sign) {
-	var regLogin, regPassword string
+	var regLogin, regPassword, masterPassword string
 	v.tui.signForm.AddInputField("login", "", 20, nil, func(login string) {
 		regLogin = login
 	})
@@ -111,26 +232,56 @@ func (v *View) callSignForm(signType Sign) {
 		regPassword = password
 	})
 
+	// мастер-пароль не передаётся на сервер: из него выводится ключ шифрования данных
+	v.tui.signForm.AddPasswordField("master password", "", 20, '*', func(password string) {
+		masterPassword = password
+	})
+
 	v.tui.signForm.AddButton("OK", func() {
-		var token string
 		var err error
 
 		switch signType {
 		case register:
-			token, err = v.ctrl.Auth.Register(context.Background(), regLogin, regPassword)
+			err = v.ctrl.Auth.Register(context.Background(), regLogin, regPassword)
 		case login:
-			token, err = v.ctrl.Auth.Login(context.Background(), regLogin, regPassword)
+			err = v.ctrl.Auth.Login(context.Background(), regLogin, regPassword)
 		default:
 			v.switchToMainMenu()
 		}
 
-		if err != nil {
-			v.tui.body.SwitchToPage(registerFail)
+		// срок действия пароля истёк - вход после его смены
+		if signType == login && controller.IsPasswordExpired(err) {
+			v.setHeader("Password expired: choose a new password")
+			v.callPasswordForm(regLogin, regPassword, func() {
+				v.signIn(regLogin, masterPassword, false)
+			}, v.switchToMainMenu)
+			return
+		}
+
+		// пароль принят - вход завершается кодом аутентификатора
+		if signType == login && errors.Is(err, controller.ErrSecondFactorRequired) {
+			v.callCodeForm(func() {
+				v.signIn(regLogin, masterPassword, false)
+			}, v.switchToMainMenu)
 			return
 		}
 
-		v.ctrl.Token = token
-		v.switchToUnitsMenu()
+		// логин или адрес заблокирован после серии неудачных попыток - повтор пока бесполезен
+		if signType == login && controller.IsLocked(err) {
+			v.callRequestFail(err, v.switchToMainMenu)
+			return
+		}
+
+		// при недоступном сервере вход выполняется только для просмотра локальной копии данных
+		offline := signType == login && controller.IsUnavailable(err)
+		if err != nil && !offline {
+			v.callRequestFail(err, func() {
+				v.tui.body.SwitchToPage(signForm)
+			})
+			return
+		}
+
+		v.signIn(regLogin, masterPassword, offline)
 	})
 
 	v.tui.signForm.AddButton("Cancel", func() {
@@ -138,6 +289,41 @@ func (v *View) callSignForm(signType Sign) {
 	})
 }
 
+// Завершение входа: вывод ключа шифрования данных из мастер-пароля и переход к меню данных.
+func (v *View) signIn(login, masterPassword string, offline bool) {
+	if err := v.ctrl.Keys.Unlock(login, masterPassword); err != nil {
+		v.callRequestFail(err, v.switchToMainMenu)
+		return
+	}
+
+	// ошибка сохранения сессии не мешает работе - при следующем запуске потребуется вход
+	_ = v.ctrl.Session.Save()
+
+	v.switchToUnitsMenu()
+	if offline {
+		v.setHeader("Resources\nOFFLINE: server unavailable, only cached data can be viewed")
+		return
+	}
+
+	v.migratePlaintext()
+}
+
+// Однократная миграция записей, сохранённых до включения сквозного шифрования (флаг --migrate-plaintext).
+func (v *View) migratePlaintext() {
+	if !v.cfg.MigratePlaintext {
+		return
+	}
+
+	migrated, err := v.ctrl.MigratePlaintext(context.Background(), v.ctrl.Session.Token())
+	if err != nil {
+		v.callRequestFail(err, v.switchToUnitsMenu)
+		return
+	}
+
+	v.cfg.MigratePlaintext = false
+	v.setHeader("Resources\nplaintext migration: " + strconv.Itoa(migrated) + " records encrypted")
+}
+
 func (v *View) createMainMenu() {
 	v.tui.mainMenu = tview.NewList().
 		AddItem("Register", "Sign up new user", 'r', func() {
@@ -158,39 +344,122 @@ func (v *View) createMainMenu() {
 
 	v.tui.body.AddPage(mainMenu, v.tui.mainMenu, true, true)
 	v.tui.body.AddPage(signForm, v.tui.signForm, true, false)
+	v.tui.body.AddPage(editForm, v.tui.editForm, true, false)
 }
 
-func (v *View) createRegisterFail() {
-	v.tui.registerFail = tview.NewModal().
-		SetText("Login already exist!").
+func (v *View) createRequestFail() {
+	v.tui.requestFail = tview.NewModal().
 		AddButtons([]string{"OK"}).
-		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
-			v.switchToMainMenu()
-		}).
 		SetBackgroundColor(tcell.ColorLightCoral)
 
-	v.tui.body.AddPage(registerFail, v.tui.registerFail, true, false)
+	v.tui.body.AddPage(requestFail, v.tui.requestFail, true, false)
+}
+
+// Отображение ошибки запроса к серверу с последующим возвратом на страницу back.
+func (v *View) callRequestFail(err error, back func()) {
+	v.tui.requestFail.
+		SetText("Request failed:\n" + failReason(err)).
+		SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+			back()
+		})
+
+	v.tui.body.SwitchToPage(requestFail)
+}
+
+// Описание ошибки для пользователя: ошибки сервера с известной причиной описываются пакетом controller,
+// у прочих статусов gRPC выводится только сообщение (без кода).
+func failReason(err error) string {
+	var serverErr *controller.ServerError
+	switch {
+	case errors.As(err, &serverErr):
+		return serverErr.Error()
+	case controller.IsUnavailable(err):
+		return "server unavailable: check connection and try again"
+	}
+
+	if st, ok := status.FromError(err); ok {
+		return st.Message()
+	}
+
+	return err.Error()
+}
+
+func (v *View) createDeleteAsk() {
+	v.tui.deleteAsk = tview.NewModal().
+		SetText("Delete selected item?").
+		AddButtons([]string{"Delete", "Cancel"})
+
+	v.tui.body.AddPage(deleteAsk, v.tui.deleteAsk, true, false)
+}
+
+// Подтверждение удаления элемента: remove выполняется только при подтверждении пользователем.
+func (v *View) callDeleteAsk(remove func() error, back func()) {
+	v.tui.deleteAsk.SetDoneFunc(func(buttonIndex int, buttonLabel string) {
+		if buttonLabel == "Delete" {
+			if err := remove(); err != nil {
+				v.callRequestFail(err, back)
+				return
+			}
+		}
+		back()
+	})
+
+	v.tui.body.SwitchToPage(deleteAsk)
 }
 
 func (v *View) createUnitsMenu() {
 	v.tui.unitsMenu = tview.NewList().
 		AddItem("Pairs", "show login/password pairs", 'r', func() {
-			v.getPairsList()
-			v.setHeader("Pairs (press ESC to exit)")
-			v.tui.body.SwitchToPage(pairsPage)
+			v.switchToPairsPage()
 		}).
 		AddItem("Notes", "show arbitrary text data", 'l', func() {
-			v.getNotesList()
-			v.setHeader("Notes (press ESC to exit)")
-			v.tui.body.SwitchToPage(notesPage)
+			v.switchToNotesPage()
 		}).
 		AddItem("Cards", "show bank cards data", 'c', func() {
-			v.getCardsList()
-			v.setHeader("Cards (press ESC to exit)")
-			v.tui.body.SwitchToPage(cardsPage)
+			v.switchToCardsPage()
+		}).
+		AddItem("Binary", "show arbitrary binary data", 'b', func() {
+			v.switchToBinariesPage()
+		}).
+		AddItem("OTP", "show one-time passwords (TOTP/HOTP)", 'o', func() {
+			v.switchToOTPsPage()
+		}).
+		AddItem("Search", "find records by words in notes and metadata", 'f', func() {
+			v.switchToSearchPage()
+		}).
+		AddItem("Folders", "browse folder tree and show records of a folder", 'g', func() {
+			v.switchToFoldersPage()
+		}).
+		AddItem("Tags", "manage tags and show records with a tag", 'a', func() {
+			v.switchToTagsPage()
+		}).
+		AddItem("Password", "change account password", 'p', func() {
+			v.setHeader("Change password")
+			v.callPasswordForm(v.ctrl.Keys.Login(), "", func() {
+				// сервер отозвал прежние сессии - сохраняется новая
+				_ = v.ctrl.Session.Save()
+				v.switchToUnitsMenu()
+			}, v.switchToUnitsMenu)
+		}).
+		AddItem("Two-factor", "enroll or disable authenticator (TOTP)", 't', func() {
+			v.callTwoFactorForm()
+		}).
+		AddItem("Security", "show login lockouts of the account", 's', func() {
+			v.switchToSecurityPage()
+		}).
+		AddItem("Export", "save all data to local file (unencrypted)", 'e', func() {
+			v.exportAccount()
+		}).
+		AddItem("Delete account", "delete account and all data", 'x', func() {
+			v.callDeleteAccountForm()
 		}).
-		AddItem("Binary", "show arbitrary binary data", 'b', nil).
 		AddItem("Back", "... to main menu", ' ', func() {
+			// сессия завершается и при недоступном сервере (токены удаляются локально)
+			_ = v.ctrl.Auth.Logout(context.Background())
+			v.ctrl.Keys.Lock()
+			v.ctrl.Sync.Reset()
+			v.resetSearch()
+			v.resetLabels()
 			v.switchToMainMenu()
 		}).
 		AddItem("Quit", "Press to exit", 'q', func() {
@@ -209,30 +478,90 @@ func (v *View) createPairsPage() {
 		AddItem(v.tui.pairsInfo, 0, 3, false)
 
 	v.tui.pairsPage.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		if event.Key() == tcell.KeyEscape {
+		if v.blockOffline(event, "ned", v.switchToPairsPage) {
+			return nil
+		}
+
+		switch {
+		case event.Key() == tcell.KeyEscape:
 			v.switchToUnitsMenu()
+		case event.Rune() == 'n':
+			v.callPairForm(entity.PairDTO{Labels: v.filterLabels()})
+			return nil
+		case event.Rune() == 'e':
+			if pair, ok := v.selectedPair(); ok {
+				v.callPairForm(pair)
+			}
+			return nil
+		case event.Rune() == 'o':
+			v.pairsPaging.toggleOrder()
+			v.switchToPairsPage()
+			return nil
+		case event.Rune() == 'd':
+			if pair, ok := v.selectedPair(); ok {
+				v.callDeleteAsk(func() error {
+					return v.ctrl.Pairs.DeletePair(context.Background(), v.ctrl.Session.Token(), pair.ID)
+				}, v.switchToPairsPage)
+			}
+			return nil
 		}
 		return event
 	})
 
+	v.tui.pairsList.SetSelectedFunc(func(index int, name string, secondName string, shortcut rune) {
+		if pair, ok := v.selectedPair(); ok {
+			v.setPairInfo(pair)
+		}
+	})
+
+	v.tui.pairsList.SetChangedFunc(func(index int, name string, secondName string, shortcut rune) {
+		v.morePairs(index)
+	})
+
 	v.tui.body.AddPage(pairsPage, v.tui.pairsPage, true, false)
 }
 
-func (v *View) getPairsList() {
-	pairs, err := v.ctrl.Pairs.ViewAllPairs(context.Background(), v.ctrl.Token)
+func (v *View) getPairsList() error {
+	pairs, err := firstPage(v, &v.pairsPaging, v.listPairs, v.ctrl.Sync.Pairs, func(pair entity.PairDTO) entity.Labels {
+		return pair.Labels
+	})
 	if err != nil {
-		v.switchToUnitsMenu()
-		return
+		return err
 	}
 
+	v.pairs = nil
 	v.tui.pairsList.Clear()
+	v.appendPairs(pairs)
+
+	return nil
+}
+
+// listPairs запрос страницы списка пар логин/пароль.
+func (v *View) listPairs(page entity.PageRequest) ([]entity.PairDTO, string, error) {
+	return v.ctrl.Pairs.ListPairs(context.Background(), v.ctrl.Session.Token(), page)
+}
+
+// appendPairs добавляет записи в конец списка.
+func (v *View) appendPairs(pairs []entity.PairDTO) {
+	v.pairs = append(v.pairs, pairs...)
 	for _, pair := range pairs {
 		v.tui.pairsList.AddItem(strconv.Itoa(pair.ID), "", ' ', nil)
 	}
+}
 
-	v.tui.pairsList.SetSelectedFunc(func(index int, name string, secondName string, shortcut rune) {
-		v.setPairInfo(pairs[index])
-	})
+// morePairs загружает следующую страницу, когда выбран последний элемент списка.
+func (v *View) morePairs(index int) {
+	if index < len(v.pairs)-1 {
+		return
+	}
+
+	pairs, err := nextPage(&v.pairsPaging, v.listPairs)
+	if err != nil {
+		v.callRequestFail(err, v.switchToPairsPage)
+		return
+	}
+
+	v.appendPairs(pairs)
 }
 
 func (v *View) setPairInfo(pair entity.PairDTO) {
@@ -245,10 +574,66 @@ func (v *View) setPairInfo(pair entity.PairDTO) {
 	sb.WriteString("\n")
 	sb.WriteString(pair.Metadata)
 	sb.WriteString("\n")
+	sb.WriteString(v.labelsText(pair.Labels))
 
 	v.tui.pairsInfo.SetText(sb.String())
 }
 
+func (v *View) selectedPair() (entity.PairDTO, bool) {
+	index := v.tui.pairsList.GetCurrentItem()
+	if index < 0 || index >= len(v.pairs) {
+		return entity.PairDTO{}, false
+	}
+
+	return v.pairs[index], true
+}
+
+// Форма создания (pair.ID == 0) или изменения пары логин/пароль.
+func (v *View) callPairForm(pair entity.PairDTO) {
+	v.tui.editForm.Clear(true)
+	v.tui.editForm.AddInputField("login", pair.Login, 40, nil, func(login string) {
+		pair.Login = login
+	})
+
+	v.tui.editForm.AddInputField("password", pair.Password, 40, nil, func(password string) {
+		pair.Password = password
+	})
+
+	v.tui.editForm.AddTextArea("metadata", pair.Metadata, 40, 4, 0, func(metadata string) {
+		pair.Metadata = metadata
+	})
+
+	resolveTags := v.addLabelFields(&pair.Labels)
+
+	v.tui.editForm.AddButton("Save", func() {
+		if err := resolveTags(); err != nil {
+			v.callRequestFail(err, v.switchToPairsPage)
+			return
+		}
+
+		var err error
+		if pair.ID == 0 {
+			_, err = v.ctrl.Pairs.CreatePair(context.Background(), v.ctrl.Session.Token(), pair)
+		} else {
+			err = v.ctrl.Pairs.UpdatePair(context.Background(), v.ctrl.Session.Token(), pair)
+		}
+
+		if err != nil {
+			v.callRequestFail(err, v.switchToPairsPage)
+			return
+		}
+
+		v.switchToPairsPage()
+	})
+
+	v.tui.editForm.AddButton("Cancel", func() {
+		v.switchToPairsPage()
+	})
+
+	v.setHeader("Pair")
+	v.tui.body.SwitchToPage(editForm)
+}
+
 func (v *View) createCardsPage() {
 	v.tui.cardsList = tview.NewList().ShowSecondaryText(false)
 	v.tui.cardInfo = tview.NewTextView()
@@ -258,30 +643,90 @@ func (v *View) createCardsPage() {
 		AddItem(v.tui.cardInfo, 0, 3, false)
 
 	v.tui.cardsPage.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		if event.Key() == tcell.KeyEscape {
+		if v.blockOffline(event, "ned", v.switchToCardsPage) {
+			return nil
+		}
+
+		switch {
+		case event.Key() == tcell.KeyEscape:
 			v.switchToUnitsMenu()
+		case event.Rune() == 'n':
+			v.callCardForm(entity.BankDTO{Labels: v.filterLabels()})
+			return nil
+		case event.Rune() == 'e':
+			if card, ok := v.selectedCard(); ok {
+				v.callCardForm(card)
+			}
+			return nil
+		case event.Rune() == 'o':
+			v.cardsPaging.toggleOrder()
+			v.switchToCardsPage()
+			return nil
+		case event.Rune() == 'd':
+			if card, ok := v.selectedCard(); ok {
+				v.callDeleteAsk(func() error {
+					return v.ctrl.Cards.DeleteCard(context.Background(), v.ctrl.Session.Token(), card.ID)
+				}, v.switchToCardsPage)
+			}
+			return nil
 		}
 		return event
 	})
 
+	v.tui.cardsList.SetSelectedFunc(func(index int, name string, secondName string, shortcut rune) {
+		if card, ok := v.selectedCard(); ok {
+			v.setCardInfo(card)
+		}
+	})
+
+	v.tui.cardsList.SetChangedFunc(func(index int, name string, secondName string, shortcut rune) {
+		v.moreCards(index)
+	})
+
 	v.tui.body.AddPage(cardsPage, v.tui.cardsPage, true, false)
 }
 
-func (v *View) getCardsList() {
-	cards, err := v.ctrl.Cards.ViewAllCards(context.Background(), v.ctrl.Token)
+func (v *View) getCardsList() error {
+	cards, err := firstPage(v, &v.cardsPaging, v.listCards, v.ctrl.Sync.Cards, func(card entity.BankDTO) entity.Labels {
+		return card.Labels
+	})
 	if err != nil {
-		v.switchToUnitsMenu()
-		return
+		return err
 	}
 
+	v.cards = nil
 	v.tui.cardsList.Clear()
+	v.appendCards(cards)
+
+	return nil
+}
+
+// listCards запрос страницы списка банковских карт.
+func (v *View) listCards(page entity.PageRequest) ([]entity.BankDTO, string, error) {
+	return v.ctrl.Cards.ListCards(context.Background(), v.ctrl.Session.Token(), page)
+}
+
+// appendCards добавляет записи в конец списка.
+func (v *View) appendCards(cards []entity.BankDTO) {
+	v.cards = append(v.cards, cards...)
 	for _, card := range cards {
 		v.tui.cardsList.AddItem(strconv.Itoa(card.ID), "", ' ', nil)
 	}
+}
 
-	v.tui.cardsList.SetSelectedFunc(func(index int, name string, secondName string, shortcut rune) {
-		v.setCardInfo(cards[index])
-	})
+// moreCards загружает следующую страницу, когда выбран последний элемент списка.
+func (v *View) moreCards(index int) {
+	if index < len(v.cards)-1 {
+		return
+	}
+
+	cards, err := nextPage(&v.cardsPaging, v.listCards)
+	if err != nil {
+		v.callRequestFail(err, v.switchToCardsPage)
+		return
+	}
+
+	v.appendCards(cards)
 }
 
 func (v *View) setCardInfo(card entity.BankDTO) {
@@ -296,10 +741,70 @@ func (v *View) setCardInfo(card entity.BankDTO) {
 	sb.WriteString("\n")
 	sb.WriteString(card.Metadata)
 	sb.WriteString("\n")
+	sb.WriteString(v.labelsText(card.Labels))
 
 	v.tui.cardInfo.SetText(sb.String())
 }
 
+func (v *View) selectedCard() (entity.BankDTO, bool) {
+	index := v.tui.cardsList.GetCurrentItem()
+	if index < 0 || index >= len(v.cards) {
+		return entity.BankDTO{}, false
+	}
+
+	return v.cards[index], true
+}
+
+// Форма создания (card.ID == 0) или изменения банковской карты.
+func (v *View) callCardForm(card entity.BankDTO) {
+	v.tui.editForm.Clear(true)
+	v.tui.editForm.AddInputField("card holder", card.CardHolder, 40, nil, func(holder string) {
+		card.CardHolder = holder
+	})
+
+	v.tui.editForm.AddInputField("number", card.Number, 20, nil, func(number string) {
+		card.Number = number
+	})
+
+	v.tui.editForm.AddInputField("expiration date", card.ExpirationDate, 6, nil, func(date string) {
+		card.ExpirationDate = date
+	})
+
+	v.tui.editForm.AddTextArea("metadata", card.Metadata, 40, 4, 0, func(metadata string) {
+		card.Metadata = metadata
+	})
+
+	resolveTags := v.addLabelFields(&card.Labels)
+
+	v.tui.editForm.AddButton("Save", func() {
+		if err := resolveTags(); err != nil {
+			v.callRequestFail(err, v.switchToCardsPage)
+			return
+		}
+
+		var err error
+		if card.ID == 0 {
+			_, err = v.ctrl.Cards.CreateCard(context.Background(), v.ctrl.Session.Token(), card)
+		} else {
+			err = v.ctrl.Cards.UpdateCard(context.Background(), v.ctrl.Session.Token(), card)
+		}
+
+		if err != nil {
+			v.callRequestFail(err, v.switchToCardsPage)
+			return
+		}
+
+		v.switchToCardsPage()
+	})
+
+	v.tui.editForm.AddButton("Cancel", func() {
+		v.switchToCardsPage()
+	})
+
+	v.setHeader("Card")
+	v.tui.body.SwitchToPage(editForm)
+}
+
 func (v *View) createNotesPage() {
 	v.tui.notesList = tview.NewList().ShowSecondaryText(false)
 	v.tui.noteInfo = tview.NewTextView()
@@ -309,30 +814,90 @@ func (v *View) createNotesPage() {
 		AddItem(v.tui.noteInfo, 0, 3, false)
 
 	v.tui.notesPage.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
-		if event.Key() == tcell.KeyEscape {
+		if v.blockOffline(event, "ned", v.switchToNotesPage) {
+			return nil
+		}
+
+		switch {
+		case event.Key() == tcell.KeyEscape:
 			v.switchToUnitsMenu()
+		case event.Rune() == 'n':
+			v.callNoteForm(entity.TextDTO{Labels: v.filterLabels()})
+			return nil
+		case event.Rune() == 'e':
+			if note, ok := v.selectedNote(); ok {
+				v.callNoteForm(note)
+			}
+			return nil
+		case event.Rune() == 'o':
+			v.notesPaging.toggleOrder()
+			v.switchToNotesPage()
+			return nil
+		case event.Rune() == 'd':
+			if note, ok := v.selectedNote(); ok {
+				v.callDeleteAsk(func() error {
+					return v.ctrl.Notes.DeleteNote(context.Background(), v.ctrl.Session.Token(), note.ID)
+				}, v.switchToNotesPage)
+			}
+			return nil
 		}
 		return event
 	})
 
+	v.tui.notesList.SetSelectedFunc(func(index int, name string, secondName string, shortcut rune) {
+		if note, ok := v.selectedNote(); ok {
+			v.setNoteInfo(note)
+		}
+	})
+
+	v.tui.notesList.SetChangedFunc(func(index int, name string, secondName string, shortcut rune) {
+		v.moreNotes(index)
+	})
+
 	v.tui.body.AddPage(notesPage, v.tui.notesPage, true, false)
 }
 
-func (v *View) getNotesList() {
-	notes, err := v.ctrl.Notes.ViewAllNotes(context.Background(), v.ctrl.Token)
+func (v *View) getNotesList() error {
+	notes, err := firstPage(v, &v.notesPaging, v.listNotes, v.ctrl.Sync.Notes, func(note entity.TextDTO) entity.Labels {
+		return note.Labels
+	})
 	if err != nil {
-		v.switchToUnitsMenu()
-		return
+		return err
 	}
 
+	v.notes = nil
 	v.tui.notesList.Clear()
+	v.appendNotes(notes)
+
+	return nil
+}
+
+// listNotes запрос страницы списка заметок.
+func (v *View) listNotes(page entity.PageRequest) ([]entity.TextDTO, string, error) {
+	return v.ctrl.Notes.ListNotes(context.Background(), v.ctrl.Session.Token(), page)
+}
+
+// appendNotes добавляет записи в конец списка.
+func (v *View) appendNotes(notes []entity.TextDTO) {
+	v.notes = append(v.notes, notes...)
 	for _, note := range notes {
 		v.tui.notesList.AddItem(strconv.Itoa(note.ID), "", ' ', nil)
 	}
+}
 
-	v.tui.notesList.SetSelectedFunc(func(index int, name string, secondName string, shortcut rune) {
-		v.setNoteInfo(notes[index])
-	})
+// moreNotes загружает следующую страницу, когда выбран последний элемент списка.
+func (v *View) moreNotes(index int) {
+	if index < len(v.notes)-1 {
+		return
+	}
+
+	notes, err := nextPage(&v.notesPaging, v.listNotes)
+	if err != nil {
+		v.callRequestFail(err, v.switchToNotesPage)
+		return
+	}
+
+	v.appendNotes(notes)
 }
 
 func (v *View) setNoteInfo(note entity.TextDTO) {
@@ -343,10 +908,62 @@ func (v *View) setNoteInfo(note entity.TextDTO) {
 	sb.WriteString("\n")
 	sb.WriteString(note.Metadata)
 	sb.WriteString("\n")
+	sb.WriteString(v.labelsText(note.Labels))
 
 	v.tui.noteInfo.SetText(sb.String())
 }
 
+func (v *View) selectedNote() (entity.TextDTO, bool) {
+	index := v.tui.notesList.GetCurrentItem()
+	if index < 0 || index >= len(v.notes) {
+		return entity.TextDTO{}, false
+	}
+
+	return v.notes[index], true
+}
+
+// Форма создания (note.ID == 0) или изменения заметки.
+func (v *View) callNoteForm(note entity.TextDTO) {
+	v.tui.editForm.Clear(true)
+	v.tui.editForm.AddTextArea("note", note.Note, 40, 6, 0, func(text string) {
+		note.Note = text
+	})
+
+	v.tui.editForm.AddTextArea("metadata", note.Metadata, 40, 4, 0, func(metadata string) {
+		note.Metadata = metadata
+	})
+
+	resolveTags := v.addLabelFields(&note.Labels)
+
+	v.tui.editForm.AddButton("Save", func() {
+		if err := resolveTags(); err != nil {
+			v.callRequestFail(err, v.switchToNotesPage)
+			return
+		}
+
+		var err error
+		if note.ID == 0 {
+			_, err = v.ctrl.Notes.CreateNote(context.Background(), v.ctrl.Session.Token(), note)
+		} else {
+			err = v.ctrl.Notes.UpdateNote(context.Background(), v.ctrl.Session.Token(), note)
+		}
+
+		if err != nil {
+			v.callRequestFail(err, v.switchToNotesPage)
+			return
+		}
+
+		v.switchToNotesPage()
+	})
+
+	v.tui.editForm.AddButton("Cancel", func() {
+		v.switchToNotesPage()
+	})
+
+	v.setHeader("Note")
+	v.tui.body.SwitchToPage(editForm)
+}
+
 func (v *View) createHeader() {
 	v.tui.header = tview.NewTextView()
 	v.tui.header.SetBorder(true)
@@ -364,15 +981,57 @@ func (v *View) createFooter() {
 	clientInfo.SetBorder(true).
 		SetTitle("Client info")
 
-	serverInfo := tview.NewTextView().
-		SetText("version: \ntarget: ")
-	serverInfo.SetBorder(true).
+	v.tui.serverInfo = tview.NewTextView().
+		SetText(serverInfoText(entity.ServerInfoDTO{}, v.ctrl.Info.Target(), v.ctrl.Info.State()))
+	v.tui.serverInfo.SetBorder(true).
 		SetTitle("Server info")
 
 	v.tui.footer = tview.NewFlex().
 		AddItem(clientInfo, 0, 1, false).
 		AddItem(tview.NewBox(), 0, 1, false).
-		AddItem(serverInfo, 0, 1, false)
+		AddItem(v.tui.serverInfo, 0, 1, false)
+}
+
+// runServerInfo обновляет информацию о сервере при каждом изменении состояния соединения.
+//
+// Информация запрашивается при запуске (пока не получена) и после каждого восстановления соединения.
+func (v *View) runServerInfo() {
+	var info entity.ServerInfoDTO
+	loaded := false
+	prev := connectivity.Idle
+
+	for {
+		state := v.ctrl.Info.State()
+		if !loaded && state == connectivity.Idle || state == connectivity.Ready && prev != connectivity.Ready {
+			ctx, cancel := context.WithTimeout(context.Background(), serverInfoTimeout)
+			if got, err := v.ctrl.Info.Info(ctx); err == nil {
+				info, loaded = got, true
+			}
+			cancel()
+			state = v.ctrl.Info.State()
+		}
+
+		text := serverInfoText(info, v.ctrl.Info.Target(), state)
+		v.tui.QueueUpdateDraw(func() {
+			v.tui.serverInfo.SetText(text)
+		})
+
+		prev = state
+		v.ctrl.Info.WaitForStateChange(context.Background(), state)
+	}
+}
+
+// Текст блока информации о сервере (пустая версия - информация ещё не получена).
+func serverInfoText(info entity.ServerInfoDTO, target string, state connectivity.State) string {
+	version := info.Version
+	if !info.BuildTime.IsZero() {
+		version += " (built " + info.BuildTime.Format("2006-01-02 15:04") + ")"
+	}
+
+	return "version: " + version +
+		"\ntarget: " + target +
+		"\nstate: " + strings.ToLower(state.String()) +
+		"\nfeatures: " + strings.Join(info.Features, ", ")
 }
 
 func (v *View) createRoot() {
@@ -388,6 +1047,36 @@ func (v *View) switchToMainMenu() {
 }
 
 func (v *View) switchToUnitsMenu() {
-	v.setHeader("Resources")
+	v.setHeader("Resources" + v.filterTitle())
 	v.tui.body.SwitchToPage(unitsMenu)
 }
+
+func (v *View) switchToPairsPage() {
+	if err := v.getPairsList(); err != nil {
+		v.callRequestFail(err, v.switchToUnitsMenu)
+		return
+	}
+
+	v.setListHeader(pairsHeader + " [" + v.pairsPaging.orderTitle() + "]" + v.filterTitle())
+	v.tui.body.SwitchToPage(pairsPage)
+}
+
+func (v *View) switchToCardsPage() {
+	if err := v.getCardsList(); err != nil {
+		v.callRequestFail(err, v.switchToUnitsMenu)
+		return
+	}
+
+	v.setListHeader(cardsHeader + " [" + v.cardsPaging.orderTitle() + "]" + v.filterTitle())
+	v.tui.body.SwitchToPage(cardsPage)
+}
+
+func (v *View) switchToNotesPage() {
+	if err := v.getNotesList(); err != nil {
+		v.callRequestFail(err, v.switchToUnitsMenu)
+		return
+	}
+
+	v.setListHeader(notesHeader + " [" + v.notesPaging.orderTitle() + "]" + v.filterTitle())
+	v.tui.body.SwitchToPage(notesPage)
+}
@@ -0,0 +1,544 @@
+package views
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/PaulYakow/gophkeeper/internal/client/controller"
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+)
+
+const (
+	foldersPage   = "folders"
+	tagsPage      = "tags"
+	foldersHeader = "Folders (Enter - show folder records, n - new subfolder, e - rename, d - delete, ESC - exit)"
+	tagsHeader    = "Tags (Enter - show tagged records, n - new, e - rename, d - delete, ESC - exit)"
+	// allFolders узел дерева папок без отбора по папке (остальные узлы - id папки, 0 - записи вне папок).
+	allFolders = -1
+	// noFolderTitle название записей вне папок.
+	noFolderTitle = "(no folder)"
+	// anyTagTitle пункт списка меток без отбора по метке.
+	anyTagTitle = "(any tag)"
+)
+
+var (
+	errDuplicateLabel = errors.New("folder or tag with this name already exists")
+	errTagComma       = errors.New("tag name must not contain commas")
+)
+
+func (v *View) createFoldersPage() {
+	v.tui.foldersTree = tview.NewTreeView()
+
+	v.tui.foldersTree.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if v.blockOffline(event, "ned", v.switchToFoldersPage) {
+			return nil
+		}
+
+		folderID := v.selectedFolderID()
+		switch {
+		case event.Key() == tcell.KeyEscape:
+			v.switchToUnitsMenu()
+		case event.Rune() == 'n':
+			parentID := folderID
+			if parentID == allFolders {
+				parentID = 0
+			}
+			v.callFolderForm(entity.FolderDTO{ParentID: parentID})
+			return nil
+		case event.Rune() == 'e':
+			if folder, ok := v.folderByID(folderID); ok {
+				v.callFolderForm(folder)
+			}
+			return nil
+		case event.Rune() == 'd':
+			// вложенные папки удаляются, их записи переносятся в родительскую папку удаляемой
+			if folder, ok := v.folderByID(folderID); ok {
+				v.callDeleteAsk(func() error {
+					return v.ctrl.Labels.DeleteFolder(context.Background(), v.ctrl.Session.Token(), folder.ID)
+				}, v.switchToFoldersPage)
+			}
+			return nil
+		}
+		return event
+	})
+
+	v.tui.foldersTree.SetSelectedFunc(func(node *tview.TreeNode) {
+		folderID, ok := node.GetReference().(int)
+		if !ok {
+			return
+		}
+
+		v.filter.FolderID = nil
+		if folderID != allFolders {
+			v.filter.FolderID = &folderID
+		}
+		v.switchToUnitsMenu()
+	})
+
+	v.tui.body.AddPage(foldersPage, v.tui.foldersTree, true, false)
+}
+
+// setFoldersTree строит дерево папок и выбирает в нём папку текущего отбора.
+func (v *View) setFoldersTree() {
+	children := make(map[int][]entity.FolderDTO)
+	for _, folder := range v.folders {
+		children[folder.ParentID] = append(children[folder.ParentID], folder)
+	}
+
+	root := tview.NewTreeNode("All records").SetReference(allFolders)
+	root.AddChild(tview.NewTreeNode(noFolderTitle).SetReference(0))
+
+	var addChildren func(parent *tview.TreeNode, parentID int)
+	addChildren = func(parent *tview.TreeNode, parentID int) {
+		folders := children[parentID]
+		sort.Slice(folders, func(i, j int) bool {
+			return folders[i].Name < folders[j].Name
+		})
+
+		for _, folder := range folders {
+			node := tview.NewTreeNode(tview.Escape(folder.Name)).SetReference(folder.ID)
+			parent.AddChild(node)
+			addChildren(node, folder.ID)
+		}
+	}
+	addChildren(root, 0)
+
+	selected := allFolders
+	if v.filter.FolderID != nil {
+		selected = *v.filter.FolderID
+	}
+
+	current := root
+	root.Walk(func(node, parent *tview.TreeNode) bool {
+		if id, ok := node.GetReference().(int); ok && id == selected {
+			current = node
+		}
+		return true
+	})
+
+	v.tui.foldersTree.SetRoot(root).SetCurrentNode(current)
+}
+
+// selectedFolderID id папки выбранного узла дерева (allFolders - корень, 0 - записи вне папок).
+func (v *View) selectedFolderID() int {
+	node := v.tui.foldersTree.GetCurrentNode()
+	if node == nil {
+		return allFolders
+	}
+
+	id, ok := node.GetReference().(int)
+	if !ok {
+		return allFolders
+	}
+
+	return id
+}
+
+// Форма создания (folder.ID == 0) или переименования папки.
+func (v *View) callFolderForm(folder entity.FolderDTO) {
+	v.tui.editForm.Clear(true)
+	v.tui.editForm.AddInputField("name", folder.Name, 40, nil, func(name string) {
+		folder.Name = strings.TrimSpace(name)
+	})
+
+	v.tui.editForm.AddButton("Save", func() {
+		// названия зашифрованы, поэтому повторы среди соседних папок проверяются на клиенте
+		for _, other := range v.folders {
+			if other.ID != folder.ID && other.ParentID == folder.ParentID && other.Name == folder.Name {
+				v.callRequestFail(errDuplicateLabel, v.switchToFoldersPage)
+				return
+			}
+		}
+
+		var err error
+		if folder.ID == 0 {
+			_, err = v.ctrl.Labels.CreateFolder(context.Background(), v.ctrl.Session.Token(), folder)
+		} else {
+			err = v.ctrl.Labels.RenameFolder(context.Background(), v.ctrl.Session.Token(), folder.ID, folder.Name)
+		}
+
+		if err != nil {
+			v.callRequestFail(err, v.switchToFoldersPage)
+			return
+		}
+
+		v.switchToFoldersPage()
+	})
+
+	v.tui.editForm.AddButton("Cancel", func() {
+		v.switchToFoldersPage()
+	})
+
+	if folder.ParentID != 0 && folder.ID == 0 {
+		v.setHeader("Folder in " + v.folderPath(folder.ParentID))
+	} else {
+		v.setHeader("Folder")
+	}
+	v.tui.body.SwitchToPage(editForm)
+}
+
+func (v *View) createTagsPage() {
+	v.tui.tagsList = tview.NewList().ShowSecondaryText(false)
+
+	v.tui.tagsList.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if v.blockOffline(event, "ned", v.switchToTagsPage) {
+			return nil
+		}
+
+		switch {
+		case event.Key() == tcell.KeyEscape:
+			v.switchToUnitsMenu()
+		case event.Rune() == 'n':
+			v.callTagForm(entity.TagDTO{})
+			return nil
+		case event.Rune() == 'e':
+			if tag, ok := v.selectedTag(); ok {
+				v.callTagForm(tag)
+			}
+			return nil
+		case event.Rune() == 'd':
+			// метка снимается со всех записей
+			if tag, ok := v.selectedTag(); ok {
+				v.callDeleteAsk(func() error {
+					return v.ctrl.Labels.DeleteTag(context.Background(), v.ctrl.Session.Token(), tag.ID)
+				}, v.switchToTagsPage)
+			}
+			return nil
+		}
+		return event
+	})
+
+	v.tui.tagsList.SetSelectedFunc(func(index int, name string, secondName string, shortcut rune) {
+		v.filter.TagID = 0
+		if tag, ok := v.selectedTag(); ok {
+			v.filter.TagID = tag.ID
+		}
+		v.switchToUnitsMenu()
+	})
+
+	v.tui.body.AddPage(tagsPage, v.tui.tagsList, true, false)
+}
+
+// setTagsList заполняет список меток и выбирает в нём метку текущего отбора.
+func (v *View) setTagsList() {
+	v.tui.tagsList.Clear()
+	v.tui.tagsList.AddItem(anyTagTitle, "", ' ', nil)
+	for i, tag := range v.tags {
+		v.tui.tagsList.AddItem(tview.Escape(tag.Name), "", ' ', nil)
+		if tag.ID == v.filter.TagID {
+			v.tui.tagsList.SetCurrentItem(i + 1)
+		}
+	}
+}
+
+// selectedTag метка выбранного пункта списка (первый пункт - без отбора по метке).
+func (v *View) selectedTag() (entity.TagDTO, bool) {
+	index := v.tui.tagsList.GetCurrentItem() - 1
+	if index < 0 || index >= len(v.tags) {
+		return entity.TagDTO{}, false
+	}
+
+	return v.tags[index], true
+}
+
+// Форма создания (tag.ID == 0) или переименования метки.
+func (v *View) callTagForm(tag entity.TagDTO) {
+	v.tui.editForm.Clear(true)
+	v.tui.editForm.AddInputField("name", tag.Name, 40, nil, func(name string) {
+		tag.Name = strings.TrimSpace(name)
+	})
+
+	v.tui.editForm.AddButton("Save", func() {
+		// метки записи вводятся через запятую
+		if strings.Contains(tag.Name, ",") {
+			v.callRequestFail(errTagComma, v.switchToTagsPage)
+			return
+		}
+		if other, ok := v.tagByName(tag.Name); ok && other.ID != tag.ID {
+			v.callRequestFail(errDuplicateLabel, v.switchToTagsPage)
+			return
+		}
+
+		var err error
+		if tag.ID == 0 {
+			_, err = v.ctrl.Labels.CreateTag(context.Background(), v.ctrl.Session.Token(), tag)
+		} else {
+			err = v.ctrl.Labels.RenameTag(context.Background(), v.ctrl.Session.Token(), tag.ID, tag.Name)
+		}
+
+		if err != nil {
+			v.callRequestFail(err, v.switchToTagsPage)
+			return
+		}
+
+		v.switchToTagsPage()
+	})
+
+	v.tui.editForm.AddButton("Cancel", func() {
+		v.switchToTagsPage()
+	})
+
+	v.setHeader("Tag")
+	v.tui.body.SwitchToPage(editForm)
+}
+
+// addLabelFields добавляет в форму записи выбор папки и метки (названия через запятую).
+//
+// Возвращает функцию, которая перед сохранением записи переносит введённые метки в labels
+// (неизвестные метки создаются).
+func (v *View) addLabelFields(labels *entity.Labels) func() error {
+	type option struct {
+		path string
+		id   int
+	}
+
+	options := make([]option, 0, len(v.folders))
+	for _, folder := range v.folders {
+		options = append(options, option{v.folderPath(folder.ID), folder.ID})
+	}
+	sort.Slice(options, func(i, j int) bool {
+		return options[i].path < options[j].path
+	})
+	options = append([]option{{noFolderTitle, 0}}, options...)
+
+	titles := make([]string, len(options))
+	current := 0
+	for i, opt := range options {
+		titles[i] = opt.path
+		if opt.id == labels.FolderID {
+			current = i
+		}
+	}
+
+	v.tui.editForm.AddDropDown("folder", titles, current, func(_ string, index int) {
+		if index >= 0 && index < len(options) {
+			labels.FolderID = options[index].id
+		}
+	})
+
+	tags := v.tagNames(labels.TagIDs)
+	v.tui.editForm.AddInputField("tags", tags, 40, nil, func(text string) {
+		tags = text
+	})
+
+	return func() error {
+		ids, err := v.resolveTags(tags)
+		if err != nil {
+			return err
+		}
+
+		labels.TagIDs = ids
+		return nil
+	}
+}
+
+// resolveTags находит метки по названиям через запятую, неизвестные метки создаются.
+func (v *View) resolveTags(text string) (entity.IDs, error) {
+	var ids entity.IDs
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(text, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		tag, ok := v.tagByName(name)
+		if !ok {
+			id, err := v.ctrl.Labels.CreateTag(context.Background(), v.ctrl.Session.Token(), entity.TagDTO{Name: name})
+			if err != nil {
+				return nil, err
+			}
+
+			tag = entity.TagDTO{ID: id, Name: name}
+			v.tags = append(v.tags, tag)
+		}
+		ids = append(ids, tag.ID)
+	}
+
+	return ids, nil
+}
+
+// loadLabels обновляет папки и метки пользователя; отбор по удалённой папке или метке сбрасывается.
+//
+// При недоступности сервера используются папки и метки из локального кеша (вместе с ними возвращается
+// *controller.OfflineError), если их нет - остаются прежние.
+func (v *View) loadLabels() error {
+	folders, err := v.ctrl.Labels.ListFolders(context.Background(), v.ctrl.Session.Token())
+	var offline *controller.OfflineError
+	if err != nil && !errors.As(err, &offline) {
+		return err
+	}
+
+	tags, errTags := v.ctrl.Labels.ListTags(context.Background(), v.ctrl.Session.Token())
+	if errTags != nil && !errors.As(errTags, &offline) {
+		return errTags
+	}
+
+	sort.Slice(tags, func(i, j int) bool {
+		return tags[i].Name < tags[j].Name
+	})
+	v.folders, v.tags = folders, tags
+
+	if id := v.filter.FolderID; id != nil && *id != 0 {
+		if _, ok := v.folderByID(*id); !ok {
+			v.filter.FolderID = nil
+		}
+	}
+	if _, ok := v.tagByID(v.filter.TagID); !ok {
+		v.filter.TagID = 0
+	}
+
+	if err == nil {
+		err = errTags
+	}
+	return err
+}
+
+// resetLabels очищает папки, метки и отбор записей (при выходе пользователя).
+func (v *View) resetLabels() {
+	v.folders, v.tags = nil, nil
+	v.filter = entity.LabelFilter{}
+}
+
+// filterLabels папка и метка текущего отбора - для новых записей (чтобы они попадали в отображаемый список).
+func (v *View) filterLabels() entity.Labels {
+	var labels entity.Labels
+	if v.filter.FolderID != nil {
+		labels.FolderID = *v.filter.FolderID
+	}
+	if v.filter.TagID != 0 {
+		labels.TagIDs = entity.IDs{v.filter.TagID}
+	}
+
+	return labels
+}
+
+// filterTitle описание текущего отбора для заголовка страницы (пустое - без отбора).
+func (v *View) filterTitle() string {
+	var parts []string
+	if v.filter.FolderID != nil {
+		folder := noFolderTitle
+		if *v.filter.FolderID != 0 {
+			folder = v.folderPath(*v.filter.FolderID)
+		}
+		parts = append(parts, "folder: "+folder)
+	}
+	if v.filter.TagID != 0 {
+		parts = append(parts, "tag: "+v.tagNames(entity.IDs{v.filter.TagID}))
+	}
+
+	if len(parts) == 0 {
+		return ""
+	}
+	return " [" + strings.Join(parts, ", ") + "]"
+}
+
+// labelsText описание папки и меток записи для панели информации.
+func (v *View) labelsText(labels entity.Labels) string {
+	var sb strings.Builder
+	if labels.FolderID != 0 {
+		sb.WriteString("folder: ")
+		sb.WriteString(v.folderPath(labels.FolderID))
+		sb.WriteString("\n")
+	}
+	if len(labels.TagIDs) > 0 {
+		sb.WriteString("tags: ")
+		sb.WriteString(v.tagNames(labels.TagIDs))
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+// folderPath путь папки от верхнего уровня (неизвестные папки - по id).
+func (v *View) folderPath(id int) string {
+	var names []string
+	// ограничение глубины защищает от зацикливания на повреждённых данных
+	for depth := 0; id != 0 && depth <= len(v.folders); depth++ {
+		folder, ok := v.folderByID(id)
+		if !ok {
+			names = append(names, "#"+strconv.Itoa(id))
+			break
+		}
+
+		names = append(names, folder.Name)
+		id = folder.ParentID
+	}
+
+	for i, j := 0, len(names)-1; i < j; i, j = i+1, j-1 {
+		names[i], names[j] = names[j], names[i]
+	}
+	return strings.Join(names, "/")
+}
+
+// tagNames названия меток через запятую (неизвестные метки - по id).
+func (v *View) tagNames(ids entity.IDs) string {
+	names := make([]string, len(ids))
+	for i, id := range ids {
+		if tag, ok := v.tagByID(id); ok {
+			names[i] = tag.Name
+		} else {
+			names[i] = "#" + strconv.Itoa(id)
+		}
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func (v *View) folderByID(id int) (entity.FolderDTO, bool) {
+	for _, folder := range v.folders {
+		if folder.ID == id {
+			return folder, true
+		}
+	}
+	return entity.FolderDTO{}, false
+}
+
+func (v *View) tagByID(id int) (entity.TagDTO, bool) {
+	for _, tag := range v.tags {
+		if tag.ID == id {
+			return tag, true
+		}
+	}
+	return entity.TagDTO{}, false
+}
+
+func (v *View) tagByName(name string) (entity.TagDTO, bool) {
+	for _, tag := range v.tags {
+		if tag.Name == name {
+			return tag, true
+		}
+	}
+	return entity.TagDTO{}, false
+}
+
+// Дерево папок: выбор папки задаёт отбор записей списков.
+func (v *View) switchToFoldersPage() {
+	if err := v.checkOffline(v.loadLabels()); err != nil {
+		v.callRequestFail(err, v.switchToUnitsMenu)
+		return
+	}
+
+	v.setFoldersTree()
+	v.setListHeader(foldersHeader + v.filterTitle())
+	v.tui.body.SwitchToPage(foldersPage)
+}
+
+// Список меток: выбор метки задаёт отбор записей списков.
+func (v *View) switchToTagsPage() {
+	if err := v.checkOffline(v.loadLabels()); err != nil {
+		v.callRequestFail(err, v.switchToUnitsMenu)
+		return
+	}
+
+	v.setTagsList()
+	v.setListHeader(tagsHeader + v.filterTitle())
+	v.tui.body.SwitchToPage(tagsPage)
+}
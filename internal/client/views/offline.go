@@ -0,0 +1,51 @@
+package views
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+
+	"github.com/PaulYakow/gophkeeper/internal/client/controller"
+)
+
+var errReadOnly = errors.New("server unavailable: cached data is read-only")
+
+// checkOffline запоминает, отображаются ли данные из локального кеша (сервер недоступен).
+//
+// Для *controller.OfflineError возвращает nil - данные из кеша можно показывать.
+func (v *View) checkOffline(err error) error {
+	var offline *controller.OfflineError
+	switch {
+	case errors.As(err, &offline):
+		v.cachedAt = offline.SavedAt
+		return nil
+	case err == nil:
+		v.cachedAt = time.Time{}
+	}
+
+	return err
+}
+
+// setListHeader устанавливает заголовок страницы списка с отметкой о работе с локальной копией данных.
+func (v *View) setListHeader(text string) {
+	if v.cachedAt.IsZero() {
+		v.setHeader(text)
+		return
+	}
+
+	age := time.Since(v.cachedAt).Round(time.Second)
+	v.setHeader(text + "\nOFFLINE: cached data from " + v.cachedAt.Format("2006-01-02 15:04:05") +
+		" (" + age.String() + " ago), read-only")
+}
+
+// blockOffline запрещает клавиши изменения данных (keys), пока отображается локальная копия.
+func (v *View) blockOffline(event *tcell.EventKey, keys string, back func()) bool {
+	if v.cachedAt.IsZero() || !strings.ContainsRune(keys, event.Rune()) {
+		return false
+	}
+
+	v.callRequestFail(errReadOnly, back)
+	return true
+}
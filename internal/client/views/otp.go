@@ -0,0 +1,288 @@
+package views
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	"github.com/PaulYakow/gophkeeper/internal/utils/otp"
+)
+
+const (
+	otpsPage   = "otps"
+	otpsHeader = "OTP (n - new, e - edit, d - delete, c - next HOTP code, o - order, ESC - exit)"
+)
+
+var (
+	otpKinds      = []string{otp.KindTOTP, otp.KindHOTP}
+	otpAlgorithms = []string{otp.AlgorithmSHA1, otp.AlgorithmSHA256, otp.AlgorithmSHA512}
+)
+
+func (v *View) createOTPsPage() {
+	v.tui.otpsList = tview.NewList().ShowSecondaryText(false)
+	v.tui.otpInfo = tview.NewTextView()
+
+	v.tui.otpsPage = tview.NewFlex().
+		AddItem(v.tui.otpsList, 0, 1, true).
+		AddItem(v.tui.otpInfo, 0, 3, false)
+
+	v.tui.otpsPage.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if v.blockOffline(event, "nedc", v.switchToOTPsPage) {
+			return nil
+		}
+
+		switch {
+		case event.Key() == tcell.KeyEscape:
+			v.switchToUnitsMenu()
+		case event.Rune() == 'n':
+			v.callOTPForm(entity.OTPDTO{Labels: v.filterLabels()})
+			return nil
+		case event.Rune() == 'e':
+			if item, ok := v.selectedOTP(); ok {
+				v.callOTPForm(item)
+			}
+			return nil
+		case event.Rune() == 'c':
+			if item, ok := v.selectedOTP(); ok && item.Kind == otp.KindHOTP {
+				item.Counter++
+				if err := v.ctrl.OTPs.UpdateOTP(context.Background(), v.ctrl.Session.Token(), item); err != nil {
+					v.callRequestFail(err, v.switchToOTPsPage)
+					return nil
+				}
+				v.switchToOTPsPage()
+			}
+			return nil
+		case event.Rune() == 'o':
+			v.otpsPaging.toggleOrder()
+			v.switchToOTPsPage()
+			return nil
+		case event.Rune() == 'd':
+			if item, ok := v.selectedOTP(); ok {
+				v.callDeleteAsk(func() error {
+					return v.ctrl.OTPs.DeleteOTP(context.Background(), v.ctrl.Session.Token(), item.ID)
+				}, v.switchToOTPsPage)
+			}
+			return nil
+		}
+		return event
+	})
+
+	v.tui.otpsList.SetChangedFunc(func(index int, name string, secondName string, shortcut rune) {
+		v.refreshOTPInfo()
+		v.moreOTPs(index)
+	})
+
+	v.tui.body.AddPage(otpsPage, v.tui.otpsPage, true, false)
+}
+
+func (v *View) getOTPsList() error {
+	otps, err := firstPage(v, &v.otpsPaging, v.listOTPs, v.ctrl.Sync.OTPs, func(item entity.OTPDTO) entity.Labels {
+		return item.Labels
+	})
+	if err != nil {
+		return err
+	}
+
+	v.otps = nil
+	v.tui.otpsList.Clear()
+	v.appendOTPs(otps)
+
+	v.refreshOTPInfo()
+	return nil
+}
+
+// listOTPs запрос страницы списка одноразовых паролей.
+func (v *View) listOTPs(page entity.PageRequest) ([]entity.OTPDTO, string, error) {
+	return v.ctrl.OTPs.ListOTPs(context.Background(), v.ctrl.Session.Token(), page)
+}
+
+// appendOTPs добавляет записи в конец списка.
+func (v *View) appendOTPs(otps []entity.OTPDTO) {
+	v.otps = append(v.otps, otps...)
+	for _, item := range otps {
+		v.tui.otpsList.AddItem(strconv.Itoa(item.ID)+" "+item.Issuer, "", ' ', nil)
+	}
+}
+
+// moreOTPs загружает следующую страницу, когда выбран последний элемент списка.
+func (v *View) moreOTPs(index int) {
+	if index < len(v.otps)-1 {
+		return
+	}
+
+	otps, err := nextPage(&v.otpsPaging, v.listOTPs)
+	if err != nil {
+		v.callRequestFail(err, v.switchToOTPsPage)
+		return
+	}
+
+	v.appendOTPs(otps)
+}
+
+// refreshOTPInfo обновляет текущий код и обратный отсчёт для выбранного одноразового пароля.
+func (v *View) refreshOTPInfo() {
+	item, ok := v.selectedOTP()
+	if !ok {
+		v.tui.otpInfo.Clear()
+		return
+	}
+
+	v.setOTPInfo(item, time.Now())
+}
+
+func (v *View) setOTPInfo(item entity.OTPDTO, now time.Time) {
+	var sb strings.Builder
+
+	sb.WriteString(item.Issuer)
+	sb.WriteString("\n")
+
+	switch item.Kind {
+	case otp.KindHOTP:
+		code, err := otp.HOTP(item.Secret, uint64(item.Counter), item.Algorithm, item.Digits)
+		if err != nil {
+			sb.WriteString(err.Error())
+			break
+		}
+		sb.WriteString(code)
+		sb.WriteString("\ncounter: ")
+		sb.WriteString(strconv.FormatInt(item.Counter, 10))
+	default:
+		code, err := otp.TOTP(item.Secret, now, item.Period, item.Algorithm, item.Digits)
+		if err != nil {
+			sb.WriteString(err.Error())
+			break
+		}
+		sb.WriteString(code)
+		sb.WriteString("\nexpires in: ")
+		sb.WriteString(strconv.Itoa(int(otp.Remaining(now, item.Period).Round(time.Second).Seconds())))
+		sb.WriteString("s")
+	}
+
+	sb.WriteString("\n")
+	sb.WriteString(item.Metadata)
+	sb.WriteString("\n")
+	sb.WriteString(v.labelsText(item.Labels))
+
+	v.tui.otpInfo.SetText(sb.String())
+}
+
+func (v *View) selectedOTP() (entity.OTPDTO, bool) {
+	index := v.tui.otpsList.GetCurrentItem()
+	if index < 0 || index >= len(v.otps) {
+		return entity.OTPDTO{}, false
+	}
+
+	return v.otps[index], true
+}
+
+// runOTPTicker раз в секунду перерисовывает код TOTP, пока открыта страница одноразовых паролей.
+func (v *View) runOTPTicker() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		v.tui.QueueUpdateDraw(func() {
+			if name, _ := v.tui.body.GetFrontPage(); name == otpsPage {
+				v.refreshOTPInfo()
+			}
+		})
+	}
+}
+
+// Форма создания (item.ID == 0) или изменения одноразового пароля.
+func (v *View) callOTPForm(item entity.OTPDTO) {
+	if item.ID == 0 {
+		item.Kind = otp.KindTOTP
+		item.Algorithm = otp.AlgorithmSHA1
+		item.Digits = otp.DefaultDigits
+		item.Period = otp.DefaultPeriod
+	}
+
+	v.tui.editForm.Clear(true)
+	v.tui.editForm.AddInputField("issuer", item.Issuer, 40, nil, func(issuer string) {
+		item.Issuer = issuer
+	})
+
+	v.tui.editForm.AddInputField("secret (base32)", item.Secret, 40, nil, func(secret string) {
+		item.Secret = secret
+	})
+
+	v.tui.editForm.AddDropDown("type", otpKinds, indexOf(otpKinds, item.Kind), func(kind string, _ int) {
+		item.Kind = kind
+	})
+
+	v.tui.editForm.AddDropDown("algorithm", otpAlgorithms, indexOf(otpAlgorithms, item.Algorithm), func(algorithm string, _ int) {
+		item.Algorithm = algorithm
+	})
+
+	v.tui.editForm.AddInputField("digits", strconv.Itoa(item.Digits), 4, tview.InputFieldInteger, func(text string) {
+		item.Digits, _ = strconv.Atoi(text)
+	})
+
+	v.tui.editForm.AddInputField("period (TOTP)", strconv.Itoa(item.Period), 6, tview.InputFieldInteger, func(text string) {
+		item.Period, _ = strconv.Atoi(text)
+	})
+
+	v.tui.editForm.AddInputField("counter (HOTP)", strconv.FormatInt(item.Counter, 10), 12, tview.InputFieldInteger, func(text string) {
+		item.Counter, _ = strconv.ParseInt(text, 10, 64)
+	})
+
+	v.tui.editForm.AddTextArea("metadata", item.Metadata, 40, 4, 0, func(metadata string) {
+		item.Metadata = metadata
+	})
+
+	resolveTags := v.addLabelFields(&item.Labels)
+
+	v.tui.editForm.AddButton("Save", func() {
+		if err := resolveTags(); err != nil {
+			v.callRequestFail(err, v.switchToOTPsPage)
+			return
+		}
+
+		var err error
+		if item.ID == 0 {
+			_, err = v.ctrl.OTPs.CreateOTP(context.Background(), v.ctrl.Session.Token(), item)
+		} else {
+			err = v.ctrl.OTPs.UpdateOTP(context.Background(), v.ctrl.Session.Token(), item)
+		}
+
+		if err != nil {
+			v.callRequestFail(err, v.switchToOTPsPage)
+			return
+		}
+
+		v.switchToOTPsPage()
+	})
+
+	v.tui.editForm.AddButton("Cancel", func() {
+		v.switchToOTPsPage()
+	})
+
+	v.setHeader("OTP")
+	v.tui.body.SwitchToPage(editForm)
+}
+
+func (v *View) switchToOTPsPage() {
+	if err := v.getOTPsList(); err != nil {
+		v.callRequestFail(err, v.switchToUnitsMenu)
+		return
+	}
+
+	v.setListHeader(otpsHeader + " [" + v.otpsPaging.orderTitle() + "]" + v.filterTitle())
+	v.tui.body.SwitchToPage(otpsPage)
+}
+
+func indexOf(values []string, value string) int {
+	for i, item := range values {
+		if item == value {
+			return i
+		}
+	}
+
+	return 0
+}
@@ -0,0 +1,116 @@
+package views
+
+import (
+	"context"
+
+	"github.com/PaulYakow/gophkeeper/internal/client/controller"
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+)
+
+// listPageSize количество записей, запрашиваемых у сервера за один раз.
+const listPageSize = 50
+
+// listPaging состояние постраничной загрузки списка.
+type listPaging struct {
+	// next токен следующей страницы (пустой - список загружен полностью)
+	next string
+	// order порядок записей (переключается клавишей 'o')
+	order entity.SortOrder
+	// loading - идёт загрузка следующей страницы (повторно не запрашивается)
+	loading bool
+	// filter отбор записей по папке и метке (задаётся при загрузке первой страницы)
+	filter entity.LabelFilter
+}
+
+// toggleOrder меняет порядок записей списка (список нужно загрузить заново).
+func (p *listPaging) toggleOrder() {
+	if p.order == entity.SortCreatedAsc {
+		p.order = entity.SortCreatedDesc
+	} else {
+		p.order = entity.SortCreatedAsc
+	}
+}
+
+// orderTitle описание порядка записей для заголовка страницы.
+func (p *listPaging) orderTitle() string {
+	if p.order == entity.SortCreatedDesc {
+		return "newest first"
+	}
+	return "oldest first"
+}
+
+// firstPage загружает первую страницу списка от сервера (list - запрос страницы) с текущим отбором
+// по папке и метке.
+//
+// После загрузки в фоне обновляется локальная копия данных. Если сервер недоступен, показывается
+// локальная копия (local) целиком в порядке списка, отобранная по папке и метке записей (labels).
+// Вместе со списком обновляются папки и метки пользователя.
+func firstPage[T any](v *View,
+	paging *listPaging,
+	list func(page entity.PageRequest) ([]T, string, error),
+	local func() []T,
+	labels func(T) entity.Labels,
+) ([]T, error) {
+	// ошибки загрузки папок и меток не мешают показу списка (остаются прежние)
+	_ = v.loadLabels()
+	paging.next, paging.loading, paging.filter = "", false, v.filter
+
+	items, next, err := list(entity.PageRequest{Size: listPageSize, Order: paging.order, Filter: paging.filter})
+	if controller.IsUnavailable(err) {
+		err = v.ctrl.Sync.Sync(context.Background(), v.ctrl.Session.Token())
+		if err = v.checkOffline(err); err != nil {
+			return nil, err
+		}
+
+		items = nil
+		for _, item := range local() {
+			if paging.filter.Match(labels(item)) {
+				items = append(items, item)
+			}
+		}
+		if paging.order == entity.SortCreatedDesc {
+			for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+				items[i], items[j] = items[j], items[i]
+			}
+		}
+		return items, nil
+	}
+	if err = v.checkOffline(err); err != nil {
+		return nil, err
+	}
+
+	paging.next = next
+	go v.refreshLocalCopy()
+
+	return items, nil
+}
+
+// nextPage загружает следующую страницу списка, если она есть и ещё не загружается.
+//
+// Вызывается при выборе последнего элемента списка.
+func nextPage[T any](paging *listPaging, list func(page entity.PageRequest) ([]T, string, error)) ([]T, error) {
+	if paging.next == "" || paging.loading {
+		return nil, nil
+	}
+
+	paging.loading = true
+	defer func() { paging.loading = false }()
+
+	items, next, err := list(entity.PageRequest{
+		Size:   listPageSize,
+		Token:  paging.next,
+		Order:  paging.order,
+		Filter: paging.filter,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	paging.next = next
+	return items, nil
+}
+
+// refreshLocalCopy обновляет локальную копию данных для работы без сервера (ошибки не показываются).
+func (v *View) refreshLocalCopy() {
+	_ = v.ctrl.Sync.Sync(context.Background(), v.ctrl.Session.Token())
+}
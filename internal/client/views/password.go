@@ -0,0 +1,70 @@
+package views
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rivo/tview"
+)
+
+const passwordForm = "password"
+
+var errPasswordConfirm = errors.New("new password and confirmation do not match")
+
+func (v *View) createPasswordForm() {
+	v.tui.passwordForm = tview.NewForm()
+	v.tui.body.AddPage(passwordForm, v.tui.passwordForm, true, false)
+}
+
+// Форма смены пароля пользователя login.
+//
+// Если текущий пароль (current) уже известен (вход отклонён из-за истёкшего пароля), он не запрашивается.
+// После успешной смены вызывается done, при отмене - back.
+func (v *View) callPasswordForm(login, current string, done, back func()) {
+	var newPassword, confirm, code string
+
+	v.tui.passwordForm.Clear(true)
+
+	if current == "" {
+		v.tui.passwordForm.AddPasswordField("current password", "", 20, '*', func(password string) {
+			current = password
+		})
+	}
+
+	v.tui.passwordForm.AddPasswordField("new password", "", 20, '*', func(password string) {
+		newPassword = password
+	})
+
+	v.tui.passwordForm.AddPasswordField("confirm", "", 20, '*', func(password string) {
+		confirm = password
+	})
+
+	// код аутентификатора требуется, только если подключён второй фактор
+	v.tui.passwordForm.AddInputField("one-time code (if enabled)", "", 10, tview.InputFieldInteger, func(text string) {
+		code = text
+	})
+
+	show := func() {
+		v.tui.body.SwitchToPage(passwordForm)
+	}
+
+	v.tui.passwordForm.AddButton("OK", func() {
+		if newPassword != confirm {
+			v.callRequestFail(errPasswordConfirm, show)
+			return
+		}
+
+		if err := v.ctrl.Auth.ChangePassword(context.Background(), login, current, newPassword, code); err != nil {
+			v.callRequestFail(err, show)
+			return
+		}
+
+		done()
+	})
+
+	v.tui.passwordForm.AddButton("Cancel", func() {
+		back()
+	})
+
+	show()
+}
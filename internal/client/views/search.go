@@ -0,0 +1,190 @@
+package views
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+
+	"github.com/PaulYakow/gophkeeper/internal/client/controller"
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+)
+
+const (
+	searchPage   = "search"
+	searchHeader = "Search (Enter - find / open, Tab - results, ESC - exit)"
+	// searchLimit максимальное количество отображаемых результатов поиска.
+	searchLimit = 50
+)
+
+func (v *View) createSearchPage() {
+	v.tui.searchInput = tview.NewInputField().SetLabel("find: ")
+	v.tui.searchList = tview.NewList()
+
+	v.tui.searchPage = tview.NewFlex().SetDirection(tview.FlexRow).
+		AddItem(v.tui.searchInput, 1, 0, true).
+		AddItem(v.tui.searchList, 0, 1, false)
+
+	v.tui.searchInput.SetDoneFunc(func(key tcell.Key) {
+		switch key {
+		case tcell.KeyEnter:
+			v.findSearchHits(v.tui.searchInput.GetText())
+		case tcell.KeyTab:
+			v.tui.SetFocus(v.tui.searchList)
+		case tcell.KeyEscape:
+			v.switchToUnitsMenu()
+		}
+	})
+
+	v.tui.searchList.SetSelectedFunc(func(index int, name string, secondName string, shortcut rune) {
+		if index >= 0 && index < len(v.searchHits) {
+			v.openSearchHit(v.searchHits[index])
+		}
+	})
+
+	v.tui.searchList.SetDoneFunc(func() {
+		v.tui.SetFocus(v.tui.searchInput)
+	})
+
+	v.tui.body.AddPage(searchPage, v.tui.searchPage, true, false)
+}
+
+// findSearchHits выполняет поиск и показывает найденные записи (тип, id и фрагмент текста).
+//
+// Перед поиском обновляется локальная копия данных: поиск выполняется только по ней (данные зашифрованы).
+func (v *View) findSearchHits(query string) {
+	err := v.ctrl.Sync.Sync(context.Background(), v.ctrl.Session.Token())
+	if err = v.checkOffline(err); err != nil {
+		v.callRequestFail(err, v.switchToSearchPage)
+		return
+	}
+
+	hits, err := v.ctrl.Search.Search(query, searchLimit)
+	if err != nil {
+		v.callRequestFail(err, v.switchToSearchPage)
+		return
+	}
+
+	v.searchHits = hits
+	v.tui.searchList.Clear()
+	for _, hit := range hits {
+		v.tui.searchList.AddItem(hit.Kind+" "+strconv.Itoa(hit.ID), tview.Escape(hit.Snippet), ' ', nil)
+	}
+
+	header := searchHeader
+	if len(hits) == 0 {
+		header += "\nNothing found"
+	}
+	v.setListHeader(header)
+
+	if len(hits) > 0 {
+		v.tui.SetFocus(v.tui.searchList)
+	}
+}
+
+// openSearchHit открывает страницу типа найденной записи и выбирает запись в списке.
+//
+// Отбор по папке и метке сбрасывается: иначе найденная запись может не попасть в список.
+func (v *View) openSearchHit(hit entity.SearchHitDTO) {
+	v.filter = entity.LabelFilter{}
+
+	switch hit.Kind {
+	case entity.PairKind:
+		v.switchToPairsPage()
+		if !v.isFrontPage(pairsPage) {
+			return
+		}
+		if i := locate(&v.pairs, hit.ID, func(pair entity.PairDTO) int { return pair.ID }, &v.pairsPaging, v.morePairs); i >= 0 {
+			v.tui.pairsList.SetCurrentItem(i)
+			v.setPairInfo(v.pairs[i])
+			return
+		}
+	case entity.CardKind:
+		v.switchToCardsPage()
+		if !v.isFrontPage(cardsPage) {
+			return
+		}
+		if i := locate(&v.cards, hit.ID, func(card entity.BankDTO) int { return card.ID }, &v.cardsPaging, v.moreCards); i >= 0 {
+			v.tui.cardsList.SetCurrentItem(i)
+			v.setCardInfo(v.cards[i])
+			return
+		}
+	case entity.NoteKind:
+		v.switchToNotesPage()
+		if !v.isFrontPage(notesPage) {
+			return
+		}
+		if i := locate(&v.notes, hit.ID, func(note entity.TextDTO) int { return note.ID }, &v.notesPaging, v.moreNotes); i >= 0 {
+			v.tui.notesList.SetCurrentItem(i)
+			v.setNoteInfo(v.notes[i])
+			return
+		}
+	case entity.BinaryKind:
+		v.switchToBinariesPage()
+		if !v.isFrontPage(binariesPage) {
+			return
+		}
+		if i := locate(&v.binaries, hit.ID, func(binary entity.BinaryDTO) int { return binary.ID }, &v.binariesPaging, v.moreBinaries); i >= 0 {
+			v.tui.binariesList.SetCurrentItem(i)
+			v.setBinaryInfo(v.binaries[i])
+			return
+		}
+	case entity.OTPKind:
+		v.switchToOTPsPage()
+		if !v.isFrontPage(otpsPage) {
+			return
+		}
+		if i := locate(&v.otps, hit.ID, func(item entity.OTPDTO) int { return item.ID }, &v.otpsPaging, v.moreOTPs); i >= 0 {
+			v.tui.otpsList.SetCurrentItem(i)
+			v.refreshOTPInfo()
+			return
+		}
+	}
+
+	// запись удалена после поиска (или неизвестного типа)
+	v.callRequestFail(controller.ErrNotFound, v.switchToSearchPage)
+}
+
+// isFrontPage проверяет, что открыта страница page (а не сообщение об ошибке её загрузки).
+func (v *View) isFrontPage(page string) bool {
+	name, _ := v.tui.body.GetFrontPage()
+	return name == page
+}
+
+// locate ищет запись id в списке items, догружая следующие страницы (more) до её появления.
+//
+// Возвращает индекс записи в списке или -1, если список загружен полностью, а запись не найдена.
+func locate[T any](items *[]T, id int, itemID func(T) int, paging *listPaging, more func(index int)) int {
+	for from := 0; ; {
+		for i := from; i < len(*items); i++ {
+			if itemID((*items)[i]) == id {
+				return i
+			}
+		}
+
+		if paging.next == "" {
+			return -1
+		}
+
+		from = len(*items)
+		more(from - 1)
+		if len(*items) == from {
+			return -1
+		}
+	}
+}
+
+// resetSearch очищает запрос и результаты поиска (при выходе пользователя).
+func (v *View) resetSearch() {
+	v.searchHits = nil
+	v.tui.searchInput.SetText("")
+	v.tui.searchList.Clear()
+}
+
+// Поиск по заметкам и метаинформации (результаты предыдущего поиска сохраняются).
+func (v *View) switchToSearchPage() {
+	v.setListHeader(searchHeader)
+	v.tui.body.SwitchToPage(searchPage)
+	v.tui.SetFocus(v.tui.searchInput)
+}
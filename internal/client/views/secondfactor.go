@@ -0,0 +1,175 @@
+package views
+
+import (
+	"context"
+	"errors"
+
+	"github.com/rivo/tview"
+
+	"github.com/PaulYakow/gophkeeper/internal/client/controller"
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+)
+
+const (
+	codeForm = "code"
+	// totpIssuer название сервиса в приложении-аутентификаторе.
+	totpIssuer = "GophKeeper"
+)
+
+func (v *View) createCodeForm() {
+	v.tui.codeForm = tview.NewForm()
+	v.tui.body.AddPage(codeForm, v.tui.codeForm, true, false)
+}
+
+// Форма завершения входа кодом аутентификатора: после успешной проверки вызывается done, при отмене - back.
+func (v *View) callCodeForm(done, back func()) {
+	var code string
+
+	v.tui.codeForm.Clear(true)
+
+	v.tui.codeForm.AddInputField("one-time code", "", 10, tview.InputFieldInteger, func(text string) {
+		code = text
+	})
+
+	show := func() {
+		v.setHeader("Two-factor authentication: enter code from authenticator app")
+		v.tui.body.SwitchToPage(codeForm)
+	}
+
+	v.tui.codeForm.AddButton("OK", func() {
+		err := v.ctrl.Auth.VerifySecondFactor(context.Background(), code)
+		// вход заблокирован или незавершённый вход истёк - код повторно не вводится
+		if controller.IsLocked(err) || errors.Is(err, controller.ErrChallengeExpired) {
+			v.callRequestFail(err, back)
+			return
+		}
+		if err != nil {
+			v.callRequestFail(err, show)
+			return
+		}
+
+		done()
+	})
+
+	v.tui.codeForm.AddButton("Cancel", func() {
+		back()
+	})
+
+	show()
+}
+
+// Форма управления вторым фактором: подключение (замена) и отключение аутентификатора.
+func (v *View) callTwoFactorForm() {
+	v.tui.editForm.Clear(true)
+
+	v.tui.editForm.AddButton("Enroll", func() {
+		v.callEnrollCredentialsForm()
+	})
+
+	v.tui.editForm.AddButton("Disable", func() {
+		v.callDisableTOTPForm()
+	})
+
+	v.tui.editForm.AddButton("Back", func() {
+		v.switchToUnitsMenu()
+	})
+
+	v.setHeader("Two-factor authentication (TOTP)")
+	v.tui.body.SwitchToPage(editForm)
+}
+
+// Подтверждение подключения (замены) аутентификатора текущим паролем и кодом действующего аутентификатора.
+func (v *View) callEnrollCredentialsForm() {
+	var password, code string
+
+	v.tui.editForm.Clear(true)
+
+	v.tui.editForm.AddPasswordField("password", "", 20, '*', func(text string) {
+		password = text
+	})
+
+	// код действующего аутентификатора требуется, только если второй фактор уже подключён
+	v.tui.editForm.AddInputField("current one-time code (if enabled)", "", 10, tview.InputFieldInteger, func(text string) {
+		code = text
+	})
+
+	v.tui.editForm.AddButton("Next", func() {
+		enrollment, err := v.ctrl.Auth.EnrollTOTP(context.Background(), v.ctrl.Session.Token(), password, code)
+		if err != nil {
+			v.callRequestFail(err, v.switchToUnitsMenu)
+			return
+		}
+
+		v.callEnrollForm(enrollment)
+	})
+
+	v.tui.editForm.AddButton("Cancel", func() {
+		v.switchToUnitsMenu()
+	})
+
+	v.setHeader("Enroll authenticator: confirm with password")
+	v.tui.body.SwitchToPage(editForm)
+}
+
+// Подключение аутентификатора: секрет добавляется в приложение-аутентификатор и подтверждается кодом.
+func (v *View) callEnrollForm(enrollment entity.TOTPEnrollmentDTO) {
+	var code string
+	uri := controller.TOTPURI(totpIssuer, v.ctrl.Keys.Login(), enrollment)
+
+	v.tui.editForm.Clear(true)
+
+	v.tui.editForm.AddTextView("secret", enrollment.Secret, 0, 1, false, false)
+	v.tui.editForm.AddTextView("uri", uri, 0, 3, false, true)
+	v.tui.editForm.AddInputField("one-time code", "", 10, tview.InputFieldInteger, func(text string) {
+		code = text
+	})
+
+	show := func() {
+		v.tui.body.SwitchToPage(editForm)
+	}
+
+	v.tui.editForm.AddButton("Confirm", func() {
+		if err := v.ctrl.Auth.ConfirmTOTP(context.Background(), v.ctrl.Session.Token(), code); err != nil {
+			v.callRequestFail(err, show)
+			return
+		}
+
+		v.switchToUnitsMenu()
+		v.setHeader("Resources\ntwo-factor authentication enabled")
+	})
+
+	v.tui.editForm.AddButton("Cancel", func() {
+		v.switchToUnitsMenu()
+	})
+
+	v.setHeader("Add the secret to authenticator app and enter its code")
+	show()
+}
+
+// Отключение второго фактора кодом действующего аутентификатора.
+func (v *View) callDisableTOTPForm() {
+	var code string
+
+	v.tui.editForm.Clear(true)
+
+	v.tui.editForm.AddInputField("one-time code", "", 10, tview.InputFieldInteger, func(text string) {
+		code = text
+	})
+
+	v.tui.editForm.AddButton("Disable", func() {
+		if err := v.ctrl.Auth.DisableTOTP(context.Background(), v.ctrl.Session.Token(), code); err != nil {
+			v.callRequestFail(err, v.switchToUnitsMenu)
+			return
+		}
+
+		v.switchToUnitsMenu()
+		v.setHeader("Resources\ntwo-factor authentication disabled")
+	})
+
+	v.tui.editForm.AddButton("Cancel", func() {
+		v.switchToUnitsMenu()
+	})
+
+	v.setHeader("Disable two-factor authentication")
+	v.tui.body.SwitchToPage(editForm)
+}
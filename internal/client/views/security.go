@@ -0,0 +1,44 @@
+package views
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+const securityPage = "security"
+
+func (v *View) createSecurityPage() {
+	v.tui.securityPage = tview.NewTextView()
+	v.tui.securityPage.SetBorder(true)
+	v.tui.securityPage.SetDoneFunc(func(key tcell.Key) {
+		v.switchToUnitsMenu()
+	})
+
+	v.tui.body.AddPage(securityPage, v.tui.securityPage, true, false)
+}
+
+// Просмотр событий безопасности учётной записи (блокировок входа после неудачных попыток).
+func (v *View) switchToSecurityPage() {
+	events, err := v.ctrl.Auth.SecurityEvents(context.Background(), v.ctrl.Session.Token())
+	if err != nil {
+		v.callRequestFail(err, v.switchToUnitsMenu)
+		return
+	}
+
+	var text strings.Builder
+	if len(events) == 0 {
+		text.WriteString("No login lockouts")
+	}
+	for _, event := range events {
+		fmt.Fprintf(&text, "%s  login locked until %s after failed attempts from %s\n",
+			event.CreatedAt.Format("2006-01-02 15:04:05"), event.LockedUntil.Format("2006-01-02 15:04:05"), event.Peer)
+	}
+
+	v.tui.securityPage.SetText(text.String())
+	v.setHeader("Security events (ESC - exit)")
+	v.tui.body.SwitchToPage(securityPage)
+}
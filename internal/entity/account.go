@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+// AccountDTO - архив всех данных пользователя (экспорт учётной записи)
+type AccountDTO struct {
+	Login      string      `json:"login"`
+	ExportedAt time.Time   `json:"exported_at"`
+	Pairs      []PairDTO   `json:"pairs"`
+	Cards      []BankDTO   `json:"cards"`
+	Notes      []TextDTO   `json:"notes"`
+	Binaries   []BinaryDTO `json:"binaries"`
+	OTPs       []OTPDTO    `json:"otps"`
+	Folders    []FolderDTO `json:"folders"`
+	Tags       []TagDTO    `json:"tags"`
+}
+
+// AccountDAO - все данные пользователя из БД (вместе с содержимым файлов)
+type AccountDAO struct {
+	User     UserDAO
+	Pairs    []PairDAO
+	Cards    []BankDAO
+	Notes    []TextDAO
+	Binaries []BinaryDAO
+	OTPs     []OTPDAO
+	Folders  []FolderDAO
+	Tags     []TagDAO
+}
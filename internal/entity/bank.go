@@ -9,6 +9,7 @@ type BankDTO struct {
 	Number         string
 	ExpirationDate string
 	Metadata       string
+	Labels
 }
 
 // BankDAO - объект типа банковской карты для БД
@@ -19,5 +20,7 @@ type BankDAO struct {
 	Number         string    `db:"number"`
 	ExpirationDate string    `db:"expiration_date"`
 	Metadata       string    `db:"metadata,omitempty"`
+	Revision       int64     `db:"revision"`
 	CreatedAt      time.Time `db:"created_at,omitempty"`
+	Labels
 }
@@ -0,0 +1,27 @@
+package entity
+
+import "time"
+
+// BinaryDTO - объект бинарных данных (файл) для API
+type BinaryDTO struct {
+	ID       int
+	Filename string
+	// Size размер файла до шифрования на клиенте.
+	Size     int64
+	Data     []byte
+	Metadata string
+	Labels
+}
+
+// BinaryDAO - объект бинарных данных (файл) для БД
+type BinaryDAO struct {
+	ID        int       `db:"id"`
+	UserID    int       `db:"user_id"`
+	Filename  string    `db:"filename"`
+	Size      int64     `db:"size"`
+	Data      []byte    `db:"data"`
+	Metadata  string    `db:"metadata,omitempty"`
+	Revision  int64     `db:"revision"`
+	CreatedAt time.Time `db:"created_at,omitempty"`
+	Labels
+}
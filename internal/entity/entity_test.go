@@ -93,3 +93,50 @@ func TestPairDAO(t *testing.T) {
 	assert.Equal(t, pairDAO.Password, testPair.pass)
 	assert.Equal(t, pairDAO.Metadata, testPair.meta)
 }
+
+func TestIDs(t *testing.T) {
+	value, err := entity.IDs{1, 20, 3}.Value()
+	require.NoError(t, err)
+	assert.Equal(t, "{1,20,3}", value)
+
+	value, err = entity.IDs(nil).Value()
+	require.NoError(t, err)
+	assert.Equal(t, "{}", value)
+
+	var ids entity.IDs
+	require.NoError(t, ids.Scan("{4,5}"))
+	assert.Equal(t, entity.IDs{4, 5}, ids)
+
+	require.NoError(t, ids.Scan([]byte("{}")))
+	assert.Empty(t, ids)
+
+	require.NoError(t, ids.Scan(nil))
+	assert.Empty(t, ids)
+
+	require.Error(t, ids.Scan("{a}"))
+	require.Error(t, ids.Scan(42))
+}
+
+func TestLabelFilter(t *testing.T) {
+	noFolder, work := 0, 7
+	labels := entity.Labels{FolderID: work, TagIDs: entity.IDs{1, 2}}
+
+	tests := []struct {
+		name   string
+		filter entity.LabelFilter
+		ok     bool
+	}{
+		{name: "no filter", filter: entity.LabelFilter{}, ok: true},
+		{name: "folder", filter: entity.LabelFilter{FolderID: &work}, ok: true},
+		{name: "no folder", filter: entity.LabelFilter{FolderID: &noFolder}, ok: false},
+		{name: "tag", filter: entity.LabelFilter{TagID: 2}, ok: true},
+		{name: "missing tag", filter: entity.LabelFilter{TagID: 3}, ok: false},
+		{name: "folder and tag", filter: entity.LabelFilter{FolderID: &work, TagID: 1}, ok: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.ok, tt.filter.Match(labels))
+		})
+	}
+}
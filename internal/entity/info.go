@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// ServerInfoDTO - информация о сервере для API
+type ServerInfoDTO struct {
+	// Version версия сервера.
+	Version string
+	// BuildTime время сборки сервера (нулевое - неизвестно).
+	BuildTime time.Time
+	// Features поддерживаемые сервером возможности.
+	Features []string
+}
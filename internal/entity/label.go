@@ -0,0 +1,123 @@
+package entity
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Labels - папка и метки записи (общие для всех типов данных)
+type Labels struct {
+	// FolderID папка записи (0 - запись вне папок).
+	FolderID int `db:"folder_id"`
+	// TagIDs метки записи (по возрастанию id, без повторов).
+	TagIDs IDs `db:"tag_ids"`
+}
+
+// HasTag проверяет, отмечена ли запись меткой tagID.
+func (l Labels) HasTag(tagID int) bool {
+	for _, id := range l.TagIDs {
+		if id == tagID {
+			return true
+		}
+	}
+	return false
+}
+
+// LabelFilter - отбор записей списка по папке и метке
+type LabelFilter struct {
+	// FolderID только записи папки (nil - любой папки, 0 - записи вне папок).
+	FolderID *int
+	// TagID только записи с меткой (0 - без отбора по метке).
+	TagID int
+}
+
+// Match проверяет, проходит ли запись с папкой и метками labels отбор.
+func (f LabelFilter) Match(labels Labels) bool {
+	if f.FolderID != nil && *f.FolderID != labels.FolderID {
+		return false
+	}
+
+	return f.TagID == 0 || labels.HasTag(f.TagID)
+}
+
+// FolderDTO - папка записей для API (папки образуют дерево)
+type FolderDTO struct {
+	ID int
+	// ParentID родительская папка (0 - папка верхнего уровня).
+	ParentID int
+	Name     string
+}
+
+// FolderDAO - папка записей для БД
+type FolderDAO struct {
+	ID        int       `db:"id"`
+	UserID    int       `db:"user_id"`
+	ParentID  int       `db:"parent_id"`
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at,omitempty"`
+}
+
+// TagDTO - метка записей для API
+type TagDTO struct {
+	ID   int
+	Name string
+}
+
+// TagDAO - метка записей для БД
+type TagDAO struct {
+	ID        int       `db:"id"`
+	UserID    int       `db:"user_id"`
+	Name      string    `db:"name"`
+	CreatedAt time.Time `db:"created_at,omitempty"`
+}
+
+// IDs - список id (в БД - массив INT[])
+type IDs []int
+
+// Value преобразует список в текстовое представление массива Postgres ({1,2,3}).
+func (ids IDs) Value() (driver.Value, error) {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = strconv.Itoa(id)
+	}
+
+	return "{" + strings.Join(parts, ",") + "}", nil
+}
+
+// Scan разбирает текстовое представление массива Postgres ({1,2,3}, NULL - пустой список).
+func (ids *IDs) Scan(src interface{}) error {
+	var text string
+	switch src := src.(type) {
+	case nil:
+		*ids = nil
+		return nil
+	case string:
+		text = src
+	case []byte:
+		text = string(src)
+	default:
+		return fmt.Errorf("entity - scan ids: unsupported type %T", src)
+	}
+
+	text = strings.TrimSuffix(strings.TrimPrefix(text, "{"), "}")
+	if text == "" {
+		*ids = nil
+		return nil
+	}
+
+	parts := strings.Split(text, ",")
+	result := make(IDs, len(parts))
+	for i, part := range parts {
+		id, err := strconv.Atoi(part)
+		if err != nil {
+			return fmt.Errorf("entity - scan ids: %w", err)
+		}
+		result[i] = id
+	}
+
+	*ids = result
+	return nil
+}
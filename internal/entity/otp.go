@@ -0,0 +1,34 @@
+package entity
+
+import "time"
+
+// OTPDTO - объект одноразового пароля (TOTP/HOTP) для API
+type OTPDTO struct {
+	ID        int
+	Kind      string
+	Secret    string
+	Algorithm string
+	Digits    int
+	Period    int
+	Counter   int64
+	Issuer    string
+	Metadata  string
+	Labels
+}
+
+// OTPDAO - объект одноразового пароля (TOTP/HOTP) для БД
+type OTPDAO struct {
+	ID        int       `db:"id"`
+	UserID    int       `db:"user_id"`
+	Kind      string    `db:"kind"`
+	Secret    string    `db:"secret"`
+	Algorithm string    `db:"algorithm"`
+	Digits    int       `db:"digits"`
+	Period    int       `db:"period"`
+	Counter   int64     `db:"counter"`
+	Issuer    string    `db:"issuer"`
+	Metadata  string    `db:"metadata,omitempty"`
+	Revision  int64     `db:"revision"`
+	CreatedAt time.Time `db:"created_at,omitempty"`
+	Labels
+}
@@ -0,0 +1,50 @@
+package entity
+
+import "time"
+
+// SortOrder порядок записей в списке (по времени создания, при равенстве - по id).
+type SortOrder int
+
+const (
+	// SortCreatedAsc - сначала старые записи.
+	SortCreatedAsc SortOrder = iota
+	// SortCreatedDesc - сначала новые записи.
+	SortCreatedDesc
+)
+
+// PageRequest - запрос страницы списка записей для API
+type PageRequest struct {
+	// Size размер страницы (0 - размер по умолчанию).
+	Size int
+	// Token непрозрачный токен следующей страницы из предыдущего ответа (пустой - первая страница).
+	Token string
+	// CreatedFrom, CreatedTo диапазон времени создания записей [CreatedFrom, CreatedTo)
+	// (нулевое значение - без ограничения).
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	// Order порядок записей.
+	Order SortOrder
+	// Filter отбор записей по папке и метке.
+	Filter LabelFilter
+}
+
+// ListQuery - параметры выборки страницы списка записей из хранилища
+type ListQuery struct {
+	// Limit максимальное количество записей.
+	Limit int
+	// CreatedFrom, CreatedTo диапазон времени создания [CreatedFrom, CreatedTo) (нулевое - без ограничения).
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+	// Order порядок записей.
+	Order SortOrder
+	// After позиция последней записи предыдущей страницы (nil - с начала списка).
+	After *Cursor
+	// Filter отбор записей по папке и метке.
+	Filter LabelFilter
+}
+
+// Cursor - позиция записи в списке
+type Cursor struct {
+	CreatedAt time.Time
+	ID        int
+}
@@ -8,6 +8,7 @@ type PairDTO struct {
 	Login    string
 	Password string
 	Metadata string
+	Labels
 }
 
 // PairDAO - объект типа логин/пароль для БД
@@ -17,5 +18,7 @@ type PairDAO struct {
 	Login     string    `db:"login"`
 	Password  string    `db:"password"`
 	Metadata  string    `db:"metadata,omitempty"`
+	Revision  int64     `db:"revision"`
 	CreatedAt time.Time `db:"created_at,omitempty"`
+	Labels
 }
@@ -0,0 +1,12 @@
+package entity
+
+// SearchHitDTO - найденная при поиске по локальной копии запись
+type SearchHitDTO struct {
+	// Kind тип записи (PairKind, CardKind, ...).
+	Kind string
+	ID   int
+	// Rank оценка совпадения (чем больше, тем лучше).
+	Rank float64
+	// Snippet фрагмент текста записи с выделенными словами запроса.
+	Snippet string
+}
@@ -0,0 +1,49 @@
+package entity
+
+import "time"
+
+// SecurityEventLoginLocked - вход в учётную запись заблокирован после серии неудачных попыток.
+const SecurityEventLoginLocked = "login_locked"
+
+// SecurityEventDTO - событие безопасности учётной записи для API
+type SecurityEventDTO struct {
+	// Kind вид события (SecurityEventLoginLocked).
+	Kind string
+	// Peer адрес, с которого выполнялись попытки входа.
+	Peer string
+	// LockedUntil время окончания блокировки.
+	LockedUntil time.Time
+	// CreatedAt время события.
+	CreatedAt time.Time
+}
+
+// SecurityEventDAO - событие безопасности учётной записи для БД
+type SecurityEventDAO struct {
+	ID          int       `db:"id"`
+	UserID      int       `db:"user_id"`
+	Kind        string    `db:"kind"`
+	Peer        string    `db:"peer"`
+	LockedUntil time.Time `db:"locked_until"`
+	CreatedAt   time.Time `db:"created_at,omitempty"`
+}
+
+// TOTPEnrollmentDTO - параметры подключаемого аутентификатора (TOTP) для API
+type TOTPEnrollmentDTO struct {
+	// Secret секрет в кодировке base32.
+	Secret    string
+	Algorithm string
+	Digits    int
+	Period    int
+}
+
+// TOTPDAO - второй фактор (TOTP) пользователя для БД
+//
+// Secret - секрет действующего аутентификатора (пустой - второй фактор не подключён),
+// PendingSecret - секрет подключаемого аутентификатора (до подтверждения кодом),
+// LastStep - номер интервала последнего принятого кода (защита от повторного использования кода).
+type TOTPDAO struct {
+	UserID        int    `db:"user_id"`
+	Secret        string `db:"secret"`
+	PendingSecret string `db:"pending_secret"`
+	LastStep      int64  `db:"last_step"`
+}
@@ -0,0 +1,36 @@
+package entity
+
+import "time"
+
+// TokensDTO - токены пользователя для API
+type TokensDTO struct {
+	// AccessToken короткоживущий токен для запросов к серверу.
+	AccessToken string
+	// RefreshToken одноразовый токен для получения новой пары токенов (при каждом обновлении заменяется).
+	RefreshToken string
+	// ExpiresIn время действия AccessToken.
+	ExpiresIn time.Duration
+	// Challenge токен незавершённого входа: если задан, требуется второй фактор (VerifySecondFactor),
+	// остальные поля пустые.
+	Challenge string
+}
+
+// SessionDAO - сессия пользователя для БД
+type SessionDAO struct {
+	ID          string     `db:"id"`
+	UserID      int        `db:"user_id"`
+	RefreshHash string     `db:"refresh_hash"`
+	ExpiresAt   time.Time  `db:"expires_at"`
+	RevokedAt   *time.Time `db:"revoked_at"`
+	CreatedAt   time.Time  `db:"created_at,omitempty"`
+}
+
+// ChallengeDAO - незавершённый вход (пароль проверен, ожидается второй фактор) для БД
+type ChallengeDAO struct {
+	ID         string    `db:"id"`
+	UserID     int       `db:"user_id"`
+	Login      string    `db:"login"`
+	SecretHash string    `db:"secret_hash"`
+	ExpiresAt  time.Time `db:"expires_at"`
+	CreatedAt  time.Time `db:"created_at,omitempty"`
+}
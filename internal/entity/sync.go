@@ -0,0 +1,42 @@
+package entity
+
+// Типы записей (используются в отметках об удалении и результатах поиска).
+const (
+	PairKind   = "pair"
+	CardKind   = "card"
+	NoteKind   = "note"
+	BinaryKind = "binary"
+	OTPKind    = "otp"
+)
+
+// Tombstone - отметка об удалении записи
+type Tombstone struct {
+	Kind     string `db:"kind"`
+	ID       int    `db:"item_id"`
+	Revision int64  `db:"revision"`
+}
+
+// ChangesDTO - изменения данных пользователя после заданной ревизии для API
+type ChangesDTO struct {
+	// Revision текущая ревизия данных пользователя (курсор для следующей синхронизации).
+	Revision int64
+	// Full - передан полный набор данных (локальное состояние следует заменить, а не дополнить).
+	Full     bool
+	Pairs    []PairDTO
+	Cards    []BankDTO
+	Notes    []TextDTO
+	Binaries []BinaryDTO
+	OTPs     []OTPDTO
+	Deleted  []Tombstone
+}
+
+// ChangesDAO - изменения данных пользователя после заданной ревизии из БД
+type ChangesDAO struct {
+	Revision int64
+	Pairs    []PairDAO
+	Cards    []BankDAO
+	Notes    []TextDAO
+	Binaries []BinaryDAO
+	OTPs     []OTPDAO
+	Deleted  []Tombstone
+}
@@ -7,6 +7,7 @@ type TextDTO struct {
 	ID       int
 	Note     string
 	Metadata string
+	Labels
 }
 
 // TextDAO - объект текстового типа (заметка) для БД
@@ -15,5 +16,7 @@ type TextDAO struct {
 	UserID    int       `db:"user_id"`
 	Note      string    `db:"note"`
 	Metadata  string    `db:"metadata,omitempty"`
+	Revision  int64     `db:"revision"`
 	CreatedAt time.Time `db:"created_at,omitempty"`
+	Labels
 }
@@ -1,5 +1,7 @@
 package entity
 
+import "time"
+
 // UserDTO - объект для  API
 type UserDTO struct {
 	Login    string `json:"login" binding:"required"`
@@ -8,7 +10,8 @@ type UserDTO struct {
 
 // UserDAO - объект для БД
 type UserDAO struct {
-	ID           int    `db:"id"`
-	Login        string `db:"login"`
-	PasswordHash string `db:"password_hash"`
+	ID                int       `db:"id"`
+	Login             string    `db:"login"`
+	PasswordHash      string    `db:"password_hash"`
+	PasswordChangedAt time.Time `db:"password_changed_at"`
 }
@@ -2,15 +2,21 @@
 package app
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/signal"
+	"sync"
 	"syscall"
+	"time"
 
 	"github.com/PaulYakow/gophkeeper/cmd/server/config"
+	"github.com/PaulYakow/gophkeeper/internal/entity"
 	"github.com/PaulYakow/gophkeeper/internal/server/controller"
 	"github.com/PaulYakow/gophkeeper/internal/server/usecase"
 	"github.com/PaulYakow/gophkeeper/internal/server/usecase/repo"
+	"github.com/PaulYakow/gophkeeper/internal/server/usecase/repo/memory"
+	"github.com/PaulYakow/gophkeeper/internal/utils/encryption"
 	"github.com/PaulYakow/gophkeeper/internal/utils/password"
 	"github.com/PaulYakow/gophkeeper/internal/utils/token"
 	"github.com/PaulYakow/gophkeeper/pkg/logger"
@@ -21,9 +27,13 @@ import (
 type App struct {
 	config  *config.Config
 	logger  *logger.Logger
-	repo    *repo.Repo
+	repo    usecase.IRepo
 	service usecase.IService
 
+	// шифрование хранимых данных (nil - отключено)
+	keys     encryption.KeyProvider
+	envelope *repo.Envelope
+
 	// todo: по сути это вспомогательные утилиты - можно сделать отдельную структуру
 	// и потом, например a.utils = a.createUtils
 	passwordHasher password.IPasswordHash
@@ -33,18 +43,29 @@ type App struct {
 }
 
 // New собирает сервер из слоёв (хранилище, сервисы, логика, контроллер).
-func New(cfg *config.Config) *App {
+//
+// buildTime - время сборки сервера (RFC 3339, пустое - неизвестно).
+func New(cfg *config.Config, buildTime string) *App {
 	var err error
 
 	// Config + Logger + Password hasher
 	a := &App{
-		config:         cfg,
-		logger:         logger.New(cfg.App.Name),
-		passwordHasher: password.New(),
+		config: cfg,
+		logger: logger.New(cfg.App.Name),
+		passwordHasher: password.NewArgon2(password.Argon2Params{
+			Memory:  cfg.Hash.Memory,
+			Time:    cfg.Hash.Time,
+			Threads: cfg.Hash.Threads,
+		}, cfg.Hash.Pepper),
 	}
 
 	// Repo
-	a.repo = a.createPostgresRepo()
+	switch cfg.Storage.Driver {
+	case config.StorageMemory:
+		a.repo = a.createMemoryRepo()
+	default:
+		a.repo = a.createPostgresRepo()
+	}
 
 	// Token
 	a.tokenMaker, err = token.NewPasetoMaker(a.config.Token.Key)
@@ -53,59 +74,162 @@ func New(cfg *config.Config) *App {
 	}
 
 	// Usecases
-	auth := usecase.NewAuthService(a.repo, a.passwordHasher, a.tokenMaker)
+	auth := usecase.NewAuthService(a.repo, a.passwordHasher, a.tokenMaker,
+		cfg.Token.AccessDuration, cfg.Token.RefreshDuration,
+		usecase.PasswordPolicy{HistorySize: cfg.Password.HistorySize, MaxAge: cfg.Password.MaxAge},
+		usecase.LoginPolicy{
+			MaxFailures:     cfg.Lockout.MaxFailures,
+			PeerMaxFailures: cfg.Lockout.PeerMaxFailures,
+			LockoutBase:     cfg.Lockout.Base,
+			LockoutMax:      cfg.Lockout.Max,
+			Window:          cfg.Lockout.Window,
+		})
 	pairs := usecase.NewPairsService(a.repo)
 	cards := usecase.NewBankService(a.repo)
 	notes := usecase.NewTextService(a.repo)
+	binaries := usecase.NewBinaryService(a.repo, cfg.Binary.MaxSize)
+	otps := usecase.NewOTPService(a.repo)
+	sync := usecase.NewSyncService(a.repo)
+	account := usecase.NewAccountService(a.repo, auth)
+	labels := usecase.NewLabelsService(a.repo)
 
-	a.service, err = usecase.New(auth, pairs, cards, notes)
+	a.service, err = usecase.New(auth, pairs, cards, notes, binaries, otps, sync, account, labels)
 	if err != nil {
 		a.logger.Fatal(fmt.Errorf("create service: %w", err))
 	}
 
 	// Controller
-	a.grpcSrv = controller.New(a.service, a.logger, cfg)
+	a.grpcSrv = controller.New(a.service, a.logger, cfg,
+		controller.Info(a.serverInfo(buildTime)),
+		controller.HealthCheck(a.repo.Ping),
+	)
 
 	return a
 }
 
-// Run - запуск сервера.
-func (a *App) Run() {
+// Run - запуск сервера и его остановка по сигналу (SIGINT, SIGTERM, SIGQUIT).
+//
+// При остановке сервер перестаёт принимать запросы и ждёт завершения выполняющихся (не дольше
+// grpc.shutdown_timeout), только после этого закрывается подключение к хранилищу.
+// Возвращает ошибку, если сервер не запустился, остановился сам или запросы пришлось прервать.
+func (a *App) Run() error {
 	defer a.logger.Exit()
 
-	if a.grpcSrv != nil {
-		a.grpcSrv.Run()
+	if err := a.grpcSrv.Start(); err != nil {
+		return err
 	}
 
-	// Waiting signal
+	// Waiting signal (SIGHUP - перечитать KEK и выполнить ротацию без остановки сервера)
 	interrupt := make(chan os.Signal, 1)
-	signal.Notify(interrupt, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT)
+	signal.Notify(interrupt, syscall.SIGINT, syscall.SIGTERM, syscall.SIGQUIT, syscall.SIGHUP)
+	defer signal.Stop(interrupt)
 
-	sig := <-interrupt
-	a.logger.Info("Run - signal: %v", sig.String())
+	// ротация выполняется в фоне, но завершается до закрытия подключения к хранилищу
+	rotateCtx, stopRotate := context.WithCancel(context.Background())
+	defer stopRotate()
+	var rotation sync.WaitGroup
+
+	var runErr error
+wait:
+	for {
+		select {
+		case sig := <-interrupt:
+			if sig == syscall.SIGHUP {
+				rotation.Add(1)
+				go func() {
+					defer rotation.Done()
+					a.rotateKEK(rotateCtx)
+				}()
+				continue
+			}
+			a.logger.Info("Run - signal: %v", sig.String())
+			break wait
+		case runErr = <-a.grpcSrv.Notify():
+			break wait
+		}
+	}
 
 	// Shutdown
+	ctx, cancel := context.WithTimeout(context.Background(), a.config.GRPC.ShutdownTimeout)
+	defer cancel()
+
+	if err := a.grpcSrv.Shutdown(ctx); err != nil {
+		a.logger.Error(fmt.Errorf("run - shutdown: %w", err))
+		if runErr == nil {
+			runErr = err
+		}
+	}
+	a.logger.Info("gRPC stopped")
+
+	// прерванная ротация безопасна: оставшиеся ключи данных обёрнуты прежним KEK до следующей ротации
+	stopRotate()
+	rotation.Wait()
+
 	if err := a.repo.CloseConnection(); err != nil {
 		a.logger.Error(fmt.Errorf("run - close connection to repo: %w", err))
+		if runErr == nil {
+			runErr = err
+		}
 	}
+
+	return runErr
+}
+
+// Информация о сервере для клиентов: версия, время сборки и поддерживаемые возможности.
+func (a *App) serverInfo(buildTime string) entity.ServerInfoDTO {
+	info := entity.ServerInfoDTO{
+		Version:  a.config.App.Version,
+		Features: []string{"sync", "otp", "second_factor", "account_export", "labels"},
+	}
+
+	if buildTime != "" {
+		t, err := time.Parse(time.RFC3339, buildTime)
+		if err != nil {
+			a.logger.Warn("server info - build time %q: %s", buildTime, err)
+		}
+		info.BuildTime = t
+	}
+
+	if a.envelope != nil {
+		info.Features = append(info.Features, "encryption_at_rest")
+	}
+	if a.config.Storage.Driver == config.StorageMemory {
+		info.Features = append(info.Features, "memory_storage")
+	}
+
+	return info
 }
 
 // Создание структуры взаимодействия с хранилищем данных.
 func (a *App) createPostgresRepo() (r *repo.Repo) {
-	pg, err := postgres.New(a.config.PG.URL,
-		postgres.ConnAttempts(a.config.PG.ConnAttempts), postgres.MaxOpenConn(a.config.PG.MaxOpen))
+	pg, err := newPostgres(a.config)
 	if err != nil {
 		a.logger.Fatal(fmt.Errorf("create DB conn: %w", err))
 	}
 
 	a.logger.Info("PostgreSQL connection ok")
 
-	auth := repo.NewAuthPostgres(pg)
-	pairs := repo.NewPairPostgres(pg)
-	cards := repo.NewBankPostgres(pg)
-	notes := repo.NewTextPostgres(pg)
+	if a.config.PG.AutoMigrate {
+		a.migrate(pg)
+	}
+
+	a.keys = a.createKeyProvider()
+	if a.keys != nil {
+		a.envelope = repo.NewEnvelope(pg, a.keys)
+		a.logger.Info("encryption at rest enabled")
+	}
+
+	auth := repo.NewAuthPostgres(pg, a.envelope)
+	pairs := repo.NewPairPostgres(pg, a.envelope)
+	cards := repo.NewBankPostgres(pg, a.envelope)
+	notes := repo.NewTextPostgres(pg, a.envelope)
+	binaries := repo.NewBinaryPostgres(pg, a.envelope)
+	otps := repo.NewOTPPostgres(pg, a.envelope)
+	sync := repo.NewSyncPostgres(pg, a.envelope)
+	account := repo.NewAccountPostgres(pg, a.envelope, a.config.PG.ExportTimeout)
+	labels := repo.NewLabelsPostgres(pg, a.envelope)
 
-	r, err = repo.New(pg, auth, pairs, cards, notes)
+	r, err = repo.New(pg, auth, pairs, cards, notes, binaries, otps, sync, account, labels)
 	if err != nil {
 		a.logger.Fatal(fmt.Errorf("Run - repo.New: %w", err))
 	}
@@ -113,3 +237,92 @@ func (a *App) createPostgresRepo() (r *repo.Repo) {
 
 	return
 }
+
+// Создание хранилища данных в оперативной памяти (данные теряются при остановке сервера).
+func (a *App) createMemoryRepo() *memory.Memory {
+	a.logger.Warn("in-memory storage in use: data will be lost on shutdown")
+	return memory.New()
+}
+
+// Подключение к БД Postgres.
+func newPostgres(cfg *config.Config) (*postgres.Postgres, error) {
+	return postgres.New(cfg.PG.URL,
+		postgres.ConnAttempts(cfg.PG.ConnAttempts), postgres.MaxOpenConn(cfg.PG.MaxOpen),
+		postgres.QueryTimeout(cfg.PG.QueryTimeout))
+}
+
+// Применение миграций схемы БД при запуске сервера (postgres.auto_migrate).
+func (a *App) migrate(pg *postgres.Postgres) {
+	migrator, err := repo.NewMigrator(pg)
+	if err != nil {
+		a.logger.Fatal(fmt.Errorf("create migrator: %w", err))
+	}
+
+	applied, err := migrator.Up(context.Background())
+	for _, m := range applied {
+		a.logger.Info("migrate - applied %d_%s", m.Version, m.Name)
+	}
+	if err != nil {
+		a.logger.Fatal(fmt.Errorf("migrate up: %w", err))
+	}
+}
+
+// Создание источника ключей шифрования хранимых данных (nil - шифрование отключено).
+func (a *App) createKeyProvider() encryption.KeyProvider {
+	keys, err := newKeyProvider(a.config)
+	if err != nil {
+		a.logger.Fatal(fmt.Errorf("create key provider: %w", err))
+	}
+
+	return keys
+}
+
+// Источник KEK по конфигурации (nil - ключи не заданы).
+//
+// Другой источник KEK (например, KMS) подключается здесь: достаточно реализации encryption.KeyProvider.
+func newKeyProvider(cfg *config.Config) (encryption.KeyProvider, error) {
+	var keys *encryption.LocalKeyProvider
+	var err error
+
+	switch {
+	case cfg.Encryption.KeysFile != "":
+		keys, err = encryption.NewFileKeyProvider(cfg.Encryption.KeysFile)
+	case cfg.Encryption.Keys != "":
+		keys, err = encryption.NewEnvKeyProvider(cfg.Encryption.Keys)
+	default:
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// Ротация KEK: перечитывает ключи и заново оборачивает актуальным KEK ключи данных пользователей.
+//
+// Для ротации новый ключ добавляется в конец файла ключей, после чего серверу отправляется SIGHUP.
+// Предыдущие ключи можно удалить из файла только после успешного завершения ротации.
+// Остановка сервера прерывает ротацию (отмена ctx).
+func (a *App) rotateKEK(ctx context.Context) {
+	if a.envelope == nil {
+		a.logger.Info("rotate KEK - encryption at rest disabled")
+		return
+	}
+
+	if r, ok := a.keys.(encryption.Reloader); ok {
+		if err := r.Reload(); err != nil {
+			a.logger.Error(fmt.Errorf("rotate KEK - reload keys: %w", err))
+			return
+		}
+	}
+
+	n, err := a.envelope.RotateKEK(ctx)
+	if err != nil {
+		a.logger.Error(fmt.Errorf("rotate KEK - rewrapped %d data keys: %w", n, err))
+		return
+	}
+
+	a.logger.Info("rotate KEK - rewrapped %d data keys", n)
+}
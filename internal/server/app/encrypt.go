@@ -0,0 +1,87 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/PaulYakow/gophkeeper/cmd/server/config"
+	"github.com/PaulYakow/gophkeeper/internal/server/usecase/repo"
+)
+
+// EncryptUsage описание команды encrypt.
+const EncryptUsage = `usage: gophkeeper-srv [-c config.yaml] encrypt <command>
+
+commands:
+  status   count records stored before encryption at rest was enabled
+  seal     encrypt these records with the data keys of their owners
+`
+
+// ErrEncryptUsage неверные аргументы команды encrypt.
+var ErrEncryptUsage = errors.New("invalid encrypt command")
+
+// Encrypt выполняет команду шифрования записей, сохранённых до включения шифрования хранимых данных
+// (status, seal), и выводит результат в out.
+//
+// Ротация KEK такие записи не затрагивает, поэтому после включения шифрования выполняется seal
+// (на работающем сервере). Прерывание (SIGINT, SIGTERM) останавливает шифрование: зашифрованные
+// записи сохраняются, повторный запуск продолжает с оставшихся.
+func Encrypt(cfg *config.Config, args []string, out io.Writer) error {
+	if len(args) != 1 || (args[0] != "status" && args[0] != "seal") {
+		return ErrEncryptUsage
+	}
+
+	if cfg.Storage.Driver != config.StoragePostgres {
+		return fmt.Errorf("encrypt: storage driver %q does not store data", cfg.Storage.Driver)
+	}
+
+	keys, err := newKeyProvider(cfg)
+	if err != nil {
+		return fmt.Errorf("create key provider: %w", err)
+	}
+	if keys == nil {
+		return errors.New("encrypt: encryption keys are not configured")
+	}
+
+	pg, err := newPostgres(cfg)
+	if err != nil {
+		return fmt.Errorf("create DB conn: %w", err)
+	}
+	defer pg.Shutdown()
+
+	env := repo.NewEnvelope(pg, keys)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if args[0] == "seal" {
+		n, err := env.SealLegacy(ctx)
+		fmt.Fprintf(out, "sealed %d records\n", n)
+		if err != nil {
+			return err
+		}
+	}
+
+	return printLegacy(ctx, env, out)
+}
+
+// printLegacy выводит количество незашифрованных записей по таблицам.
+func printLegacy(ctx context.Context, env *repo.Envelope, out io.Writer) error {
+	counts, err := env.CountLegacy(ctx)
+	if err != nil {
+		return err
+	}
+
+	var total int
+	for _, c := range counts {
+		fmt.Fprintf(out, "%-24s %d\n", c.Table, c.Rows)
+		total += c.Rows
+	}
+	fmt.Fprintf(out, "unencrypted records: %d\n", total)
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+
+	"github.com/PaulYakow/gophkeeper/cmd/server/config"
+	"github.com/PaulYakow/gophkeeper/internal/server/usecase/repo"
+)
+
+// timeLayout формат времени применения миграций.
+const timeLayout = "2006-01-02 15:04:05"
+
+// MigrateUsage описание команды migrate.
+const MigrateUsage = `usage: gophkeeper-srv [-c config.yaml] migrate <command>
+
+commands:
+  status   list migrations and when they were applied
+  up       apply all pending migrations
+  down N   revert the last N applied migrations (default 1)
+`
+
+// ErrMigrateUsage неверные аргументы команды migrate.
+var ErrMigrateUsage = errors.New("invalid migrate command")
+
+// Migrate выполняет команду управления схемой БД (status, up, down N) и выводит результат в out.
+//
+// Прерывание (SIGINT, SIGTERM) отменяет выполнение: текущая миграция откатывается целиком.
+func Migrate(cfg *config.Config, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return ErrMigrateUsage
+	}
+
+	n := 1
+	switch args[0] {
+	case "status", "up":
+		if len(args) != 1 {
+			return ErrMigrateUsage
+		}
+	case "down":
+		if len(args) > 2 {
+			return ErrMigrateUsage
+		}
+		if len(args) == 2 {
+			var err error
+			if n, err = strconv.Atoi(args[1]); err != nil || n <= 0 {
+				return ErrMigrateUsage
+			}
+		}
+	default:
+		return ErrMigrateUsage
+	}
+
+	if cfg.Storage.Driver != config.StoragePostgres {
+		return fmt.Errorf("migrate: storage driver %q has no schema", cfg.Storage.Driver)
+	}
+
+	pg, err := newPostgres(cfg)
+	if err != nil {
+		return fmt.Errorf("create DB conn: %w", err)
+	}
+	defer pg.Shutdown()
+
+	migrator, err := repo.NewMigrator(pg)
+	if err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var done []repo.Migration
+	switch args[0] {
+	case "status":
+		return printStatus(ctx, migrator, out)
+	case "up":
+		done, err = migrator.Up(ctx)
+		for _, m := range done {
+			fmt.Fprintf(out, "applied %d_%s\n", m.Version, m.Name)
+		}
+	case "down":
+		done, err = migrator.Down(ctx, n)
+		for _, m := range done {
+			fmt.Fprintf(out, "reverted %d_%s\n", m.Version, m.Name)
+		}
+	}
+
+	if err == nil && len(done) == 0 {
+		fmt.Fprintln(out, "no changes")
+	}
+
+	return err
+}
+
+// printStatus выводит список миграций и время их применения.
+func printStatus(ctx context.Context, migrator *repo.Migrator, out io.Writer) error {
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range status {
+		name, applied := s.Name, "pending"
+		if name == "" {
+			name = "(unknown)"
+		}
+		if s.AppliedAt != nil {
+			applied = s.AppliedAt.Local().Format(timeLayout)
+		}
+		fmt.Fprintf(out, "%04d  %-24s %s\n", s.Version, name, applied)
+	}
+
+	return nil
+}
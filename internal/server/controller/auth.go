@@ -2,6 +2,12 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
+	"net"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 
 	"github.com/PaulYakow/gophkeeper/internal/server/usecase"
 	pb "github.com/PaulYakow/gophkeeper/proto"
@@ -10,35 +16,241 @@ import (
 // UserServer реализация интерфейса proto.UserServer (описание - gophkeeper/proto/user.proto)
 type UserServer struct {
 	pb.UnimplementedUserServer
-	auth usecase.IAuthorizationService
+	auth    usecase.IAuthorizationService
+	account usecase.IAccountService
 }
 
-func NewUserServer(auth usecase.IAuthorizationService) *UserServer {
+// NewUserServer создаёт объект UserServer.
+func NewUserServer(auth usecase.IAuthorizationService, account usecase.IAccountService) *UserServer {
 	return &UserServer{
-		auth: auth,
+		auth:    auth,
+		account: account,
 	}
 }
 
 // Register - регистрация пользователя.
 func (s *UserServer) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.RegisterResponse, error) {
 	var resp pb.RegisterResponse
-	token, err := s.auth.RegisterUser(req.GetLogin(), req.GetPassword())
+	tokens, err := s.auth.RegisterUser(ctx, req.GetLogin(), req.GetPassword())
 	if err != nil {
-		return nil, err
+		return nil, statusError(err)
 	}
 
-	resp.Token = token
+	resp.Token = tokens.AccessToken
+	resp.RefreshToken = tokens.RefreshToken
+	resp.ExpiresIn = int64(tokens.ExpiresIn.Seconds())
 	return &resp, nil
 }
 
 // Login - авторизация пользователя.
 func (s *UserServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
 	var resp pb.LoginResponse
-	token, err := s.auth.LoginUser(req.GetLogin(), req.GetPassword())
+	tokens, err := s.auth.LoginUser(ctx, req.GetLogin(), req.GetPassword(), peerAddress(ctx))
+	if err != nil {
+		return nil, credentialsError(err)
+	}
+
+	resp.Token = tokens.AccessToken
+	resp.RefreshToken = tokens.RefreshToken
+	resp.ExpiresIn = int64(tokens.ExpiresIn.Seconds())
+	resp.Challenge = tokens.Challenge
+	return &resp, nil
+}
+
+// VerifySecondFactor - завершение входа кодом аутентификатора.
+func (s *UserServer) VerifySecondFactor(ctx context.Context, req *pb.VerifySecondFactorRequest) (*pb.VerifySecondFactorResponse, error) {
+	var resp pb.VerifySecondFactorResponse
+	tokens, err := s.auth.VerifySecondFactor(ctx, req.GetChallenge(), req.GetCode(), peerAddress(ctx))
+	if err != nil {
+		return nil, credentialsError(err)
+	}
+
+	resp.Token = tokens.AccessToken
+	resp.RefreshToken = tokens.RefreshToken
+	resp.ExpiresIn = int64(tokens.ExpiresIn.Seconds())
+	return &resp, nil
+}
+
+// Refresh - обновление токенов сессии (refresh-токен одноразовый).
+func (s *UserServer) Refresh(ctx context.Context, req *pb.RefreshRequest) (*pb.RefreshResponse, error) {
+	var resp pb.RefreshResponse
+	tokens, err := s.auth.Refresh(ctx, req.GetRefreshToken())
 	if err != nil {
-		return nil, err
+		return nil, statusError(err)
 	}
 
-	resp.Token = token
+	resp.Token = tokens.AccessToken
+	resp.RefreshToken = tokens.RefreshToken
+	resp.ExpiresIn = int64(tokens.ExpiresIn.Seconds())
 	return &resp, nil
 }
+
+// Logout - завершение текущей сессии пользователя.
+func (s *UserServer) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+	var resp pb.LogoutResponse
+
+	sessionID, ok := ctx.Value(sessionIDKey).(string)
+	if !ok {
+		return nil, status.Error(codes.Aborted, "missing session_id")
+	}
+
+	if err := s.auth.Logout(ctx, sessionID); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &resp, nil
+}
+
+// ChangePassword - смена пароля пользователя.
+func (s *UserServer) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*pb.ChangePasswordResponse, error) {
+	var resp pb.ChangePasswordResponse
+	tokens, err := s.auth.ChangePassword(ctx, req.GetLogin(), req.GetPassword(), req.GetNewPassword(), req.GetCode(), peerAddress(ctx))
+	if err != nil {
+		return nil, credentialsError(err)
+	}
+
+	resp.Token = tokens.AccessToken
+	resp.RefreshToken = tokens.RefreshToken
+	resp.ExpiresIn = int64(tokens.ExpiresIn.Seconds())
+	return &resp, nil
+}
+
+// ExportAccount - выгрузка всех данных пользователя (серверный поток).
+//
+// Архив (JSON) передаётся частями по chunkSize.
+func (s *UserServer) ExportAccount(req *pb.ExportAccountRequest, stream pb.User_ExportAccountServer) error {
+	ctx := stream.Context()
+
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return status.Error(codes.Aborted, "missing user_id")
+	}
+
+	account, err := s.account.ExportAccount(ctx, userID)
+	if err != nil {
+		return statusError(err)
+	}
+
+	data, err := json.Marshal(account)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	for start := 0; start < len(data); start += chunkSize {
+		end := start + chunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+
+		if err = stream.Send(&pb.ExportAccountResponse{Chunk: data[start:end]}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteAccount - удаление учётной записи пользователя вместе со всеми данными.
+func (s *UserServer) DeleteAccount(ctx context.Context, req *pb.DeleteAccountRequest) (*pb.DeleteAccountResponse, error) {
+	var resp pb.DeleteAccountResponse
+
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return nil, status.Error(codes.Aborted, "missing user_id")
+	}
+
+	err := s.account.DeleteAccount(ctx, userID, req.GetPassword(), req.GetCode(), peerAddress(ctx))
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &resp, nil
+}
+
+// SecurityEvents - события безопасности учётной записи (блокировки входа).
+func (s *UserServer) SecurityEvents(ctx context.Context, req *pb.SecurityEventsRequest) (*pb.SecurityEventsResponse, error) {
+	var resp pb.SecurityEventsResponse
+
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return nil, status.Error(codes.Aborted, "missing user_id")
+	}
+
+	events, err := s.auth.SecurityEvents(ctx, userID)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	for _, event := range events {
+		resp.Events = append(resp.Events, &pb.SecurityEvent{
+			Kind:        event.Kind,
+			Peer:        event.Peer,
+			CreatedAt:   event.CreatedAt.Unix(),
+			LockedUntil: event.LockedUntil.Unix(),
+		})
+	}
+
+	return &resp, nil
+}
+
+// EnrollTOTP - подключение (или замена) аутентификатора.
+func (s *UserServer) EnrollTOTP(ctx context.Context, req *pb.EnrollTOTPRequest) (*pb.EnrollTOTPResponse, error) {
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return nil, status.Error(codes.Aborted, "missing user_id")
+	}
+
+	enrollment, err := s.auth.EnrollTOTP(ctx, userID, req.GetPassword(), req.GetCode(), peerAddress(ctx))
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &pb.EnrollTOTPResponse{
+		Secret:    enrollment.Secret,
+		Algorithm: enrollment.Algorithm,
+		Digits:    int32(enrollment.Digits),
+		Period:    int32(enrollment.Period),
+	}, nil
+}
+
+// ConfirmTOTP - подтверждение подключаемого аутентификатора кодом.
+func (s *UserServer) ConfirmTOTP(ctx context.Context, req *pb.ConfirmTOTPRequest) (*pb.ConfirmTOTPResponse, error) {
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return nil, status.Error(codes.Aborted, "missing user_id")
+	}
+
+	if err := s.auth.ConfirmTOTP(ctx, userID, req.GetCode()); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &pb.ConfirmTOTPResponse{}, nil
+}
+
+// DisableTOTP - отключение второго фактора.
+func (s *UserServer) DisableTOTP(ctx context.Context, req *pb.DisableTOTPRequest) (*pb.DisableTOTPResponse, error) {
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return nil, status.Error(codes.Aborted, "missing user_id")
+	}
+
+	if err := s.auth.DisableTOTP(ctx, userID, req.GetCode()); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &pb.DisableTOTPResponse{}, nil
+}
+
+// peerAddress возвращает адрес клиента (без порта) или пустую строку, если адрес неизвестен.
+func peerAddress(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+
+	addr := p.Addr.String()
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
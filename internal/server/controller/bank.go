@@ -6,6 +6,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/PaulYakow/gophkeeper/internal/entity"
 	"github.com/PaulYakow/gophkeeper/internal/server/usecase"
 	pb "github.com/PaulYakow/gophkeeper/proto"
 )
@@ -23,7 +24,7 @@ func NewBankServer(cards usecase.IBankService) *BankServer {
 	}
 }
 
-// GetAll - получение всех значений банковских карт.
+// GetAll - получение страницы списка банковских карт.
 func (s *BankServer) GetAll(ctx context.Context, req *pb.GetAllCardsRequest) (*pb.GetAllCardsResponse, error) {
 	var resp pb.GetAllCardsResponse
 
@@ -32,20 +33,92 @@ func (s *BankServer) GetAll(ctx context.Context, req *pb.GetAllCardsRequest) (*p
 		return nil, status.Error(codes.Aborted, "missing user_id")
 	}
 
-	cards, err := s.cards.ViewAllCards(userID)
+	cards, next, err := s.cards.ViewCards(ctx, userID, pageFromMsg(req.GetPage()))
 	if err != nil {
-		return nil, err
+		return nil, statusError(err)
 	}
 
 	for _, card := range cards {
-		resp.Cards = append(resp.Cards, &pb.CardMsg{
-			Id:             int64(card.ID),
-			CardHolder:     card.CardHolder,
-			Number:         card.Number,
-			ExpirationDate: card.ExpirationDate,
-			Metadata:       card.Metadata,
-		})
+		resp.Cards = append(resp.Cards, cardToMsg(card))
 	}
 
+	resp.NextPageToken = next
+
+	return &resp, nil
+}
+
+// Create - создание новой банковской карты.
+func (s *BankServer) Create(ctx context.Context, req *pb.CreateCardRequest) (*pb.CreateCardResponse, error) {
+	var resp pb.CreateCardResponse
+
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return nil, status.Error(codes.Aborted, "missing user_id")
+	}
+
+	id, err := s.cards.CreateCard(ctx, userID, entity.BankDTO{
+		CardHolder:     req.GetCard().GetCardHolder(),
+		Number:         req.GetCard().GetNumber(),
+		ExpirationDate: req.GetCard().GetExpirationDate(),
+		Metadata:       req.GetCard().GetMetadata(),
+		Labels:         labelsFromMsg(req.GetCard().GetFolderId(), req.GetCard().GetTagIds()),
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	resp.Id = int64(id)
 	return &resp, nil
 }
+
+// Update - изменение существующей банковской карты.
+func (s *BankServer) Update(ctx context.Context, req *pb.UpdateCardRequest) (*pb.UpdateCardResponse, error) {
+	var resp pb.UpdateCardResponse
+
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return nil, status.Error(codes.Aborted, "missing user_id")
+	}
+
+	err := s.cards.UpdateCard(ctx, userID, entity.BankDTO{
+		ID:             int(req.GetCard().GetId()),
+		CardHolder:     req.GetCard().GetCardHolder(),
+		Number:         req.GetCard().GetNumber(),
+		ExpirationDate: req.GetCard().GetExpirationDate(),
+		Metadata:       req.GetCard().GetMetadata(),
+		Labels:         labelsFromMsg(req.GetCard().GetFolderId(), req.GetCard().GetTagIds()),
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &resp, nil
+}
+
+// Delete - удаление банковской карты.
+func (s *BankServer) Delete(ctx context.Context, req *pb.DeleteCardRequest) (*pb.DeleteCardResponse, error) {
+	var resp pb.DeleteCardResponse
+
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return nil, status.Error(codes.Aborted, "missing user_id")
+	}
+
+	if err := s.cards.DeleteCard(ctx, userID, int(req.GetId())); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &resp, nil
+}
+
+func cardToMsg(card entity.BankDTO) *pb.CardMsg {
+	return &pb.CardMsg{
+		Id:             int64(card.ID),
+		CardHolder:     card.CardHolder,
+		Number:         card.Number,
+		ExpirationDate: card.ExpirationDate,
+		Metadata:       card.Metadata,
+		FolderId:       int64(card.FolderID),
+		TagIds:         tagIDsToMsg(card.TagIDs),
+	}
+}
@@ -0,0 +1,184 @@
+package controller
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	"github.com/PaulYakow/gophkeeper/internal/server/usecase"
+	pb "github.com/PaulYakow/gophkeeper/proto"
+)
+
+// chunkSize размер части файла, передаваемой одним сообщением потока.
+const chunkSize = 64 * 1024
+
+// BinaryServer реализация интерфейса proto.BinaryServer (описание - gophkeeper/proto/binary.proto)
+type BinaryServer struct {
+	pb.UnimplementedBinaryServer
+	binaries usecase.IBinaryService
+}
+
+// NewBinaryServer создаёт объект BinaryServer.
+func NewBinaryServer(binaries usecase.IBinaryService) *BinaryServer {
+	return &BinaryServer{
+		binaries: binaries,
+	}
+}
+
+// GetAll - получение страницы списка описаний файлов пользователя.
+func (s *BinaryServer) GetAll(ctx context.Context, req *pb.GetAllBinariesRequest) (*pb.GetAllBinariesResponse, error) {
+	var resp pb.GetAllBinariesResponse
+
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return nil, status.Error(codes.Aborted, "missing user_id")
+	}
+
+	binaries, next, err := s.binaries.ViewBinaries(ctx, userID, pageFromMsg(req.GetPage()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	for _, binary := range binaries {
+		resp.Binaries = append(resp.Binaries, binaryToMsg(binary))
+	}
+
+	resp.NextPageToken = next
+
+	return &resp, nil
+}
+
+// Upload - загрузка нового файла на сервер (клиентский поток).
+//
+// Первое сообщение потока содержит описание файла, последующие - его содержимое.
+func (s *BinaryServer) Upload(stream pb.Binary_UploadServer) error {
+	ctx := stream.Context()
+
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return status.Error(codes.Aborted, "missing user_id")
+	}
+
+	var binary entity.BinaryDTO
+	var data bytes.Buffer
+
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		switch msg := req.GetData().(type) {
+		case *pb.UploadBinaryRequest_Info:
+			binary.Filename = msg.Info.GetFilename()
+			binary.Size = msg.Info.GetSize()
+			binary.Metadata = msg.Info.GetMetadata()
+			binary.Labels = labelsFromMsg(msg.Info.GetFolderId(), msg.Info.GetTagIds())
+		case *pb.UploadBinaryRequest_Chunk:
+			if data.Len()+len(msg.Chunk) > s.binaries.MaxStoredSize() {
+				return statusError(usecase.ErrBinaryTooLarge)
+			}
+			data.Write(msg.Chunk)
+		}
+	}
+
+	if binary.Filename == "" {
+		return status.Error(codes.InvalidArgument, "missing file info")
+	}
+	// зашифрованный файл не меньше исходного
+	if binary.Size < 0 || binary.Size > int64(data.Len()) {
+		return status.Error(codes.InvalidArgument, "invalid file size")
+	}
+
+	binary.Data = data.Bytes()
+	id, err := s.binaries.CreateBinary(ctx, userID, binary)
+	if err != nil {
+		return statusError(err)
+	}
+
+	return stream.SendAndClose(&pb.UploadBinaryResponse{Id: int64(id)})
+}
+
+// Download - выгрузка файла с сервера (серверный поток).
+//
+// Первое сообщение потока содержит описание файла, последующие - его содержимое.
+func (s *BinaryServer) Download(req *pb.DownloadBinaryRequest, stream pb.Binary_DownloadServer) error {
+	ctx := stream.Context()
+
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return status.Error(codes.Aborted, "missing user_id")
+	}
+
+	binary, err := s.binaries.GetBinary(ctx, userID, int(req.GetId()))
+	if err != nil {
+		return statusError(err)
+	}
+
+	err = stream.Send(&pb.DownloadBinaryResponse{
+		Data: &pb.DownloadBinaryResponse_Info{
+			Info: &pb.BinaryMsg{
+				Id:       int64(binary.ID),
+				Filename: binary.Filename,
+				Size:     binary.Size,
+				Metadata: binary.Metadata,
+				FolderId: int64(binary.FolderID),
+				TagIds:   tagIDsToMsg(binary.TagIDs),
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	for start := 0; start < len(binary.Data); start += chunkSize {
+		end := start + chunkSize
+		if end > len(binary.Data) {
+			end = len(binary.Data)
+		}
+
+		err = stream.Send(&pb.DownloadBinaryResponse{
+			Data: &pb.DownloadBinaryResponse_Chunk{Chunk: binary.Data[start:end]},
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Delete - удаление файла.
+func (s *BinaryServer) Delete(ctx context.Context, req *pb.DeleteBinaryRequest) (*pb.DeleteBinaryResponse, error) {
+	var resp pb.DeleteBinaryResponse
+
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return nil, status.Error(codes.Aborted, "missing user_id")
+	}
+
+	if err := s.binaries.DeleteBinary(ctx, userID, int(req.GetId())); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &resp, nil
+}
+
+func binaryToMsg(binary entity.BinaryDTO) *pb.BinaryMsg {
+	return &pb.BinaryMsg{
+		Id:       int64(binary.ID),
+		Filename: binary.Filename,
+		Size:     binary.Size,
+		Metadata: binary.Metadata,
+		FolderId: int64(binary.FolderID),
+		TagIds:   tagIDsToMsg(binary.TagIDs),
+	}
+}
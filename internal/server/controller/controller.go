@@ -3,75 +3,280 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net"
+	"sync"
+	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/metadata"
-	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/reflection"
 
 	"github.com/PaulYakow/gophkeeper/cmd/server/config"
+	"github.com/PaulYakow/gophkeeper/internal/entity"
 	"github.com/PaulYakow/gophkeeper/internal/server/usecase"
+	"github.com/PaulYakow/gophkeeper/internal/utils/tlsconfig"
+	tokenPkg "github.com/PaulYakow/gophkeeper/internal/utils/token"
 	"github.com/PaulYakow/gophkeeper/pkg/logger"
 	pb "github.com/PaulYakow/gophkeeper/proto"
 )
 
 type ctxKey string
 
-const userIDKey ctxKey = "user_id"
+const (
+	userIDKey    ctxKey = "user_id"
+	sessionIDKey ctxKey = "session_id"
+)
+
+// publicMethods методы, доступные без access-токена.
+var publicMethods = map[string]bool{
+	"/proto.User/Register": true,
+	"/proto.User/Login":    true,
+	// завершение входа по токену незавершённого входа
+	"/proto.User/VerifySecondFactor": true,
+	"/proto.User/Refresh":            true,
+	// смена пароля проверяет текущий пароль (в том числе, если срок его действия истёк)
+	"/proto.User/ChangePassword": true,
+	"/proto.Info/Info":           true,
+	// проверка состояния и описание API (reflection) - для балансировщиков и инструментов отладки
+	"/grpc.health.v1.Health/Check":                                   true,
+	"/grpc.health.v1.Health/Watch":                                   true,
+	"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo": true,
+}
 
+// healthCheckInterval период проверки доступности хранилища для сервиса проверки состояния.
+const healthCheckInterval = 5 * time.Second
+
+// Controller gRPC-сервер: запуск (Start) и остановка с завершением выполняющихся запросов (Shutdown).
 type Controller struct {
 	service usecase.IService
 	logger  logger.ILogger
 	port    string
+	tls     config.TLS
+
+	reflection  bool
+	info        entity.ServerInfoDTO
+	healthCheck func(ctx context.Context) error
+
+	grpcSrv *grpc.Server
+	health  *health.Server
+	notify  chan error
+	done    chan struct{}
+	stop    sync.Once
 }
 
-// New создаёт объект Controller.
-func New(service usecase.IService, l logger.ILogger, cfg *config.Config) *Controller {
-	return &Controller{
-		service: service,
-		logger:  l,
-		port:    ":" + cfg.GRPC.Port,
+// New создаёт объект Controller с заданными настройками.
+func New(service usecase.IService, l logger.ILogger, cfg *config.Config, opts ...Option) *Controller {
+	c := &Controller{
+		service:    service,
+		logger:     l,
+		port:       ":" + cfg.GRPC.Port,
+		tls:        cfg.TLS,
+		reflection: cfg.GRPC.Reflection,
+		info:       entity.ServerInfoDTO{Version: cfg.App.Version},
+		notify:     make(chan error, 1),
+		done:       make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(c)
 	}
+
+	return c
 }
 
-// Run - запуск gRPC-сервера.
-func (c *Controller) Run() {
+// Start - запуск gRPC-сервера.
+//
+// Ошибки подготовки (сертификаты, порт) возвращаются сразу, ошибка приёма запросов - через Notify.
+func (c *Controller) Start() error {
+	creds, err := c.credentials()
+	if err != nil {
+		return fmt.Errorf("gRPC - credentials: %w", err)
+	}
+
+	listen, err := net.Listen("tcp", c.port)
+	if err != nil {
+		return fmt.Errorf("gRPC - net.Listen: %w", err)
+	}
+
+	// создаём gRPC-сервер
+	c.grpcSrv = grpc.NewServer(
+		grpc.Creds(creds),
+		grpc.ChainUnaryInterceptor(c.unaryInterceptors()...),
+		grpc.ChainStreamInterceptor(c.streamInterceptors()...),
+	)
+
+	// регистрируем сервисы
+	pb.RegisterUserServer(c.grpcSrv, NewUserServer(c.service, c.service))
+	pb.RegisterPairServer(c.grpcSrv, NewPairsServer(c.service))
+	pb.RegisterBankServer(c.grpcSrv, NewBankServer(c.service))
+	pb.RegisterTextServer(c.grpcSrv, NewTextServer(c.service))
+	pb.RegisterBinaryServer(c.grpcSrv, NewBinaryServer(c.service))
+	pb.RegisterOTPServer(c.grpcSrv, NewOTPServer(c.service))
+	pb.RegisterSyncServer(c.grpcSrv, NewSyncServer(c.service))
+	pb.RegisterLabelsServer(c.grpcSrv, NewLabelsServer(c.service))
+	pb.RegisterInfoServer(c.grpcSrv, NewInfoServer(c.info))
+
+	// проверка состояния (статус зависит от доступности хранилища)
+	c.health = health.NewServer()
+	healthpb.RegisterHealthServer(c.grpcSrv, c.health)
+	c.checkHealth()
+	go c.monitorHealth()
+
+	if c.reflection {
+		reflection.Register(c.grpcSrv)
+		c.logger.Warn("gRPC - server reflection enabled")
+	}
+
+	c.logger.Info("gRPC run: %s", c.port)
+
+	// получаем запросы gRPC
 	go func() {
-		listen, err := net.Listen("tcp", c.port)
-		if err != nil {
-			c.logger.Fatal(fmt.Errorf("gRPC - net.Listen: %w", err))
+		if err := c.grpcSrv.Serve(listen); err != nil {
+			c.notify <- fmt.Errorf("gRPC - Serve: %w", err)
 		}
+		close(c.notify)
+	}()
 
-		// создаём gRPC-сервер
-		grpcSrv := grpc.NewServer(grpc.UnaryInterceptor(c.userIdentity))
+	return nil
+}
 
-		// регистрируем сервисы
-		pb.RegisterUserServer(grpcSrv, NewUserServer(c.service))
-		pb.RegisterPairServer(grpcSrv, NewPairsServer(c.service))
-		pb.RegisterBankServer(grpcSrv, NewBankServer(c.service))
-		pb.RegisterTextServer(grpcSrv, NewTextServer(c.service))
+// Notify возвращает канал ошибки приёма запросов (канал закрывается после остановки сервера).
+func (c *Controller) Notify() <-chan error {
+	return c.notify
+}
 
-		c.logger.Info("gRPC run: %s", c.port)
+// Shutdown останавливает приём новых запросов и ожидает завершения выполняющихся.
+// Повторный вызов ожидает завершения остановки.
+//
+// Если запросы не завершились до отмены ctx, они прерываются (Stop) и возвращается ошибка ctx.
+func (c *Controller) Shutdown(ctx context.Context) error {
+	if c.grpcSrv == nil {
+		return nil
+	}
 
-		// получаем запрос gRPC
-		if err = grpcSrv.Serve(listen); err != nil {
-			c.logger.Fatal(fmt.Errorf("gRPC - Serve: %w", err))
-		}
+	// клиенты проверки состояния узнают об остановке до завершения запросов
+	c.stop.Do(func() {
+		close(c.done)
+		c.health.Shutdown()
+	})
+
+	stopped := make(chan struct{})
+	go func() {
+		c.grpcSrv.GracefulStop()
+		close(stopped)
 	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		c.grpcSrv.Stop()
+		<-stopped
+		return fmt.Errorf("gRPC - graceful stop: %w", ctx.Err())
+	}
+}
+
+// Периодическая проверка доступности хранилища (до остановки сервера).
+func (c *Controller) monitorHealth() {
+	ticker := time.NewTicker(healthCheckInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			c.checkHealth()
+		}
+	}
 }
 
-// Идентификация пользователя.
+// Обновление статуса сервиса проверки состояния для сервера в целом ("") и всех его сервисов.
+func (c *Controller) checkHealth() {
+	serving := healthpb.HealthCheckResponse_SERVING
+	if c.healthCheck != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), healthCheckInterval)
+		err := c.healthCheck(ctx)
+		cancel()
+
+		if err != nil {
+			c.logger.Error(fmt.Errorf("gRPC - health check: %w", err))
+			serving = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+	}
+
+	c.health.SetServingStatus("", serving)
+	for name := range c.grpcSrv.GetServiceInfo() {
+		c.health.SetServingStatus(name, serving)
+	}
+}
+
+// Формирование параметров защиты соединения (TLS/mTLS, без шифрования - только при явном Insecure).
+func (c *Controller) credentials() (credentials.TransportCredentials, error) {
+	if c.tls.Insecure {
+		c.logger.Warn("gRPC - TLS disabled (insecure mode): data is transferred in plaintext")
+		return insecure.NewCredentials(), nil
+	}
+
+	cfg, err := tlsconfig.Server(c.tls.CertFile, c.tls.KeyFile, c.tls.ClientCAFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.tls.ClientCAFile != "" {
+		c.logger.Info("gRPC - mutual TLS enabled")
+	}
+
+	return credentials.NewTLS(cfg), nil
+}
+
+// Идентификация пользователя (кроме публичных методов).
 func (c *Controller) userIdentity(ctx context.Context,
 	req interface{},
 	info *grpc.UnaryServerInfo,
 	handler grpc.UnaryHandler,
 ) (interface{}, error) {
-	if _, ok := info.Server.(*UserServer); ok {
+	if publicMethods[info.FullMethod] {
 		return handler(ctx, req)
 	}
 
+	ctx, err := c.identify(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return handler(ctx, req)
+}
+
+// Идентификация пользователя для потоковых запросов.
+func (c *Controller) streamUserIdentity(srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	if publicMethods[info.FullMethod] {
+		return handler(srv, ss)
+	}
+
+	ctx, err := c.identify(ss.Context())
+	if err != nil {
+		return err
+	}
+
+	return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+}
+
+// Проверка токена из метаданных запроса.
+//
+// Возвращает контекст, содержащий id пользователя и id его сессии.
+func (c *Controller) identify(ctx context.Context) (context.Context, error) {
 	var token string
 	if md, ok := metadata.FromIncomingContext(ctx); ok {
 		values := md.Get("token")
@@ -82,16 +287,23 @@ func (c *Controller) userIdentity(ctx context.Context,
 
 	if token == "" {
 		c.logger.Error(fmt.Errorf("missing token in metadata"))
-		return nil, status.Error(codes.FailedPrecondition, "missing token")
+		return nil, newStatus(codes.FailedPrecondition, pb.ErrorReason_TOKEN_MISSING, "missing token")
 	}
 
-	userID, err := c.service.ParseToken(token)
+	userID, sessionID, err := c.service.ParseToken(ctx, token)
 	if err != nil {
 		c.logger.Error(fmt.Errorf("user identity: %w", err))
-		return nil, status.Error(codes.Aborted, "user identity error")
+		if errors.Is(err, tokenPkg.ErrExpiredToken) {
+			return nil, newStatus(codes.Unauthenticated, pb.ErrorReason_TOKEN_EXPIRED, err.Error())
+		}
+		return nil, newStatus(codes.Unauthenticated, pb.ErrorReason_SESSION_INVALID, "user identity error")
 	}
 
-	ctx = context.WithValue(ctx, userIDKey, userID)
+	// id пользователя - для журнала запросов
+	if entry, ok := ctx.Value(accessKey).(*accessEntry); ok {
+		entry.userID = userID
+	}
 
-	return handler(ctx, req)
+	ctx = context.WithValue(ctx, userIDKey, userID)
+	return context.WithValue(ctx, sessionIDKey, sessionID), nil
 }
@@ -6,15 +6,26 @@ import (
 	"log"
 	"net"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
 	"google.golang.org/grpc/test/bufconn"
 
+	"github.com/PaulYakow/gophkeeper/cmd/server/config"
+	"github.com/PaulYakow/gophkeeper/internal/entity"
 	"github.com/PaulYakow/gophkeeper/internal/server/controller"
 	"github.com/PaulYakow/gophkeeper/internal/server/mocks"
+	"github.com/PaulYakow/gophkeeper/internal/server/usecase"
+	"github.com/PaulYakow/gophkeeper/internal/server/usecase/repo"
+	"github.com/PaulYakow/gophkeeper/pkg/logger"
 	pb "github.com/PaulYakow/gophkeeper/proto"
 )
 
@@ -37,7 +48,7 @@ func dialer() func(context.Context, string) (net.Conn, error) {
 	server := grpc.NewServer()
 
 	grpcMock.service = mocks.NewMockIService(grpcMock.ctrl)
-	pb.RegisterUserServer(server, controller.NewUserServer(grpcMock.service))
+	pb.RegisterUserServer(server, controller.NewUserServer(grpcMock.service, grpcMock.service))
 
 	go func() {
 		if err := server.Serve(listener); err != nil {
@@ -50,6 +61,16 @@ func dialer() func(context.Context, string) (net.Conn, error) {
 	}
 }
 
+// errorReason возвращает причину ошибки из деталей статуса (errdetails.ErrorInfo).
+func errorReason(err error) string {
+	for _, detail := range status.Convert(err).Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.GetReason()
+		}
+	}
+	return ""
+}
+
 func TestRegister(t *testing.T) {
 	mockHelper(t)
 	defer grpcMock.ctrl.Finish()
@@ -64,23 +85,46 @@ func TestRegister(t *testing.T) {
 	client := pb.NewUserClient(conn)
 
 	login, password := "user", "password"
-	token := "token"
+	tokens := entity.TokensDTO{AccessToken: "token", RefreshToken: "session.secret", ExpiresIn: 15 * time.Minute}
 
 	t.Run("proper register", func(t *testing.T) {
-		grpcMock.service.EXPECT().RegisterUser(login, password).Return(token, nil)
+		grpcMock.service.EXPECT().RegisterUser(gomock.Any(), login, password).Return(tokens, nil)
 		resp, err := client.Register(ctx, &pb.RegisterRequest{Login: login, Password: password})
 		require.NoError(t, err)
-		require.Equal(t, token, resp.Token)
+		require.Equal(t, tokens.AccessToken, resp.Token)
+		require.Equal(t, tokens.RefreshToken, resp.RefreshToken)
+		require.Equal(t, int64(900), resp.ExpiresIn)
 		require.Empty(t, resp.Error)
 	})
 
 	t.Run("fail register", func(t *testing.T) {
 		errFail := errors.New("fail")
-		grpcMock.service.EXPECT().RegisterUser(login, password).Return("", errFail)
+		grpcMock.service.EXPECT().RegisterUser(gomock.Any(), login, password).Return(entity.TokensDTO{}, errFail)
 		resp, err := client.Register(ctx, &pb.RegisterRequest{Login: login, Password: password})
 		require.Error(t, err)
 		require.Empty(t, resp)
 	})
+
+	t.Run("login exists", func(t *testing.T) {
+		grpcMock.service.EXPECT().RegisterUser(gomock.Any(), login, password).Return(entity.TokensDTO{}, repo.ErrUserExist)
+		_, err := client.Register(ctx, &pb.RegisterRequest{Login: login, Password: password})
+		require.Equal(t, codes.AlreadyExists, status.Code(err))
+		require.Equal(t, pb.ErrorReason_USER_EXISTS.String(), errorReason(err))
+	})
+
+	t.Run("request deadline reaches service", func(t *testing.T) {
+		reqCtx, cancel := context.WithTimeout(ctx, time.Minute)
+		defer cancel()
+
+		grpcMock.service.EXPECT().RegisterUser(gomock.Any(), login, password).
+			DoAndReturn(func(ctx context.Context, _, _ string) (entity.TokensDTO, error) {
+				_, ok := ctx.Deadline()
+				require.True(t, ok)
+				return tokens, nil
+			})
+		_, err := client.Register(reqCtx, &pb.RegisterRequest{Login: login, Password: password})
+		require.NoError(t, err)
+	})
 }
 
 func TestLogin(t *testing.T) {
@@ -97,21 +141,382 @@ func TestLogin(t *testing.T) {
 	client := pb.NewUserClient(conn)
 
 	login, password := "user", "password"
-	token := "token"
+	tokens := entity.TokensDTO{AccessToken: "token", RefreshToken: "session.secret", ExpiresIn: 15 * time.Minute}
 
 	t.Run("proper login", func(t *testing.T) {
-		grpcMock.service.EXPECT().LoginUser(login, password).Return(token, nil)
+		grpcMock.service.EXPECT().LoginUser(gomock.Any(), login, password, gomock.Any()).Return(tokens, nil)
 		resp, err := client.Login(ctx, &pb.LoginRequest{Login: login, Password: password})
 		require.NoError(t, err)
-		require.Equal(t, token, resp.Token)
+		require.Equal(t, tokens.AccessToken, resp.Token)
+		require.Equal(t, tokens.RefreshToken, resp.RefreshToken)
+		require.Equal(t, int64(900), resp.ExpiresIn)
 		require.Empty(t, resp.Error)
 	})
 
+	t.Run("password expired", func(t *testing.T) {
+		grpcMock.service.EXPECT().LoginUser(gomock.Any(), login, password, gomock.Any()).Return(entity.TokensDTO{}, usecase.ErrPasswordExpired)
+		resp, err := client.Login(ctx, &pb.LoginRequest{Login: login, Password: password})
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+		require.Equal(t, pb.ErrorReason_PASSWORD_EXPIRED.String(), errorReason(err))
+		require.Empty(t, resp)
+	})
+
+	t.Run("invalid credentials", func(t *testing.T) {
+		grpcMock.service.EXPECT().LoginUser(gomock.Any(), login, password, gomock.Any()).Return(entity.TokensDTO{}, usecase.ErrInvalidCredentials)
+		resp, err := client.Login(ctx, &pb.LoginRequest{Login: login, Password: password})
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+		require.Equal(t, pb.ErrorReason_INVALID_CREDENTIALS.String(), errorReason(err))
+		require.Empty(t, resp)
+	})
+
+	t.Run("too many attempts", func(t *testing.T) {
+		grpcMock.service.EXPECT().LoginUser(gomock.Any(), login, password, gomock.Any()).Return(entity.TokensDTO{}, usecase.ErrTooManyAttempts)
+		resp, err := client.Login(ctx, &pb.LoginRequest{Login: login, Password: password})
+		require.Equal(t, codes.ResourceExhausted, status.Code(err))
+		require.Equal(t, pb.ErrorReason_TOO_MANY_ATTEMPTS.String(), errorReason(err))
+		require.Empty(t, resp)
+	})
+
 	t.Run("fail login", func(t *testing.T) {
 		errFail := errors.New("fail")
-		grpcMock.service.EXPECT().LoginUser(login, password).Return("", errFail)
+		grpcMock.service.EXPECT().LoginUser(gomock.Any(), login, password, gomock.Any()).Return(entity.TokensDTO{}, errFail)
 		resp, err := client.Login(ctx, &pb.LoginRequest{Login: login, Password: password})
 		require.Error(t, err)
 		require.Empty(t, resp)
 	})
 }
+
+func TestRefresh(t *testing.T) {
+	mockHelper(t)
+	defer grpcMock.ctrl.Finish()
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(dialer()), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewUserClient(conn)
+
+	refreshToken := "session.secret"
+	tokens := entity.TokensDTO{AccessToken: "token", RefreshToken: "session.new_secret", ExpiresIn: 15 * time.Minute}
+
+	t.Run("proper refresh", func(t *testing.T) {
+		grpcMock.service.EXPECT().Refresh(gomock.Any(), refreshToken).Return(tokens, nil)
+		resp, err := client.Refresh(ctx, &pb.RefreshRequest{RefreshToken: refreshToken})
+		require.NoError(t, err)
+		require.Equal(t, tokens.AccessToken, resp.Token)
+		require.Equal(t, tokens.RefreshToken, resp.RefreshToken)
+	})
+
+	t.Run("invalid session", func(t *testing.T) {
+		grpcMock.service.EXPECT().Refresh(gomock.Any(), refreshToken).Return(entity.TokensDTO{}, usecase.ErrInvalidSession)
+		resp, err := client.Refresh(ctx, &pb.RefreshRequest{RefreshToken: refreshToken})
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+		require.Equal(t, pb.ErrorReason_SESSION_INVALID.String(), errorReason(err))
+		require.Empty(t, resp)
+	})
+
+	t.Run("unexpected error is hidden", func(t *testing.T) {
+		grpcMock.service.EXPECT().Refresh(gomock.Any(), refreshToken).Return(entity.TokensDTO{}, errors.New("connection reset"))
+		_, err := client.Refresh(ctx, &pb.RefreshRequest{RefreshToken: refreshToken})
+		require.Equal(t, codes.Internal, status.Code(err))
+		require.Equal(t, pb.ErrorReason_INTERNAL.String(), errorReason(err))
+		require.NotContains(t, err.Error(), "connection reset")
+	})
+}
+
+func TestChangePassword(t *testing.T) {
+	mockHelper(t)
+	defer grpcMock.ctrl.Finish()
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(dialer()), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewUserClient(conn)
+
+	req := &pb.ChangePasswordRequest{Login: "user", Password: "password", NewPassword: "new_password"}
+	tokens := entity.TokensDTO{AccessToken: "token", RefreshToken: "session.secret", ExpiresIn: 15 * time.Minute}
+
+	t.Run("proper change password", func(t *testing.T) {
+		grpcMock.service.EXPECT().ChangePassword(gomock.Any(), req.Login, req.Password, req.NewPassword, req.Code, gomock.Any()).Return(tokens, nil)
+		resp, err := client.ChangePassword(ctx, req)
+		require.NoError(t, err)
+		require.Equal(t, tokens.AccessToken, resp.Token)
+		require.Equal(t, tokens.RefreshToken, resp.RefreshToken)
+	})
+
+	t.Run("password reused", func(t *testing.T) {
+		grpcMock.service.EXPECT().ChangePassword(gomock.Any(), req.Login, req.Password, req.NewPassword, req.Code, gomock.Any()).Return(entity.TokensDTO{}, usecase.ErrPasswordReused)
+		resp, err := client.ChangePassword(ctx, req)
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+		require.Equal(t, pb.ErrorReason_PASSWORD_REUSED.String(), errorReason(err))
+		require.Empty(t, resp)
+	})
+
+	t.Run("one-time code required", func(t *testing.T) {
+		grpcMock.service.EXPECT().ChangePassword(gomock.Any(), req.Login, req.Password, req.NewPassword, req.Code, gomock.Any()).Return(entity.TokensDTO{}, usecase.ErrSecondFactorRequired)
+		resp, err := client.ChangePassword(ctx, req)
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+		require.Equal(t, pb.ErrorReason_SECOND_FACTOR_REQUIRED.String(), errorReason(err))
+		require.Empty(t, resp)
+	})
+}
+
+func TestVerifySecondFactor(t *testing.T) {
+	mockHelper(t)
+	defer grpcMock.ctrl.Finish()
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet", grpc.WithContextDialer(dialer()), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatalf("Failed to dial bufnet: %v", err)
+	}
+	defer conn.Close()
+
+	client := pb.NewUserClient(conn)
+
+	login, password, challenge := "user", "password", "challenge.secret"
+	tokens := entity.TokensDTO{AccessToken: "token", RefreshToken: "session.secret", ExpiresIn: 15 * time.Minute}
+
+	t.Run("login returns challenge", func(t *testing.T) {
+		grpcMock.service.EXPECT().LoginUser(gomock.Any(), login, password, gomock.Any()).Return(entity.TokensDTO{Challenge: challenge}, nil)
+		resp, err := client.Login(ctx, &pb.LoginRequest{Login: login, Password: password})
+		require.NoError(t, err)
+		require.Equal(t, challenge, resp.Challenge)
+		require.Empty(t, resp.Token)
+		require.Empty(t, resp.RefreshToken)
+	})
+
+	t.Run("proper code", func(t *testing.T) {
+		grpcMock.service.EXPECT().VerifySecondFactor(gomock.Any(), challenge, "123456", gomock.Any()).Return(tokens, nil)
+		resp, err := client.VerifySecondFactor(ctx, &pb.VerifySecondFactorRequest{Challenge: challenge, Code: "123456"})
+		require.NoError(t, err)
+		require.Equal(t, tokens.AccessToken, resp.Token)
+		require.Equal(t, tokens.RefreshToken, resp.RefreshToken)
+	})
+
+	t.Run("invalid code", func(t *testing.T) {
+		grpcMock.service.EXPECT().VerifySecondFactor(gomock.Any(), challenge, "000000", gomock.Any()).Return(entity.TokensDTO{}, usecase.ErrInvalidCode)
+		resp, err := client.VerifySecondFactor(ctx, &pb.VerifySecondFactorRequest{Challenge: challenge, Code: "000000"})
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+		require.Equal(t, pb.ErrorReason_INVALID_CODE.String(), errorReason(err))
+		require.Empty(t, resp)
+	})
+
+	t.Run("invalid challenge", func(t *testing.T) {
+		grpcMock.service.EXPECT().VerifySecondFactor(gomock.Any(), "bad", "123456", gomock.Any()).Return(entity.TokensDTO{}, usecase.ErrInvalidChallenge)
+		resp, err := client.VerifySecondFactor(ctx, &pb.VerifySecondFactorRequest{Challenge: "bad", Code: "123456"})
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+		require.Empty(t, resp)
+	})
+}
+
+// startServer запускает сервер (без TLS) на свободном порту и возвращает подключение к нему.
+func startServer(t *testing.T, service usecase.IService, cfg *config.Config, opts ...controller.Option) (*controller.Controller, *grpc.ClientConn) {
+	t.Helper()
+
+	listen, err := net.Listen("tcp", "localhost:0")
+	require.NoError(t, err)
+	_, port, err := net.SplitHostPort(listen.Addr().String())
+	require.NoError(t, err)
+	require.NoError(t, listen.Close())
+
+	cfg.GRPC.Port = port
+	cfg.TLS.Insecure = true
+
+	srv := controller.New(service, logger.New("test"), cfg, opts...)
+	require.NoError(t, srv.Start())
+
+	conn, err := grpc.Dial("localhost:"+port, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return srv, conn
+}
+
+func TestShutdown(t *testing.T) {
+	mockHelper(t)
+	defer grpcMock.ctrl.Finish()
+
+	service := mocks.NewMockIService(grpcMock.ctrl)
+
+	start := func(t *testing.T) (*controller.Controller, pb.UserClient) {
+		srv, conn := startServer(t, service, &config.Config{})
+		return srv, pb.NewUserClient(conn)
+	}
+
+	login, password := "user", "password"
+	tokens := entity.TokensDTO{AccessToken: "token", RefreshToken: "session.secret", ExpiresIn: 15 * time.Minute}
+
+	t.Run("in-flight request completes", func(t *testing.T) {
+		srv, client := start(t)
+
+		started, release := make(chan struct{}), make(chan struct{})
+		service.EXPECT().RegisterUser(gomock.Any(), login, password).
+			DoAndReturn(func(context.Context, string, string) (entity.TokensDTO, error) {
+				close(started)
+				<-release
+				return tokens, nil
+			})
+
+		result := make(chan error, 1)
+		go func() {
+			_, err := client.Register(context.Background(), &pb.RegisterRequest{Login: login, Password: password})
+			result <- err
+		}()
+		<-started
+
+		shutdown := make(chan error, 1)
+		go func() {
+			shutdown <- srv.Shutdown(context.Background())
+		}()
+
+		// сервер ждёт завершения запроса
+		select {
+		case err := <-shutdown:
+			t.Fatalf("shutdown before request completed: %v", err)
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		close(release)
+		require.NoError(t, <-result)
+		require.NoError(t, <-shutdown)
+
+		_, open := <-srv.Notify()
+		require.False(t, open)
+	})
+
+	t.Run("drain timeout", func(t *testing.T) {
+		srv, client := start(t)
+
+		started := make(chan struct{})
+		service.EXPECT().RegisterUser(gomock.Any(), login, password).
+			DoAndReturn(func(ctx context.Context, _, _ string) (entity.TokensDTO, error) {
+				close(started)
+				<-ctx.Done()
+				return entity.TokensDTO{}, ctx.Err()
+			})
+
+		result := make(chan error, 1)
+		go func() {
+			_, err := client.Register(context.Background(), &pb.RegisterRequest{Login: login, Password: password})
+			result <- err
+		}()
+		<-started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		err := srv.Shutdown(ctx)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Error(t, <-result)
+	})
+}
+
+func TestInfoAndHealth(t *testing.T) {
+	mockHelper(t)
+	defer grpcMock.ctrl.Finish()
+
+	service := mocks.NewMockIService(grpcMock.ctrl)
+	ctx := context.Background()
+
+	cfg := &config.Config{}
+	cfg.App.Version = "1.2.3"
+	cfg.GRPC.Reflection = true
+
+	info := entity.ServerInfoDTO{
+		Version:   "1.2.3",
+		BuildTime: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+		Features:  []string{"sync", "otp"},
+	}
+
+	var storageErr error
+	check := func(context.Context) error { return storageErr }
+
+	srv, conn := startServer(t, service, cfg, controller.Info(info), controller.HealthCheck(check))
+	defer srv.Shutdown(ctx)
+
+	t.Run("info without token", func(t *testing.T) {
+		resp, err := pb.NewInfoClient(conn).Info(ctx, &pb.InfoRequest{})
+		require.NoError(t, err)
+		require.Equal(t, info.Version, resp.GetVersion())
+		require.Equal(t, info.BuildTime.Unix(), resp.GetBuildTime())
+		require.Equal(t, info.Features, resp.GetFeatures())
+	})
+
+	t.Run("health serving", func(t *testing.T) {
+		for _, name := range []string{"", "proto.User"} {
+			resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: name})
+			require.NoError(t, err)
+			require.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+		}
+	})
+
+	t.Run("reflection", func(t *testing.T) {
+		stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+		require.NoError(t, err)
+		require.NoError(t, stream.Send(&reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+		}))
+		resp, err := stream.Recv()
+		require.NoError(t, err)
+
+		var names []string
+		for _, s := range resp.GetListServicesResponse().GetService() {
+			names = append(names, s.GetName())
+		}
+		require.Contains(t, names, "proto.Info")
+		require.NoError(t, stream.CloseSend())
+	})
+
+	t.Run("shutdown not serving", func(t *testing.T) {
+		// открытый поток Watch задерживает остановку - клиент закрывает его после NOT_SERVING
+		watchCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		watch, err := healthpb.NewHealthClient(conn).Watch(watchCtx, &healthpb.HealthCheckRequest{})
+		require.NoError(t, err)
+		resp, err := watch.Recv()
+		require.NoError(t, err)
+		require.Equal(t, healthpb.HealthCheckResponse_SERVING, resp.GetStatus())
+
+		shutdown := make(chan error, 1)
+		go func() {
+			shutdown <- srv.Shutdown(ctx)
+		}()
+
+		resp, err = watch.Recv()
+		require.NoError(t, err)
+		require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.GetStatus())
+
+		cancel()
+		require.NoError(t, <-shutdown)
+	})
+}
+
+func TestHealthStorageUnavailable(t *testing.T) {
+	mockHelper(t)
+	defer grpcMock.ctrl.Finish()
+
+	ctx := context.Background()
+	check := func(context.Context) error { return errors.New("connection refused") }
+
+	srv, conn := startServer(t, mocks.NewMockIService(grpcMock.ctrl), &config.Config{}, controller.HealthCheck(check))
+	defer srv.Shutdown(ctx)
+
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	require.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, resp.GetStatus())
+
+	// reflection выключена по умолчанию
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	require.Equal(t, codes.Unimplemented, status.Code(err))
+}
@@ -0,0 +1,97 @@
+package controller
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/PaulYakow/gophkeeper/internal/server/usecase"
+	"github.com/PaulYakow/gophkeeper/internal/server/usecase/repo"
+	pb "github.com/PaulYakow/gophkeeper/proto"
+)
+
+// errorDomain домен ошибок сервера (google.rpc.ErrorInfo.domain).
+const errorDomain = "gophkeeper"
+
+// domainErrors соответствие ошибок сервисов и хранилища статусам gRPC и причинам (pb.ErrorReason).
+var domainErrors = []struct {
+	err    error
+	code   codes.Code
+	reason pb.ErrorReason
+}{
+	{repo.ErrUserExist, codes.AlreadyExists, pb.ErrorReason_USER_EXISTS},
+	{repo.ErrNotFound, codes.NotFound, pb.ErrorReason_NOT_FOUND},
+	{usecase.ErrInvalidCredentials, codes.Unauthenticated, pb.ErrorReason_INVALID_CREDENTIALS},
+	{usecase.ErrMismatchPassword, codes.PermissionDenied, pb.ErrorReason_PASSWORD_MISMATCH},
+	{usecase.ErrPasswordExpired, codes.FailedPrecondition, pb.ErrorReason_PASSWORD_EXPIRED},
+	{usecase.ErrPasswordReused, codes.InvalidArgument, pb.ErrorReason_PASSWORD_REUSED},
+	{usecase.ErrTooManyAttempts, codes.ResourceExhausted, pb.ErrorReason_TOO_MANY_ATTEMPTS},
+	{usecase.ErrSecondFactorRequired, codes.FailedPrecondition, pb.ErrorReason_SECOND_FACTOR_REQUIRED},
+	{usecase.ErrInvalidCode, codes.InvalidArgument, pb.ErrorReason_INVALID_CODE},
+	{usecase.ErrInvalidChallenge, codes.Unauthenticated, pb.ErrorReason_INVALID_CHALLENGE},
+	{usecase.ErrTOTPNotEnrolled, codes.FailedPrecondition, pb.ErrorReason_TOTP_NOT_ENROLLED},
+	{usecase.ErrInvalidSession, codes.Unauthenticated, pb.ErrorReason_SESSION_INVALID},
+	{usecase.ErrBinaryTooLarge, codes.ResourceExhausted, pb.ErrorReason_BINARY_TOO_LARGE},
+	{usecase.ErrInvalidOTP, codes.InvalidArgument, pb.ErrorReason_INVALID_OTP},
+	{usecase.ErrInvalidPage, codes.InvalidArgument, pb.ErrorReason_INVALID_PAGE},
+	{usecase.ErrInvalidLabel, codes.InvalidArgument, pb.ErrorReason_INVALID_LABEL},
+}
+
+// statusError преобразует ошибку сервиса в статус gRPC с причиной в деталях (errdetails.ErrorInfo).
+//
+// Отмена запроса и истечение его срока передаются соответствующими кодами. Прочие (неожиданные)
+// ошибки скрываются от клиента: возвращается Internal без подробностей.
+func statusError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if _, ok := err.(interface{ GRPCStatus() *status.Status }); ok {
+		return err
+	}
+
+	for _, e := range domainErrors {
+		if errors.Is(err, e.err) {
+			return newStatus(e.code, e.reason, e.err.Error())
+		}
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, err.Error())
+	}
+
+	return newStatus(codes.Internal, pb.ErrorReason_INTERNAL, "internal error")
+}
+
+// credentialsError преобразует ошибку проверки логина/пароля (и кода при входе) в статус gRPC.
+//
+// Несуществующий логин и неверный пароль дают один и тот же статус (Unauthenticated),
+// неверный код при входе - тоже Unauthenticated (вход не выполнен).
+func credentialsError(err error) error {
+	if errors.Is(err, usecase.ErrInvalidCode) {
+		return newStatus(codes.Unauthenticated, pb.ErrorReason_INVALID_CODE, usecase.ErrInvalidCode.Error())
+	}
+
+	return statusError(err)
+}
+
+// newStatus создаёт ошибку со статусом code, сообщением msg и причиной reason.
+func newStatus(code codes.Code, reason pb.ErrorReason, msg string) error {
+	st := status.New(code, msg)
+
+	detailed, err := st.WithDetails(&errdetails.ErrorInfo{
+		Reason: reason.String(),
+		Domain: errorDomain,
+	})
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
@@ -0,0 +1,35 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	pb "github.com/PaulYakow/gophkeeper/proto"
+)
+
+// InfoServer реализация интерфейса proto.InfoServer (описание - gophkeeper/proto/info.proto)
+type InfoServer struct {
+	pb.UnimplementedInfoServer
+	info entity.ServerInfoDTO
+}
+
+// NewInfoServer создаёт объект InfoServer.
+func NewInfoServer(info entity.ServerInfoDTO) *InfoServer {
+	return &InfoServer{
+		info: info,
+	}
+}
+
+// Info - информация о сервере: версия, время сборки и поддерживаемые возможности.
+func (s *InfoServer) Info(ctx context.Context, req *pb.InfoRequest) (*pb.InfoResponse, error) {
+	resp := &pb.InfoResponse{
+		Version:  s.info.Version,
+		Features: s.info.Features,
+	}
+
+	if !s.info.BuildTime.IsZero() {
+		resp.BuildTime = s.info.BuildTime.Unix()
+	}
+
+	return resp, nil
+}
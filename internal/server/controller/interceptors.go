@@ -0,0 +1,212 @@
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"runtime/debug"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	pb "github.com/PaulYakow/gophkeeper/proto"
+)
+
+const (
+	requestIDKey ctxKey = "request_id"
+	accessKey    ctxKey = "access"
+	// requestIDHeader метаданные запроса и ответа с идентификатором запроса.
+	requestIDHeader = "x-request-id"
+	// maxRequestIDLen максимальная длина идентификатора, переданного клиентом (более длинный заменяется).
+	maxRequestIDLen = 64
+)
+
+// Цепочка перехватчиков запросов (порядок выполнения):
+//   - присвоение идентификатора запроса;
+//   - журнал запросов (метод, пользователь, код ответа, длительность);
+//   - перехват паники обработчика (ответ - Internal);
+//   - идентификация пользователя.
+//
+// Журнал стоит перед перехватом паники и идентификацией, чтобы в него попадали и запросы,
+// завершившиеся паникой или отклонённые при проверке токена.
+func (c *Controller) unaryInterceptors() []grpc.UnaryServerInterceptor {
+	return []grpc.UnaryServerInterceptor{
+		requestIDUnary,
+		c.accessLogUnary,
+		c.recoveryUnary,
+		c.userIdentity,
+	}
+}
+
+// Цепочка перехватчиков потоковых запросов (порядок - как у unaryInterceptors).
+func (c *Controller) streamInterceptors() []grpc.StreamServerInterceptor {
+	return []grpc.StreamServerInterceptor{
+		requestIDStream,
+		c.accessLogStream,
+		c.recoveryStream,
+		c.streamUserIdentity,
+	}
+}
+
+// Присвоение идентификатора запроса: берётся из метаданных клиента (x-request-id) или генерируется.
+//
+// Идентификатор возвращается клиенту в заголовке ответа.
+func requestIDUnary(ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	ctx, id := withRequestID(ctx)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(requestIDHeader, id))
+
+	return handler(ctx, req)
+}
+
+// Присвоение идентификатора потоковому запросу.
+func requestIDStream(srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx, id := withRequestID(ss.Context())
+	_ = ss.SetHeader(metadata.Pairs(requestIDHeader, id))
+
+	return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+}
+
+// Добавление в контекст идентификатора запроса.
+func withRequestID(ctx context.Context) (context.Context, string) {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(requestIDHeader); len(values) > 0 && len(values[0]) <= maxRequestIDLen {
+			id = values[0]
+		}
+	}
+
+	if id == "" {
+		id = newRequestID()
+	}
+
+	return context.WithValue(ctx, requestIDKey, id), id
+}
+
+// Генерация случайного идентификатора запроса.
+func newRequestID() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+
+	return hex.EncodeToString(buf)
+}
+
+// accessEntry данные запроса для журнала, заполняемые следующими перехватчиками (id пользователя).
+type accessEntry struct {
+	userID int
+}
+
+// Журнал запросов.
+func (c *Controller) accessLogUnary(ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (interface{}, error) {
+	entry := &accessEntry{}
+	start := time.Now()
+
+	resp, err := handler(context.WithValue(ctx, accessKey, entry), req)
+	c.logAccess(ctx, info.FullMethod, entry, err, time.Since(start))
+
+	return resp, err
+}
+
+// Журнал потоковых запросов.
+func (c *Controller) accessLogStream(srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	entry := &accessEntry{}
+	start := time.Now()
+
+	ctx := context.WithValue(ss.Context(), accessKey, entry)
+	err := handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	c.logAccess(ss.Context(), info.FullMethod, entry, err, time.Since(start))
+
+	return err
+}
+
+// Запись о запросе: успешные и отменённые клиентом запросы - уровень Info, ошибки - Warn.
+func (c *Controller) logAccess(ctx context.Context, method string, entry *accessEntry, err error, latency time.Duration) {
+	code := status.Code(err)
+	requestID, _ := ctx.Value(requestIDKey).(string)
+
+	l := c.logger.With(
+		"request_id", requestID,
+		"method", method,
+		"user_id", entry.userID,
+		"code", code.String(),
+		"latency", latency,
+	)
+
+	switch code {
+	case codes.OK, codes.Canceled:
+		l.Info("gRPC request")
+	default:
+		l.Warn("gRPC request failed")
+	}
+}
+
+// Перехват паники обработчика: паника записывается в журнал, клиент получает Internal без подробностей.
+func (c *Controller) recoveryUnary(ctx context.Context,
+	req interface{},
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (resp interface{}, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = c.recovered(ctx, info.FullMethod, r)
+		}
+	}()
+
+	return handler(ctx, req)
+}
+
+// Перехват паники обработчика потокового запроса.
+func (c *Controller) recoveryStream(srv interface{},
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = c.recovered(ss.Context(), info.FullMethod, r)
+		}
+	}()
+
+	return handler(srv, ss)
+}
+
+// Запись паники в журнал и формирование ответа клиенту.
+func (c *Controller) recovered(ctx context.Context, method string, r interface{}) error {
+	requestID, _ := ctx.Value(requestIDKey).(string)
+	c.logger.With("request_id", requestID, "method", method).
+		Error(fmt.Errorf("gRPC - panic: %v\n%s", r, debug.Stack()))
+
+	return newStatus(codes.Internal, pb.ErrorReason_INTERNAL, "internal error")
+}
+
+// contextStream подменяет контекст потока.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+// Context возвращает подменённый контекст потока.
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/PaulYakow/gophkeeper/internal/server/mocks"
+	"github.com/PaulYakow/gophkeeper/pkg/logger"
+	pb "github.com/PaulYakow/gophkeeper/proto"
+)
+
+// testLogger сохраняет записи журнала (сообщение и поля) для проверки.
+type testLogger struct {
+	mu      *sync.Mutex
+	entries *[]map[string]interface{}
+	fields  []interface{}
+}
+
+func newTestLogger() *testLogger {
+	return &testLogger{mu: &sync.Mutex{}, entries: &[]map[string]interface{}{}}
+}
+
+func (l *testLogger) add(message string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry := map[string]interface{}{"msg": message}
+	for i := 0; i+1 < len(l.fields); i += 2 {
+		entry[fmt.Sprint(l.fields[i])] = l.fields[i+1]
+	}
+	*l.entries = append(*l.entries, entry)
+}
+
+// find возвращает последнюю запись с сообщением message.
+func (l *testLogger) find(message string) map[string]interface{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for i := len(*l.entries) - 1; i >= 0; i-- {
+		if (*l.entries)[i]["msg"] == message {
+			return (*l.entries)[i]
+		}
+	}
+	return nil
+}
+
+func (l *testLogger) Debug(message string, args ...interface{}) { l.add(fmt.Sprintf(message, args...)) }
+func (l *testLogger) Info(message string, args ...interface{})  { l.add(fmt.Sprintf(message, args...)) }
+func (l *testLogger) Warn(message string, args ...interface{})  { l.add(fmt.Sprintf(message, args...)) }
+func (l *testLogger) Error(message error, args ...interface{})  { l.add("error") }
+func (l *testLogger) Fatal(message error, args ...interface{})  { l.add("fatal") }
+func (l *testLogger) Named(s string) logger.ILogger             { return l }
+
+func (l *testLogger) With(keysAndValues ...interface{}) logger.ILogger {
+	fields := append(append([]interface{}{}, l.fields...), keysAndValues...)
+	return &testLogger{mu: l.mu, entries: l.entries, fields: fields}
+}
+
+// panicUserServer обработчики, завершающиеся паникой.
+type panicUserServer struct {
+	pb.UnimplementedUserServer
+}
+
+func (panicUserServer) Register(context.Context, *pb.RegisterRequest) (*pb.RegisterResponse, error) {
+	panic("register failed")
+}
+
+func (panicUserServer) ExportAccount(*pb.ExportAccountRequest, pb.User_ExportAccountServer) error {
+	panic("export failed")
+}
+
+func TestInterceptors(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	service := mocks.NewMockIService(mockCtrl)
+	l := newTestLogger()
+	c := &Controller{service: service, logger: l}
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(c.unaryInterceptors()...),
+		grpc.ChainStreamInterceptor(c.streamInterceptors()...),
+	)
+	pb.RegisterUserServer(server, panicUserServer{})
+	go func() {
+		if err := server.Serve(listener); err != nil {
+			log.Fatal(err)
+		}
+	}()
+	defer server.Stop()
+
+	ctx := context.Background()
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return listener.Dial()
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := pb.NewUserClient(conn)
+
+	t.Run("unary panic", func(t *testing.T) {
+		var header metadata.MD
+		_, err := client.Register(ctx, &pb.RegisterRequest{Login: "user", Password: "password"}, grpc.Header(&header))
+		require.Equal(t, codes.Internal, status.Code(err))
+		require.NotContains(t, err.Error(), "register failed")
+
+		ids := header.Get(requestIDHeader)
+		require.Len(t, ids, 1)
+		require.NotEmpty(t, ids[0])
+
+		entry := l.find("gRPC request failed")
+		require.NotNil(t, entry)
+		require.Equal(t, "/proto.User/Register", entry["method"])
+		require.Equal(t, codes.Internal.String(), entry["code"])
+		require.Equal(t, ids[0], entry["request_id"])
+		require.Contains(t, entry, "latency")
+	})
+
+	t.Run("client request id", func(t *testing.T) {
+		reqCtx := metadata.AppendToOutgoingContext(ctx, requestIDHeader, "req-1")
+		var header metadata.MD
+		_, err := client.Register(reqCtx, &pb.RegisterRequest{}, grpc.Header(&header))
+		require.Error(t, err)
+		require.Equal(t, []string{"req-1"}, header.Get(requestIDHeader))
+		require.Equal(t, "req-1", l.find("gRPC request failed")["request_id"])
+	})
+
+	t.Run("stream requires token", func(t *testing.T) {
+		stream, err := client.ExportAccount(ctx, &pb.ExportAccountRequest{})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		require.Equal(t, codes.FailedPrecondition, status.Code(err))
+
+		entry := l.find("gRPC request failed")
+		require.Equal(t, "/proto.User/ExportAccount", entry["method"])
+		require.Equal(t, codes.FailedPrecondition.String(), entry["code"])
+	})
+
+	t.Run("stream panic", func(t *testing.T) {
+		service.EXPECT().ParseToken(gomock.Any(), "token").Return(7, "session", nil)
+
+		reqCtx := metadata.AppendToOutgoingContext(ctx, "token", "token")
+		stream, err := client.ExportAccount(reqCtx, &pb.ExportAccountRequest{})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		require.Equal(t, codes.Internal, status.Code(err))
+
+		entry := l.find("gRPC request failed")
+		require.Equal(t, "/proto.User/ExportAccount", entry["method"])
+		require.Equal(t, 7, entry["user_id"])
+	})
+}
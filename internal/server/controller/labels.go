@@ -0,0 +1,189 @@
+package controller
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	"github.com/PaulYakow/gophkeeper/internal/server/usecase"
+	pb "github.com/PaulYakow/gophkeeper/proto"
+)
+
+// LabelsServer реализация интерфейса proto.LabelsServer (описание - gophkeeper/proto/labels.proto)
+type LabelsServer struct {
+	pb.UnimplementedLabelsServer
+	labels usecase.ILabelsService
+}
+
+// NewLabelsServer создаёт объект LabelsServer.
+func NewLabelsServer(labels usecase.ILabelsService) *LabelsServer {
+	return &LabelsServer{
+		labels: labels,
+	}
+}
+
+// ListFolders - получение всех папок пользователя.
+func (s *LabelsServer) ListFolders(ctx context.Context, _ *pb.ListFoldersRequest) (*pb.ListFoldersResponse, error) {
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return nil, status.Error(codes.Aborted, "missing user_id")
+	}
+
+	folders, err := s.labels.ListFolders(ctx, userID)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	resp := pb.ListFoldersResponse{
+		Folders: make([]*pb.FolderMsg, 0, len(folders)),
+	}
+	for _, folder := range folders {
+		resp.Folders = append(resp.Folders, &pb.FolderMsg{
+			Id:       int64(folder.ID),
+			ParentId: int64(folder.ParentID),
+			Name:     folder.Name,
+		})
+	}
+
+	return &resp, nil
+}
+
+// CreateFolder - создание новой папки.
+func (s *LabelsServer) CreateFolder(ctx context.Context, req *pb.CreateFolderRequest) (*pb.CreateFolderResponse, error) {
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return nil, status.Error(codes.Aborted, "missing user_id")
+	}
+
+	id, err := s.labels.CreateFolder(ctx, userID, entity.FolderDTO{
+		ParentID: int(req.GetFolder().GetParentId()),
+		Name:     req.GetFolder().GetName(),
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &pb.CreateFolderResponse{Id: int64(id)}, nil
+}
+
+// RenameFolder - изменение названия папки.
+func (s *LabelsServer) RenameFolder(ctx context.Context, req *pb.RenameFolderRequest) (*pb.RenameFolderResponse, error) {
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return nil, status.Error(codes.Aborted, "missing user_id")
+	}
+
+	if err := s.labels.RenameFolder(ctx, userID, int(req.GetId()), req.GetName()); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &pb.RenameFolderResponse{}, nil
+}
+
+// DeleteFolder - удаление папки вместе с вложенными (записи переносятся в родительскую папку).
+func (s *LabelsServer) DeleteFolder(ctx context.Context, req *pb.DeleteFolderRequest) (*pb.DeleteFolderResponse, error) {
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return nil, status.Error(codes.Aborted, "missing user_id")
+	}
+
+	if err := s.labels.DeleteFolder(ctx, userID, int(req.GetId())); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &pb.DeleteFolderResponse{}, nil
+}
+
+// ListTags - получение всех меток пользователя.
+func (s *LabelsServer) ListTags(ctx context.Context, _ *pb.ListTagsRequest) (*pb.ListTagsResponse, error) {
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return nil, status.Error(codes.Aborted, "missing user_id")
+	}
+
+	tags, err := s.labels.ListTags(ctx, userID)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	resp := pb.ListTagsResponse{
+		Tags: make([]*pb.TagMsg, 0, len(tags)),
+	}
+	for _, tag := range tags {
+		resp.Tags = append(resp.Tags, &pb.TagMsg{
+			Id:   int64(tag.ID),
+			Name: tag.Name,
+		})
+	}
+
+	return &resp, nil
+}
+
+// CreateTag - создание новой метки.
+func (s *LabelsServer) CreateTag(ctx context.Context, req *pb.CreateTagRequest) (*pb.CreateTagResponse, error) {
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return nil, status.Error(codes.Aborted, "missing user_id")
+	}
+
+	id, err := s.labels.CreateTag(ctx, userID, entity.TagDTO{Name: req.GetTag().GetName()})
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &pb.CreateTagResponse{Id: int64(id)}, nil
+}
+
+// RenameTag - изменение названия метки.
+func (s *LabelsServer) RenameTag(ctx context.Context, req *pb.RenameTagRequest) (*pb.RenameTagResponse, error) {
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return nil, status.Error(codes.Aborted, "missing user_id")
+	}
+
+	if err := s.labels.RenameTag(ctx, userID, int(req.GetId()), req.GetName()); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &pb.RenameTagResponse{}, nil
+}
+
+// DeleteTag - удаление метки (метка снимается со всех записей).
+func (s *LabelsServer) DeleteTag(ctx context.Context, req *pb.DeleteTagRequest) (*pb.DeleteTagResponse, error) {
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return nil, status.Error(codes.Aborted, "missing user_id")
+	}
+
+	if err := s.labels.DeleteTag(ctx, userID, int(req.GetId())); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &pb.DeleteTagResponse{}, nil
+}
+
+// labelsFromMsg преобразует папку и метки записи из сообщения.
+func labelsFromMsg(folderID int64, tagIDs []int64) entity.Labels {
+	labels := entity.Labels{FolderID: int(folderID)}
+	for _, id := range tagIDs {
+		labels.TagIDs = append(labels.TagIDs, int(id))
+	}
+
+	return labels
+}
+
+// tagIDsToMsg преобразует метки записи для сообщения.
+func tagIDsToMsg(ids entity.IDs) []int64 {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	out := make([]int64, len(ids))
+	for i, id := range ids {
+		out[i] = int64(id)
+	}
+
+	return out
+}
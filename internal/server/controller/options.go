@@ -0,0 +1,25 @@
+package controller
+
+import (
+	"context"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+)
+
+// Option применяет заданную настройку к gRPC-серверу (Controller).
+type Option func(*Controller)
+
+// Info задаёт информацию о сервере, возвращаемую командой Info.
+func Info(info entity.ServerInfoDTO) Option {
+	return func(c *Controller) {
+		c.info = info
+	}
+}
+
+// HealthCheck задаёт проверку доступности хранилища: от неё зависит статус сервиса проверки
+// состояния (grpc.health.v1.Health).
+func HealthCheck(check func(ctx context.Context) error) Option {
+	return func(c *Controller) {
+		c.healthCheck = check
+	}
+}
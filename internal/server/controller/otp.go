@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	"github.com/PaulYakow/gophkeeper/internal/server/usecase"
+	pb "github.com/PaulYakow/gophkeeper/proto"
+)
+
+// OTPServer реализация интерфейса proto.OTPServer (описание - gophkeeper/proto/otp.proto)
+type OTPServer struct {
+	pb.UnimplementedOTPServer
+	otps usecase.IOTPService
+}
+
+// NewOTPServer создаёт объект OTPServer.
+func NewOTPServer(otps usecase.IOTPService) *OTPServer {
+	return &OTPServer{
+		otps: otps,
+	}
+}
+
+// GetAll - получение страницы списка одноразовых паролей.
+func (s *OTPServer) GetAll(ctx context.Context, req *pb.GetAllOTPsRequest) (*pb.GetAllOTPsResponse, error) {
+	var resp pb.GetAllOTPsResponse
+
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return nil, status.Error(codes.Aborted, "missing user_id")
+	}
+
+	otps, next, err := s.otps.ViewOTPs(ctx, userID, pageFromMsg(req.GetPage()))
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	for _, item := range otps {
+		resp.Otps = append(resp.Otps, otpToMsg(item))
+	}
+
+	resp.NextPageToken = next
+
+	return &resp, nil
+}
+
+// Create - создание нового одноразового пароля.
+func (s *OTPServer) Create(ctx context.Context, req *pb.CreateOTPRequest) (*pb.CreateOTPResponse, error) {
+	var resp pb.CreateOTPResponse
+
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return nil, status.Error(codes.Aborted, "missing user_id")
+	}
+
+	item := otpFromMsg(req.GetOtp())
+	item.ID = 0
+
+	id, err := s.otps.CreateOTP(ctx, userID, item)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	resp.Id = int64(id)
+	return &resp, nil
+}
+
+// Update - изменение существующего одноразового пароля.
+func (s *OTPServer) Update(ctx context.Context, req *pb.UpdateOTPRequest) (*pb.UpdateOTPResponse, error) {
+	var resp pb.UpdateOTPResponse
+
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return nil, status.Error(codes.Aborted, "missing user_id")
+	}
+
+	if err := s.otps.UpdateOTP(ctx, userID, otpFromMsg(req.GetOtp())); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &resp, nil
+}
+
+// Delete - удаление одноразового пароля.
+func (s *OTPServer) Delete(ctx context.Context, req *pb.DeleteOTPRequest) (*pb.DeleteOTPResponse, error) {
+	var resp pb.DeleteOTPResponse
+
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return nil, status.Error(codes.Aborted, "missing user_id")
+	}
+
+	if err := s.otps.DeleteOTP(ctx, userID, int(req.GetId())); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &resp, nil
+}
+
+func otpToMsg(item entity.OTPDTO) *pb.OTPMsg {
+	return &pb.OTPMsg{
+		Id:        int64(item.ID),
+		Kind:      item.Kind,
+		Secret:    item.Secret,
+		Algorithm: item.Algorithm,
+		Digits:    int32(item.Digits),
+		Period:    int32(item.Period),
+		Counter:   item.Counter,
+		Issuer:    item.Issuer,
+		Metadata:  item.Metadata,
+		FolderId:  int64(item.FolderID),
+		TagIds:    tagIDsToMsg(item.TagIDs),
+	}
+}
+
+func otpFromMsg(msg *pb.OTPMsg) entity.OTPDTO {
+	return entity.OTPDTO{
+		ID:        int(msg.GetId()),
+		Kind:      msg.GetKind(),
+		Secret:    msg.GetSecret(),
+		Algorithm: msg.GetAlgorithm(),
+		Digits:    int(msg.GetDigits()),
+		Period:    int(msg.GetPeriod()),
+		Counter:   msg.GetCounter(),
+		Issuer:    msg.GetIssuer(),
+		Metadata:  msg.GetMetadata(),
+		Labels:    labelsFromMsg(msg.GetFolderId(), msg.GetTagIds()),
+	}
+}
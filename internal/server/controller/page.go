@@ -0,0 +1,36 @@
+package controller
+
+import (
+	"time"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	pb "github.com/PaulYakow/gophkeeper/proto"
+)
+
+// pageFromMsg преобразует запрос страницы списка (nil - первая страница по умолчанию).
+func pageFromMsg(msg *pb.PageRequest) entity.PageRequest {
+	page := entity.PageRequest{
+		Size:        int(msg.GetSize()),
+		Token:       msg.GetToken(),
+		CreatedFrom: timeFromUnixNano(msg.GetCreatedFrom()),
+		CreatedTo:   timeFromUnixNano(msg.GetCreatedTo()),
+		Order:       entity.SortOrder(msg.GetOrder()),
+		Filter:      entity.LabelFilter{TagID: int(msg.GetTagId())},
+	}
+
+	if msg != nil && msg.FolderId != nil {
+		folderID := int(msg.GetFolderId())
+		page.Filter.FolderID = &folderID
+	}
+
+	return page
+}
+
+// timeFromUnixNano преобразует unix-время в наносекундах (0 - нулевое время).
+func timeFromUnixNano(nano int64) time.Time {
+	if nano == 0 {
+		return time.Time{}
+	}
+
+	return time.Unix(0, nano)
+}
@@ -6,6 +6,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/PaulYakow/gophkeeper/internal/entity"
 	"github.com/PaulYakow/gophkeeper/internal/server/usecase"
 	pb "github.com/PaulYakow/gophkeeper/proto"
 )
@@ -22,7 +23,7 @@ func NewPairsServer(pairs usecase.IPairsService) *PairServer {
 	}
 }
 
-// GetAll - получение всех значений пар логин/пароль.
+// GetAll - получение страницы списка пар логин/пароль.
 func (s *PairServer) GetAll(ctx context.Context, req *pb.GetAllPairsRequest) (*pb.GetAllPairsResponse, error) {
 	var resp pb.GetAllPairsResponse
 
@@ -31,19 +32,89 @@ func (s *PairServer) GetAll(ctx context.Context, req *pb.GetAllPairsRequest) (*p
 		return nil, status.Error(codes.Aborted, "missing user_id")
 	}
 
-	pairs, err := s.pairs.ViewAllPairs(userID)
+	pairs, next, err := s.pairs.ViewPairs(ctx, userID, pageFromMsg(req.GetPage()))
 	if err != nil {
-		return nil, err
+		return nil, statusError(err)
 	}
 
 	for _, pair := range pairs {
-		resp.Pairs = append(resp.Pairs, &pb.PairMsg{
-			Id:       int64(pair.ID),
-			Login:    pair.Login,
-			Password: pair.Password,
-			Metadata: pair.Metadata,
-		})
+		resp.Pairs = append(resp.Pairs, pairToMsg(pair))
 	}
 
+	resp.NextPageToken = next
+
+	return &resp, nil
+}
+
+// Create - создание новой пары логин/пароль.
+func (s *PairServer) Create(ctx context.Context, req *pb.CreatePairRequest) (*pb.CreatePairResponse, error) {
+	var resp pb.CreatePairResponse
+
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return nil, status.Error(codes.Aborted, "missing user_id")
+	}
+
+	id, err := s.pairs.CreatePair(ctx, userID, entity.PairDTO{
+		Login:    req.GetPair().GetLogin(),
+		Password: req.GetPair().GetPassword(),
+		Metadata: req.GetPair().GetMetadata(),
+		Labels:   labelsFromMsg(req.GetPair().GetFolderId(), req.GetPair().GetTagIds()),
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	resp.Id = int64(id)
 	return &resp, nil
 }
+
+// Update - изменение существующей пары логин/пароль.
+func (s *PairServer) Update(ctx context.Context, req *pb.UpdatePairRequest) (*pb.UpdatePairResponse, error) {
+	var resp pb.UpdatePairResponse
+
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return nil, status.Error(codes.Aborted, "missing user_id")
+	}
+
+	err := s.pairs.UpdatePair(ctx, userID, entity.PairDTO{
+		ID:       int(req.GetPair().GetId()),
+		Login:    req.GetPair().GetLogin(),
+		Password: req.GetPair().GetPassword(),
+		Metadata: req.GetPair().GetMetadata(),
+		Labels:   labelsFromMsg(req.GetPair().GetFolderId(), req.GetPair().GetTagIds()),
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &resp, nil
+}
+
+// Delete - удаление пары логин/пароль.
+func (s *PairServer) Delete(ctx context.Context, req *pb.DeletePairRequest) (*pb.DeletePairResponse, error) {
+	var resp pb.DeletePairResponse
+
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return nil, status.Error(codes.Aborted, "missing user_id")
+	}
+
+	if err := s.pairs.DeletePair(ctx, userID, int(req.GetId())); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &resp, nil
+}
+
+func pairToMsg(pair entity.PairDTO) *pb.PairMsg {
+	return &pb.PairMsg{
+		Id:       int64(pair.ID),
+		Login:    pair.Login,
+		Password: pair.Password,
+		Metadata: pair.Metadata,
+		FolderId: int64(pair.FolderID),
+		TagIds:   tagIDsToMsg(pair.TagIDs),
+	}
+}
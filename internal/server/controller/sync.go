@@ -0,0 +1,70 @@
+package controller
+
+import (
+	"context"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/PaulYakow/gophkeeper/internal/server/usecase"
+	pb "github.com/PaulYakow/gophkeeper/proto"
+)
+
+// SyncServer реализация интерфейса proto.SyncServer (описание - gophkeeper/proto/sync.proto)
+type SyncServer struct {
+	pb.UnimplementedSyncServer
+	sync usecase.ISyncService
+}
+
+// NewSyncServer создаёт объект SyncServer.
+func NewSyncServer(sync usecase.ISyncService) *SyncServer {
+	return &SyncServer{
+		sync: sync,
+	}
+}
+
+// Sync - получение изменений всех типов данных пользователя после известной клиенту ревизии.
+func (s *SyncServer) Sync(ctx context.Context, req *pb.SyncRequest) (*pb.SyncResponse, error) {
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return nil, status.Error(codes.Aborted, "missing user_id")
+	}
+
+	if req.GetSince() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "negative revision")
+	}
+
+	changes, err := s.sync.Sync(ctx, userID, req.GetSince())
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	resp := pb.SyncResponse{
+		Revision: changes.Revision,
+		Full:     changes.Full,
+	}
+
+	for _, pair := range changes.Pairs {
+		resp.Pairs = append(resp.Pairs, pairToMsg(pair))
+	}
+	for _, card := range changes.Cards {
+		resp.Cards = append(resp.Cards, cardToMsg(card))
+	}
+	for _, note := range changes.Notes {
+		resp.Notes = append(resp.Notes, noteToMsg(note))
+	}
+	for _, binary := range changes.Binaries {
+		resp.Binaries = append(resp.Binaries, binaryToMsg(binary))
+	}
+	for _, item := range changes.OTPs {
+		resp.Otps = append(resp.Otps, otpToMsg(item))
+	}
+	for _, deleted := range changes.Deleted {
+		resp.Deleted = append(resp.Deleted, &pb.TombstoneMsg{
+			Kind: deleted.Kind,
+			Id:   int64(deleted.ID),
+		})
+	}
+
+	return &resp, nil
+}
@@ -6,6 +6,7 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 
+	"github.com/PaulYakow/gophkeeper/internal/entity"
 	"github.com/PaulYakow/gophkeeper/internal/server/usecase"
 	pb "github.com/PaulYakow/gophkeeper/proto"
 )
@@ -23,7 +24,7 @@ func NewTextServer(notes usecase.ITextService) *TextServer {
 	}
 }
 
-// GetAll - получение всех значений заметок.
+// GetAll - получение страницы списка заметок.
 func (s *TextServer) GetAll(ctx context.Context, req *pb.GetAllNotesRequest) (*pb.GetAllNotesResponse, error) {
 	var resp pb.GetAllNotesResponse
 
@@ -32,18 +33,86 @@ func (s *TextServer) GetAll(ctx context.Context, req *pb.GetAllNotesRequest) (*p
 		return nil, status.Error(codes.Aborted, "missing user_id")
 	}
 
-	notes, err := s.notes.ViewAllNotes(userID)
+	notes, next, err := s.notes.ViewNotes(ctx, userID, pageFromMsg(req.GetPage()))
 	if err != nil {
-		return nil, err
+		return nil, statusError(err)
 	}
 
 	for _, note := range notes {
-		resp.Notes = append(resp.Notes, &pb.NoteMsg{
-			Id:       int64(note.ID),
-			Note:     note.Note,
-			Metadata: note.Metadata,
-		})
+		resp.Notes = append(resp.Notes, noteToMsg(note))
 	}
 
+	resp.NextPageToken = next
+
+	return &resp, nil
+}
+
+// Create - создание новой заметки.
+func (s *TextServer) Create(ctx context.Context, req *pb.CreateNoteRequest) (*pb.CreateNoteResponse, error) {
+	var resp pb.CreateNoteResponse
+
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return nil, status.Error(codes.Aborted, "missing user_id")
+	}
+
+	id, err := s.notes.CreateNote(ctx, userID, entity.TextDTO{
+		Note:     req.GetNote().GetNote(),
+		Metadata: req.GetNote().GetMetadata(),
+		Labels:   labelsFromMsg(req.GetNote().GetFolderId(), req.GetNote().GetTagIds()),
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	resp.Id = int64(id)
 	return &resp, nil
 }
+
+// Update - изменение существующей заметки.
+func (s *TextServer) Update(ctx context.Context, req *pb.UpdateNoteRequest) (*pb.UpdateNoteResponse, error) {
+	var resp pb.UpdateNoteResponse
+
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return nil, status.Error(codes.Aborted, "missing user_id")
+	}
+
+	err := s.notes.UpdateNote(ctx, userID, entity.TextDTO{
+		ID:       int(req.GetNote().GetId()),
+		Note:     req.GetNote().GetNote(),
+		Metadata: req.GetNote().GetMetadata(),
+		Labels:   labelsFromMsg(req.GetNote().GetFolderId(), req.GetNote().GetTagIds()),
+	})
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &resp, nil
+}
+
+// Delete - удаление заметки.
+func (s *TextServer) Delete(ctx context.Context, req *pb.DeleteNoteRequest) (*pb.DeleteNoteResponse, error) {
+	var resp pb.DeleteNoteResponse
+
+	userID, ok := ctx.Value(userIDKey).(int)
+	if !ok {
+		return nil, status.Error(codes.Aborted, "missing user_id")
+	}
+
+	if err := s.notes.DeleteNote(ctx, userID, int(req.GetId())); err != nil {
+		return nil, statusError(err)
+	}
+
+	return &resp, nil
+}
+
+func noteToMsg(note entity.TextDTO) *pb.NoteMsg {
+	return &pb.NoteMsg{
+		Id:       int64(note.ID),
+		Note:     note.Note,
+		Metadata: note.Metadata,
+		FolderId: int64(note.FolderID),
+		TagIds:   tagIDsToMsg(note.TagIDs),
+	}
+}
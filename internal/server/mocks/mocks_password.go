@@ -61,3 +61,17 @@ func (mr *MockIPasswordHashMockRecorder) Hash(password interface{}) *gomock.Call
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hash", reflect.TypeOf((*MockIPasswordHash)(nil).Hash), password)
 }
+
+// NeedsRehash mocks base method.
+func (m *MockIPasswordHash) NeedsRehash(hashedPassword string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NeedsRehash", hashedPassword)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// NeedsRehash indicates an expected call of NeedsRehash.
+func (mr *MockIPasswordHashMockRecorder) NeedsRehash(hashedPassword interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NeedsRehash", reflect.TypeOf((*MockIPasswordHash)(nil).NeedsRehash), hashedPassword)
+}
@@ -36,18 +36,18 @@ func (m *MockIMaker) EXPECT() *MockIMakerMockRecorder {
 }
 
 // Create mocks base method.
-func (m *MockIMaker) Create(userID int, duration time.Duration) (string, error) {
+func (m *MockIMaker) Create(userID int, sessionID string, duration time.Duration) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", userID, duration)
+	ret := m.ctrl.Call(m, "Create", userID, sessionID, duration)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockIMakerMockRecorder) Create(userID, duration interface{}) *gomock.Call {
+func (mr *MockIMakerMockRecorder) Create(userID, sessionID, duration interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIMaker)(nil).Create), userID, duration)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockIMaker)(nil).Create), userID, sessionID, duration)
 }
 
 // Verify mocks base method.
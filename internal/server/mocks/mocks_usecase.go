@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/PaulYakow/gophkeeper/internal/entity"
 	gomock "github.com/golang/mock/gomock"
@@ -35,553 +36,3337 @@ func (m *MockIService) EXPECT() *MockIServiceMockRecorder {
 	return m.recorder
 }
 
+// ChangePassword mocks base method.
+func (m *MockIService) ChangePassword(ctx context.Context, login, password, newPassword, code, peer string) (entity.TokensDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, login, password, newPassword, code, peer)
+	ret0, _ := ret[0].(entity.TokensDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockIServiceMockRecorder) ChangePassword(ctx, login, password, newPassword, code, peer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockIService)(nil).ChangePassword), ctx, login, password, newPassword, code, peer)
+}
+
+// ConfirmTOTP mocks base method.
+func (m *MockIService) ConfirmTOTP(ctx context.Context, userID int, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTOTP", ctx, userID, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmTOTP indicates an expected call of ConfirmTOTP.
+func (mr *MockIServiceMockRecorder) ConfirmTOTP(ctx, userID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTOTP", reflect.TypeOf((*MockIService)(nil).ConfirmTOTP), ctx, userID, code)
+}
+
+// CreateBinary mocks base method.
+func (m *MockIService) CreateBinary(ctx context.Context, userID int, binary entity.BinaryDTO) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBinary", ctx, userID, binary)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBinary indicates an expected call of CreateBinary.
+func (mr *MockIServiceMockRecorder) CreateBinary(ctx, userID, binary interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBinary", reflect.TypeOf((*MockIService)(nil).CreateBinary), ctx, userID, binary)
+}
+
+// CreateCard mocks base method.
+func (m *MockIService) CreateCard(ctx context.Context, userID int, card entity.BankDTO) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCard", ctx, userID, card)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCard indicates an expected call of CreateCard.
+func (mr *MockIServiceMockRecorder) CreateCard(ctx, userID, card interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCard", reflect.TypeOf((*MockIService)(nil).CreateCard), ctx, userID, card)
+}
+
+// CreateFolder mocks base method.
+func (m *MockIService) CreateFolder(ctx context.Context, userID int, folder entity.FolderDTO) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFolder", ctx, userID, folder)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFolder indicates an expected call of CreateFolder.
+func (mr *MockIServiceMockRecorder) CreateFolder(ctx, userID, folder interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFolder", reflect.TypeOf((*MockIService)(nil).CreateFolder), ctx, userID, folder)
+}
+
+// CreateNote mocks base method.
+func (m *MockIService) CreateNote(ctx context.Context, userID int, note entity.TextDTO) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNote", ctx, userID, note)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNote indicates an expected call of CreateNote.
+func (mr *MockIServiceMockRecorder) CreateNote(ctx, userID, note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNote", reflect.TypeOf((*MockIService)(nil).CreateNote), ctx, userID, note)
+}
+
+// CreateOTP mocks base method.
+func (m *MockIService) CreateOTP(ctx context.Context, userID int, otp entity.OTPDTO) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOTP", ctx, userID, otp)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOTP indicates an expected call of CreateOTP.
+func (mr *MockIServiceMockRecorder) CreateOTP(ctx, userID, otp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOTP", reflect.TypeOf((*MockIService)(nil).CreateOTP), ctx, userID, otp)
+}
+
+// CreatePair mocks base method.
+func (m *MockIService) CreatePair(ctx context.Context, userID int, pair entity.PairDTO) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePair", ctx, userID, pair)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePair indicates an expected call of CreatePair.
+func (mr *MockIServiceMockRecorder) CreatePair(ctx, userID, pair interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePair", reflect.TypeOf((*MockIService)(nil).CreatePair), ctx, userID, pair)
+}
+
+// CreateTag mocks base method.
+func (m *MockIService) CreateTag(ctx context.Context, userID int, tag entity.TagDTO) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTag", ctx, userID, tag)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTag indicates an expected call of CreateTag.
+func (mr *MockIServiceMockRecorder) CreateTag(ctx, userID, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTag", reflect.TypeOf((*MockIService)(nil).CreateTag), ctx, userID, tag)
+}
+
+// DeleteAccount mocks base method.
+func (m *MockIService) DeleteAccount(ctx context.Context, userID int, password, code, peer string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", ctx, userID, password, code, peer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockIServiceMockRecorder) DeleteAccount(ctx, userID, password, code, peer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockIService)(nil).DeleteAccount), ctx, userID, password, code, peer)
+}
+
+// DeleteBinary mocks base method.
+func (m *MockIService) DeleteBinary(ctx context.Context, userID, binaryID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBinary", ctx, userID, binaryID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBinary indicates an expected call of DeleteBinary.
+func (mr *MockIServiceMockRecorder) DeleteBinary(ctx, userID, binaryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBinary", reflect.TypeOf((*MockIService)(nil).DeleteBinary), ctx, userID, binaryID)
+}
+
+// DeleteCard mocks base method.
+func (m *MockIService) DeleteCard(ctx context.Context, userID, cardID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCard", ctx, userID, cardID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCard indicates an expected call of DeleteCard.
+func (mr *MockIServiceMockRecorder) DeleteCard(ctx, userID, cardID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCard", reflect.TypeOf((*MockIService)(nil).DeleteCard), ctx, userID, cardID)
+}
+
+// DeleteFolder mocks base method.
+func (m *MockIService) DeleteFolder(ctx context.Context, userID, folderID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFolder", ctx, userID, folderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFolder indicates an expected call of DeleteFolder.
+func (mr *MockIServiceMockRecorder) DeleteFolder(ctx, userID, folderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFolder", reflect.TypeOf((*MockIService)(nil).DeleteFolder), ctx, userID, folderID)
+}
+
+// DeleteNote mocks base method.
+func (m *MockIService) DeleteNote(ctx context.Context, userID, noteID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNote", ctx, userID, noteID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNote indicates an expected call of DeleteNote.
+func (mr *MockIServiceMockRecorder) DeleteNote(ctx, userID, noteID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNote", reflect.TypeOf((*MockIService)(nil).DeleteNote), ctx, userID, noteID)
+}
+
+// DeleteOTP mocks base method.
+func (m *MockIService) DeleteOTP(ctx context.Context, userID, otpID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOTP", ctx, userID, otpID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOTP indicates an expected call of DeleteOTP.
+func (mr *MockIServiceMockRecorder) DeleteOTP(ctx, userID, otpID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOTP", reflect.TypeOf((*MockIService)(nil).DeleteOTP), ctx, userID, otpID)
+}
+
+// DeletePair mocks base method.
+func (m *MockIService) DeletePair(ctx context.Context, userID, pairID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePair", ctx, userID, pairID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePair indicates an expected call of DeletePair.
+func (mr *MockIServiceMockRecorder) DeletePair(ctx, userID, pairID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePair", reflect.TypeOf((*MockIService)(nil).DeletePair), ctx, userID, pairID)
+}
+
+// DeleteTag mocks base method.
+func (m *MockIService) DeleteTag(ctx context.Context, userID, tagID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTag", ctx, userID, tagID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTag indicates an expected call of DeleteTag.
+func (mr *MockIServiceMockRecorder) DeleteTag(ctx, userID, tagID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockIService)(nil).DeleteTag), ctx, userID, tagID)
+}
+
+// DisableTOTP mocks base method.
+func (m *MockIService) DisableTOTP(ctx context.Context, userID int, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTOTP", ctx, userID, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTOTP indicates an expected call of DisableTOTP.
+func (mr *MockIServiceMockRecorder) DisableTOTP(ctx, userID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTOTP", reflect.TypeOf((*MockIService)(nil).DisableTOTP), ctx, userID, code)
+}
+
+// EnrollTOTP mocks base method.
+func (m *MockIService) EnrollTOTP(ctx context.Context, userID int, pass, code, peer string) (entity.TOTPEnrollmentDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollTOTP", ctx, userID, pass, code, peer)
+	ret0, _ := ret[0].(entity.TOTPEnrollmentDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollTOTP indicates an expected call of EnrollTOTP.
+func (mr *MockIServiceMockRecorder) EnrollTOTP(ctx, userID, pass, code, peer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollTOTP", reflect.TypeOf((*MockIService)(nil).EnrollTOTP), ctx, userID, pass, code, peer)
+}
+
+// ExportAccount mocks base method.
+func (m *MockIService) ExportAccount(ctx context.Context, userID int) (entity.AccountDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportAccount", ctx, userID)
+	ret0, _ := ret[0].(entity.AccountDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportAccount indicates an expected call of ExportAccount.
+func (mr *MockIServiceMockRecorder) ExportAccount(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportAccount", reflect.TypeOf((*MockIService)(nil).ExportAccount), ctx, userID)
+}
+
+// GetBinary mocks base method.
+func (m *MockIService) GetBinary(ctx context.Context, userID, binaryID int) (entity.BinaryDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBinary", ctx, userID, binaryID)
+	ret0, _ := ret[0].(entity.BinaryDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBinary indicates an expected call of GetBinary.
+func (mr *MockIServiceMockRecorder) GetBinary(ctx, userID, binaryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBinary", reflect.TypeOf((*MockIService)(nil).GetBinary), ctx, userID, binaryID)
+}
+
+// ListFolders mocks base method.
+func (m *MockIService) ListFolders(ctx context.Context, userID int) ([]entity.FolderDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFolders", ctx, userID)
+	ret0, _ := ret[0].([]entity.FolderDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFolders indicates an expected call of ListFolders.
+func (mr *MockIServiceMockRecorder) ListFolders(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFolders", reflect.TypeOf((*MockIService)(nil).ListFolders), ctx, userID)
+}
+
+// ListTags mocks base method.
+func (m *MockIService) ListTags(ctx context.Context, userID int) ([]entity.TagDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTags", ctx, userID)
+	ret0, _ := ret[0].([]entity.TagDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTags indicates an expected call of ListTags.
+func (mr *MockIServiceMockRecorder) ListTags(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTags", reflect.TypeOf((*MockIService)(nil).ListTags), ctx, userID)
+}
+
 // LoginUser mocks base method.
-func (m *MockIService) LoginUser(login, password string) (string, error) {
+func (m *MockIService) LoginUser(ctx context.Context, login, password, peer string) (entity.TokensDTO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LoginUser", login, password)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "LoginUser", ctx, login, password, peer)
+	ret0, _ := ret[0].(entity.TokensDTO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // LoginUser indicates an expected call of LoginUser.
-func (mr *MockIServiceMockRecorder) LoginUser(login, password interface{}) *gomock.Call {
+func (mr *MockIServiceMockRecorder) LoginUser(ctx, login, password, peer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginUser", reflect.TypeOf((*MockIService)(nil).LoginUser), ctx, login, password, peer)
+}
+
+// Logout mocks base method.
+func (m *MockIService) Logout(ctx context.Context, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockIServiceMockRecorder) Logout(ctx, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockIService)(nil).Logout), ctx, sessionID)
+}
+
+// MaxStoredSize mocks base method.
+func (m *MockIService) MaxStoredSize() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaxStoredSize")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// MaxStoredSize indicates an expected call of MaxStoredSize.
+func (mr *MockIServiceMockRecorder) MaxStoredSize() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginUser", reflect.TypeOf((*MockIService)(nil).LoginUser), login, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaxStoredSize", reflect.TypeOf((*MockIService)(nil).MaxStoredSize))
 }
 
 // ParseToken mocks base method.
-func (m *MockIService) ParseToken(token string) (int, error) {
+func (m *MockIService) ParseToken(ctx context.Context, token string) (int, string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ParseToken", token)
+	ret := m.ctrl.Call(m, "ParseToken", ctx, token)
 	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ParseToken indicates an expected call of ParseToken.
+func (mr *MockIServiceMockRecorder) ParseToken(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseToken", reflect.TypeOf((*MockIService)(nil).ParseToken), ctx, token)
+}
+
+// Refresh mocks base method.
+func (m *MockIService) Refresh(ctx context.Context, refreshToken string) (entity.TokensDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, refreshToken)
+	ret0, _ := ret[0].(entity.TokensDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockIServiceMockRecorder) Refresh(ctx, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockIService)(nil).Refresh), ctx, refreshToken)
+}
+
+// RegisterUser mocks base method.
+func (m *MockIService) RegisterUser(ctx context.Context, login, password string) (entity.TokensDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterUser", ctx, login, password)
+	ret0, _ := ret[0].(entity.TokensDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterUser indicates an expected call of RegisterUser.
+func (mr *MockIServiceMockRecorder) RegisterUser(ctx, login, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUser", reflect.TypeOf((*MockIService)(nil).RegisterUser), ctx, login, password)
+}
+
+// RenameFolder mocks base method.
+func (m *MockIService) RenameFolder(ctx context.Context, userID, folderID int, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameFolder", ctx, userID, folderID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameFolder indicates an expected call of RenameFolder.
+func (mr *MockIServiceMockRecorder) RenameFolder(ctx, userID, folderID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameFolder", reflect.TypeOf((*MockIService)(nil).RenameFolder), ctx, userID, folderID, name)
+}
+
+// RenameTag mocks base method.
+func (m *MockIService) RenameTag(ctx context.Context, userID, tagID int, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameTag", ctx, userID, tagID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameTag indicates an expected call of RenameTag.
+func (mr *MockIServiceMockRecorder) RenameTag(ctx, userID, tagID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameTag", reflect.TypeOf((*MockIService)(nil).RenameTag), ctx, userID, tagID, name)
+}
+
+// SecurityEvents mocks base method.
+func (m *MockIService) SecurityEvents(ctx context.Context, userID int) ([]entity.SecurityEventDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecurityEvents", ctx, userID)
+	ret0, _ := ret[0].([]entity.SecurityEventDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SecurityEvents indicates an expected call of SecurityEvents.
+func (mr *MockIServiceMockRecorder) SecurityEvents(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecurityEvents", reflect.TypeOf((*MockIService)(nil).SecurityEvents), ctx, userID)
+}
+
+// Sync mocks base method.
+func (m *MockIService) Sync(ctx context.Context, userID int, since int64) (entity.ChangesDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sync", ctx, userID, since)
+	ret0, _ := ret[0].(entity.ChangesDTO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ParseToken indicates an expected call of ParseToken.
-func (mr *MockIServiceMockRecorder) ParseToken(token interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseToken", reflect.TypeOf((*MockIService)(nil).ParseToken), token)
+// Sync indicates an expected call of Sync.
+func (mr *MockIServiceMockRecorder) Sync(ctx, userID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sync", reflect.TypeOf((*MockIService)(nil).Sync), ctx, userID, since)
+}
+
+// UpdateCard mocks base method.
+func (m *MockIService) UpdateCard(ctx context.Context, userID int, card entity.BankDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCard", ctx, userID, card)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCard indicates an expected call of UpdateCard.
+func (mr *MockIServiceMockRecorder) UpdateCard(ctx, userID, card interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCard", reflect.TypeOf((*MockIService)(nil).UpdateCard), ctx, userID, card)
+}
+
+// UpdateNote mocks base method.
+func (m *MockIService) UpdateNote(ctx context.Context, userID int, note entity.TextDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNote", ctx, userID, note)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNote indicates an expected call of UpdateNote.
+func (mr *MockIServiceMockRecorder) UpdateNote(ctx, userID, note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNote", reflect.TypeOf((*MockIService)(nil).UpdateNote), ctx, userID, note)
+}
+
+// UpdateOTP mocks base method.
+func (m *MockIService) UpdateOTP(ctx context.Context, userID int, otp entity.OTPDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOTP", ctx, userID, otp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOTP indicates an expected call of UpdateOTP.
+func (mr *MockIServiceMockRecorder) UpdateOTP(ctx, userID, otp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOTP", reflect.TypeOf((*MockIService)(nil).UpdateOTP), ctx, userID, otp)
+}
+
+// UpdatePair mocks base method.
+func (m *MockIService) UpdatePair(ctx context.Context, userID int, pair entity.PairDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePair", ctx, userID, pair)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePair indicates an expected call of UpdatePair.
+func (mr *MockIServiceMockRecorder) UpdatePair(ctx, userID, pair interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePair", reflect.TypeOf((*MockIService)(nil).UpdatePair), ctx, userID, pair)
+}
+
+// VerifySecondFactor mocks base method.
+func (m *MockIService) VerifySecondFactor(ctx context.Context, challenge, code, peer string) (entity.TokensDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifySecondFactor", ctx, challenge, code, peer)
+	ret0, _ := ret[0].(entity.TokensDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifySecondFactor indicates an expected call of VerifySecondFactor.
+func (mr *MockIServiceMockRecorder) VerifySecondFactor(ctx, challenge, code, peer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifySecondFactor", reflect.TypeOf((*MockIService)(nil).VerifySecondFactor), ctx, challenge, code, peer)
+}
+
+// ViewBinaries mocks base method.
+func (m *MockIService) ViewBinaries(ctx context.Context, userID int, page entity.PageRequest) ([]entity.BinaryDTO, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewBinaries", ctx, userID, page)
+	ret0, _ := ret[0].([]entity.BinaryDTO)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ViewBinaries indicates an expected call of ViewBinaries.
+func (mr *MockIServiceMockRecorder) ViewBinaries(ctx, userID, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewBinaries", reflect.TypeOf((*MockIService)(nil).ViewBinaries), ctx, userID, page)
+}
+
+// ViewCards mocks base method.
+func (m *MockIService) ViewCards(ctx context.Context, userID int, page entity.PageRequest) ([]entity.BankDTO, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewCards", ctx, userID, page)
+	ret0, _ := ret[0].([]entity.BankDTO)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ViewCards indicates an expected call of ViewCards.
+func (mr *MockIServiceMockRecorder) ViewCards(ctx, userID, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewCards", reflect.TypeOf((*MockIService)(nil).ViewCards), ctx, userID, page)
+}
+
+// ViewNotes mocks base method.
+func (m *MockIService) ViewNotes(ctx context.Context, userID int, page entity.PageRequest) ([]entity.TextDTO, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewNotes", ctx, userID, page)
+	ret0, _ := ret[0].([]entity.TextDTO)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ViewNotes indicates an expected call of ViewNotes.
+func (mr *MockIServiceMockRecorder) ViewNotes(ctx, userID, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewNotes", reflect.TypeOf((*MockIService)(nil).ViewNotes), ctx, userID, page)
+}
+
+// ViewOTPs mocks base method.
+func (m *MockIService) ViewOTPs(ctx context.Context, userID int, page entity.PageRequest) ([]entity.OTPDTO, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewOTPs", ctx, userID, page)
+	ret0, _ := ret[0].([]entity.OTPDTO)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ViewOTPs indicates an expected call of ViewOTPs.
+func (mr *MockIServiceMockRecorder) ViewOTPs(ctx, userID, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewOTPs", reflect.TypeOf((*MockIService)(nil).ViewOTPs), ctx, userID, page)
+}
+
+// ViewPairs mocks base method.
+func (m *MockIService) ViewPairs(ctx context.Context, userID int, page entity.PageRequest) ([]entity.PairDTO, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewPairs", ctx, userID, page)
+	ret0, _ := ret[0].([]entity.PairDTO)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ViewPairs indicates an expected call of ViewPairs.
+func (mr *MockIServiceMockRecorder) ViewPairs(ctx, userID, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewPairs", reflect.TypeOf((*MockIService)(nil).ViewPairs), ctx, userID, page)
+}
+
+// MockIAuthorizationService is a mock of IAuthorizationService interface.
+type MockIAuthorizationService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAuthorizationServiceMockRecorder
+}
+
+// MockIAuthorizationServiceMockRecorder is the mock recorder for MockIAuthorizationService.
+type MockIAuthorizationServiceMockRecorder struct {
+	mock *MockIAuthorizationService
+}
+
+// NewMockIAuthorizationService creates a new mock instance.
+func NewMockIAuthorizationService(ctrl *gomock.Controller) *MockIAuthorizationService {
+	mock := &MockIAuthorizationService{ctrl: ctrl}
+	mock.recorder = &MockIAuthorizationServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAuthorizationService) EXPECT() *MockIAuthorizationServiceMockRecorder {
+	return m.recorder
+}
+
+// ChangePassword mocks base method.
+func (m *MockIAuthorizationService) ChangePassword(ctx context.Context, login, password, newPassword, code, peer string) (entity.TokensDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, login, password, newPassword, code, peer)
+	ret0, _ := ret[0].(entity.TokensDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockIAuthorizationServiceMockRecorder) ChangePassword(ctx, login, password, newPassword, code, peer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockIAuthorizationService)(nil).ChangePassword), ctx, login, password, newPassword, code, peer)
+}
+
+// ConfirmTOTP mocks base method.
+func (m *MockIAuthorizationService) ConfirmTOTP(ctx context.Context, userID int, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTOTP", ctx, userID, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmTOTP indicates an expected call of ConfirmTOTP.
+func (mr *MockIAuthorizationServiceMockRecorder) ConfirmTOTP(ctx, userID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTOTP", reflect.TypeOf((*MockIAuthorizationService)(nil).ConfirmTOTP), ctx, userID, code)
+}
+
+// DisableTOTP mocks base method.
+func (m *MockIAuthorizationService) DisableTOTP(ctx context.Context, userID int, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DisableTOTP", ctx, userID, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DisableTOTP indicates an expected call of DisableTOTP.
+func (mr *MockIAuthorizationServiceMockRecorder) DisableTOTP(ctx, userID, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DisableTOTP", reflect.TypeOf((*MockIAuthorizationService)(nil).DisableTOTP), ctx, userID, code)
+}
+
+// EnrollTOTP mocks base method.
+func (m *MockIAuthorizationService) EnrollTOTP(ctx context.Context, userID int, pass, code, peer string) (entity.TOTPEnrollmentDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "EnrollTOTP", ctx, userID, pass, code, peer)
+	ret0, _ := ret[0].(entity.TOTPEnrollmentDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// EnrollTOTP indicates an expected call of EnrollTOTP.
+func (mr *MockIAuthorizationServiceMockRecorder) EnrollTOTP(ctx, userID, pass, code, peer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnrollTOTP", reflect.TypeOf((*MockIAuthorizationService)(nil).EnrollTOTP), ctx, userID, pass, code, peer)
+}
+
+// LoginUser mocks base method.
+func (m *MockIAuthorizationService) LoginUser(ctx context.Context, login, password, peer string) (entity.TokensDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginUser", ctx, login, password, peer)
+	ret0, _ := ret[0].(entity.TokensDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginUser indicates an expected call of LoginUser.
+func (mr *MockIAuthorizationServiceMockRecorder) LoginUser(ctx, login, password, peer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginUser", reflect.TypeOf((*MockIAuthorizationService)(nil).LoginUser), ctx, login, password, peer)
+}
+
+// Logout mocks base method.
+func (m *MockIAuthorizationService) Logout(ctx context.Context, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", ctx, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockIAuthorizationServiceMockRecorder) Logout(ctx, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockIAuthorizationService)(nil).Logout), ctx, sessionID)
+}
+
+// ParseToken mocks base method.
+func (m *MockIAuthorizationService) ParseToken(ctx context.Context, token string) (int, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseToken", ctx, token)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ParseToken indicates an expected call of ParseToken.
+func (mr *MockIAuthorizationServiceMockRecorder) ParseToken(ctx, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseToken", reflect.TypeOf((*MockIAuthorizationService)(nil).ParseToken), ctx, token)
+}
+
+// Refresh mocks base method.
+func (m *MockIAuthorizationService) Refresh(ctx context.Context, refreshToken string) (entity.TokensDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, refreshToken)
+	ret0, _ := ret[0].(entity.TokensDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockIAuthorizationServiceMockRecorder) Refresh(ctx, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockIAuthorizationService)(nil).Refresh), ctx, refreshToken)
+}
+
+// RegisterUser mocks base method.
+func (m *MockIAuthorizationService) RegisterUser(ctx context.Context, login, password string) (entity.TokensDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterUser", ctx, login, password)
+	ret0, _ := ret[0].(entity.TokensDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterUser indicates an expected call of RegisterUser.
+func (mr *MockIAuthorizationServiceMockRecorder) RegisterUser(ctx, login, password interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUser", reflect.TypeOf((*MockIAuthorizationService)(nil).RegisterUser), ctx, login, password)
+}
+
+// SecurityEvents mocks base method.
+func (m *MockIAuthorizationService) SecurityEvents(ctx context.Context, userID int) ([]entity.SecurityEventDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SecurityEvents", ctx, userID)
+	ret0, _ := ret[0].([]entity.SecurityEventDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SecurityEvents indicates an expected call of SecurityEvents.
+func (mr *MockIAuthorizationServiceMockRecorder) SecurityEvents(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SecurityEvents", reflect.TypeOf((*MockIAuthorizationService)(nil).SecurityEvents), ctx, userID)
+}
+
+// VerifySecondFactor mocks base method.
+func (m *MockIAuthorizationService) VerifySecondFactor(ctx context.Context, challenge, code, peer string) (entity.TokensDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifySecondFactor", ctx, challenge, code, peer)
+	ret0, _ := ret[0].(entity.TokensDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// VerifySecondFactor indicates an expected call of VerifySecondFactor.
+func (mr *MockIAuthorizationServiceMockRecorder) VerifySecondFactor(ctx, challenge, code, peer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifySecondFactor", reflect.TypeOf((*MockIAuthorizationService)(nil).VerifySecondFactor), ctx, challenge, code, peer)
+}
+
+// MockIPairsService is a mock of IPairsService interface.
+type MockIPairsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPairsServiceMockRecorder
+}
+
+// MockIPairsServiceMockRecorder is the mock recorder for MockIPairsService.
+type MockIPairsServiceMockRecorder struct {
+	mock *MockIPairsService
+}
+
+// NewMockIPairsService creates a new mock instance.
+func NewMockIPairsService(ctrl *gomock.Controller) *MockIPairsService {
+	mock := &MockIPairsService{ctrl: ctrl}
+	mock.recorder = &MockIPairsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPairsService) EXPECT() *MockIPairsServiceMockRecorder {
+	return m.recorder
+}
+
+// CreatePair mocks base method.
+func (m *MockIPairsService) CreatePair(ctx context.Context, userID int, pair entity.PairDTO) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePair", ctx, userID, pair)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePair indicates an expected call of CreatePair.
+func (mr *MockIPairsServiceMockRecorder) CreatePair(ctx, userID, pair interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePair", reflect.TypeOf((*MockIPairsService)(nil).CreatePair), ctx, userID, pair)
+}
+
+// DeletePair mocks base method.
+func (m *MockIPairsService) DeletePair(ctx context.Context, userID, pairID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePair", ctx, userID, pairID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePair indicates an expected call of DeletePair.
+func (mr *MockIPairsServiceMockRecorder) DeletePair(ctx, userID, pairID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePair", reflect.TypeOf((*MockIPairsService)(nil).DeletePair), ctx, userID, pairID)
+}
+
+// UpdatePair mocks base method.
+func (m *MockIPairsService) UpdatePair(ctx context.Context, userID int, pair entity.PairDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePair", ctx, userID, pair)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePair indicates an expected call of UpdatePair.
+func (mr *MockIPairsServiceMockRecorder) UpdatePair(ctx, userID, pair interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePair", reflect.TypeOf((*MockIPairsService)(nil).UpdatePair), ctx, userID, pair)
+}
+
+// ViewPairs mocks base method.
+func (m *MockIPairsService) ViewPairs(ctx context.Context, userID int, page entity.PageRequest) ([]entity.PairDTO, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewPairs", ctx, userID, page)
+	ret0, _ := ret[0].([]entity.PairDTO)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ViewPairs indicates an expected call of ViewPairs.
+func (mr *MockIPairsServiceMockRecorder) ViewPairs(ctx, userID, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewPairs", reflect.TypeOf((*MockIPairsService)(nil).ViewPairs), ctx, userID, page)
+}
+
+// MockIBankService is a mock of IBankService interface.
+type MockIBankService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIBankServiceMockRecorder
+}
+
+// MockIBankServiceMockRecorder is the mock recorder for MockIBankService.
+type MockIBankServiceMockRecorder struct {
+	mock *MockIBankService
+}
+
+// NewMockIBankService creates a new mock instance.
+func NewMockIBankService(ctrl *gomock.Controller) *MockIBankService {
+	mock := &MockIBankService{ctrl: ctrl}
+	mock.recorder = &MockIBankServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIBankService) EXPECT() *MockIBankServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateCard mocks base method.
+func (m *MockIBankService) CreateCard(ctx context.Context, userID int, card entity.BankDTO) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCard", ctx, userID, card)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCard indicates an expected call of CreateCard.
+func (mr *MockIBankServiceMockRecorder) CreateCard(ctx, userID, card interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCard", reflect.TypeOf((*MockIBankService)(nil).CreateCard), ctx, userID, card)
+}
+
+// DeleteCard mocks base method.
+func (m *MockIBankService) DeleteCard(ctx context.Context, userID, cardID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCard", ctx, userID, cardID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCard indicates an expected call of DeleteCard.
+func (mr *MockIBankServiceMockRecorder) DeleteCard(ctx, userID, cardID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCard", reflect.TypeOf((*MockIBankService)(nil).DeleteCard), ctx, userID, cardID)
+}
+
+// UpdateCard mocks base method.
+func (m *MockIBankService) UpdateCard(ctx context.Context, userID int, card entity.BankDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCard", ctx, userID, card)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCard indicates an expected call of UpdateCard.
+func (mr *MockIBankServiceMockRecorder) UpdateCard(ctx, userID, card interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCard", reflect.TypeOf((*MockIBankService)(nil).UpdateCard), ctx, userID, card)
+}
+
+// ViewCards mocks base method.
+func (m *MockIBankService) ViewCards(ctx context.Context, userID int, page entity.PageRequest) ([]entity.BankDTO, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewCards", ctx, userID, page)
+	ret0, _ := ret[0].([]entity.BankDTO)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ViewCards indicates an expected call of ViewCards.
+func (mr *MockIBankServiceMockRecorder) ViewCards(ctx, userID, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewCards", reflect.TypeOf((*MockIBankService)(nil).ViewCards), ctx, userID, page)
+}
+
+// MockITextService is a mock of ITextService interface.
+type MockITextService struct {
+	ctrl     *gomock.Controller
+	recorder *MockITextServiceMockRecorder
+}
+
+// MockITextServiceMockRecorder is the mock recorder for MockITextService.
+type MockITextServiceMockRecorder struct {
+	mock *MockITextService
+}
+
+// NewMockITextService creates a new mock instance.
+func NewMockITextService(ctrl *gomock.Controller) *MockITextService {
+	mock := &MockITextService{ctrl: ctrl}
+	mock.recorder = &MockITextServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockITextService) EXPECT() *MockITextServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateNote mocks base method.
+func (m *MockITextService) CreateNote(ctx context.Context, userID int, note entity.TextDTO) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNote", ctx, userID, note)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNote indicates an expected call of CreateNote.
+func (mr *MockITextServiceMockRecorder) CreateNote(ctx, userID, note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNote", reflect.TypeOf((*MockITextService)(nil).CreateNote), ctx, userID, note)
+}
+
+// DeleteNote mocks base method.
+func (m *MockITextService) DeleteNote(ctx context.Context, userID, noteID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNote", ctx, userID, noteID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNote indicates an expected call of DeleteNote.
+func (mr *MockITextServiceMockRecorder) DeleteNote(ctx, userID, noteID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNote", reflect.TypeOf((*MockITextService)(nil).DeleteNote), ctx, userID, noteID)
+}
+
+// UpdateNote mocks base method.
+func (m *MockITextService) UpdateNote(ctx context.Context, userID int, note entity.TextDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNote", ctx, userID, note)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNote indicates an expected call of UpdateNote.
+func (mr *MockITextServiceMockRecorder) UpdateNote(ctx, userID, note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNote", reflect.TypeOf((*MockITextService)(nil).UpdateNote), ctx, userID, note)
+}
+
+// ViewNotes mocks base method.
+func (m *MockITextService) ViewNotes(ctx context.Context, userID int, page entity.PageRequest) ([]entity.TextDTO, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewNotes", ctx, userID, page)
+	ret0, _ := ret[0].([]entity.TextDTO)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ViewNotes indicates an expected call of ViewNotes.
+func (mr *MockITextServiceMockRecorder) ViewNotes(ctx, userID, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewNotes", reflect.TypeOf((*MockITextService)(nil).ViewNotes), ctx, userID, page)
+}
+
+// MockIBinaryService is a mock of IBinaryService interface.
+type MockIBinaryService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIBinaryServiceMockRecorder
+}
+
+// MockIBinaryServiceMockRecorder is the mock recorder for MockIBinaryService.
+type MockIBinaryServiceMockRecorder struct {
+	mock *MockIBinaryService
+}
+
+// NewMockIBinaryService creates a new mock instance.
+func NewMockIBinaryService(ctrl *gomock.Controller) *MockIBinaryService {
+	mock := &MockIBinaryService{ctrl: ctrl}
+	mock.recorder = &MockIBinaryServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIBinaryService) EXPECT() *MockIBinaryServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateBinary mocks base method.
+func (m *MockIBinaryService) CreateBinary(ctx context.Context, userID int, binary entity.BinaryDTO) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBinary", ctx, userID, binary)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBinary indicates an expected call of CreateBinary.
+func (mr *MockIBinaryServiceMockRecorder) CreateBinary(ctx, userID, binary interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBinary", reflect.TypeOf((*MockIBinaryService)(nil).CreateBinary), ctx, userID, binary)
+}
+
+// DeleteBinary mocks base method.
+func (m *MockIBinaryService) DeleteBinary(ctx context.Context, userID, binaryID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBinary", ctx, userID, binaryID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBinary indicates an expected call of DeleteBinary.
+func (mr *MockIBinaryServiceMockRecorder) DeleteBinary(ctx, userID, binaryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBinary", reflect.TypeOf((*MockIBinaryService)(nil).DeleteBinary), ctx, userID, binaryID)
+}
+
+// GetBinary mocks base method.
+func (m *MockIBinaryService) GetBinary(ctx context.Context, userID, binaryID int) (entity.BinaryDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBinary", ctx, userID, binaryID)
+	ret0, _ := ret[0].(entity.BinaryDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBinary indicates an expected call of GetBinary.
+func (mr *MockIBinaryServiceMockRecorder) GetBinary(ctx, userID, binaryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBinary", reflect.TypeOf((*MockIBinaryService)(nil).GetBinary), ctx, userID, binaryID)
+}
+
+// MaxStoredSize mocks base method.
+func (m *MockIBinaryService) MaxStoredSize() int {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaxStoredSize")
+	ret0, _ := ret[0].(int)
+	return ret0
+}
+
+// MaxStoredSize indicates an expected call of MaxStoredSize.
+func (mr *MockIBinaryServiceMockRecorder) MaxStoredSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaxStoredSize", reflect.TypeOf((*MockIBinaryService)(nil).MaxStoredSize))
+}
+
+// ViewBinaries mocks base method.
+func (m *MockIBinaryService) ViewBinaries(ctx context.Context, userID int, page entity.PageRequest) ([]entity.BinaryDTO, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewBinaries", ctx, userID, page)
+	ret0, _ := ret[0].([]entity.BinaryDTO)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ViewBinaries indicates an expected call of ViewBinaries.
+func (mr *MockIBinaryServiceMockRecorder) ViewBinaries(ctx, userID, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewBinaries", reflect.TypeOf((*MockIBinaryService)(nil).ViewBinaries), ctx, userID, page)
+}
+
+// MockIOTPService is a mock of IOTPService interface.
+type MockIOTPService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOTPServiceMockRecorder
+}
+
+// MockIOTPServiceMockRecorder is the mock recorder for MockIOTPService.
+type MockIOTPServiceMockRecorder struct {
+	mock *MockIOTPService
+}
+
+// NewMockIOTPService creates a new mock instance.
+func NewMockIOTPService(ctrl *gomock.Controller) *MockIOTPService {
+	mock := &MockIOTPService{ctrl: ctrl}
+	mock.recorder = &MockIOTPServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOTPService) EXPECT() *MockIOTPServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateOTP mocks base method.
+func (m *MockIOTPService) CreateOTP(ctx context.Context, userID int, otp entity.OTPDTO) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOTP", ctx, userID, otp)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOTP indicates an expected call of CreateOTP.
+func (mr *MockIOTPServiceMockRecorder) CreateOTP(ctx, userID, otp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOTP", reflect.TypeOf((*MockIOTPService)(nil).CreateOTP), ctx, userID, otp)
+}
+
+// DeleteOTP mocks base method.
+func (m *MockIOTPService) DeleteOTP(ctx context.Context, userID, otpID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOTP", ctx, userID, otpID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOTP indicates an expected call of DeleteOTP.
+func (mr *MockIOTPServiceMockRecorder) DeleteOTP(ctx, userID, otpID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOTP", reflect.TypeOf((*MockIOTPService)(nil).DeleteOTP), ctx, userID, otpID)
+}
+
+// UpdateOTP mocks base method.
+func (m *MockIOTPService) UpdateOTP(ctx context.Context, userID int, otp entity.OTPDTO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOTP", ctx, userID, otp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOTP indicates an expected call of UpdateOTP.
+func (mr *MockIOTPServiceMockRecorder) UpdateOTP(ctx, userID, otp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOTP", reflect.TypeOf((*MockIOTPService)(nil).UpdateOTP), ctx, userID, otp)
+}
+
+// ViewOTPs mocks base method.
+func (m *MockIOTPService) ViewOTPs(ctx context.Context, userID int, page entity.PageRequest) ([]entity.OTPDTO, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ViewOTPs", ctx, userID, page)
+	ret0, _ := ret[0].([]entity.OTPDTO)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ViewOTPs indicates an expected call of ViewOTPs.
+func (mr *MockIOTPServiceMockRecorder) ViewOTPs(ctx, userID, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewOTPs", reflect.TypeOf((*MockIOTPService)(nil).ViewOTPs), ctx, userID, page)
+}
+
+// MockISyncService is a mock of ISyncService interface.
+type MockISyncService struct {
+	ctrl     *gomock.Controller
+	recorder *MockISyncServiceMockRecorder
+}
+
+// MockISyncServiceMockRecorder is the mock recorder for MockISyncService.
+type MockISyncServiceMockRecorder struct {
+	mock *MockISyncService
+}
+
+// NewMockISyncService creates a new mock instance.
+func NewMockISyncService(ctrl *gomock.Controller) *MockISyncService {
+	mock := &MockISyncService{ctrl: ctrl}
+	mock.recorder = &MockISyncServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISyncService) EXPECT() *MockISyncServiceMockRecorder {
+	return m.recorder
+}
+
+// Sync mocks base method.
+func (m *MockISyncService) Sync(ctx context.Context, userID int, since int64) (entity.ChangesDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Sync", ctx, userID, since)
+	ret0, _ := ret[0].(entity.ChangesDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Sync indicates an expected call of Sync.
+func (mr *MockISyncServiceMockRecorder) Sync(ctx, userID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Sync", reflect.TypeOf((*MockISyncService)(nil).Sync), ctx, userID, since)
+}
+
+// MockILabelsService is a mock of ILabelsService interface.
+type MockILabelsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockILabelsServiceMockRecorder
+}
+
+// MockILabelsServiceMockRecorder is the mock recorder for MockILabelsService.
+type MockILabelsServiceMockRecorder struct {
+	mock *MockILabelsService
+}
+
+// NewMockILabelsService creates a new mock instance.
+func NewMockILabelsService(ctrl *gomock.Controller) *MockILabelsService {
+	mock := &MockILabelsService{ctrl: ctrl}
+	mock.recorder = &MockILabelsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockILabelsService) EXPECT() *MockILabelsServiceMockRecorder {
+	return m.recorder
+}
+
+// CreateFolder mocks base method.
+func (m *MockILabelsService) CreateFolder(ctx context.Context, userID int, folder entity.FolderDTO) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFolder", ctx, userID, folder)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFolder indicates an expected call of CreateFolder.
+func (mr *MockILabelsServiceMockRecorder) CreateFolder(ctx, userID, folder interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFolder", reflect.TypeOf((*MockILabelsService)(nil).CreateFolder), ctx, userID, folder)
+}
+
+// CreateTag mocks base method.
+func (m *MockILabelsService) CreateTag(ctx context.Context, userID int, tag entity.TagDTO) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTag", ctx, userID, tag)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTag indicates an expected call of CreateTag.
+func (mr *MockILabelsServiceMockRecorder) CreateTag(ctx, userID, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTag", reflect.TypeOf((*MockILabelsService)(nil).CreateTag), ctx, userID, tag)
+}
+
+// DeleteFolder mocks base method.
+func (m *MockILabelsService) DeleteFolder(ctx context.Context, userID, folderID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFolder", ctx, userID, folderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFolder indicates an expected call of DeleteFolder.
+func (mr *MockILabelsServiceMockRecorder) DeleteFolder(ctx, userID, folderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFolder", reflect.TypeOf((*MockILabelsService)(nil).DeleteFolder), ctx, userID, folderID)
+}
+
+// DeleteTag mocks base method.
+func (m *MockILabelsService) DeleteTag(ctx context.Context, userID, tagID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTag", ctx, userID, tagID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTag indicates an expected call of DeleteTag.
+func (mr *MockILabelsServiceMockRecorder) DeleteTag(ctx, userID, tagID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockILabelsService)(nil).DeleteTag), ctx, userID, tagID)
+}
+
+// ListFolders mocks base method.
+func (m *MockILabelsService) ListFolders(ctx context.Context, userID int) ([]entity.FolderDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFolders", ctx, userID)
+	ret0, _ := ret[0].([]entity.FolderDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFolders indicates an expected call of ListFolders.
+func (mr *MockILabelsServiceMockRecorder) ListFolders(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFolders", reflect.TypeOf((*MockILabelsService)(nil).ListFolders), ctx, userID)
+}
+
+// ListTags mocks base method.
+func (m *MockILabelsService) ListTags(ctx context.Context, userID int) ([]entity.TagDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTags", ctx, userID)
+	ret0, _ := ret[0].([]entity.TagDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTags indicates an expected call of ListTags.
+func (mr *MockILabelsServiceMockRecorder) ListTags(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTags", reflect.TypeOf((*MockILabelsService)(nil).ListTags), ctx, userID)
+}
+
+// RenameFolder mocks base method.
+func (m *MockILabelsService) RenameFolder(ctx context.Context, userID, folderID int, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameFolder", ctx, userID, folderID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameFolder indicates an expected call of RenameFolder.
+func (mr *MockILabelsServiceMockRecorder) RenameFolder(ctx, userID, folderID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameFolder", reflect.TypeOf((*MockILabelsService)(nil).RenameFolder), ctx, userID, folderID, name)
+}
+
+// RenameTag mocks base method.
+func (m *MockILabelsService) RenameTag(ctx context.Context, userID, tagID int, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameTag", ctx, userID, tagID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameTag indicates an expected call of RenameTag.
+func (mr *MockILabelsServiceMockRecorder) RenameTag(ctx, userID, tagID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameTag", reflect.TypeOf((*MockILabelsService)(nil).RenameTag), ctx, userID, tagID, name)
+}
+
+// MockIAccountService is a mock of IAccountService interface.
+type MockIAccountService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAccountServiceMockRecorder
+}
+
+// MockIAccountServiceMockRecorder is the mock recorder for MockIAccountService.
+type MockIAccountServiceMockRecorder struct {
+	mock *MockIAccountService
+}
+
+// NewMockIAccountService creates a new mock instance.
+func NewMockIAccountService(ctrl *gomock.Controller) *MockIAccountService {
+	mock := &MockIAccountService{ctrl: ctrl}
+	mock.recorder = &MockIAccountServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAccountService) EXPECT() *MockIAccountServiceMockRecorder {
+	return m.recorder
+}
+
+// DeleteAccount mocks base method.
+func (m *MockIAccountService) DeleteAccount(ctx context.Context, userID int, password, code, peer string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", ctx, userID, password, code, peer)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockIAccountServiceMockRecorder) DeleteAccount(ctx, userID, password, code, peer interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockIAccountService)(nil).DeleteAccount), ctx, userID, password, code, peer)
+}
+
+// ExportAccount mocks base method.
+func (m *MockIAccountService) ExportAccount(ctx context.Context, userID int) (entity.AccountDTO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportAccount", ctx, userID)
+	ret0, _ := ret[0].(entity.AccountDTO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportAccount indicates an expected call of ExportAccount.
+func (mr *MockIAccountServiceMockRecorder) ExportAccount(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportAccount", reflect.TypeOf((*MockIAccountService)(nil).ExportAccount), ctx, userID)
+}
+
+// MockIRepo is a mock of IRepo interface.
+type MockIRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockIRepoMockRecorder
+}
+
+// MockIRepoMockRecorder is the mock recorder for MockIRepo.
+type MockIRepoMockRecorder struct {
+	mock *MockIRepo
+}
+
+// NewMockIRepo creates a new mock instance.
+func NewMockIRepo(ctrl *gomock.Controller) *MockIRepo {
+	mock := &MockIRepo{ctrl: ctrl}
+	mock.recorder = &MockIRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIRepo) EXPECT() *MockIRepoMockRecorder {
+	return m.recorder
+}
+
+// AddLoginFailure mocks base method.
+func (m *MockIRepo) AddLoginFailure(ctx context.Context, key string, window time.Duration) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLoginFailure", ctx, key, window)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddLoginFailure indicates an expected call of AddLoginFailure.
+func (mr *MockIRepoMockRecorder) AddLoginFailure(ctx, key, window interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLoginFailure", reflect.TypeOf((*MockIRepo)(nil).AddLoginFailure), ctx, key, window)
+}
+
+// ChangePassword mocks base method.
+func (m *MockIRepo) ChangePassword(ctx context.Context, userID int, oldHash, newHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, userID, oldHash, newHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockIRepoMockRecorder) ChangePassword(ctx, userID, oldHash, newHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockIRepo)(nil).ChangePassword), ctx, userID, oldHash, newHash)
+}
+
+// CloseConnection mocks base method.
+func (m *MockIRepo) CloseConnection() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CloseConnection")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CloseConnection indicates an expected call of CloseConnection.
+func (mr *MockIRepoMockRecorder) CloseConnection() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseConnection", reflect.TypeOf((*MockIRepo)(nil).CloseConnection))
+}
+
+// ConfirmTOTP mocks base method.
+func (m *MockIRepo) ConfirmTOTP(ctx context.Context, userID int, step int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTOTP", ctx, userID, step)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmTOTP indicates an expected call of ConfirmTOTP.
+func (mr *MockIRepoMockRecorder) ConfirmTOTP(ctx, userID, step interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTOTP", reflect.TypeOf((*MockIRepo)(nil).ConfirmTOTP), ctx, userID, step)
+}
+
+// CreateBinary mocks base method.
+func (m *MockIRepo) CreateBinary(ctx context.Context, binary entity.BinaryDAO) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateBinary", ctx, binary)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateBinary indicates an expected call of CreateBinary.
+func (mr *MockIRepoMockRecorder) CreateBinary(ctx, binary interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBinary", reflect.TypeOf((*MockIRepo)(nil).CreateBinary), ctx, binary)
+}
+
+// CreateCard mocks base method.
+func (m *MockIRepo) CreateCard(ctx context.Context, card entity.BankDAO) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateCard", ctx, card)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCard indicates an expected call of CreateCard.
+func (mr *MockIRepoMockRecorder) CreateCard(ctx, card interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCard", reflect.TypeOf((*MockIRepo)(nil).CreateCard), ctx, card)
+}
+
+// CreateChallenge mocks base method.
+func (m *MockIRepo) CreateChallenge(ctx context.Context, challenge entity.ChallengeDAO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChallenge", ctx, challenge)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateChallenge indicates an expected call of CreateChallenge.
+func (mr *MockIRepoMockRecorder) CreateChallenge(ctx, challenge interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChallenge", reflect.TypeOf((*MockIRepo)(nil).CreateChallenge), ctx, challenge)
+}
+
+// CreateFolder mocks base method.
+func (m *MockIRepo) CreateFolder(ctx context.Context, folder entity.FolderDAO) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFolder", ctx, folder)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFolder indicates an expected call of CreateFolder.
+func (mr *MockIRepoMockRecorder) CreateFolder(ctx, folder interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFolder", reflect.TypeOf((*MockIRepo)(nil).CreateFolder), ctx, folder)
+}
+
+// CreateNote mocks base method.
+func (m *MockIRepo) CreateNote(ctx context.Context, note entity.TextDAO) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateNote", ctx, note)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateNote indicates an expected call of CreateNote.
+func (mr *MockIRepoMockRecorder) CreateNote(ctx, note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNote", reflect.TypeOf((*MockIRepo)(nil).CreateNote), ctx, note)
+}
+
+// CreateOTP mocks base method.
+func (m *MockIRepo) CreateOTP(ctx context.Context, otp entity.OTPDAO) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOTP", ctx, otp)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOTP indicates an expected call of CreateOTP.
+func (mr *MockIRepoMockRecorder) CreateOTP(ctx, otp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOTP", reflect.TypeOf((*MockIRepo)(nil).CreateOTP), ctx, otp)
+}
+
+// CreatePair mocks base method.
+func (m *MockIRepo) CreatePair(ctx context.Context, pair entity.PairDAO) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePair", ctx, pair)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePair indicates an expected call of CreatePair.
+func (mr *MockIRepoMockRecorder) CreatePair(ctx, pair interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePair", reflect.TypeOf((*MockIRepo)(nil).CreatePair), ctx, pair)
+}
+
+// CreateSecurityEvent mocks base method.
+func (m *MockIRepo) CreateSecurityEvent(ctx context.Context, event entity.SecurityEventDAO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSecurityEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSecurityEvent indicates an expected call of CreateSecurityEvent.
+func (mr *MockIRepoMockRecorder) CreateSecurityEvent(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecurityEvent", reflect.TypeOf((*MockIRepo)(nil).CreateSecurityEvent), ctx, event)
+}
+
+// CreateSession mocks base method.
+func (m *MockIRepo) CreateSession(ctx context.Context, session entity.SessionDAO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockIRepoMockRecorder) CreateSession(ctx, session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockIRepo)(nil).CreateSession), ctx, session)
+}
+
+// CreateTag mocks base method.
+func (m *MockIRepo) CreateTag(ctx context.Context, tag entity.TagDAO) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateTag", ctx, tag)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateTag indicates an expected call of CreateTag.
+func (mr *MockIRepoMockRecorder) CreateTag(ctx, tag interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTag", reflect.TypeOf((*MockIRepo)(nil).CreateTag), ctx, tag)
+}
+
+// CreateUser mocks base method.
+func (m *MockIRepo) CreateUser(ctx context.Context, login, passwordHash string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", ctx, login, passwordHash)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockIRepoMockRecorder) CreateUser(ctx, login, passwordHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockIRepo)(nil).CreateUser), ctx, login, passwordHash)
+}
+
+// DeleteAccount mocks base method.
+func (m *MockIRepo) DeleteAccount(ctx context.Context, userID int, passwordHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", ctx, userID, passwordHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockIRepoMockRecorder) DeleteAccount(ctx, userID, passwordHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockIRepo)(nil).DeleteAccount), ctx, userID, passwordHash)
+}
+
+// DeleteBinary mocks base method.
+func (m *MockIRepo) DeleteBinary(ctx context.Context, userID, binaryID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBinary", ctx, userID, binaryID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBinary indicates an expected call of DeleteBinary.
+func (mr *MockIRepoMockRecorder) DeleteBinary(ctx, userID, binaryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBinary", reflect.TypeOf((*MockIRepo)(nil).DeleteBinary), ctx, userID, binaryID)
+}
+
+// DeleteCard mocks base method.
+func (m *MockIRepo) DeleteCard(ctx context.Context, userID, cardID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteCard", ctx, userID, cardID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteCard indicates an expected call of DeleteCard.
+func (mr *MockIRepoMockRecorder) DeleteCard(ctx, userID, cardID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCard", reflect.TypeOf((*MockIRepo)(nil).DeleteCard), ctx, userID, cardID)
+}
+
+// DeleteChallenge mocks base method.
+func (m *MockIRepo) DeleteChallenge(ctx context.Context, challengeID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChallenge", ctx, challengeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChallenge indicates an expected call of DeleteChallenge.
+func (mr *MockIRepoMockRecorder) DeleteChallenge(ctx, challengeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChallenge", reflect.TypeOf((*MockIRepo)(nil).DeleteChallenge), ctx, challengeID)
+}
+
+// DeleteFolder mocks base method.
+func (m *MockIRepo) DeleteFolder(ctx context.Context, userID, folderID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFolder", ctx, userID, folderID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFolder indicates an expected call of DeleteFolder.
+func (mr *MockIRepoMockRecorder) DeleteFolder(ctx, userID, folderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFolder", reflect.TypeOf((*MockIRepo)(nil).DeleteFolder), ctx, userID, folderID)
+}
+
+// DeleteNote mocks base method.
+func (m *MockIRepo) DeleteNote(ctx context.Context, userID, noteID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteNote", ctx, userID, noteID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteNote indicates an expected call of DeleteNote.
+func (mr *MockIRepoMockRecorder) DeleteNote(ctx, userID, noteID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNote", reflect.TypeOf((*MockIRepo)(nil).DeleteNote), ctx, userID, noteID)
+}
+
+// DeleteOTP mocks base method.
+func (m *MockIRepo) DeleteOTP(ctx context.Context, userID, otpID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOTP", ctx, userID, otpID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOTP indicates an expected call of DeleteOTP.
+func (mr *MockIRepoMockRecorder) DeleteOTP(ctx, userID, otpID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOTP", reflect.TypeOf((*MockIRepo)(nil).DeleteOTP), ctx, userID, otpID)
+}
+
+// DeletePair mocks base method.
+func (m *MockIRepo) DeletePair(ctx context.Context, userID, pairID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePair", ctx, userID, pairID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePair indicates an expected call of DeletePair.
+func (mr *MockIRepoMockRecorder) DeletePair(ctx, userID, pairID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePair", reflect.TypeOf((*MockIRepo)(nil).DeletePair), ctx, userID, pairID)
+}
+
+// DeleteTOTP mocks base method.
+func (m *MockIRepo) DeleteTOTP(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTOTP", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTOTP indicates an expected call of DeleteTOTP.
+func (mr *MockIRepoMockRecorder) DeleteTOTP(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTOTP", reflect.TypeOf((*MockIRepo)(nil).DeleteTOTP), ctx, userID)
+}
+
+// DeleteTag mocks base method.
+func (m *MockIRepo) DeleteTag(ctx context.Context, userID, tagID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTag", ctx, userID, tagID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTag indicates an expected call of DeleteTag.
+func (mr *MockIRepoMockRecorder) DeleteTag(ctx, userID, tagID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockIRepo)(nil).DeleteTag), ctx, userID, tagID)
+}
+
+// ExportAccount mocks base method.
+func (m *MockIRepo) ExportAccount(ctx context.Context, userID int) (entity.AccountDAO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportAccount", ctx, userID)
+	ret0, _ := ret[0].(entity.AccountDAO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExportAccount indicates an expected call of ExportAccount.
+func (mr *MockIRepoMockRecorder) ExportAccount(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportAccount", reflect.TypeOf((*MockIRepo)(nil).ExportAccount), ctx, userID)
+}
+
+// GetBinary mocks base method.
+func (m *MockIRepo) GetBinary(ctx context.Context, userID, binaryID int) (entity.BinaryDAO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBinary", ctx, userID, binaryID)
+	ret0, _ := ret[0].(entity.BinaryDAO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBinary indicates an expected call of GetBinary.
+func (mr *MockIRepoMockRecorder) GetBinary(ctx, userID, binaryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBinary", reflect.TypeOf((*MockIRepo)(nil).GetBinary), ctx, userID, binaryID)
+}
+
+// GetChallenge mocks base method.
+func (m *MockIRepo) GetChallenge(ctx context.Context, challengeID string) (entity.ChallengeDAO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChallenge", ctx, challengeID)
+	ret0, _ := ret[0].(entity.ChallengeDAO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChallenge indicates an expected call of GetChallenge.
+func (mr *MockIRepoMockRecorder) GetChallenge(ctx, challengeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChallenge", reflect.TypeOf((*MockIRepo)(nil).GetChallenge), ctx, challengeID)
+}
+
+// GetChanges mocks base method.
+func (m *MockIRepo) GetChanges(ctx context.Context, userID int, since int64) (entity.ChangesDAO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChanges", ctx, userID, since)
+	ret0, _ := ret[0].(entity.ChangesDAO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChanges indicates an expected call of GetChanges.
+func (mr *MockIRepoMockRecorder) GetChanges(ctx, userID, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChanges", reflect.TypeOf((*MockIRepo)(nil).GetChanges), ctx, userID, since)
+}
+
+// GetLoginLock mocks base method.
+func (m *MockIRepo) GetLoginLock(ctx context.Context, keys ...string) (time.Time, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range keys {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetLoginLock", varargs...)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginLock indicates an expected call of GetLoginLock.
+func (mr *MockIRepoMockRecorder) GetLoginLock(ctx interface{}, keys ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, keys...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginLock", reflect.TypeOf((*MockIRepo)(nil).GetLoginLock), varargs...)
+}
+
+// GetPasswordHistory mocks base method.
+func (m *MockIRepo) GetPasswordHistory(ctx context.Context, userID, limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasswordHistory", ctx, userID, limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPasswordHistory indicates an expected call of GetPasswordHistory.
+func (mr *MockIRepoMockRecorder) GetPasswordHistory(ctx, userID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordHistory", reflect.TypeOf((*MockIRepo)(nil).GetPasswordHistory), ctx, userID, limit)
+}
+
+// GetSecurityEvents mocks base method.
+func (m *MockIRepo) GetSecurityEvents(ctx context.Context, userID, limit int) ([]entity.SecurityEventDAO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecurityEvents", ctx, userID, limit)
+	ret0, _ := ret[0].([]entity.SecurityEventDAO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecurityEvents indicates an expected call of GetSecurityEvents.
+func (mr *MockIRepoMockRecorder) GetSecurityEvents(ctx, userID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecurityEvents", reflect.TypeOf((*MockIRepo)(nil).GetSecurityEvents), ctx, userID, limit)
+}
+
+// GetSession mocks base method.
+func (m *MockIRepo) GetSession(ctx context.Context, sessionID string) (entity.SessionDAO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", ctx, sessionID)
+	ret0, _ := ret[0].(entity.SessionDAO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockIRepoMockRecorder) GetSession(ctx, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockIRepo)(nil).GetSession), ctx, sessionID)
+}
+
+// GetTOTP mocks base method.
+func (m *MockIRepo) GetTOTP(ctx context.Context, userID int) (entity.TOTPDAO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTOTP", ctx, userID)
+	ret0, _ := ret[0].(entity.TOTPDAO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTOTP indicates an expected call of GetTOTP.
+func (mr *MockIRepoMockRecorder) GetTOTP(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTOTP", reflect.TypeOf((*MockIRepo)(nil).GetTOTP), ctx, userID)
+}
+
+// GetUser mocks base method.
+func (m *MockIRepo) GetUser(ctx context.Context, login string) (entity.UserDAO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, login)
+	ret0, _ := ret[0].(entity.UserDAO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockIRepoMockRecorder) GetUser(ctx, login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockIRepo)(nil).GetUser), ctx, login)
+}
+
+// GetUserByID mocks base method.
+func (m *MockIRepo) GetUserByID(ctx context.Context, userID int) (entity.UserDAO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, userID)
+	ret0, _ := ret[0].(entity.UserDAO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockIRepoMockRecorder) GetUserByID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockIRepo)(nil).GetUserByID), ctx, userID)
+}
+
+// ListBinaries mocks base method.
+func (m *MockIRepo) ListBinaries(ctx context.Context, userID int, query entity.ListQuery) ([]entity.BinaryDAO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBinaries", ctx, userID, query)
+	ret0, _ := ret[0].([]entity.BinaryDAO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBinaries indicates an expected call of ListBinaries.
+func (mr *MockIRepoMockRecorder) ListBinaries(ctx, userID, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBinaries", reflect.TypeOf((*MockIRepo)(nil).ListBinaries), ctx, userID, query)
+}
+
+// ListCards mocks base method.
+func (m *MockIRepo) ListCards(ctx context.Context, userID int, query entity.ListQuery) ([]entity.BankDAO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListCards", ctx, userID, query)
+	ret0, _ := ret[0].([]entity.BankDAO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCards indicates an expected call of ListCards.
+func (mr *MockIRepoMockRecorder) ListCards(ctx, userID, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCards", reflect.TypeOf((*MockIRepo)(nil).ListCards), ctx, userID, query)
+}
+
+// ListFolders mocks base method.
+func (m *MockIRepo) ListFolders(ctx context.Context, userID int) ([]entity.FolderDAO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFolders", ctx, userID)
+	ret0, _ := ret[0].([]entity.FolderDAO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFolders indicates an expected call of ListFolders.
+func (mr *MockIRepoMockRecorder) ListFolders(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFolders", reflect.TypeOf((*MockIRepo)(nil).ListFolders), ctx, userID)
+}
+
+// ListNotes mocks base method.
+func (m *MockIRepo) ListNotes(ctx context.Context, userID int, query entity.ListQuery) ([]entity.TextDAO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListNotes", ctx, userID, query)
+	ret0, _ := ret[0].([]entity.TextDAO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListNotes indicates an expected call of ListNotes.
+func (mr *MockIRepoMockRecorder) ListNotes(ctx, userID, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotes", reflect.TypeOf((*MockIRepo)(nil).ListNotes), ctx, userID, query)
+}
+
+// ListOTPs mocks base method.
+func (m *MockIRepo) ListOTPs(ctx context.Context, userID int, query entity.ListQuery) ([]entity.OTPDAO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListOTPs", ctx, userID, query)
+	ret0, _ := ret[0].([]entity.OTPDAO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListOTPs indicates an expected call of ListOTPs.
+func (mr *MockIRepoMockRecorder) ListOTPs(ctx, userID, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOTPs", reflect.TypeOf((*MockIRepo)(nil).ListOTPs), ctx, userID, query)
+}
+
+// ListPairs mocks base method.
+func (m *MockIRepo) ListPairs(ctx context.Context, userID int, query entity.ListQuery) ([]entity.PairDAO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPairs", ctx, userID, query)
+	ret0, _ := ret[0].([]entity.PairDAO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPairs indicates an expected call of ListPairs.
+func (mr *MockIRepoMockRecorder) ListPairs(ctx, userID, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPairs", reflect.TypeOf((*MockIRepo)(nil).ListPairs), ctx, userID, query)
+}
+
+// ListTags mocks base method.
+func (m *MockIRepo) ListTags(ctx context.Context, userID int) ([]entity.TagDAO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTags", ctx, userID)
+	ret0, _ := ret[0].([]entity.TagDAO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListTags indicates an expected call of ListTags.
+func (mr *MockIRepoMockRecorder) ListTags(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTags", reflect.TypeOf((*MockIRepo)(nil).ListTags), ctx, userID)
+}
+
+// LockLogin mocks base method.
+func (m *MockIRepo) LockLogin(ctx context.Context, key string, until time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockLogin", ctx, key, until)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockLogin indicates an expected call of LockLogin.
+func (mr *MockIRepoMockRecorder) LockLogin(ctx, key, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockLogin", reflect.TypeOf((*MockIRepo)(nil).LockLogin), ctx, key, until)
+}
+
+// Ping mocks base method.
+func (m *MockIRepo) Ping(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Ping", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Ping indicates an expected call of Ping.
+func (mr *MockIRepoMockRecorder) Ping(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Ping", reflect.TypeOf((*MockIRepo)(nil).Ping), ctx)
+}
+
+// RenameFolder mocks base method.
+func (m *MockIRepo) RenameFolder(ctx context.Context, userID, folderID int, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameFolder", ctx, userID, folderID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameFolder indicates an expected call of RenameFolder.
+func (mr *MockIRepoMockRecorder) RenameFolder(ctx, userID, folderID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameFolder", reflect.TypeOf((*MockIRepo)(nil).RenameFolder), ctx, userID, folderID, name)
+}
+
+// RenameTag mocks base method.
+func (m *MockIRepo) RenameTag(ctx context.Context, userID, tagID int, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameTag", ctx, userID, tagID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RenameTag indicates an expected call of RenameTag.
+func (mr *MockIRepoMockRecorder) RenameTag(ctx, userID, tagID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameTag", reflect.TypeOf((*MockIRepo)(nil).RenameTag), ctx, userID, tagID, name)
+}
+
+// ResetLoginFailures mocks base method.
+func (m *MockIRepo) ResetLoginFailures(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetLoginFailures", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetLoginFailures indicates an expected call of ResetLoginFailures.
+func (mr *MockIRepoMockRecorder) ResetLoginFailures(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetLoginFailures", reflect.TypeOf((*MockIRepo)(nil).ResetLoginFailures), ctx, key)
+}
+
+// RevokeSession mocks base method.
+func (m *MockIRepo) RevokeSession(ctx context.Context, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockIRepoMockRecorder) RevokeSession(ctx, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockIRepo)(nil).RevokeSession), ctx, sessionID)
+}
+
+// RotateSession mocks base method.
+func (m *MockIRepo) RotateSession(ctx context.Context, sessionID, oldHash, newHash string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateSession", ctx, sessionID, oldHash, newHash, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RotateSession indicates an expected call of RotateSession.
+func (mr *MockIRepoMockRecorder) RotateSession(ctx, sessionID, oldHash, newHash, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSession", reflect.TypeOf((*MockIRepo)(nil).RotateSession), ctx, sessionID, oldHash, newHash, expiresAt)
+}
+
+// SaveTOTPPending mocks base method.
+func (m *MockIRepo) SaveTOTPPending(ctx context.Context, userID int, secret string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTOTPPending", ctx, userID, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTOTPPending indicates an expected call of SaveTOTPPending.
+func (mr *MockIRepoMockRecorder) SaveTOTPPending(ctx, userID, secret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTOTPPending", reflect.TypeOf((*MockIRepo)(nil).SaveTOTPPending), ctx, userID, secret)
+}
+
+// UpdateCard mocks base method.
+func (m *MockIRepo) UpdateCard(ctx context.Context, card entity.BankDAO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateCard", ctx, card)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateCard indicates an expected call of UpdateCard.
+func (mr *MockIRepoMockRecorder) UpdateCard(ctx, card interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCard", reflect.TypeOf((*MockIRepo)(nil).UpdateCard), ctx, card)
+}
+
+// UpdateNote mocks base method.
+func (m *MockIRepo) UpdateNote(ctx context.Context, note entity.TextDAO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNote", ctx, note)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNote indicates an expected call of UpdateNote.
+func (mr *MockIRepoMockRecorder) UpdateNote(ctx, note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNote", reflect.TypeOf((*MockIRepo)(nil).UpdateNote), ctx, note)
+}
+
+// UpdateOTP mocks base method.
+func (m *MockIRepo) UpdateOTP(ctx context.Context, otp entity.OTPDAO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateOTP", ctx, otp)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateOTP indicates an expected call of UpdateOTP.
+func (mr *MockIRepoMockRecorder) UpdateOTP(ctx, otp interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOTP", reflect.TypeOf((*MockIRepo)(nil).UpdateOTP), ctx, otp)
+}
+
+// UpdatePair mocks base method.
+func (m *MockIRepo) UpdatePair(ctx context.Context, pair entity.PairDAO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePair", ctx, pair)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePair indicates an expected call of UpdatePair.
+func (mr *MockIRepoMockRecorder) UpdatePair(ctx, pair interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePair", reflect.TypeOf((*MockIRepo)(nil).UpdatePair), ctx, pair)
+}
+
+// UpdatePasswordHash mocks base method.
+func (m *MockIRepo) UpdatePasswordHash(ctx context.Context, userID int, oldHash, newHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePasswordHash", ctx, userID, oldHash, newHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePasswordHash indicates an expected call of UpdatePasswordHash.
+func (mr *MockIRepoMockRecorder) UpdatePasswordHash(ctx, userID, oldHash, newHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePasswordHash", reflect.TypeOf((*MockIRepo)(nil).UpdatePasswordHash), ctx, userID, oldHash, newHash)
+}
+
+// UseTOTPStep mocks base method.
+func (m *MockIRepo) UseTOTPStep(ctx context.Context, userID int, step int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTOTPStep", ctx, userID, step)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseTOTPStep indicates an expected call of UseTOTPStep.
+func (mr *MockIRepoMockRecorder) UseTOTPStep(ctx, userID, step interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTOTPStep", reflect.TypeOf((*MockIRepo)(nil).UseTOTPStep), ctx, userID, step)
+}
+
+// MockIAuthorizationRepo is a mock of IAuthorizationRepo interface.
+type MockIAuthorizationRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAuthorizationRepoMockRecorder
+}
+
+// MockIAuthorizationRepoMockRecorder is the mock recorder for MockIAuthorizationRepo.
+type MockIAuthorizationRepoMockRecorder struct {
+	mock *MockIAuthorizationRepo
+}
+
+// NewMockIAuthorizationRepo creates a new mock instance.
+func NewMockIAuthorizationRepo(ctrl *gomock.Controller) *MockIAuthorizationRepo {
+	mock := &MockIAuthorizationRepo{ctrl: ctrl}
+	mock.recorder = &MockIAuthorizationRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAuthorizationRepo) EXPECT() *MockIAuthorizationRepoMockRecorder {
+	return m.recorder
+}
+
+// AddLoginFailure mocks base method.
+func (m *MockIAuthorizationRepo) AddLoginFailure(ctx context.Context, key string, window time.Duration) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddLoginFailure", ctx, key, window)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddLoginFailure indicates an expected call of AddLoginFailure.
+func (mr *MockIAuthorizationRepoMockRecorder) AddLoginFailure(ctx, key, window interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddLoginFailure", reflect.TypeOf((*MockIAuthorizationRepo)(nil).AddLoginFailure), ctx, key, window)
+}
+
+// ChangePassword mocks base method.
+func (m *MockIAuthorizationRepo) ChangePassword(ctx context.Context, userID int, oldHash, newHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, userID, oldHash, newHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockIAuthorizationRepoMockRecorder) ChangePassword(ctx, userID, oldHash, newHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockIAuthorizationRepo)(nil).ChangePassword), ctx, userID, oldHash, newHash)
+}
+
+// ConfirmTOTP mocks base method.
+func (m *MockIAuthorizationRepo) ConfirmTOTP(ctx context.Context, userID int, step int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConfirmTOTP", ctx, userID, step)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ConfirmTOTP indicates an expected call of ConfirmTOTP.
+func (mr *MockIAuthorizationRepoMockRecorder) ConfirmTOTP(ctx, userID, step interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConfirmTOTP", reflect.TypeOf((*MockIAuthorizationRepo)(nil).ConfirmTOTP), ctx, userID, step)
+}
+
+// CreateChallenge mocks base method.
+func (m *MockIAuthorizationRepo) CreateChallenge(ctx context.Context, challenge entity.ChallengeDAO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChallenge", ctx, challenge)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateChallenge indicates an expected call of CreateChallenge.
+func (mr *MockIAuthorizationRepoMockRecorder) CreateChallenge(ctx, challenge interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChallenge", reflect.TypeOf((*MockIAuthorizationRepo)(nil).CreateChallenge), ctx, challenge)
+}
+
+// CreateSecurityEvent mocks base method.
+func (m *MockIAuthorizationRepo) CreateSecurityEvent(ctx context.Context, event entity.SecurityEventDAO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSecurityEvent", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSecurityEvent indicates an expected call of CreateSecurityEvent.
+func (mr *MockIAuthorizationRepoMockRecorder) CreateSecurityEvent(ctx, event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSecurityEvent", reflect.TypeOf((*MockIAuthorizationRepo)(nil).CreateSecurityEvent), ctx, event)
+}
+
+// CreateSession mocks base method.
+func (m *MockIAuthorizationRepo) CreateSession(ctx context.Context, session entity.SessionDAO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", ctx, session)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockIAuthorizationRepoMockRecorder) CreateSession(ctx, session interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockIAuthorizationRepo)(nil).CreateSession), ctx, session)
+}
+
+// CreateUser mocks base method.
+func (m *MockIAuthorizationRepo) CreateUser(ctx context.Context, login, passwordHash string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", ctx, login, passwordHash)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockIAuthorizationRepoMockRecorder) CreateUser(ctx, login, passwordHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockIAuthorizationRepo)(nil).CreateUser), ctx, login, passwordHash)
+}
+
+// DeleteChallenge mocks base method.
+func (m *MockIAuthorizationRepo) DeleteChallenge(ctx context.Context, challengeID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteChallenge", ctx, challengeID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteChallenge indicates an expected call of DeleteChallenge.
+func (mr *MockIAuthorizationRepoMockRecorder) DeleteChallenge(ctx, challengeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteChallenge", reflect.TypeOf((*MockIAuthorizationRepo)(nil).DeleteChallenge), ctx, challengeID)
+}
+
+// DeleteTOTP mocks base method.
+func (m *MockIAuthorizationRepo) DeleteTOTP(ctx context.Context, userID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTOTP", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteTOTP indicates an expected call of DeleteTOTP.
+func (mr *MockIAuthorizationRepoMockRecorder) DeleteTOTP(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTOTP", reflect.TypeOf((*MockIAuthorizationRepo)(nil).DeleteTOTP), ctx, userID)
+}
+
+// GetChallenge mocks base method.
+func (m *MockIAuthorizationRepo) GetChallenge(ctx context.Context, challengeID string) (entity.ChallengeDAO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChallenge", ctx, challengeID)
+	ret0, _ := ret[0].(entity.ChallengeDAO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChallenge indicates an expected call of GetChallenge.
+func (mr *MockIAuthorizationRepoMockRecorder) GetChallenge(ctx, challengeID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChallenge", reflect.TypeOf((*MockIAuthorizationRepo)(nil).GetChallenge), ctx, challengeID)
+}
+
+// GetLoginLock mocks base method.
+func (m *MockIAuthorizationRepo) GetLoginLock(ctx context.Context, keys ...string) (time.Time, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range keys {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetLoginLock", varargs...)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLoginLock indicates an expected call of GetLoginLock.
+func (mr *MockIAuthorizationRepoMockRecorder) GetLoginLock(ctx interface{}, keys ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, keys...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLoginLock", reflect.TypeOf((*MockIAuthorizationRepo)(nil).GetLoginLock), varargs...)
+}
+
+// GetPasswordHistory mocks base method.
+func (m *MockIAuthorizationRepo) GetPasswordHistory(ctx context.Context, userID, limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasswordHistory", ctx, userID, limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPasswordHistory indicates an expected call of GetPasswordHistory.
+func (mr *MockIAuthorizationRepoMockRecorder) GetPasswordHistory(ctx, userID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordHistory", reflect.TypeOf((*MockIAuthorizationRepo)(nil).GetPasswordHistory), ctx, userID, limit)
+}
+
+// GetSecurityEvents mocks base method.
+func (m *MockIAuthorizationRepo) GetSecurityEvents(ctx context.Context, userID, limit int) ([]entity.SecurityEventDAO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSecurityEvents", ctx, userID, limit)
+	ret0, _ := ret[0].([]entity.SecurityEventDAO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSecurityEvents indicates an expected call of GetSecurityEvents.
+func (mr *MockIAuthorizationRepoMockRecorder) GetSecurityEvents(ctx, userID, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSecurityEvents", reflect.TypeOf((*MockIAuthorizationRepo)(nil).GetSecurityEvents), ctx, userID, limit)
+}
+
+// GetSession mocks base method.
+func (m *MockIAuthorizationRepo) GetSession(ctx context.Context, sessionID string) (entity.SessionDAO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSession", ctx, sessionID)
+	ret0, _ := ret[0].(entity.SessionDAO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSession indicates an expected call of GetSession.
+func (mr *MockIAuthorizationRepoMockRecorder) GetSession(ctx, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSession", reflect.TypeOf((*MockIAuthorizationRepo)(nil).GetSession), ctx, sessionID)
+}
+
+// GetTOTP mocks base method.
+func (m *MockIAuthorizationRepo) GetTOTP(ctx context.Context, userID int) (entity.TOTPDAO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTOTP", ctx, userID)
+	ret0, _ := ret[0].(entity.TOTPDAO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTOTP indicates an expected call of GetTOTP.
+func (mr *MockIAuthorizationRepoMockRecorder) GetTOTP(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTOTP", reflect.TypeOf((*MockIAuthorizationRepo)(nil).GetTOTP), ctx, userID)
+}
+
+// GetUser mocks base method.
+func (m *MockIAuthorizationRepo) GetUser(ctx context.Context, login string) (entity.UserDAO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", ctx, login)
+	ret0, _ := ret[0].(entity.UserDAO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockIAuthorizationRepoMockRecorder) GetUser(ctx, login interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockIAuthorizationRepo)(nil).GetUser), ctx, login)
+}
+
+// GetUserByID mocks base method.
+func (m *MockIAuthorizationRepo) GetUserByID(ctx context.Context, userID int) (entity.UserDAO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByID", ctx, userID)
+	ret0, _ := ret[0].(entity.UserDAO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByID indicates an expected call of GetUserByID.
+func (mr *MockIAuthorizationRepoMockRecorder) GetUserByID(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByID", reflect.TypeOf((*MockIAuthorizationRepo)(nil).GetUserByID), ctx, userID)
+}
+
+// LockLogin mocks base method.
+func (m *MockIAuthorizationRepo) LockLogin(ctx context.Context, key string, until time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockLogin", ctx, key, until)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockLogin indicates an expected call of LockLogin.
+func (mr *MockIAuthorizationRepoMockRecorder) LockLogin(ctx, key, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockLogin", reflect.TypeOf((*MockIAuthorizationRepo)(nil).LockLogin), ctx, key, until)
+}
+
+// ResetLoginFailures mocks base method.
+func (m *MockIAuthorizationRepo) ResetLoginFailures(ctx context.Context, key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetLoginFailures", ctx, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetLoginFailures indicates an expected call of ResetLoginFailures.
+func (mr *MockIAuthorizationRepoMockRecorder) ResetLoginFailures(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetLoginFailures", reflect.TypeOf((*MockIAuthorizationRepo)(nil).ResetLoginFailures), ctx, key)
+}
+
+// RevokeSession mocks base method.
+func (m *MockIAuthorizationRepo) RevokeSession(ctx context.Context, sessionID string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSession", ctx, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSession indicates an expected call of RevokeSession.
+func (mr *MockIAuthorizationRepoMockRecorder) RevokeSession(ctx, sessionID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSession", reflect.TypeOf((*MockIAuthorizationRepo)(nil).RevokeSession), ctx, sessionID)
+}
+
+// RotateSession mocks base method.
+func (m *MockIAuthorizationRepo) RotateSession(ctx context.Context, sessionID, oldHash, newHash string, expiresAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateSession", ctx, sessionID, oldHash, newHash, expiresAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RotateSession indicates an expected call of RotateSession.
+func (mr *MockIAuthorizationRepoMockRecorder) RotateSession(ctx, sessionID, oldHash, newHash, expiresAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateSession", reflect.TypeOf((*MockIAuthorizationRepo)(nil).RotateSession), ctx, sessionID, oldHash, newHash, expiresAt)
+}
+
+// SaveTOTPPending mocks base method.
+func (m *MockIAuthorizationRepo) SaveTOTPPending(ctx context.Context, userID int, secret string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveTOTPPending", ctx, userID, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveTOTPPending indicates an expected call of SaveTOTPPending.
+func (mr *MockIAuthorizationRepoMockRecorder) SaveTOTPPending(ctx, userID, secret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveTOTPPending", reflect.TypeOf((*MockIAuthorizationRepo)(nil).SaveTOTPPending), ctx, userID, secret)
+}
+
+// UpdatePasswordHash mocks base method.
+func (m *MockIAuthorizationRepo) UpdatePasswordHash(ctx context.Context, userID int, oldHash, newHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePasswordHash", ctx, userID, oldHash, newHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePasswordHash indicates an expected call of UpdatePasswordHash.
+func (mr *MockIAuthorizationRepoMockRecorder) UpdatePasswordHash(ctx, userID, oldHash, newHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePasswordHash", reflect.TypeOf((*MockIAuthorizationRepo)(nil).UpdatePasswordHash), ctx, userID, oldHash, newHash)
+}
+
+// UseTOTPStep mocks base method.
+func (m *MockIAuthorizationRepo) UseTOTPStep(ctx context.Context, userID int, step int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseTOTPStep", ctx, userID, step)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseTOTPStep indicates an expected call of UseTOTPStep.
+func (mr *MockIAuthorizationRepoMockRecorder) UseTOTPStep(ctx, userID, step interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseTOTPStep", reflect.TypeOf((*MockIAuthorizationRepo)(nil).UseTOTPStep), ctx, userID, step)
+}
+
+// MockIPairsRepo is a mock of IPairsRepo interface.
+type MockIPairsRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPairsRepoMockRecorder
+}
+
+// MockIPairsRepoMockRecorder is the mock recorder for MockIPairsRepo.
+type MockIPairsRepoMockRecorder struct {
+	mock *MockIPairsRepo
+}
+
+// NewMockIPairsRepo creates a new mock instance.
+func NewMockIPairsRepo(ctrl *gomock.Controller) *MockIPairsRepo {
+	mock := &MockIPairsRepo{ctrl: ctrl}
+	mock.recorder = &MockIPairsRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPairsRepo) EXPECT() *MockIPairsRepoMockRecorder {
+	return m.recorder
+}
+
+// CreatePair mocks base method.
+func (m *MockIPairsRepo) CreatePair(ctx context.Context, pair entity.PairDAO) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePair", ctx, pair)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePair indicates an expected call of CreatePair.
+func (mr *MockIPairsRepoMockRecorder) CreatePair(ctx, pair interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePair", reflect.TypeOf((*MockIPairsRepo)(nil).CreatePair), ctx, pair)
+}
+
+// DeletePair mocks base method.
+func (m *MockIPairsRepo) DeletePair(ctx context.Context, userID, pairID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePair", ctx, userID, pairID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePair indicates an expected call of DeletePair.
+func (mr *MockIPairsRepoMockRecorder) DeletePair(ctx, userID, pairID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePair", reflect.TypeOf((*MockIPairsRepo)(nil).DeletePair), ctx, userID, pairID)
+}
+
+// ListPairs mocks base method.
+func (m *MockIPairsRepo) ListPairs(ctx context.Context, userID int, query entity.ListQuery) ([]entity.PairDAO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPairs", ctx, userID, query)
+	ret0, _ := ret[0].([]entity.PairDAO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPairs indicates an expected call of ListPairs.
+func (mr *MockIPairsRepoMockRecorder) ListPairs(ctx, userID, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPairs", reflect.TypeOf((*MockIPairsRepo)(nil).ListPairs), ctx, userID, query)
+}
+
+// UpdatePair mocks base method.
+func (m *MockIPairsRepo) UpdatePair(ctx context.Context, pair entity.PairDAO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePair", ctx, pair)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePair indicates an expected call of UpdatePair.
+func (mr *MockIPairsRepoMockRecorder) UpdatePair(ctx, pair interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePair", reflect.TypeOf((*MockIPairsRepo)(nil).UpdatePair), ctx, pair)
+}
+
+// MockIBankRepo is a mock of IBankRepo interface.
+type MockIBankRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockIBankRepoMockRecorder
+}
+
+// MockIBankRepoMockRecorder is the mock recorder for MockIBankRepo.
+type MockIBankRepoMockRecorder struct {
+	mock *MockIBankRepo
+}
+
+// NewMockIBankRepo creates a new mock instance.
+func NewMockIBankRepo(ctrl *gomock.Controller) *MockIBankRepo {
+	mock := &MockIBankRepo{ctrl: ctrl}
+	mock.recorder = &MockIBankRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIBankRepo) EXPECT() *MockIBankRepoMockRecorder {
+	return m.recorder
 }
 
-// RegisterUser mocks base method.
-func (m *MockIService) RegisterUser(login, password string) (string, error) {
+// CreateCard mocks base method.
+func (m *MockIBankRepo) CreateCard(ctx context.Context, card entity.BankDAO) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RegisterUser", login, password)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "CreateCard", ctx, card)
+	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// RegisterUser indicates an expected call of RegisterUser.
-func (mr *MockIServiceMockRecorder) RegisterUser(login, password interface{}) *gomock.Call {
+// CreateCard indicates an expected call of CreateCard.
+func (mr *MockIBankRepoMockRecorder) CreateCard(ctx, card interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUser", reflect.TypeOf((*MockIService)(nil).RegisterUser), login, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCard", reflect.TypeOf((*MockIBankRepo)(nil).CreateCard), ctx, card)
 }
 
-// ViewAllCards mocks base method.
-func (m *MockIService) ViewAllCards(userID int) ([]entity.BankDTO, error) {
+// DeleteCard mocks base method.
+func (m *MockIBankRepo) DeleteCard(ctx context.Context, userID, cardID int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ViewAllCards", userID)
-	ret0, _ := ret[0].([]entity.BankDTO)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "DeleteCard", ctx, userID, cardID)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ViewAllCards indicates an expected call of ViewAllCards.
-func (mr *MockIServiceMockRecorder) ViewAllCards(userID interface{}) *gomock.Call {
+// DeleteCard indicates an expected call of DeleteCard.
+func (mr *MockIBankRepoMockRecorder) DeleteCard(ctx, userID, cardID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewAllCards", reflect.TypeOf((*MockIService)(nil).ViewAllCards), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCard", reflect.TypeOf((*MockIBankRepo)(nil).DeleteCard), ctx, userID, cardID)
 }
 
-// ViewAllNotes mocks base method.
-func (m *MockIService) ViewAllNotes(userID int) ([]entity.TextDTO, error) {
+// ListCards mocks base method.
+func (m *MockIBankRepo) ListCards(ctx context.Context, userID int, query entity.ListQuery) ([]entity.BankDAO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ViewAllNotes", userID)
-	ret0, _ := ret[0].([]entity.TextDTO)
+	ret := m.ctrl.Call(m, "ListCards", ctx, userID, query)
+	ret0, _ := ret[0].([]entity.BankDAO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ViewAllNotes indicates an expected call of ViewAllNotes.
-func (mr *MockIServiceMockRecorder) ViewAllNotes(userID interface{}) *gomock.Call {
+// ListCards indicates an expected call of ListCards.
+func (mr *MockIBankRepoMockRecorder) ListCards(ctx, userID, query interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewAllNotes", reflect.TypeOf((*MockIService)(nil).ViewAllNotes), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCards", reflect.TypeOf((*MockIBankRepo)(nil).ListCards), ctx, userID, query)
 }
 
-// ViewAllPairs mocks base method.
-func (m *MockIService) ViewAllPairs(userID int) ([]entity.PairDTO, error) {
+// UpdateCard mocks base method.
+func (m *MockIBankRepo) UpdateCard(ctx context.Context, card entity.BankDAO) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ViewAllPairs", userID)
-	ret0, _ := ret[0].([]entity.PairDTO)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdateCard", ctx, card)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ViewAllPairs indicates an expected call of ViewAllPairs.
-func (mr *MockIServiceMockRecorder) ViewAllPairs(userID interface{}) *gomock.Call {
+// UpdateCard indicates an expected call of UpdateCard.
+func (mr *MockIBankRepoMockRecorder) UpdateCard(ctx, card interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewAllPairs", reflect.TypeOf((*MockIService)(nil).ViewAllPairs), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateCard", reflect.TypeOf((*MockIBankRepo)(nil).UpdateCard), ctx, card)
 }
 
-// MockIAuthorizationService is a mock of IAuthorizationService interface.
-type MockIAuthorizationService struct {
+// MockITextRepo is a mock of ITextRepo interface.
+type MockITextRepo struct {
 	ctrl     *gomock.Controller
-	recorder *MockIAuthorizationServiceMockRecorder
+	recorder *MockITextRepoMockRecorder
 }
 
-// MockIAuthorizationServiceMockRecorder is the mock recorder for MockIAuthorizationService.
-type MockIAuthorizationServiceMockRecorder struct {
-	mock *MockIAuthorizationService
+// MockITextRepoMockRecorder is the mock recorder for MockITextRepo.
+type MockITextRepoMockRecorder struct {
+	mock *MockITextRepo
 }
 
-// NewMockIAuthorizationService creates a new mock instance.
-func NewMockIAuthorizationService(ctrl *gomock.Controller) *MockIAuthorizationService {
-	mock := &MockIAuthorizationService{ctrl: ctrl}
-	mock.recorder = &MockIAuthorizationServiceMockRecorder{mock}
+// NewMockITextRepo creates a new mock instance.
+func NewMockITextRepo(ctrl *gomock.Controller) *MockITextRepo {
+	mock := &MockITextRepo{ctrl: ctrl}
+	mock.recorder = &MockITextRepoMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockIAuthorizationService) EXPECT() *MockIAuthorizationServiceMockRecorder {
+func (m *MockITextRepo) EXPECT() *MockITextRepoMockRecorder {
 	return m.recorder
 }
 
-// LoginUser mocks base method.
-func (m *MockIAuthorizationService) LoginUser(login, password string) (string, error) {
+// CreateNote mocks base method.
+func (m *MockITextRepo) CreateNote(ctx context.Context, note entity.TextDAO) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "LoginUser", login, password)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "CreateNote", ctx, note)
+	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// LoginUser indicates an expected call of LoginUser.
-func (mr *MockIAuthorizationServiceMockRecorder) LoginUser(login, password interface{}) *gomock.Call {
+// CreateNote indicates an expected call of CreateNote.
+func (mr *MockITextRepoMockRecorder) CreateNote(ctx, note interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginUser", reflect.TypeOf((*MockIAuthorizationService)(nil).LoginUser), login, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateNote", reflect.TypeOf((*MockITextRepo)(nil).CreateNote), ctx, note)
 }
 
-// ParseToken mocks base method.
-func (m *MockIAuthorizationService) ParseToken(token string) (int, error) {
+// DeleteNote mocks base method.
+func (m *MockITextRepo) DeleteNote(ctx context.Context, userID, noteID int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ParseToken", token)
-	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "DeleteNote", ctx, userID, noteID)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ParseToken indicates an expected call of ParseToken.
-func (mr *MockIAuthorizationServiceMockRecorder) ParseToken(token interface{}) *gomock.Call {
+// DeleteNote indicates an expected call of DeleteNote.
+func (mr *MockITextRepoMockRecorder) DeleteNote(ctx, userID, noteID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseToken", reflect.TypeOf((*MockIAuthorizationService)(nil).ParseToken), token)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteNote", reflect.TypeOf((*MockITextRepo)(nil).DeleteNote), ctx, userID, noteID)
 }
 
-// RegisterUser mocks base method.
-func (m *MockIAuthorizationService) RegisterUser(login, password string) (string, error) {
+// ListNotes mocks base method.
+func (m *MockITextRepo) ListNotes(ctx context.Context, userID int, query entity.ListQuery) ([]entity.TextDAO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RegisterUser", login, password)
-	ret0, _ := ret[0].(string)
+	ret := m.ctrl.Call(m, "ListNotes", ctx, userID, query)
+	ret0, _ := ret[0].([]entity.TextDAO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// RegisterUser indicates an expected call of RegisterUser.
-func (mr *MockIAuthorizationServiceMockRecorder) RegisterUser(login, password interface{}) *gomock.Call {
+// ListNotes indicates an expected call of ListNotes.
+func (mr *MockITextRepoMockRecorder) ListNotes(ctx, userID, query interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterUser", reflect.TypeOf((*MockIAuthorizationService)(nil).RegisterUser), login, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListNotes", reflect.TypeOf((*MockITextRepo)(nil).ListNotes), ctx, userID, query)
 }
 
-// MockIPairsService is a mock of IPairsService interface.
-type MockIPairsService struct {
+// UpdateNote mocks base method.
+func (m *MockITextRepo) UpdateNote(ctx context.Context, note entity.TextDAO) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateNote", ctx, note)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateNote indicates an expected call of UpdateNote.
+func (mr *MockITextRepoMockRecorder) UpdateNote(ctx, note interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateNote", reflect.TypeOf((*MockITextRepo)(nil).UpdateNote), ctx, note)
+}
+
+// MockIBinaryRepo is a mock of IBinaryRepo interface.
+type MockIBinaryRepo struct {
 	ctrl     *gomock.Controller
-	recorder *MockIPairsServiceMockRecorder
+	recorder *MockIBinaryRepoMockRecorder
 }
 
-// MockIPairsServiceMockRecorder is the mock recorder for MockIPairsService.
-type MockIPairsServiceMockRecorder struct {
-	mock *MockIPairsService
+// MockIBinaryRepoMockRecorder is the mock recorder for MockIBinaryRepo.
+type MockIBinaryRepoMockRecorder struct {
+	mock *MockIBinaryRepo
 }
 
-// NewMockIPairsService creates a new mock instance.
-func NewMockIPairsService(ctrl *gomock.Controller) *MockIPairsService {
-	mock := &MockIPairsService{ctrl: ctrl}
-	mock.recorder = &MockIPairsServiceMockRecorder{mock}
+// NewMockIBinaryRepo creates a new mock instance.
+func NewMockIBinaryRepo(ctrl *gomock.Controller) *MockIBinaryRepo {
+	mock := &MockIBinaryRepo{ctrl: ctrl}
+	mock.recorder = &MockIBinaryRepoMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockIPairsService) EXPECT() *MockIPairsServiceMockRecorder {
+func (m *MockIBinaryRepo) EXPECT() *MockIBinaryRepoMockRecorder {
 	return m.recorder
 }
 
-// ViewAllPairs mocks base method.
-func (m *MockIPairsService) ViewAllPairs(userID int) ([]entity.PairDTO, error) {
+// CreateBinary mocks base method.
+func (m *MockIBinaryRepo) CreateBinary(ctx context.Context, binary entity.BinaryDAO) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ViewAllPairs", userID)
-	ret0, _ := ret[0].([]entity.PairDTO)
+	ret := m.ctrl.Call(m, "CreateBinary", ctx, binary)
+	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ViewAllPairs indicates an expected call of ViewAllPairs.
-func (mr *MockIPairsServiceMockRecorder) ViewAllPairs(userID interface{}) *gomock.Call {
+// CreateBinary indicates an expected call of CreateBinary.
+func (mr *MockIBinaryRepoMockRecorder) CreateBinary(ctx, binary interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewAllPairs", reflect.TypeOf((*MockIPairsService)(nil).ViewAllPairs), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateBinary", reflect.TypeOf((*MockIBinaryRepo)(nil).CreateBinary), ctx, binary)
 }
 
-// MockIBankService is a mock of IBankService interface.
-type MockIBankService struct {
-	ctrl     *gomock.Controller
-	recorder *MockIBankServiceMockRecorder
+// DeleteBinary mocks base method.
+func (m *MockIBinaryRepo) DeleteBinary(ctx context.Context, userID, binaryID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBinary", ctx, userID, binaryID)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// MockIBankServiceMockRecorder is the mock recorder for MockIBankService.
-type MockIBankServiceMockRecorder struct {
-	mock *MockIBankService
+// DeleteBinary indicates an expected call of DeleteBinary.
+func (mr *MockIBinaryRepoMockRecorder) DeleteBinary(ctx, userID, binaryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBinary", reflect.TypeOf((*MockIBinaryRepo)(nil).DeleteBinary), ctx, userID, binaryID)
 }
 
-// NewMockIBankService creates a new mock instance.
-func NewMockIBankService(ctrl *gomock.Controller) *MockIBankService {
-	mock := &MockIBankService{ctrl: ctrl}
-	mock.recorder = &MockIBankServiceMockRecorder{mock}
-	return mock
+// GetBinary mocks base method.
+func (m *MockIBinaryRepo) GetBinary(ctx context.Context, userID, binaryID int) (entity.BinaryDAO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBinary", ctx, userID, binaryID)
+	ret0, _ := ret[0].(entity.BinaryDAO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockIBankService) EXPECT() *MockIBankServiceMockRecorder {
-	return m.recorder
+// GetBinary indicates an expected call of GetBinary.
+func (mr *MockIBinaryRepoMockRecorder) GetBinary(ctx, userID, binaryID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBinary", reflect.TypeOf((*MockIBinaryRepo)(nil).GetBinary), ctx, userID, binaryID)
 }
 
-// ViewAllCards mocks base method.
-func (m *MockIBankService) ViewAllCards(userID int) ([]entity.BankDTO, error) {
+// ListBinaries mocks base method.
+func (m *MockIBinaryRepo) ListBinaries(ctx context.Context, userID int, query entity.ListQuery) ([]entity.BinaryDAO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ViewAllCards", userID)
-	ret0, _ := ret[0].([]entity.BankDTO)
+	ret := m.ctrl.Call(m, "ListBinaries", ctx, userID, query)
+	ret0, _ := ret[0].([]entity.BinaryDAO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ViewAllCards indicates an expected call of ViewAllCards.
-func (mr *MockIBankServiceMockRecorder) ViewAllCards(userID interface{}) *gomock.Call {
+// ListBinaries indicates an expected call of ListBinaries.
+func (mr *MockIBinaryRepoMockRecorder) ListBinaries(ctx, userID, query interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewAllCards", reflect.TypeOf((*MockIBankService)(nil).ViewAllCards), userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBinaries", reflect.TypeOf((*MockIBinaryRepo)(nil).ListBinaries), ctx, userID, query)
 }
 
-// MockITextService is a mock of ITextService interface.
-type MockITextService struct {
+// MockIOTPRepo is a mock of IOTPRepo interface.
+type MockIOTPRepo struct {
 	ctrl     *gomock.Controller
-	recorder *MockITextServiceMockRecorder
+	recorder *MockIOTPRepoMockRecorder
 }
 
-// MockITextServiceMockRecorder is the mock recorder for MockITextService.
-type MockITextServiceMockRecorder struct {
-	mock *MockITextService
+// MockIOTPRepoMockRecorder is the mock recorder for MockIOTPRepo.
+type MockIOTPRepoMockRecorder struct {
+	mock *MockIOTPRepo
 }
 
-// NewMockITextService creates a new mock instance.
-func NewMockITextService(ctrl *gomock.Controller) *MockITextService {
-	mock := &MockITextService{ctrl: ctrl}
-	mock.recorder = &MockITextServiceMockRecorder{mock}
+// NewMockIOTPRepo creates a new mock instance.
+func NewMockIOTPRepo(ctrl *gomock.Controller) *MockIOTPRepo {
+	mock := &MockIOTPRepo{ctrl: ctrl}
+	mock.recorder = &MockIOTPRepoMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockITextService) EXPECT() *MockITextServiceMockRecorder {
+func (m *MockIOTPRepo) EXPECT() *MockIOTPRepoMockRecorder {
 	return m.recorder
 }
 
-// ViewAllNotes mocks base method.
-func (m *MockITextService) ViewAllNotes(userID int) ([]entity.TextDTO, error) {
+// CreateOTP mocks base method.
+func (m *MockIOTPRepo) CreateOTP(ctx context.Context, otp entity.OTPDAO) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ViewAllNotes", userID)
-	ret0, _ := ret[0].([]entity.TextDTO)
+	ret := m.ctrl.Call(m, "CreateOTP", ctx, otp)
+	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// ViewAllNotes indicates an expected call of ViewAllNotes.
-func (mr *MockITextServiceMockRecorder) ViewAllNotes(userID interface{}) *gomock.Call {
+// CreateOTP indicates an expected call of CreateOTP.
+func (mr *MockIOTPRepoMockRecorder) CreateOTP(ctx, otp interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ViewAllNotes", reflect.TypeOf((*MockITextService)(nil).ViewAllNotes), userID)
-}
-
-// MockIRepo is a mock of IRepo interface.
-type MockIRepo struct {
-	ctrl     *gomock.Controller
-	recorder *MockIRepoMockRecorder
-}
-
-// MockIRepoMockRecorder is the mock recorder for MockIRepo.
-type MockIRepoMockRecorder struct {
-	mock *MockIRepo
-}
-
-// NewMockIRepo creates a new mock instance.
-func NewMockIRepo(ctrl *gomock.Controller) *MockIRepo {
-	mock := &MockIRepo{ctrl: ctrl}
-	mock.recorder = &MockIRepoMockRecorder{mock}
-	return mock
-}
-
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockIRepo) EXPECT() *MockIRepoMockRecorder {
-	return m.recorder
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOTP", reflect.TypeOf((*MockIOTPRepo)(nil).CreateOTP), ctx, otp)
 }
 
-// CloseConnection mocks base method.
-func (m *MockIRepo) CloseConnection() error {
+// DeleteOTP mocks base method.
+func (m *MockIOTPRepo) DeleteOTP(ctx context.Context, userID, otpID int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CloseConnection")
+	ret := m.ctrl.Call(m, "DeleteOTP", ctx, userID, otpID)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// CloseConnection indicates an expected call of CloseConnection.
-func (mr *MockIRepoMockRecorder) CloseConnection() *gomock.Call {
+// DeleteOTP indicates an expected call of DeleteOTP.
+func (mr *MockIOTPRepoMockRecorder) DeleteOTP(ctx, userID, otpID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CloseConnection", reflect.TypeOf((*MockIRepo)(nil).CloseConnection))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOTP", reflect.TypeOf((*MockIOTPRepo)(nil).DeleteOTP), ctx, userID, otpID)
 }
 
-// CreateUser mocks base method.
-func (m *MockIRepo) CreateUser(login, passwordHash string) (int, error) {
+// ListOTPs mocks base method.
+func (m *MockIOTPRepo) ListOTPs(ctx context.Context, userID int, query entity.ListQuery) ([]entity.OTPDAO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", login, passwordHash)
-	ret0, _ := ret[0].(int)
+	ret := m.ctrl.Call(m, "ListOTPs", ctx, userID, query)
+	ret0, _ := ret[0].([]entity.OTPDAO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUser indicates an expected call of CreateUser.
-func (mr *MockIRepoMockRecorder) CreateUser(login, passwordHash interface{}) *gomock.Call {
+// ListOTPs indicates an expected call of ListOTPs.
+func (mr *MockIOTPRepoMockRecorder) ListOTPs(ctx, userID, query interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockIRepo)(nil).CreateUser), login, passwordHash)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListOTPs", reflect.TypeOf((*MockIOTPRepo)(nil).ListOTPs), ctx, userID, query)
 }
 
-// GetAllCards mocks base method.
-func (m *MockIRepo) GetAllCards(ctx context.Context, userID int) ([]entity.BankDAO, error) {
+// UpdateOTP mocks base method.
+func (m *MockIOTPRepo) UpdateOTP(ctx context.Context, otp entity.OTPDAO) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllCards", ctx, userID)
-	ret0, _ := ret[0].([]entity.BankDAO)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "UpdateOTP", ctx, otp)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetAllCards indicates an expected call of GetAllCards.
-func (mr *MockIRepoMockRecorder) GetAllCards(ctx, userID interface{}) *gomock.Call {
+// UpdateOTP indicates an expected call of UpdateOTP.
+func (mr *MockIOTPRepoMockRecorder) UpdateOTP(ctx, otp interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCards", reflect.TypeOf((*MockIRepo)(nil).GetAllCards), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateOTP", reflect.TypeOf((*MockIOTPRepo)(nil).UpdateOTP), ctx, otp)
 }
 
-// GetAllNotes mocks base method.
-func (m *MockIRepo) GetAllNotes(ctx context.Context, userID int) ([]entity.TextDAO, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllNotes", ctx, userID)
-	ret0, _ := ret[0].([]entity.TextDAO)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+// MockISyncRepo is a mock of ISyncRepo interface.
+type MockISyncRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockISyncRepoMockRecorder
 }
 
-// GetAllNotes indicates an expected call of GetAllNotes.
-func (mr *MockIRepoMockRecorder) GetAllNotes(ctx, userID interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllNotes", reflect.TypeOf((*MockIRepo)(nil).GetAllNotes), ctx, userID)
+// MockISyncRepoMockRecorder is the mock recorder for MockISyncRepo.
+type MockISyncRepoMockRecorder struct {
+	mock *MockISyncRepo
 }
 
-// GetAllPairs mocks base method.
-func (m *MockIRepo) GetAllPairs(ctx context.Context, userID int) ([]entity.PairDAO, error) {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllPairs", ctx, userID)
-	ret0, _ := ret[0].([]entity.PairDAO)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+// NewMockISyncRepo creates a new mock instance.
+func NewMockISyncRepo(ctrl *gomock.Controller) *MockISyncRepo {
+	mock := &MockISyncRepo{ctrl: ctrl}
+	mock.recorder = &MockISyncRepoMockRecorder{mock}
+	return mock
 }
 
-// GetAllPairs indicates an expected call of GetAllPairs.
-func (mr *MockIRepoMockRecorder) GetAllPairs(ctx, userID interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPairs", reflect.TypeOf((*MockIRepo)(nil).GetAllPairs), ctx, userID)
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISyncRepo) EXPECT() *MockISyncRepoMockRecorder {
+	return m.recorder
 }
 
-// GetUser mocks base method.
-func (m *MockIRepo) GetUser(login string) (entity.UserDAO, error) {
+// GetChanges mocks base method.
+func (m *MockISyncRepo) GetChanges(ctx context.Context, userID int, since int64) (entity.ChangesDAO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", login)
-	ret0, _ := ret[0].(entity.UserDAO)
+	ret := m.ctrl.Call(m, "GetChanges", ctx, userID, since)
+	ret0, _ := ret[0].(entity.ChangesDAO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockIRepoMockRecorder) GetUser(login interface{}) *gomock.Call {
+// GetChanges indicates an expected call of GetChanges.
+func (mr *MockISyncRepoMockRecorder) GetChanges(ctx, userID, since interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockIRepo)(nil).GetUser), login)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChanges", reflect.TypeOf((*MockISyncRepo)(nil).GetChanges), ctx, userID, since)
 }
 
-// MockIAuthorizationRepo is a mock of IAuthorizationRepo interface.
-type MockIAuthorizationRepo struct {
+// MockILabelsRepo is a mock of ILabelsRepo interface.
+type MockILabelsRepo struct {
 	ctrl     *gomock.Controller
-	recorder *MockIAuthorizationRepoMockRecorder
+	recorder *MockILabelsRepoMockRecorder
 }
 
-// MockIAuthorizationRepoMockRecorder is the mock recorder for MockIAuthorizationRepo.
-type MockIAuthorizationRepoMockRecorder struct {
-	mock *MockIAuthorizationRepo
+// MockILabelsRepoMockRecorder is the mock recorder for MockILabelsRepo.
+type MockILabelsRepoMockRecorder struct {
+	mock *MockILabelsRepo
 }
 
-// NewMockIAuthorizationRepo creates a new mock instance.
-func NewMockIAuthorizationRepo(ctrl *gomock.Controller) *MockIAuthorizationRepo {
-	mock := &MockIAuthorizationRepo{ctrl: ctrl}
-	mock.recorder = &MockIAuthorizationRepoMockRecorder{mock}
+// NewMockILabelsRepo creates a new mock instance.
+func NewMockILabelsRepo(ctrl *gomock.Controller) *MockILabelsRepo {
+	mock := &MockILabelsRepo{ctrl: ctrl}
+	mock.recorder = &MockILabelsRepoMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockIAuthorizationRepo) EXPECT() *MockIAuthorizationRepoMockRecorder {
+func (m *MockILabelsRepo) EXPECT() *MockILabelsRepoMockRecorder {
 	return m.recorder
 }
 
-// CreateUser mocks base method.
-func (m *MockIAuthorizationRepo) CreateUser(login, passwordHash string) (int, error) {
+// CreateFolder mocks base method.
+func (m *MockILabelsRepo) CreateFolder(ctx context.Context, folder entity.FolderDAO) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateUser", login, passwordHash)
+	ret := m.ctrl.Call(m, "CreateFolder", ctx, folder)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// CreateUser indicates an expected call of CreateUser.
-func (mr *MockIAuthorizationRepoMockRecorder) CreateUser(login, passwordHash interface{}) *gomock.Call {
+// CreateFolder indicates an expected call of CreateFolder.
+func (mr *MockILabelsRepoMockRecorder) CreateFolder(ctx, folder interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockIAuthorizationRepo)(nil).CreateUser), login, passwordHash)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFolder", reflect.TypeOf((*MockILabelsRepo)(nil).CreateFolder), ctx, folder)
 }
 
-// GetUser mocks base method.
-func (m *MockIAuthorizationRepo) GetUser(login string) (entity.UserDAO, error) {
+// CreateTag mocks base method.
+func (m *MockILabelsRepo) CreateTag(ctx context.Context, tag entity.TagDAO) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", login)
-	ret0, _ := ret[0].(entity.UserDAO)
+	ret := m.ctrl.Call(m, "CreateTag", ctx, tag)
+	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockIAuthorizationRepoMockRecorder) GetUser(login interface{}) *gomock.Call {
+// CreateTag indicates an expected call of CreateTag.
+func (mr *MockILabelsRepoMockRecorder) CreateTag(ctx, tag interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockIAuthorizationRepo)(nil).GetUser), login)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateTag", reflect.TypeOf((*MockILabelsRepo)(nil).CreateTag), ctx, tag)
 }
 
-// MockIPairsRepo is a mock of IPairsRepo interface.
-type MockIPairsRepo struct {
-	ctrl     *gomock.Controller
-	recorder *MockIPairsRepoMockRecorder
+// DeleteFolder mocks base method.
+func (m *MockILabelsRepo) DeleteFolder(ctx context.Context, userID, folderID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFolder", ctx, userID, folderID)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// MockIPairsRepoMockRecorder is the mock recorder for MockIPairsRepo.
-type MockIPairsRepoMockRecorder struct {
-	mock *MockIPairsRepo
+// DeleteFolder indicates an expected call of DeleteFolder.
+func (mr *MockILabelsRepoMockRecorder) DeleteFolder(ctx, userID, folderID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFolder", reflect.TypeOf((*MockILabelsRepo)(nil).DeleteFolder), ctx, userID, folderID)
 }
 
-// NewMockIPairsRepo creates a new mock instance.
-func NewMockIPairsRepo(ctrl *gomock.Controller) *MockIPairsRepo {
-	mock := &MockIPairsRepo{ctrl: ctrl}
-	mock.recorder = &MockIPairsRepoMockRecorder{mock}
-	return mock
+// DeleteTag mocks base method.
+func (m *MockILabelsRepo) DeleteTag(ctx context.Context, userID, tagID int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteTag", ctx, userID, tagID)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockIPairsRepo) EXPECT() *MockIPairsRepoMockRecorder {
-	return m.recorder
+// DeleteTag indicates an expected call of DeleteTag.
+func (mr *MockILabelsRepoMockRecorder) DeleteTag(ctx, userID, tagID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteTag", reflect.TypeOf((*MockILabelsRepo)(nil).DeleteTag), ctx, userID, tagID)
 }
 
-// GetAllPairs mocks base method.
-func (m *MockIPairsRepo) GetAllPairs(ctx context.Context, userID int) ([]entity.PairDAO, error) {
+// ListFolders mocks base method.
+func (m *MockILabelsRepo) ListFolders(ctx context.Context, userID int) ([]entity.FolderDAO, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllPairs", ctx, userID)
-	ret0, _ := ret[0].([]entity.PairDAO)
+	ret := m.ctrl.Call(m, "ListFolders", ctx, userID)
+	ret0, _ := ret[0].([]entity.FolderDAO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllPairs indicates an expected call of GetAllPairs.
-func (mr *MockIPairsRepoMockRecorder) GetAllPairs(ctx, userID interface{}) *gomock.Call {
+// ListFolders indicates an expected call of ListFolders.
+func (mr *MockILabelsRepoMockRecorder) ListFolders(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPairs", reflect.TypeOf((*MockIPairsRepo)(nil).GetAllPairs), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFolders", reflect.TypeOf((*MockILabelsRepo)(nil).ListFolders), ctx, userID)
 }
 
-// MockIBankRepo is a mock of IBankRepo interface.
-type MockIBankRepo struct {
-	ctrl     *gomock.Controller
-	recorder *MockIBankRepoMockRecorder
+// ListTags mocks base method.
+func (m *MockILabelsRepo) ListTags(ctx context.Context, userID int) ([]entity.TagDAO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListTags", ctx, userID)
+	ret0, _ := ret[0].([]entity.TagDAO)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
-// MockIBankRepoMockRecorder is the mock recorder for MockIBankRepo.
-type MockIBankRepoMockRecorder struct {
-	mock *MockIBankRepo
+// ListTags indicates an expected call of ListTags.
+func (mr *MockILabelsRepoMockRecorder) ListTags(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListTags", reflect.TypeOf((*MockILabelsRepo)(nil).ListTags), ctx, userID)
 }
 
-// NewMockIBankRepo creates a new mock instance.
-func NewMockIBankRepo(ctrl *gomock.Controller) *MockIBankRepo {
-	mock := &MockIBankRepo{ctrl: ctrl}
-	mock.recorder = &MockIBankRepoMockRecorder{mock}
-	return mock
+// RenameFolder mocks base method.
+func (m *MockILabelsRepo) RenameFolder(ctx context.Context, userID, folderID int, name string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RenameFolder", ctx, userID, folderID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockIBankRepo) EXPECT() *MockIBankRepoMockRecorder {
-	return m.recorder
+// RenameFolder indicates an expected call of RenameFolder.
+func (mr *MockILabelsRepoMockRecorder) RenameFolder(ctx, userID, folderID, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameFolder", reflect.TypeOf((*MockILabelsRepo)(nil).RenameFolder), ctx, userID, folderID, name)
 }
 
-// GetAllCards mocks base method.
-func (m *MockIBankRepo) GetAllCards(ctx context.Context, userID int) ([]entity.BankDAO, error) {
+// RenameTag mocks base method.
+func (m *MockILabelsRepo) RenameTag(ctx context.Context, userID, tagID int, name string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllCards", ctx, userID)
-	ret0, _ := ret[0].([]entity.BankDAO)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "RenameTag", ctx, userID, tagID, name)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GetAllCards indicates an expected call of GetAllCards.
-func (mr *MockIBankRepoMockRecorder) GetAllCards(ctx, userID interface{}) *gomock.Call {
+// RenameTag indicates an expected call of RenameTag.
+func (mr *MockILabelsRepoMockRecorder) RenameTag(ctx, userID, tagID, name interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllCards", reflect.TypeOf((*MockIBankRepo)(nil).GetAllCards), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RenameTag", reflect.TypeOf((*MockILabelsRepo)(nil).RenameTag), ctx, userID, tagID, name)
 }
 
-// MockITextRepo is a mock of ITextRepo interface.
-type MockITextRepo struct {
+// MockIAccountRepo is a mock of IAccountRepo interface.
+type MockIAccountRepo struct {
 	ctrl     *gomock.Controller
-	recorder *MockITextRepoMockRecorder
+	recorder *MockIAccountRepoMockRecorder
 }
 
-// MockITextRepoMockRecorder is the mock recorder for MockITextRepo.
-type MockITextRepoMockRecorder struct {
-	mock *MockITextRepo
+// MockIAccountRepoMockRecorder is the mock recorder for MockIAccountRepo.
+type MockIAccountRepoMockRecorder struct {
+	mock *MockIAccountRepo
 }
 
-// NewMockITextRepo creates a new mock instance.
-func NewMockITextRepo(ctrl *gomock.Controller) *MockITextRepo {
-	mock := &MockITextRepo{ctrl: ctrl}
-	mock.recorder = &MockITextRepoMockRecorder{mock}
+// NewMockIAccountRepo creates a new mock instance.
+func NewMockIAccountRepo(ctrl *gomock.Controller) *MockIAccountRepo {
+	mock := &MockIAccountRepo{ctrl: ctrl}
+	mock.recorder = &MockIAccountRepoMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockITextRepo) EXPECT() *MockITextRepoMockRecorder {
+func (m *MockIAccountRepo) EXPECT() *MockIAccountRepoMockRecorder {
 	return m.recorder
 }
 
-// GetAllNotes mocks base method.
-func (m *MockITextRepo) GetAllNotes(ctx context.Context, userID int) ([]entity.TextDAO, error) {
+// DeleteAccount mocks base method.
+func (m *MockIAccountRepo) DeleteAccount(ctx context.Context, userID int, passwordHash string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllNotes", ctx, userID)
-	ret0, _ := ret[0].([]entity.TextDAO)
+	ret := m.ctrl.Call(m, "DeleteAccount", ctx, userID, passwordHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockIAccountRepoMockRecorder) DeleteAccount(ctx, userID, passwordHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockIAccountRepo)(nil).DeleteAccount), ctx, userID, passwordHash)
+}
+
+// ExportAccount mocks base method.
+func (m *MockIAccountRepo) ExportAccount(ctx context.Context, userID int) (entity.AccountDAO, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportAccount", ctx, userID)
+	ret0, _ := ret[0].(entity.AccountDAO)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetAllNotes indicates an expected call of GetAllNotes.
-func (mr *MockITextRepoMockRecorder) GetAllNotes(ctx, userID interface{}) *gomock.Call {
+// ExportAccount indicates an expected call of ExportAccount.
+func (mr *MockIAccountRepoMockRecorder) ExportAccount(ctx, userID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllNotes", reflect.TypeOf((*MockITextRepo)(nil).GetAllNotes), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportAccount", reflect.TypeOf((*MockIAccountRepo)(nil).ExportAccount), ctx, userID)
 }
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+)
+
+// AccountService сервис управления учётной записью пользователя (экспорт и удаление данных).
+type AccountService struct {
+	repo IAccountRepo
+	auth *AuthService
+}
+
+// NewAccountService создаёт объект типа AccountService.
+//
+// Пароль (и код второго фактора) при удалении учётной записи проверяет auth - с теми же ограничениями, что при входе.
+func NewAccountService(repo IAccountRepo, auth *AuthService) *AccountService {
+	return &AccountService{
+		repo: repo,
+		auth: auth,
+	}
+}
+
+// ExportAccount выгрузка всех данных пользователя (включая содержимое файлов).
+//
+// Поля записей возвращаются в том виде, в котором их прислал клиент (зашифрованными ключом пользователя).
+func (s *AccountService) ExportAccount(ctx context.Context, userID int) (entity.AccountDTO, error) {
+	account, err := s.repo.ExportAccount(ctx, userID)
+	if err != nil {
+		return entity.AccountDTO{}, err
+	}
+
+	return entity.AccountDTO{
+		Login:      account.User.Login,
+		ExportedAt: time.Now().UTC(),
+		Pairs:      pairsToDTO(account.Pairs),
+		Cards:      cardsToDTO(account.Cards),
+		Notes:      notesToDTO(account.Notes),
+		Binaries:   binariesToDTO(account.Binaries),
+		OTPs:       otpsToDTO(account.OTPs),
+		Folders:    foldersToDTO(account.Folders),
+		Tags:       tagsToDTO(account.Tags),
+	}, nil
+}
+
+// DeleteAccount удаление пользователя вместе со всеми его данными (требуется подтверждение паролем).
+//
+// Возвращает ErrMismatchPassword, если пароль неверный. Проверка пароля ограничена так же, как вход:
+// после серии неудач возвращается ErrTooManyAttempts. Если подключён второй фактор, требуется код
+// аутентификатора (code), иначе возвращается ErrSecondFactorRequired.
+func (s *AccountService) DeleteAccount(ctx context.Context, userID int, pass, code, peer string) error {
+	user, err := s.auth.confirmUser(ctx, userID, pass, code, peer)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.DeleteAccount(ctx, userID, user.PasswordHash)
+}
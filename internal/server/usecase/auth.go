@@ -1,90 +1,329 @@
 package usecase
 
 import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/PaulYakow/gophkeeper/internal/entity"
 	"github.com/PaulYakow/gophkeeper/internal/utils/password"
 	"github.com/PaulYakow/gophkeeper/internal/utils/token"
 )
 
+const (
+	// sessionIDSize размер id сессии (в байтах, до кодирования).
+	sessionIDSize = 16
+	// refreshSecretSize размер секретной части refresh-токена (в байтах, до кодирования).
+	refreshSecretSize = 32
+)
+
+// PasswordPolicy правила смены паролей пользователей.
+type PasswordPolicy struct {
+	// HistorySize количество последних паролей (включая текущий), которые нельзя использовать повторно.
+	HistorySize int
+	// MaxAge максимальный срок действия пароля (0 - без ограничения).
+	MaxAge time.Duration
+}
+
 // AuthService сервис аутентификации пользователей.
+//
+// Каждый вход открывает сессию (public.sessions). Access-токен короткоживущий и содержит id сессии,
+// refresh-токен имеет вид "<id сессии>.<секрет>", в БД хранится только хэш секрета.
 type AuthService struct {
-	repo           IAuthorizationRepo
-	passwordHasher password.IPasswordHash
-	tokenMaker     token.IMaker
+	repo            IAuthorizationRepo
+	passwordHasher  password.IPasswordHash
+	tokenMaker      token.IMaker
+	accessDuration  time.Duration
+	refreshDuration time.Duration
+	policy          PasswordPolicy
+	lockout         LoginPolicy
+
+	// хэш-заглушка для проверки пароля несуществующего логина
+	dummy string
 }
 
 // NewAuthService создаёт объект типа AuthService.
-func NewAuthService(repo IAuthorizationRepo, hasher password.IPasswordHash, maker token.IMaker) *AuthService {
+//
+// accessDuration - время действия access-токена, refreshDuration - время действия сессии
+// без обновления токенов, policy - правила смены паролей, lockout - правила блокировки входа.
+// Хэш-заглушка для несуществующих логинов вычисляется здесь же, чтобы первый такой вход
+// не отличался по времени ответа.
+func NewAuthService(repo IAuthorizationRepo,
+	hasher password.IPasswordHash,
+	maker token.IMaker,
+	accessDuration, refreshDuration time.Duration,
+	policy PasswordPolicy,
+	lockout LoginPolicy,
+) *AuthService {
 	return &AuthService{
-		repo:           repo,
-		passwordHasher: hasher,
-		tokenMaker:     maker,
+		repo:            repo,
+		passwordHasher:  hasher,
+		tokenMaker:      maker,
+		accessDuration:  accessDuration,
+		refreshDuration: refreshDuration,
+		policy:          policy,
+		lockout:         lockout,
+		dummy:           dummyHash(hasher),
 	}
 }
 
 // RegisterUser - регистрация нового пользователя с переданным логином и паролем.
 //
-// Возвращает токен или ошибку (например, если логин уже существует).
-func (s *AuthService) RegisterUser(login, pass string) (string, error) {
+// Открывает новую сессию и возвращает её токены или ошибку (например, если логин уже существует).
+func (s *AuthService) RegisterUser(ctx context.Context, login, pass string) (entity.TokensDTO, error) {
 	passwordHash, err := s.passwordHasher.Hash(pass)
 	if err != nil {
-		return "", err
+		return entity.TokensDTO{}, err
 	}
 
-	id, err := s.repo.CreateUser(login, passwordHash)
+	id, err := s.repo.CreateUser(ctx, login, passwordHash)
 	if err != nil {
-		return "", err
+		return entity.TokensDTO{}, err
 	}
 
-	userToken, err := s.generateToken(id)
+	return s.openSession(ctx, id)
+}
+
+// LoginUser - авторизация существующего пользователя.
+//
+// Открывает новую сессию и возвращает её токены или ошибку (например, если логина не существует).
+// Если срок действия пароля истёк - возвращает ErrPasswordExpired (пароль необходимо сменить).
+// Хэш пароля, созданный по устаревшей схеме или с прежними параметрами, пересчитывается по текущей.
+// Неверный пароль и несуществующий логин неразличимы (ErrInvalidCredentials); после серии неудач
+// по логину или с адреса клиента peer вход блокируется (ErrTooManyAttempts).
+// Если подключён второй фактор, возвращается только токен незавершённого входа (TokensDTO.Challenge).
+func (s *AuthService) LoginUser(ctx context.Context, login, pass, peer string) (entity.TokensDTO, error) {
+	user, err := s.checkPassword(ctx, login, pass, peer)
 	if err != nil {
-		return "", err
+		return entity.TokensDTO{}, err
 	}
 
-	return userToken, nil
+	if s.policy.MaxAge > 0 && time.Since(user.PasswordChangedAt) > s.policy.MaxAge {
+		return entity.TokensDTO{}, ErrPasswordExpired
+	}
+
+	if s.passwordHasher.NeedsRehash(user.PasswordHash) {
+		s.rehashPassword(ctx, user, pass)
+	}
+
+	return s.authenticated(ctx, user)
 }
 
-// LoginUser - авторизация существующего пользователя.
+// rehashPassword пересчитывает хэш пароля пользователя по текущей схеме.
+//
+// Ошибки не влияют на вход: прежний хэш остаётся рабочим и будет пересчитан при следующем входе.
+func (s *AuthService) rehashPassword(ctx context.Context, user entity.UserDAO, pass string) {
+	newHash, err := s.passwordHasher.Hash(pass)
+	if err != nil {
+		return
+	}
+
+	_ = s.repo.UpdatePasswordHash(ctx, user.ID, user.PasswordHash, newHash)
+}
+
+// ChangePassword - смена пароля пользователя (требуется текущий пароль).
+//
+// Недавние пароли (policy.HistorySize) повторно использовать нельзя - возвращается ErrPasswordReused.
+// Все сессии пользователя отзываются, открывается новая сессия и возвращаются её токены.
+// Проверка текущего пароля ограничена так же, как вход (LoginUser). Если подключён второй фактор,
+// требуется код аутентификатора (code), иначе возвращается ErrSecondFactorRequired.
+func (s *AuthService) ChangePassword(ctx context.Context, login, pass, newPass, code, peer string) (entity.TokensDTO, error) {
+	user, err := s.confirmCredentials(ctx, login, pass, code, peer)
+	if err != nil {
+		return entity.TokensDTO{}, err
+	}
+
+	recent := []string{user.PasswordHash}
+	if s.policy.HistorySize > 1 {
+		history, err := s.repo.GetPasswordHistory(ctx, user.ID, s.policy.HistorySize-1)
+		if err != nil {
+			return entity.TokensDTO{}, err
+		}
+		recent = append(recent, history...)
+	}
+
+	for _, hash := range recent {
+		if s.passwordHasher.Check(newPass, hash) == nil {
+			return entity.TokensDTO{}, ErrPasswordReused
+		}
+	}
+
+	newHash, err := s.passwordHasher.Hash(newPass)
+	if err != nil {
+		return entity.TokensDTO{}, err
+	}
+
+	if err = s.repo.ChangePassword(ctx, user.ID, user.PasswordHash, newHash); err != nil {
+		return entity.TokensDTO{}, err
+	}
+
+	return s.openSession(ctx, user.ID)
+}
+
+// confirmUser - confirmCredentials для пользователя с id userID (запросы с токеном доступа).
+//
+// Неверный пароль возвращается как ErrMismatchPassword (пользователь уже вошёл, логин известен).
+func (s *AuthService) confirmUser(ctx context.Context, userID int, pass, code, peer string) (entity.UserDAO, error) {
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return entity.UserDAO{}, err
+	}
+
+	user, err = s.confirmCredentials(ctx, user.Login, pass, code, peer)
+	if errors.Is(err, ErrInvalidCredentials) {
+		return entity.UserDAO{}, ErrMismatchPassword
+	}
+
+	return user, err
+}
+
+// confirmCredentials повторно проверяет пароль пользователя (и код аутентификатора, если подключён второй фактор)
+// перед изменением учётной записи.
 //
-// Возвращает токен или ошибку (например, если логина не существует).
-func (s *AuthService) LoginUser(login, pass string) (string, error) {
-	user, err := s.repo.GetUser(login)
+// Проверка ограничена так же, как вход (LoginUser): неудачи учитываются, после серии неудач возвращается
+// ErrTooManyAttempts. Без кода при подключённом втором факторе возвращается ErrSecondFactorRequired.
+func (s *AuthService) confirmCredentials(ctx context.Context, login, pass, code, peer string) (entity.UserDAO, error) {
+	user, err := s.checkPassword(ctx, login, pass, peer)
 	if err != nil {
-		return "", ErrLoginNotExist
+		return entity.UserDAO{}, err
 	}
 
-	err = s.passwordHasher.Check(pass, user.PasswordHash)
+	totp, err := s.repo.GetTOTP(ctx, user.ID)
 	if err != nil {
-		return "", ErrMismatchPassword
+		return entity.UserDAO{}, err
+	}
+	if totp.Secret != "" {
+		if code == "" {
+			return entity.UserDAO{}, ErrSecondFactorRequired
+		}
+		if err = s.useCode(ctx, totp, login, code, peer); err != nil {
+			return entity.UserDAO{}, err
+		}
+	}
+
+	s.loginSucceeded(ctx, login)
+
+	return user, nil
+}
+
+// Refresh - обновление токенов сессии по refresh-токену.
+//
+// Переданный refresh-токен становится недействительным. Повторное использование уже заменённого
+// refresh-токена (признак его кражи) отзывает сессию целиком.
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string) (entity.TokensDTO, error) {
+	sessionID, secret, ok := strings.Cut(refreshToken, ".")
+	if !ok {
+		return entity.TokensDTO{}, ErrInvalidSession
 	}
 
-	userToken, err := s.generateToken(user.ID)
+	session, err := s.repo.GetSession(ctx, sessionID)
+	if err != nil || !sessionActive(session) {
+		return entity.TokensDTO{}, ErrInvalidSession
+	}
+
+	if subtle.ConstantTimeCompare([]byte(hashSecret(secret)), []byte(session.RefreshHash)) != 1 {
+		// ошибка отзыва не меняет результат - токен в любом случае отклоняется
+		_ = s.repo.RevokeSession(ctx, sessionID)
+		return entity.TokensDTO{}, ErrInvalidSession
+	}
+
+	newSecret, err := randomString(refreshSecretSize)
+	if err != nil {
+		return entity.TokensDTO{}, err
+	}
+
+	// при одновременном обновлении одним токеном успешно только первое
+	err = s.repo.RotateSession(ctx, sessionID, session.RefreshHash, hashSecret(newSecret), time.Now().Add(s.refreshDuration))
 	if err != nil {
-		return "", err
+		return entity.TokensDTO{}, ErrInvalidSession
 	}
 
-	return userToken, nil
+	return s.tokens(session.UserID, sessionID, newSecret)
 }
 
-// ParseToken - проверяет переданный токен.
+// Logout - завершение (отзыв) сессии.
+func (s *AuthService) Logout(ctx context.Context, sessionID string) error {
+	return s.repo.RevokeSession(ctx, sessionID)
+}
+
+// ParseToken - проверяет переданный access-токен и состояние его сессии.
 //
-// Возвращает id пользователя или ошибку.
-func (s *AuthService) ParseToken(token string) (int, error) {
+// Возвращает id пользователя и id сессии или ошибку (в том числе, если сессия отозвана).
+func (s *AuthService) ParseToken(ctx context.Context, token string) (int, string, error) {
 	payload, err := s.tokenMaker.Verify(token)
 	if err != nil {
-		return 0, err
+		return 0, "", err
 	}
 
-	return payload.UserID, nil
+	session, err := s.repo.GetSession(ctx, payload.SessionID)
+	if err != nil || !sessionActive(session) || session.UserID != payload.UserID {
+		return 0, "", ErrInvalidSession
+	}
+
+	return payload.UserID, payload.SessionID, nil
+}
+
+// openSession создаёт новую сессию пользователя и выдаёт её токены.
+func (s *AuthService) openSession(ctx context.Context, userID int) (entity.TokensDTO, error) {
+	sessionID, err := randomString(sessionIDSize)
+	if err != nil {
+		return entity.TokensDTO{}, err
+	}
+
+	secret, err := randomString(refreshSecretSize)
+	if err != nil {
+		return entity.TokensDTO{}, err
+	}
+
+	err = s.repo.CreateSession(ctx, entity.SessionDAO{
+		ID:          sessionID,
+		UserID:      userID,
+		RefreshHash: hashSecret(secret),
+		ExpiresAt:   time.Now().Add(s.refreshDuration),
+	})
+	if err != nil {
+		return entity.TokensDTO{}, err
+	}
+
+	return s.tokens(userID, sessionID, secret)
 }
 
-func (s *AuthService) generateToken(userID int) (string, error) {
-	// fixme: change magic time to parameter from config
-	authToken, err := s.tokenMaker.Create(userID, 12*time.Hour)
+// tokens выдаёт access-токен сессии и refresh-токен с переданным секретом.
+func (s *AuthService) tokens(userID int, sessionID, secret string) (entity.TokensDTO, error) {
+	accessToken, err := s.tokenMaker.Create(userID, sessionID, s.accessDuration)
 	if err != nil {
-		return "", err
+		return entity.TokensDTO{}, err
+	}
+
+	return entity.TokensDTO{
+		AccessToken:  accessToken,
+		RefreshToken: sessionID + "." + secret,
+		ExpiresIn:    s.accessDuration,
+	}, nil
+}
+
+// sessionActive проверяет, что сессия не отозвана и не истекла.
+func sessionActive(session entity.SessionDAO) bool {
+	return session.RevokedAt == nil && time.Now().Before(session.ExpiresAt)
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomString(size int) (string, error) {
+	buf := make([]byte, size)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate random: %w", err)
 	}
 
-	return authToken, nil
+	return base64.RawURLEncoding.EncodeToString(buf), nil
 }
@@ -18,23 +18,72 @@ func NewBankService(repo IBankRepo) *BankService {
 	}
 }
 
-// ViewAllCards получение всех значений банковских карт.
-func (s *BankService) ViewAllCards(userID int) ([]entity.BankDTO, error) {
-	cardsDAO, err := s.repo.GetAllCards(context.Background(), userID)
+// ViewCards получение страницы списка банковских карт.
+//
+// Возвращает записи страницы и токен следующей страницы (пустой - страница последняя).
+func (s *BankService) ViewCards(ctx context.Context, userID int, page entity.PageRequest) ([]entity.BankDTO, string, error) {
+	return viewPage(page, func(query entity.ListQuery) ([]entity.BankDAO, error) {
+		return s.repo.ListCards(ctx, userID, query)
+	}, func(item entity.BankDAO) entity.Cursor {
+		return entity.Cursor{CreatedAt: item.CreatedAt, ID: item.ID}
+	}, cardsToDTO)
+}
+
+// CreateCard создание новой банковской карты пользователя.
+//
+// Возвращает id созданной записи или ошибку.
+func (s *BankService) CreateCard(ctx context.Context, userID int, card entity.BankDTO) (int, error) {
+	labels, err := normalizeLabels(card.Labels)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
-	cardsDTO := make([]entity.BankDTO, len(cardsDAO))
-	for i, card := range cardsDAO {
-		cardsDTO[i] = entity.BankDTO{
+	return s.repo.CreateCard(ctx, entity.BankDAO{
+		UserID:         userID,
+		CardHolder:     card.CardHolder,
+		Number:         card.Number,
+		ExpirationDate: card.ExpirationDate,
+		Metadata:       card.Metadata,
+		Labels:         labels,
+	})
+}
+
+// UpdateCard изменение существующей банковской карты пользователя.
+func (s *BankService) UpdateCard(ctx context.Context, userID int, card entity.BankDTO) error {
+	labels, err := normalizeLabels(card.Labels)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.UpdateCard(ctx, entity.BankDAO{
+		ID:             card.ID,
+		UserID:         userID,
+		CardHolder:     card.CardHolder,
+		Number:         card.Number,
+		ExpirationDate: card.ExpirationDate,
+		Metadata:       card.Metadata,
+		Labels:         labels,
+	})
+}
+
+// DeleteCard удаление банковской карты пользователя.
+func (s *BankService) DeleteCard(ctx context.Context, userID, cardID int) error {
+	return s.repo.DeleteCard(ctx, userID, cardID)
+}
+
+// cardsToDTO преобразует записи банковских карт из БД в объекты для API.
+func cardsToDTO(items []entity.BankDAO) []entity.BankDTO {
+	out := make([]entity.BankDTO, len(items))
+	for i, card := range items {
+		out[i] = entity.BankDTO{
 			ID:             card.ID,
 			CardHolder:     card.CardHolder,
 			Number:         card.Number,
 			ExpirationDate: card.ExpirationDate,
 			Metadata:       card.Metadata,
+			Labels:         card.Labels,
 		}
 	}
 
-	return cardsDTO, nil
+	return out
 }
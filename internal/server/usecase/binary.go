@@ -0,0 +1,106 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	"github.com/PaulYakow/gophkeeper/internal/utils/encryption"
+)
+
+// BinaryService сервис доступа к бинарным данным (файлам).
+//
+// Файл принимается и хранится целиком (в памяти сервера и одним значением в БД), поэтому его размер ограничен.
+type BinaryService struct {
+	repo    IBinaryRepo
+	maxSize int
+}
+
+// NewBinaryService создаёт объект типа BinaryService.
+//
+// maxSize - максимальный размер файла (в байтах, до шифрования на клиенте).
+func NewBinaryService(repo IBinaryRepo, maxSize int) *BinaryService {
+	return &BinaryService{
+		repo:    repo,
+		maxSize: maxSize,
+	}
+}
+
+// MaxStoredSize максимальный размер сохраняемых данных файла (с учётом шифрования на клиенте).
+func (s *BinaryService) MaxStoredSize() int {
+	return s.maxSize + encryption.Overhead
+}
+
+// ViewBinaries получение страницы списка описаний файлов пользователя (без содержимого).
+//
+// Возвращает записи страницы и токен следующей страницы (пустой - страница последняя).
+func (s *BinaryService) ViewBinaries(ctx context.Context, userID int, page entity.PageRequest) ([]entity.BinaryDTO, string, error) {
+	return viewPage(page, func(query entity.ListQuery) ([]entity.BinaryDAO, error) {
+		return s.repo.ListBinaries(ctx, userID, query)
+	}, func(item entity.BinaryDAO) entity.Cursor {
+		return entity.Cursor{CreatedAt: item.CreatedAt, ID: item.ID}
+	}, binariesToDTO)
+}
+
+// CreateBinary сохранение нового файла пользователя.
+//
+// Сохраняется размер файла, сообщённый клиентом (binary.Size - до шифрования): по зашифрованным данным
+// сервер его не определит.
+// Возвращает id созданной записи или ошибку (ErrBinaryTooLarge, если размер больше MaxStoredSize).
+func (s *BinaryService) CreateBinary(ctx context.Context, userID int, binary entity.BinaryDTO) (int, error) {
+	if len(binary.Data) > s.MaxStoredSize() || binary.Size > int64(s.maxSize) {
+		return 0, ErrBinaryTooLarge
+	}
+
+	labels, err := normalizeLabels(binary.Labels)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.repo.CreateBinary(ctx, entity.BinaryDAO{
+		UserID:   userID,
+		Filename: binary.Filename,
+		Size:     binary.Size,
+		Data:     binary.Data,
+		Metadata: binary.Metadata,
+		Labels:   labels,
+	})
+}
+
+// GetBinary получение файла пользователя вместе с содержимым.
+func (s *BinaryService) GetBinary(ctx context.Context, userID, binaryID int) (entity.BinaryDTO, error) {
+	binary, err := s.repo.GetBinary(ctx, userID, binaryID)
+	if err != nil {
+		return entity.BinaryDTO{}, err
+	}
+
+	return entity.BinaryDTO{
+		ID:       binary.ID,
+		Filename: binary.Filename,
+		Size:     binary.Size,
+		Data:     binary.Data,
+		Metadata: binary.Metadata,
+		Labels:   binary.Labels,
+	}, nil
+}
+
+// DeleteBinary удаление файла пользователя.
+func (s *BinaryService) DeleteBinary(ctx context.Context, userID, binaryID int) error {
+	return s.repo.DeleteBinary(ctx, userID, binaryID)
+}
+
+// binariesToDTO преобразует записи описаний файлов из БД в объекты для API.
+func binariesToDTO(items []entity.BinaryDAO) []entity.BinaryDTO {
+	out := make([]entity.BinaryDTO, len(items))
+	for i, binary := range items {
+		out[i] = entity.BinaryDTO{
+			ID:       binary.ID,
+			Filename: binary.Filename,
+			Size:     binary.Size,
+			Data:     binary.Data,
+			Metadata: binary.Metadata,
+			Labels:   binary.Labels,
+		}
+	}
+
+	return out
+}
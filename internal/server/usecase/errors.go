@@ -3,6 +3,18 @@ package usecase
 import "errors"
 
 var (
-	ErrLoginNotExist    = errors.New("login not exist")
-	ErrMismatchPassword = errors.New("password mismatch")
+	ErrMismatchPassword     = errors.New("password mismatch")
+	ErrInvalidCredentials   = errors.New("invalid login or password")
+	ErrTooManyAttempts      = errors.New("too many failed login attempts, try later")
+	ErrInvalidChallenge     = errors.New("second factor challenge is invalid or expired")
+	ErrInvalidCode          = errors.New("invalid one-time code")
+	ErrSecondFactorRequired = errors.New("one-time code required")
+	ErrTOTPNotEnrolled      = errors.New("authenticator is not enrolled")
+	ErrBinaryTooLarge       = errors.New("binary data too large")
+	ErrInvalidOTP           = errors.New("invalid otp parameters")
+	ErrInvalidSession       = errors.New("session is invalid, expired or revoked")
+	ErrPasswordExpired      = errors.New("password expired, must change")
+	ErrPasswordReused       = errors.New("password was used recently")
+	ErrInvalidPage          = errors.New("invalid page request")
+	ErrInvalidLabel         = errors.New("invalid folder or tag")
 )
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"time"
 
 	"github.com/PaulYakow/gophkeeper/internal/entity"
 )
@@ -10,47 +11,229 @@ import (
 
 type (
 	// IService общая абстракция для взаимодействия с сервисами.
+	//
+	// Все методы принимают контекст запроса клиента: его отмена или истечение срока прерывают обращения к хранилищу.
 	IService interface {
 		IAuthorizationService
 		IPairsService
 		IBankService
 		ITextService
+		IBinaryService
+		IOTPService
+		ISyncService
+		IAccountService
+		ILabelsService
 	}
 
 	// IAuthorizationService абстракция сервиса авторизации.
 	IAuthorizationService interface {
 		// RegisterUser - регистрация нового пользователя с переданным логином и паролем.
 		//
-		// Возвращает токен или ошибку (например, если логин уже существует).
-		RegisterUser(login, password string) (string, error)
+		// Открывает новую сессию и возвращает её токены или ошибку (например, если логин уже существует).
+		RegisterUser(ctx context.Context, login, password string) (entity.TokensDTO, error)
 
 		// LoginUser - авторизация существующего пользователя.
 		//
-		// Возвращает токен или ошибку (например, если логина не существует).
-		LoginUser(login, password string) (string, error)
+		// Открывает новую сессию и возвращает её токены или ошибку (например, если логина не существует).
+		// Если срок действия пароля истёк - возвращает ErrPasswordExpired (пароль необходимо сменить).
+		// Неудачные попытки учитываются по логину и адресу клиента peer: после серии неудач
+		// вход блокируется (ErrTooManyAttempts). Если подключён второй фактор, возвращается только
+		// токен незавершённого входа (TokensDTO.Challenge).
+		LoginUser(ctx context.Context, login, password, peer string) (entity.TokensDTO, error)
 
-		// ParseToken - проверяет переданный токен.
+		// ChangePassword - смена пароля пользователя (требуется текущий пароль).
 		//
-		// Возвращает id пользователя или ошибку.
-		ParseToken(token string) (int, error)
+		// Недавние пароли повторно использовать нельзя. Все сессии пользователя отзываются,
+		// открывается новая сессия и возвращаются её токены. Проверка текущего пароля
+		// ограничена так же, как вход. Если подключён второй фактор, требуется код аутентификатора.
+		ChangePassword(ctx context.Context, login, password, newPassword, code, peer string) (entity.TokensDTO, error)
+
+		// Refresh - обновление токенов сессии по refresh-токену.
+		//
+		// Переданный refresh-токен становится недействительным. Повторное использование уже заменённого
+		// refresh-токена отзывает сессию целиком.
+		Refresh(ctx context.Context, refreshToken string) (entity.TokensDTO, error)
+
+		// Logout - завершение (отзыв) сессии.
+		Logout(ctx context.Context, sessionID string) error
+
+		// ParseToken - проверяет переданный access-токен и состояние его сессии.
+		//
+		// Возвращает id пользователя и id сессии или ошибку (в том числе, если сессия отозвана).
+		ParseToken(ctx context.Context, token string) (userID int, sessionID string, err error)
+
+		// VerifySecondFactor - завершение входа кодом аутентификатора (TOTP).
+		//
+		// Обменивает токен незавершённого входа и код на токены новой сессии.
+		VerifySecondFactor(ctx context.Context, challenge, code, peer string) (entity.TokensDTO, error)
+
+		// EnrollTOTP - подключение (или замена) аутентификатора: создаёт новый секрет (действует после ConfirmTOTP).
+		// Требуется пароль pass и код code действующего аутентификатора (если подключён).
+		EnrollTOTP(ctx context.Context, userID int, pass, code, peer string) (entity.TOTPEnrollmentDTO, error)
+
+		// ConfirmTOTP - подтверждение подключаемого аутентификатора кодом.
+		ConfirmTOTP(ctx context.Context, userID int, code string) error
+
+		// DisableTOTP - отключение второго фактора (требуется код действующего аутентификатора).
+		DisableTOTP(ctx context.Context, userID int, code string) error
+
+		// SecurityEvents - последние события безопасности учётной записи (блокировки входа), от новых к старым.
+		SecurityEvents(ctx context.Context, userID int) ([]entity.SecurityEventDTO, error)
 	}
 
 	// IPairsService абстракция сервиса доступа к парам логин/пароль.
 	IPairsService interface {
-		// ViewAllPairs получение всех значений типа логин/пароль.
-		ViewAllPairs(userID int) ([]entity.PairDTO, error)
+		// ViewPairs получение страницы списка значений типа логин/пароль.
+		//
+		// Возвращает записи страницы и токен следующей страницы (пустой - страница последняя)
+		// или ErrInvalidPage при некорректных параметрах страницы.
+		ViewPairs(ctx context.Context, userID int, page entity.PageRequest) ([]entity.PairDTO, string, error)
+
+		// CreatePair создание новой пары логин/пароль пользователя.
+		//
+		// Возвращает id созданной записи или ошибку.
+		CreatePair(ctx context.Context, userID int, pair entity.PairDTO) (int, error)
+
+		// UpdatePair изменение существующей пары логин/пароль пользователя.
+		UpdatePair(ctx context.Context, userID int, pair entity.PairDTO) error
+
+		// DeletePair удаление пары логин/пароль пользователя.
+		DeletePair(ctx context.Context, userID, pairID int) error
 	}
 
 	// IBankService абстракция сервиса доступа к банковским картам.
 	IBankService interface {
-		// ViewAllCards получение всех значений банковских карт.
-		ViewAllCards(userID int) ([]entity.BankDTO, error)
+		// ViewCards получение страницы списка банковских карт.
+		//
+		// Возвращает записи страницы и токен следующей страницы (пустой - страница последняя)
+		// или ErrInvalidPage при некорректных параметрах страницы.
+		ViewCards(ctx context.Context, userID int, page entity.PageRequest) ([]entity.BankDTO, string, error)
+
+		// CreateCard создание новой банковской карты пользователя.
+		//
+		// Возвращает id созданной записи или ошибку.
+		CreateCard(ctx context.Context, userID int, card entity.BankDTO) (int, error)
+
+		// UpdateCard изменение существующей банковской карты пользователя.
+		UpdateCard(ctx context.Context, userID int, card entity.BankDTO) error
+
+		// DeleteCard удаление банковской карты пользователя.
+		DeleteCard(ctx context.Context, userID, cardID int) error
 	}
 
 	// ITextService абстракция сервиса доступа к заметкам.
 	ITextService interface {
-		// ViewAllNotes получение всех значений заметок.
-		ViewAllNotes(userID int) ([]entity.TextDTO, error)
+		// ViewNotes получение страницы списка заметок.
+		//
+		// Возвращает записи страницы и токен следующей страницы (пустой - страница последняя)
+		// или ErrInvalidPage при некорректных параметрах страницы.
+		ViewNotes(ctx context.Context, userID int, page entity.PageRequest) ([]entity.TextDTO, string, error)
+
+		// CreateNote создание новой заметки пользователя.
+		//
+		// Возвращает id созданной записи или ошибку.
+		CreateNote(ctx context.Context, userID int, note entity.TextDTO) (int, error)
+
+		// UpdateNote изменение существующей заметки пользователя.
+		UpdateNote(ctx context.Context, userID int, note entity.TextDTO) error
+
+		// DeleteNote удаление заметки пользователя.
+		DeleteNote(ctx context.Context, userID, noteID int) error
+	}
+
+	// IBinaryService абстракция сервиса доступа к бинарным данным (файлам).
+	IBinaryService interface {
+		// ViewBinaries получение страницы списка описаний файлов пользователя (без содержимого).
+		//
+		// Возвращает записи страницы и токен следующей страницы (пустой - страница последняя)
+		// или ErrInvalidPage при некорректных параметрах страницы.
+		ViewBinaries(ctx context.Context, userID int, page entity.PageRequest) ([]entity.BinaryDTO, string, error)
+
+		// MaxStoredSize максимальный размер сохраняемых данных файла (с учётом шифрования на клиенте).
+		MaxStoredSize() int
+
+		// CreateBinary сохранение нового файла пользователя.
+		//
+		// Возвращает id созданной записи или ошибку (ErrBinaryTooLarge, если размер больше MaxStoredSize).
+		CreateBinary(ctx context.Context, userID int, binary entity.BinaryDTO) (int, error)
+
+		// GetBinary получение файла пользователя вместе с содержимым.
+		GetBinary(ctx context.Context, userID, binaryID int) (entity.BinaryDTO, error)
+
+		// DeleteBinary удаление файла пользователя.
+		DeleteBinary(ctx context.Context, userID, binaryID int) error
+	}
+
+	// IOTPService абстракция сервиса доступа к одноразовым паролям (TOTP/HOTP).
+	IOTPService interface {
+		// ViewOTPs получение страницы списка одноразовых паролей пользователя.
+		//
+		// Возвращает записи страницы и токен следующей страницы (пустой - страница последняя)
+		// или ErrInvalidPage при некорректных параметрах страницы.
+		ViewOTPs(ctx context.Context, userID int, page entity.PageRequest) ([]entity.OTPDTO, string, error)
+
+		// CreateOTP создание нового одноразового пароля пользователя.
+		//
+		// Возвращает id созданной записи или ошибку (например, при некорректных параметрах).
+		CreateOTP(ctx context.Context, userID int, otp entity.OTPDTO) (int, error)
+
+		// UpdateOTP изменение существующего одноразового пароля пользователя.
+		UpdateOTP(ctx context.Context, userID int, otp entity.OTPDTO) error
+
+		// DeleteOTP удаление одноразового пароля пользователя.
+		DeleteOTP(ctx context.Context, userID, otpID int) error
+	}
+
+	// ISyncService абстракция сервиса синхронизации данных пользователя.
+	ISyncService interface {
+		// Sync получение изменений всех типов данных пользователя после ревизии since (0 - все данные).
+		//
+		// Возвращает изменённые записи, отметки об удалении и текущую ревизию.
+		Sync(ctx context.Context, userID int, since int64) (entity.ChangesDTO, error)
+	}
+
+	// ILabelsService абстракция сервиса папок и меток записей (общих для всех типов данных).
+	ILabelsService interface {
+		// ListFolders получение всех папок пользователя (дерево задаётся ParentID).
+		ListFolders(ctx context.Context, userID int) ([]entity.FolderDTO, error)
+
+		// CreateFolder создание новой папки пользователя.
+		//
+		// Возвращает id созданной папки или ошибку (ErrInvalidLabel при некорректном названии).
+		CreateFolder(ctx context.Context, userID int, folder entity.FolderDTO) (int, error)
+
+		// RenameFolder изменение названия папки пользователя.
+		RenameFolder(ctx context.Context, userID, folderID int, name string) error
+
+		// DeleteFolder удаление папки пользователя вместе с вложенными папками.
+		//
+		// Записи удалённых папок переносятся в родительскую папку удалённой.
+		DeleteFolder(ctx context.Context, userID, folderID int) error
+
+		// ListTags получение всех меток пользователя.
+		ListTags(ctx context.Context, userID int) ([]entity.TagDTO, error)
+
+		// CreateTag создание новой метки пользователя.
+		//
+		// Возвращает id созданной метки или ошибку (ErrInvalidLabel при некорректном названии).
+		CreateTag(ctx context.Context, userID int, tag entity.TagDTO) (int, error)
+
+		// RenameTag изменение названия метки пользователя.
+		RenameTag(ctx context.Context, userID, tagID int, name string) error
+
+		// DeleteTag удаление метки пользователя (метка снимается со всех записей).
+		DeleteTag(ctx context.Context, userID, tagID int) error
+	}
+
+	// IAccountService абстракция сервиса управления учётной записью пользователя.
+	IAccountService interface {
+		// ExportAccount выгрузка всех данных пользователя (включая содержимое файлов).
+		ExportAccount(ctx context.Context, userID int) (entity.AccountDTO, error)
+
+		// DeleteAccount удаление пользователя вместе со всеми его данными (требуется подтверждение паролем).
+		//
+		// Проверка пароля ограничена так же, как вход. Если подключён второй фактор, требуется код аутентификатора.
+		DeleteAccount(ctx context.Context, userID int, password, code, peer string) error
 	}
 
 	// IRepo общая абстракция для взаимодействия с хранилищем.
@@ -59,6 +242,13 @@ type (
 		IPairsRepo
 		IBankRepo
 		ITextRepo
+		IBinaryRepo
+		IOTPRepo
+		ISyncRepo
+		IAccountRepo
+		ILabelsRepo
+		// Ping - проверка доступности хранилища.
+		Ping(ctx context.Context) error
 		CloseConnection() error
 	}
 
@@ -67,28 +257,264 @@ type (
 		// CreateUser - создание пользователя с заданными логином и хэшем пароля.
 		//
 		// Возвращает id пользователя или ошибку (если логин уже существует).
-		CreateUser(login, passwordHash string) (int, error)
+		CreateUser(ctx context.Context, login, passwordHash string) (int, error)
 
 		// GetUser - находит пользователя в БД по логину.
 		//
 		// Возвращает объект пользователя или ошибку (при отсутствии логина).
-		GetUser(login string) (entity.UserDAO, error)
+		GetUser(ctx context.Context, login string) (entity.UserDAO, error)
+
+		// GetUserByID находит пользователя в БД по id.
+		GetUserByID(ctx context.Context, userID int) (entity.UserDAO, error)
+
+		// GetPasswordHistory находит в БД limit последних прежних хэшей пароля пользователя (от новых к старым).
+		GetPasswordHistory(ctx context.Context, userID, limit int) ([]string, error)
+
+		// ChangePassword заменяет хэш пароля пользователя (прежний сохраняется в историю) и отзывает все его сессии.
+		//
+		// Возвращает ошибку, если текущий хэш не равен oldHash (пароль уже был изменён).
+		ChangePassword(ctx context.Context, userID int, oldHash, newHash string) error
+
+		// UpdatePasswordHash заменяет хэш пароля пользователя хэшем того же пароля по текущей схеме.
+		//
+		// Возвращает ошибку, если текущий хэш не равен oldHash.
+		UpdatePasswordHash(ctx context.Context, userID int, oldHash, newHash string) error
+
+		// CreateSession сохраняет в БД новую сессию пользователя.
+		CreateSession(ctx context.Context, session entity.SessionDAO) error
+
+		// GetSession находит в БД сессию по её id.
+		GetSession(ctx context.Context, sessionID string) (entity.SessionDAO, error)
+
+		// RotateSession заменяет хэш refresh-токена действующей сессии и продлевает её до expiresAt.
+		//
+		// Возвращает ошибку, если текущий хэш не равен oldHash (токен уже был заменён).
+		RotateSession(ctx context.Context, sessionID, oldHash, newHash string, expiresAt time.Time) error
+
+		// RevokeSession отзывает сессию.
+		RevokeSession(ctx context.Context, sessionID string) error
+
+		// GetLoginLock возвращает наибольшее время окончания блокировки по ключам неудачных попыток keys
+		// (нулевое - блокировок нет).
+		GetLoginLock(ctx context.Context, keys ...string) (time.Time, error)
+
+		// AddLoginFailure учитывает неудачную попытку по ключу key и возвращает число неудач подряд.
+		//
+		// Если предыдущая неудача была раньше, чем window назад, счёт начинается заново.
+		AddLoginFailure(ctx context.Context, key string, window time.Duration) (int, error)
+
+		// LockLogin блокирует попытки по ключу key до until.
+		LockLogin(ctx context.Context, key string, until time.Time) error
+
+		// ResetLoginFailures сбрасывает счётчик неудачных попыток по ключу key.
+		ResetLoginFailures(ctx context.Context, key string) error
+
+		// CreateSecurityEvent сохраняет в БД событие безопасности учётной записи.
+		CreateSecurityEvent(ctx context.Context, event entity.SecurityEventDAO) error
+
+		// GetSecurityEvents находит в БД limit последних событий безопасности пользователя (от новых к старым).
+		GetSecurityEvents(ctx context.Context, userID, limit int) ([]entity.SecurityEventDAO, error)
+
+		// GetTOTP находит в БД настройки второго фактора пользователя (нулевые, если не подключался).
+		GetTOTP(ctx context.Context, userID int) (entity.TOTPDAO, error)
+
+		// SaveTOTPPending сохраняет секрет подключаемого аутентификатора (действующий не меняется).
+		SaveTOTPPending(ctx context.Context, userID int, secret string) error
+
+		// ConfirmTOTP делает подключаемый аутентификатор действующим; step - интервал принятого кода.
+		ConfirmTOTP(ctx context.Context, userID int, step int64) error
+
+		// UseTOTPStep отмечает интервал step как использованный.
+		//
+		// Возвращает ошибку, если код этого или более позднего интервала уже принят.
+		UseTOTPStep(ctx context.Context, userID int, step int64) error
+
+		// DeleteTOTP отключает второй фактор пользователя.
+		DeleteTOTP(ctx context.Context, userID int) error
+
+		// CreateChallenge сохраняет в БД незавершённый вход (удаляя истёкшие).
+		CreateChallenge(ctx context.Context, challenge entity.ChallengeDAO) error
+
+		// GetChallenge находит в БД незавершённый вход по его id.
+		GetChallenge(ctx context.Context, challengeID string) (entity.ChallengeDAO, error)
+
+		// DeleteChallenge удаляет незавершённый вход.
+		//
+		// Возвращает ошибку, если он уже удалён (вход завершён).
+		DeleteChallenge(ctx context.Context, challengeID string) error
 	}
 
 	// IPairsRepo абстракция взаимодействия с частью хранилища отвечающей за хранение пар логин/пароль.
 	IPairsRepo interface {
-		// GetAllPairs находит в БД все записи типа логин/пароль принадлежащие конкретному пользователю (userID).
-		GetAllPairs(ctx context.Context, userID int) ([]entity.PairDAO, error)
+		// ListPairs находит в БД страницу списка записей типа логин/пароль, принадлежащих конкретному пользователю (userID).
+		//
+		// Страница ограничена фильтром и курсором query.
+		ListPairs(ctx context.Context, userID int, query entity.ListQuery) ([]entity.PairDAO, error)
+
+		// CreatePair сохраняет в БД новую запись типа логин/пароль.
+		//
+		// Возвращает id созданной записи или ошибку.
+		CreatePair(ctx context.Context, pair entity.PairDAO) (int, error)
+
+		// UpdatePair изменяет в БД запись типа логин/пароль (поиск по id и user_id).
+		//
+		// Возвращает ошибку, если запись не найдена.
+		UpdatePair(ctx context.Context, pair entity.PairDAO) error
+
+		// DeletePair удаляет из БД запись типа логин/пароль принадлежащую конкретному пользователю (userID).
+		//
+		// Возвращает ошибку, если запись не найдена.
+		DeletePair(ctx context.Context, userID, pairID int) error
 	}
 
 	// IBankRepo абстракция взаимодействия с частью хранилища отвечающей за хранение банковских данных о картах.
 	IBankRepo interface {
-		// GetAllCards находит в БД все записи банковских карт принадлежащие конкретному пользователю (userID).
-		GetAllCards(ctx context.Context, userID int) ([]entity.BankDAO, error)
+		// ListCards находит в БД страницу списка записей банковских карт, принадлежащих конкретному пользователю (userID).
+		//
+		// Страница ограничена фильтром и курсором query.
+		ListCards(ctx context.Context, userID int, query entity.ListQuery) ([]entity.BankDAO, error)
+
+		// CreateCard сохраняет в БД новую запись банковской карты.
+		//
+		// Возвращает id созданной записи или ошибку.
+		CreateCard(ctx context.Context, card entity.BankDAO) (int, error)
+
+		// UpdateCard изменяет в БД запись банковской карты (поиск по id и user_id).
+		//
+		// Возвращает ошибку, если запись не найдена.
+		UpdateCard(ctx context.Context, card entity.BankDAO) error
+
+		// DeleteCard удаляет из БД запись банковской карты принадлежащую конкретному пользователю (userID).
+		//
+		// Возвращает ошибку, если запись не найдена.
+		DeleteCard(ctx context.Context, userID, cardID int) error
 	}
 
+	// ITextRepo абстракция взаимодействия с частью хранилища отвечающей за хранение заметок.
 	ITextRepo interface {
-		// GetAllNotes абстракция взаимодействия с частью хранилища отвечающей за хранение заметок.
-		GetAllNotes(ctx context.Context, userID int) ([]entity.TextDAO, error)
+		// ListNotes находит в БД страницу списка заметок, принадлежащих конкретному пользователю (userID).
+		//
+		// Страница ограничена фильтром и курсором query.
+		ListNotes(ctx context.Context, userID int, query entity.ListQuery) ([]entity.TextDAO, error)
+
+		// CreateNote сохраняет в БД новую заметку.
+		//
+		// Возвращает id созданной записи или ошибку.
+		CreateNote(ctx context.Context, note entity.TextDAO) (int, error)
+
+		// UpdateNote изменяет в БД заметку (поиск по id и user_id).
+		//
+		// Возвращает ошибку, если запись не найдена.
+		UpdateNote(ctx context.Context, note entity.TextDAO) error
+
+		// DeleteNote удаляет из БД заметку принадлежащую конкретному пользователю (userID).
+		//
+		// Возвращает ошибку, если запись не найдена.
+		DeleteNote(ctx context.Context, userID, noteID int) error
+	}
+
+	// IBinaryRepo абстракция взаимодействия с частью хранилища отвечающей за хранение бинарных данных (файлов).
+	IBinaryRepo interface {
+		// ListBinaries находит в БД страницу списка описаний файлов, принадлежащих конкретному пользователю (userID).
+		//
+		// Страница ограничена фильтром и курсором query. Содержимое файлов (Data) не загружается.
+		ListBinaries(ctx context.Context, userID int, query entity.ListQuery) ([]entity.BinaryDAO, error)
+
+		// CreateBinary сохраняет в БД новый файл.
+		//
+		// Возвращает id созданной записи или ошибку.
+		CreateBinary(ctx context.Context, binary entity.BinaryDAO) (int, error)
+
+		// GetBinary находит в БД файл (вместе с содержимым) принадлежащий конкретному пользователю (userID).
+		//
+		// Возвращает ошибку, если запись не найдена.
+		GetBinary(ctx context.Context, userID, binaryID int) (entity.BinaryDAO, error)
+
+		// DeleteBinary удаляет из БД файл принадлежащий конкретному пользователю (userID).
+		//
+		// Возвращает ошибку, если запись не найдена.
+		DeleteBinary(ctx context.Context, userID, binaryID int) error
+	}
+
+	// IOTPRepo абстракция взаимодействия с частью хранилища отвечающей за хранение одноразовых паролей.
+	IOTPRepo interface {
+		// ListOTPs находит в БД страницу списка одноразовых паролей, принадлежащих конкретному пользователю (userID).
+		//
+		// Страница ограничена фильтром и курсором query.
+		ListOTPs(ctx context.Context, userID int, query entity.ListQuery) ([]entity.OTPDAO, error)
+
+		// CreateOTP сохраняет в БД новый одноразовый пароль.
+		//
+		// Возвращает id созданной записи или ошибку.
+		CreateOTP(ctx context.Context, otp entity.OTPDAO) (int, error)
+
+		// UpdateOTP изменяет в БД одноразовый пароль (поиск по id и user_id).
+		//
+		// Возвращает ошибку, если запись не найдена.
+		UpdateOTP(ctx context.Context, otp entity.OTPDAO) error
+
+		// DeleteOTP удаляет из БД одноразовый пароль принадлежащий конкретному пользователю (userID).
+		//
+		// Возвращает ошибку, если запись не найдена.
+		DeleteOTP(ctx context.Context, userID, otpID int) error
+	}
+
+	// ISyncRepo абстракция взаимодействия с частью хранилища отвечающей за ревизии данных пользователей.
+	ISyncRepo interface {
+		// GetChanges находит в БД все записи и отметки об удалении пользователя (userID) с ревизией больше since.
+		//
+		// Возвращает также текущую ревизию данных пользователя.
+		GetChanges(ctx context.Context, userID int, since int64) (entity.ChangesDAO, error)
+	}
+
+	// ILabelsRepo абстракция взаимодействия с частью хранилища отвечающей за папки и метки записей.
+	ILabelsRepo interface {
+		// ListFolders находит в БД все папки пользователя (userID) по возрастанию id.
+		ListFolders(ctx context.Context, userID int) ([]entity.FolderDAO, error)
+
+		// CreateFolder сохраняет в БД новую папку.
+		//
+		// Возвращает id созданной папки или ошибку (если родительская папка не найдена).
+		CreateFolder(ctx context.Context, folder entity.FolderDAO) (int, error)
+
+		// RenameFolder изменяет название папки пользователя (userID).
+		//
+		// Возвращает ошибку, если папка не найдена.
+		RenameFolder(ctx context.Context, userID, folderID int, name string) error
+
+		// DeleteFolder удаляет из БД папку пользователя (userID) вместе с вложенными папками.
+		//
+		// Записи удалённых папок переносятся в родительскую папку удалённой (с новой ревизией).
+		// Возвращает ошибку, если папка не найдена.
+		DeleteFolder(ctx context.Context, userID, folderID int) error
+
+		// ListTags находит в БД все метки пользователя (userID) по возрастанию id.
+		ListTags(ctx context.Context, userID int) ([]entity.TagDAO, error)
+
+		// CreateTag сохраняет в БД новую метку.
+		//
+		// Возвращает id созданной метки или ошибку.
+		CreateTag(ctx context.Context, tag entity.TagDAO) (int, error)
+
+		// RenameTag изменяет название метки пользователя (userID).
+		//
+		// Возвращает ошибку, если метка не найдена.
+		RenameTag(ctx context.Context, userID, tagID int, name string) error
+
+		// DeleteTag удаляет из БД метку пользователя (userID) и снимает её со всех записей (с новой ревизией).
+		//
+		// Возвращает ошибку, если метка не найдена.
+		DeleteTag(ctx context.Context, userID, tagID int) error
+	}
+
+	// IAccountRepo абстракция взаимодействия с частью хранилища отвечающей за учётные записи пользователей.
+	IAccountRepo interface {
+		// ExportAccount находит в БД все данные пользователя (userID), включая содержимое файлов.
+		ExportAccount(ctx context.Context, userID int) (entity.AccountDAO, error)
+
+		// DeleteAccount удаляет из БД пользователя вместе со всеми его данными (в одной транзакции).
+		//
+		// Возвращает ошибку, если текущий хэш пароля не равен passwordHash.
+		DeleteAccount(ctx context.Context, userID int, passwordHash string) error
 	}
 )
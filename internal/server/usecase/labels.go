@@ -0,0 +1,192 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+)
+
+const (
+	// MaxLabelNameLen максимальная длина названия папки или метки (в символах, с учётом шифрования клиентом).
+	MaxLabelNameLen = 1024
+	// MaxTagsPerItem максимальное количество меток одной записи.
+	MaxTagsPerItem = 32
+)
+
+// LabelsService сервис папок и меток записей (общих для всех типов данных).
+type LabelsService struct {
+	repo ILabelsRepo
+}
+
+// NewLabelsService создаёт объект типа LabelsService.
+func NewLabelsService(repo ILabelsRepo) *LabelsService {
+	return &LabelsService{
+		repo: repo,
+	}
+}
+
+// ListFolders получение всех папок пользователя (дерево задаётся ParentID).
+func (s *LabelsService) ListFolders(ctx context.Context, userID int) ([]entity.FolderDTO, error) {
+	foldersDAO, err := s.repo.ListFolders(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return foldersToDTO(foldersDAO), nil
+}
+
+// CreateFolder создание новой папки пользователя.
+//
+// Возвращает id созданной папки или ошибку (ErrInvalidLabel при некорректном названии или родителе).
+func (s *LabelsService) CreateFolder(ctx context.Context, userID int, folder entity.FolderDTO) (int, error) {
+	name, err := labelName(folder.Name)
+	if err != nil {
+		return 0, err
+	}
+	if folder.ParentID < 0 {
+		return 0, fmt.Errorf("%w: negative parent id", ErrInvalidLabel)
+	}
+
+	return s.repo.CreateFolder(ctx, entity.FolderDAO{
+		UserID:   userID,
+		ParentID: folder.ParentID,
+		Name:     name,
+	})
+}
+
+// RenameFolder изменение названия папки пользователя.
+func (s *LabelsService) RenameFolder(ctx context.Context, userID, folderID int, name string) error {
+	name, err := labelName(name)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.RenameFolder(ctx, userID, folderID, name)
+}
+
+// DeleteFolder удаление папки пользователя вместе с вложенными папками.
+//
+// Записи удалённых папок переносятся в родительскую папку удалённой.
+func (s *LabelsService) DeleteFolder(ctx context.Context, userID, folderID int) error {
+	return s.repo.DeleteFolder(ctx, userID, folderID)
+}
+
+// ListTags получение всех меток пользователя.
+func (s *LabelsService) ListTags(ctx context.Context, userID int) ([]entity.TagDTO, error) {
+	tagsDAO, err := s.repo.ListTags(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return tagsToDTO(tagsDAO), nil
+}
+
+// CreateTag создание новой метки пользователя.
+//
+// Возвращает id созданной метки или ошибку (ErrInvalidLabel при некорректном названии).
+func (s *LabelsService) CreateTag(ctx context.Context, userID int, tag entity.TagDTO) (int, error) {
+	name, err := labelName(tag.Name)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.repo.CreateTag(ctx, entity.TagDAO{
+		UserID: userID,
+		Name:   name,
+	})
+}
+
+// RenameTag изменение названия метки пользователя.
+func (s *LabelsService) RenameTag(ctx context.Context, userID, tagID int, name string) error {
+	name, err := labelName(name)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.RenameTag(ctx, userID, tagID, name)
+}
+
+// DeleteTag удаление метки пользователя (метка снимается со всех записей).
+func (s *LabelsService) DeleteTag(ctx context.Context, userID, tagID int) error {
+	return s.repo.DeleteTag(ctx, userID, tagID)
+}
+
+// labelName проверяет название папки или метки (пробелы по краям отбрасываются).
+func labelName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	switch {
+	case name == "":
+		return "", fmt.Errorf("%w: empty name", ErrInvalidLabel)
+	case utf8.RuneCountInString(name) > MaxLabelNameLen:
+		return "", fmt.Errorf("%w: name too long", ErrInvalidLabel)
+	}
+
+	return name, nil
+}
+
+// normalizeLabels проверяет папку и метки записи, метки сортируются по возрастанию id без повторов.
+//
+// Существование папки и меток проверяет хранилище.
+func normalizeLabels(labels entity.Labels) (entity.Labels, error) {
+	if labels.FolderID < 0 {
+		return labels, fmt.Errorf("%w: negative folder id", ErrInvalidLabel)
+	}
+
+	if len(labels.TagIDs) == 0 {
+		labels.TagIDs = nil
+		return labels, nil
+	}
+
+	tags := make(entity.IDs, 0, len(labels.TagIDs))
+	for _, id := range labels.TagIDs {
+		if id <= 0 {
+			return labels, fmt.Errorf("%w: non-positive tag id", ErrInvalidLabel)
+		}
+		tags = append(tags, id)
+	}
+
+	sort.Ints(tags)
+	unique := tags[:1]
+	for _, id := range tags[1:] {
+		if id != unique[len(unique)-1] {
+			unique = append(unique, id)
+		}
+	}
+	if len(unique) > MaxTagsPerItem {
+		return labels, fmt.Errorf("%w: too many tags", ErrInvalidLabel)
+	}
+
+	labels.TagIDs = unique
+	return labels, nil
+}
+
+// foldersToDTO преобразует папки из БД в объекты для API.
+func foldersToDTO(items []entity.FolderDAO) []entity.FolderDTO {
+	out := make([]entity.FolderDTO, len(items))
+	for i, folder := range items {
+		out[i] = entity.FolderDTO{
+			ID:       folder.ID,
+			ParentID: folder.ParentID,
+			Name:     folder.Name,
+		}
+	}
+
+	return out
+}
+
+// tagsToDTO преобразует метки из БД в объекты для API.
+func tagsToDTO(items []entity.TagDAO) []entity.TagDTO {
+	out := make([]entity.TagDTO, len(items))
+	for i, tag := range items {
+		out[i] = entity.TagDTO{
+			ID:   tag.ID,
+			Name: tag.Name,
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,188 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	"github.com/PaulYakow/gophkeeper/internal/utils/password"
+)
+
+const (
+	// securityEventsLimit количество последних событий безопасности, возвращаемых пользователю.
+	securityEventsLimit = 50
+	// dummyPasswordSize размер пароля для хэша-заглушки (в байтах, до кодирования).
+	dummyPasswordSize = 16
+)
+
+// LoginPolicy правила блокировки входа после неудачных попыток.
+//
+// Неудачи считаются отдельно по логину и по адресу клиента. Когда число неудач подряд достигает
+// порога, попытки блокируются на LockoutBase, каждая следующая неудача удваивает срок (не более LockoutMax).
+// Счётчики хранятся в БД (public.login_failures) и переживают перезапуск сервера.
+type LoginPolicy struct {
+	// MaxFailures порог неудач по логину (0 - без ограничения).
+	MaxFailures int
+	// PeerMaxFailures порог неудач с одного адреса (0 - без ограничения).
+	PeerMaxFailures int
+	// LockoutBase срок первой блокировки.
+	LockoutBase time.Duration
+	// LockoutMax наибольший срок блокировки.
+	LockoutMax time.Duration
+	// Window время без неудач, после которого счёт начинается заново.
+	Window time.Duration
+}
+
+// lockoutDelay возвращает срок блокировки для failures неудач подряд при пороге limit.
+func (p LoginPolicy) lockoutDelay(failures, limit int) time.Duration {
+	delay := p.LockoutBase
+	for i := limit; i < failures && delay < p.LockoutMax; i++ {
+		delay *= 2
+	}
+
+	if delay > p.LockoutMax {
+		return p.LockoutMax
+	}
+	return delay
+}
+
+func loginKey(login string) string {
+	return "login:" + login
+}
+
+func peerKey(peer string) string {
+	return "peer:" + peer
+}
+
+// checkPassword находит пользователя по логину и проверяет его пароль.
+//
+// Несуществующий логин и неверный пароль неразличимы: возвращается ErrInvalidCredentials, пароль
+// в обоих случаях проверяется по хэшу (для несуществующего логина - по хэшу-заглушке), поэтому
+// время ответа одинаково. При активной блокировке логина или адреса возвращается ErrTooManyAttempts.
+// Счётчик неудач логина сбрасывает вызывающий после полной аутентификации (loginSucceeded).
+func (s *AuthService) checkPassword(ctx context.Context, login, pass, peer string) (entity.UserDAO, error) {
+	keys := []string{loginKey(login)}
+	if peer != "" {
+		keys = append(keys, peerKey(peer))
+	}
+
+	lockedUntil, err := s.repo.GetLoginLock(ctx, keys...)
+	if err != nil {
+		return entity.UserDAO{}, err
+	}
+	if time.Now().Before(lockedUntil) {
+		return entity.UserDAO{}, ErrTooManyAttempts
+	}
+
+	user, err := s.repo.GetUser(ctx, login)
+	if err != nil {
+		// результат не важен - проверка нужна только для одинакового времени ответа
+		_ = s.passwordHasher.Check(pass, s.dummy)
+		s.loginFailed(ctx, 0, login, peer)
+		return entity.UserDAO{}, ErrInvalidCredentials
+	}
+
+	if err = s.passwordHasher.Check(pass, user.PasswordHash); err != nil {
+		s.loginFailed(ctx, user.ID, login, peer)
+		return entity.UserDAO{}, ErrInvalidCredentials
+	}
+
+	return user, nil
+}
+
+// loginSucceeded сбрасывает счётчик неудач логина после полной аутентификации (пароль и второй фактор).
+//
+// Счётчик адреса не сбрасывается - иначе успешный вход в свою учётную запись позволял бы продолжать перебор.
+func (s *AuthService) loginSucceeded(ctx context.Context, login string) {
+	_ = s.repo.ResetLoginFailures(ctx, loginKey(login))
+}
+
+// loginFailed учитывает неудачную попытку входа и при достижении порога блокирует логин или адрес.
+//
+// Блокировка существующего логина сохраняется как событие безопасности его владельца (userID != 0).
+// Ошибки учёта не меняют результат попытки (она в любом случае отклонена). Учёт не прерывается
+// отменой запроса - иначе клиент, разрывающий соединение после ответа, обходил бы блокировку.
+func (s *AuthService) loginFailed(ctx context.Context, userID int, login, peer string) {
+	ctx = detach(ctx)
+
+	if until, ok := s.countFailure(ctx, loginKey(login), s.lockout.MaxFailures); ok && userID != 0 {
+		_ = s.repo.CreateSecurityEvent(ctx, entity.SecurityEventDAO{
+			UserID:      userID,
+			Kind:        entity.SecurityEventLoginLocked,
+			Peer:        peer,
+			LockedUntil: until,
+		})
+	}
+
+	if peer != "" {
+		s.countFailure(ctx, peerKey(peer), s.lockout.PeerMaxFailures)
+	}
+}
+
+// countFailure учитывает неудачу по ключу key и блокирует его при достижении порога limit.
+//
+// Возвращает время окончания блокировки и true, если ключ заблокирован.
+func (s *AuthService) countFailure(ctx context.Context, key string, limit int) (time.Time, bool) {
+	if limit <= 0 {
+		return time.Time{}, false
+	}
+
+	ctx = detach(ctx)
+
+	failures, err := s.repo.AddLoginFailure(ctx, key, s.lockout.Window)
+	if err != nil || failures < limit {
+		return time.Time{}, false
+	}
+
+	until := time.Now().Add(s.lockout.lockoutDelay(failures, limit))
+	if err = s.repo.LockLogin(ctx, key, until); err != nil {
+		return time.Time{}, false
+	}
+
+	return until, true
+}
+
+// detachedContext контекст запроса без его отмены и срока (значения сохраняются).
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+// detach отвязывает ctx от отмены запроса: операции хранилища ограничены только его собственным таймаутом.
+func detach(ctx context.Context) context.Context {
+	return detachedContext{ctx}
+}
+
+// dummyHash возвращает хэш случайного пароля, вычисленный по текущей схеме.
+func dummyHash(hasher password.IPasswordHash) string {
+	pass, err := randomString(dummyPasswordSize)
+	if err != nil {
+		return ""
+	}
+
+	hash, _ := hasher.Hash(pass)
+	return hash
+}
+
+// SecurityEvents - последние события безопасности учётной записи (блокировки входа), от новых к старым.
+func (s *AuthService) SecurityEvents(ctx context.Context, userID int) ([]entity.SecurityEventDTO, error) {
+	events, err := s.repo.GetSecurityEvents(ctx, userID, securityEventsLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]entity.SecurityEventDTO, 0, len(events))
+	for _, event := range events {
+		result = append(result, entity.SecurityEventDTO{
+			Kind:        event.Kind,
+			Peer:        event.Peer,
+			LockedUntil: event.LockedUntil,
+			CreatedAt:   event.CreatedAt,
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,160 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	"github.com/PaulYakow/gophkeeper/internal/utils/encryption"
+	"github.com/PaulYakow/gophkeeper/internal/utils/otp"
+)
+
+// probeSecret секрет для проверки параметров одноразового пароля с зашифрованным секретом.
+const probeSecret = "GEZDGNBVGY3TQOJQ"
+
+// OTPService сервис доступа к одноразовым паролям (TOTP/HOTP).
+type OTPService struct {
+	repo IOTPRepo
+}
+
+// NewOTPService создаёт объект типа OTPService.
+func NewOTPService(repo IOTPRepo) *OTPService {
+	return &OTPService{
+		repo: repo,
+	}
+}
+
+// ViewOTPs получение страницы списка одноразовых паролей пользователя.
+//
+// Возвращает записи страницы и токен следующей страницы (пустой - страница последняя).
+func (s *OTPService) ViewOTPs(ctx context.Context, userID int, page entity.PageRequest) ([]entity.OTPDTO, string, error) {
+	return viewPage(page, func(query entity.ListQuery) ([]entity.OTPDAO, error) {
+		return s.repo.ListOTPs(ctx, userID, query)
+	}, func(item entity.OTPDAO) entity.Cursor {
+		return entity.Cursor{CreatedAt: item.CreatedAt, ID: item.ID}
+	}, otpsToDTO)
+}
+
+// CreateOTP создание нового одноразового пароля пользователя.
+//
+// Возвращает id созданной записи или ошибку (например, при некорректных параметрах).
+func (s *OTPService) CreateOTP(ctx context.Context, userID int, item entity.OTPDTO) (int, error) {
+	item, err := normalizeOTP(item)
+	if err != nil {
+		return 0, err
+	}
+
+	item.Labels, err = normalizeLabels(item.Labels)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.repo.CreateOTP(ctx, otpToDAO(userID, item))
+}
+
+// UpdateOTP изменение существующего одноразового пароля пользователя.
+func (s *OTPService) UpdateOTP(ctx context.Context, userID int, item entity.OTPDTO) error {
+	item, err := normalizeOTP(item)
+	if err != nil {
+		return err
+	}
+
+	item.Labels, err = normalizeLabels(item.Labels)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.UpdateOTP(ctx, otpToDAO(userID, item))
+}
+
+// DeleteOTP удаление одноразового пароля пользователя.
+func (s *OTPService) DeleteOTP(ctx context.Context, userID, otpID int) error {
+	return s.repo.DeleteOTP(ctx, userID, otpID)
+}
+
+// Заполнение параметров по умолчанию и проверка корректности одноразового пароля.
+func normalizeOTP(item entity.OTPDTO) (entity.OTPDTO, error) {
+	item.Kind = strings.ToLower(item.Kind)
+	if item.Kind == "" {
+		item.Kind = otp.KindTOTP
+	}
+
+	item.Algorithm = strings.ToUpper(item.Algorithm)
+	if item.Algorithm == "" {
+		item.Algorithm = otp.AlgorithmSHA1
+	}
+
+	if item.Digits == 0 {
+		item.Digits = otp.DefaultDigits
+	}
+
+	if item.Kind == otp.KindTOTP && item.Period == 0 {
+		item.Period = otp.DefaultPeriod
+	}
+
+	if item.Kind != otp.KindTOTP && item.Kind != otp.KindHOTP {
+		return item, fmt.Errorf("%w: unknown kind %q", ErrInvalidOTP, item.Kind)
+	}
+
+	if item.Counter < 0 {
+		return item, fmt.Errorf("%w: negative counter", ErrInvalidOTP)
+	}
+
+	// пробное вычисление проверяет секрет, алгоритм, количество цифр и период;
+	// зашифрованный на клиенте секрет недоступен серверу, поэтому для него проверяются только параметры
+	secret := item.Secret
+	if encryption.IsEncrypted(secret) {
+		secret = probeSecret
+	}
+
+	var err error
+	if item.Kind == otp.KindTOTP {
+		_, err = otp.TOTP(secret, time.Now(), item.Period, item.Algorithm, item.Digits)
+	} else {
+		_, err = otp.HOTP(secret, uint64(item.Counter), item.Algorithm, item.Digits)
+	}
+	if err != nil {
+		return item, fmt.Errorf("%w: %s", ErrInvalidOTP, err.Error())
+	}
+
+	return item, nil
+}
+
+func otpToDAO(userID int, item entity.OTPDTO) entity.OTPDAO {
+	return entity.OTPDAO{
+		ID:        item.ID,
+		UserID:    userID,
+		Kind:      item.Kind,
+		Secret:    item.Secret,
+		Algorithm: item.Algorithm,
+		Digits:    item.Digits,
+		Period:    item.Period,
+		Counter:   item.Counter,
+		Issuer:    item.Issuer,
+		Metadata:  item.Metadata,
+		Labels:    item.Labels,
+	}
+}
+
+// otpsToDTO преобразует записи одноразовых паролей из БД в объекты для API.
+func otpsToDTO(items []entity.OTPDAO) []entity.OTPDTO {
+	out := make([]entity.OTPDTO, len(items))
+	for i, item := range items {
+		out[i] = entity.OTPDTO{
+			ID:        item.ID,
+			Kind:      item.Kind,
+			Secret:    item.Secret,
+			Algorithm: item.Algorithm,
+			Digits:    item.Digits,
+			Period:    item.Period,
+			Counter:   item.Counter,
+			Issuer:    item.Issuer,
+			Metadata:  item.Metadata,
+			Labels:    item.Labels,
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+)
+
+const (
+	// DefaultPageSize размер страницы списка, если клиент его не указал.
+	DefaultPageSize = 50
+	// MaxPageSize максимальный размер страницы списка (больший запрос уменьшается до него).
+	MaxPageSize = 500
+	// pageTokenVersion версия формата токена страницы.
+	pageTokenVersion = 1
+)
+
+// viewPage получение страницы списка записей.
+//
+// list выбирает записи из хранилища, cursor - позиция записи в списке, toDTO - преобразование записей для API.
+// Запрашивается на одну запись больше размера страницы: если она есть, возвращается токен следующей страницы,
+// указывающий на последнюю запись текущей.
+func viewPage[D, T any](page entity.PageRequest,
+	list func(query entity.ListQuery) ([]D, error),
+	cursor func(D) entity.Cursor,
+	toDTO func([]D) []T,
+) ([]T, string, error) {
+	query, err := listQuery(page)
+	if err != nil {
+		return nil, "", err
+	}
+
+	items, err := list(query)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var next string
+	if len(items) >= query.Limit {
+		items = items[:query.Limit-1]
+		next = encodePageToken(query.Order, cursor(items[len(items)-1]))
+	}
+
+	return toDTO(items), next, nil
+}
+
+// listQuery проверяет параметры страницы и формирует по ним выборку из хранилища (размер - на одну запись больше).
+//
+// Возвращает ErrInvalidPage при отрицательном размере, пустом диапазоне времени создания, неизвестном порядке,
+// отрицательном id папки или метки фильтра или некорректном токене.
+func listQuery(page entity.PageRequest) (entity.ListQuery, error) {
+	switch {
+	case page.Size < 0:
+		return entity.ListQuery{}, fmt.Errorf("%w: negative size", ErrInvalidPage)
+	case page.Order != entity.SortCreatedAsc && page.Order != entity.SortCreatedDesc:
+		return entity.ListQuery{}, fmt.Errorf("%w: unknown order", ErrInvalidPage)
+	case !page.CreatedFrom.IsZero() && !page.CreatedTo.IsZero() && !page.CreatedFrom.Before(page.CreatedTo):
+		return entity.ListQuery{}, fmt.Errorf("%w: empty creation time range", ErrInvalidPage)
+	case page.Filter.FolderID != nil && *page.Filter.FolderID < 0, page.Filter.TagID < 0:
+		return entity.ListQuery{}, fmt.Errorf("%w: negative folder or tag id", ErrInvalidPage)
+	}
+
+	size := page.Size
+	if size == 0 {
+		size = DefaultPageSize
+	}
+	if size > MaxPageSize {
+		size = MaxPageSize
+	}
+
+	query := entity.ListQuery{
+		Limit:       size + 1,
+		CreatedFrom: page.CreatedFrom,
+		CreatedTo:   page.CreatedTo,
+		Order:       page.Order,
+		Filter:      page.Filter,
+	}
+
+	if page.Token != "" {
+		after, err := decodePageToken(page.Token, page.Order)
+		if err != nil {
+			return entity.ListQuery{}, err
+		}
+		query.After = &after
+	}
+
+	return query, nil
+}
+
+// encodePageToken формирует токен следующей страницы (позиция последней записи страницы и порядок списка).
+func encodePageToken(order entity.SortOrder, after entity.Cursor) string {
+	raw := fmt.Sprintf("%d:%d:%d:%d", pageTokenVersion, order, after.CreatedAt.UnixNano(), after.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// decodePageToken разбирает токен страницы.
+//
+// Возвращает ErrInvalidPage, если токен повреждён или выдан для списка с другим порядком.
+func decodePageToken(token string, order entity.SortOrder) (entity.Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return entity.Cursor{}, fmt.Errorf("%w: malformed token", ErrInvalidPage)
+	}
+
+	var (
+		version     int
+		tokenOrder  entity.SortOrder
+		createdNano int64
+		id          int
+	)
+	n, err := fmt.Sscanf(string(raw), "%d:%d:%d:%d", &version, &tokenOrder, &createdNano, &id)
+	if err != nil || n != 4 || version != pageTokenVersion {
+		return entity.Cursor{}, fmt.Errorf("%w: malformed token", ErrInvalidPage)
+	}
+	if tokenOrder != order {
+		return entity.Cursor{}, fmt.Errorf("%w: token issued for another order", ErrInvalidPage)
+	}
+
+	return entity.Cursor{CreatedAt: time.Unix(0, createdNano).UTC(), ID: id}, nil
+}
@@ -18,22 +18,69 @@ func NewPairsService(repo IPairsRepo) *PairsService {
 	}
 }
 
-// ViewAllPairs получение всех значений типа логин/пароль.
-func (s *PairsService) ViewAllPairs(userID int) ([]entity.PairDTO, error) {
-	pairsDAO, err := s.repo.GetAllPairs(context.Background(), userID)
+// ViewPairs получение страницы списка значений типа логин/пароль.
+//
+// Возвращает записи страницы и токен следующей страницы (пустой - страница последняя).
+func (s *PairsService) ViewPairs(ctx context.Context, userID int, page entity.PageRequest) ([]entity.PairDTO, string, error) {
+	return viewPage(page, func(query entity.ListQuery) ([]entity.PairDAO, error) {
+		return s.repo.ListPairs(ctx, userID, query)
+	}, func(item entity.PairDAO) entity.Cursor {
+		return entity.Cursor{CreatedAt: item.CreatedAt, ID: item.ID}
+	}, pairsToDTO)
+}
+
+// CreatePair создание новой пары логин/пароль пользователя.
+//
+// Возвращает id созданной записи или ошибку.
+func (s *PairsService) CreatePair(ctx context.Context, userID int, pair entity.PairDTO) (int, error) {
+	labels, err := normalizeLabels(pair.Labels)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
-	pairsDTO := make([]entity.PairDTO, len(pairsDAO))
-	for i, pair := range pairsDAO {
-		pairsDTO[i] = entity.PairDTO{
+	return s.repo.CreatePair(ctx, entity.PairDAO{
+		UserID:   userID,
+		Login:    pair.Login,
+		Password: pair.Password,
+		Metadata: pair.Metadata,
+		Labels:   labels,
+	})
+}
+
+// UpdatePair изменение существующей пары логин/пароль пользователя.
+func (s *PairsService) UpdatePair(ctx context.Context, userID int, pair entity.PairDTO) error {
+	labels, err := normalizeLabels(pair.Labels)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.UpdatePair(ctx, entity.PairDAO{
+		ID:       pair.ID,
+		UserID:   userID,
+		Login:    pair.Login,
+		Password: pair.Password,
+		Metadata: pair.Metadata,
+		Labels:   labels,
+	})
+}
+
+// DeletePair удаление пары логин/пароль пользователя.
+func (s *PairsService) DeletePair(ctx context.Context, userID, pairID int) error {
+	return s.repo.DeletePair(ctx, userID, pairID)
+}
+
+// pairsToDTO преобразует записи пар логин/пароль из БД в объекты для API.
+func pairsToDTO(items []entity.PairDAO) []entity.PairDTO {
+	out := make([]entity.PairDTO, len(items))
+	for i, pair := range items {
+		out[i] = entity.PairDTO{
 			ID:       pair.ID,
 			Login:    pair.Login,
 			Password: pair.Password,
 			Metadata: pair.Metadata,
+			Labels:   pair.Labels,
 		}
 	}
 
-	return pairsDTO, nil
+	return out
 }
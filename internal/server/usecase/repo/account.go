@@ -0,0 +1,136 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	"github.com/PaulYakow/gophkeeper/pkg/postgres"
+)
+
+const (
+	getBinariesWithDataByUserID = `
+SELECT * FROM resources.binary_data
+WHERE user_id = $1
+ORDER BY id;
+`
+	deleteUser = `
+DELETE FROM public.users
+WHERE id = $1 AND password_hash = $2;
+`
+)
+
+// AccountPostgres реализация интерфейса usecase.IAccountRepo
+type AccountPostgres struct {
+	db            *postgres.Postgres
+	env           *Envelope
+	exportTimeout time.Duration
+}
+
+// NewAccountPostgres создаёт объект типа AccountPostgres.
+//
+// exportTimeout - время на выгрузку всех данных пользователя (включая содержимое файлов).
+func NewAccountPostgres(pg *postgres.Postgres, env *Envelope, exportTimeout time.Duration) *AccountPostgres {
+	return &AccountPostgres{pg, env, exportTimeout}
+}
+
+// ExportAccount находит в БД все данные пользователя (userID), включая содержимое файлов.
+//
+// Все данные читаются из одного снимка БД.
+func (p *AccountPostgres) ExportAccount(ctx context.Context, userID int) (entity.AccountDAO, error) {
+	var result entity.AccountDAO
+
+	ctxInner, cancel := context.WithTimeout(ctx, p.exportTimeout)
+	defer cancel()
+
+	tx, err := p.db.BeginTxx(ctxInner, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return result, fmt.Errorf("repo - begin export: %w", err)
+	}
+	// после Commit откат ничего не делает
+	defer tx.Rollback()
+
+	err = tx.GetContext(ctxInner, &result.User, getUserByID, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return result, ErrNotFound
+	}
+	if err != nil {
+		return result, fmt.Errorf("repo - get user by id: %w", err)
+	}
+
+	queries := []struct {
+		dest  any
+		query string
+		name  string
+	}{
+		{&result.Pairs, getPairsByUserID, "pairs"},
+		{&result.Cards, getCardsByUserID, "cards"},
+		{&result.Notes, getNotesByUserID, "notes"},
+		{&result.Binaries, getBinariesWithDataByUserID, "binaries"},
+		{&result.OTPs, getOTPsByUserID, "otps"},
+		{&result.Folders, getFoldersByUserID, "folders"},
+		{&result.Tags, getTagsByUserID, "tags"},
+	}
+	for _, q := range queries {
+		if err = tx.SelectContext(ctxInner, q.dest, q.query, userID); err != nil {
+			return result, fmt.Errorf("repo - export %s: %w", q.name, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return result, fmt.Errorf("repo - commit export: %w", err)
+	}
+
+	if err = openPairs(ctx, p.env, userID, result.Pairs); err != nil {
+		return result, err
+	}
+	if err = openCards(ctx, p.env, userID, result.Cards); err != nil {
+		return result, err
+	}
+	if err = openNotes(ctx, p.env, userID, result.Notes); err != nil {
+		return result, err
+	}
+	if err = openBinaries(ctx, p.env, userID, result.Binaries); err != nil {
+		return result, err
+	}
+	for i := range result.Binaries {
+		if result.Binaries[i].Data, err = p.env.openBytes(ctx, userID, result.Binaries[i].Data); err != nil {
+			return result, err
+		}
+	}
+	if err = openOTPs(ctx, p.env, userID, result.OTPs); err != nil {
+		return result, err
+	}
+	if err = openFolders(ctx, p.env, userID, result.Folders); err != nil {
+		return result, err
+	}
+	if err = openTags(ctx, p.env, userID, result.Tags); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// DeleteAccount удаляет из БД пользователя (userID) вместе со всеми его данными.
+//
+// Данные, ключи, сессии и история удаляются каскадно (ON DELETE CASCADE) в той же транзакции.
+// Удаление выполняется, только если текущий хэш пароля равен passwordHash, иначе возвращается ErrNotFound.
+func (p *AccountPostgres) DeleteAccount(ctx context.Context, userID int, passwordHash string) error {
+	ctxInner, cancel := p.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := p.db.ExecContext(ctxInner, deleteUser, userID, passwordHash)
+	if err != nil {
+		return fmt.Errorf("repo - delete account: %w", err)
+	}
+
+	if err = checkAffected(res); err != nil {
+		return err
+	}
+
+	p.env.forget(userID)
+	return nil
+}
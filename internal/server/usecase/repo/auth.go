@@ -2,12 +2,21 @@ package repo
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"time"
 
+	"github.com/jackc/pgx"
+	"github.com/jmoiron/sqlx"
+
 	"github.com/PaulYakow/gophkeeper/internal/entity"
 	"github.com/PaulYakow/gophkeeper/pkg/postgres"
 )
 
+// uniqueViolation код ошибки Postgres при нарушении уникальности.
+const uniqueViolation = "23505"
+
 const (
 	createUser = `
 INSERT INTO public.users (login, password_hash)
@@ -18,28 +27,162 @@ RETURNING id;
 SELECT *
 FROM users
 WHERE login=$1;
+`
+	getUserByID = `
+SELECT * FROM public.users
+WHERE id = $1;
+`
+	getPasswordHistory = `
+SELECT password_hash FROM public.password_history
+WHERE user_id = $1
+ORDER BY id DESC
+LIMIT $2;
+`
+	archivePassword = `
+INSERT INTO public.password_history (user_id, password_hash)
+SELECT id, password_hash FROM public.users
+WHERE id = $1 AND password_hash = $2
+FOR UPDATE;
+`
+	changePassword = `
+UPDATE public.users
+SET password_hash = $2, password_changed_at = NOW()
+WHERE id = $1;
+`
+	rehashPassword = `
+UPDATE public.users
+SET password_hash = $3
+WHERE id = $1 AND password_hash = $2;
+`
+	revokeUserSessions = `
+UPDATE public.sessions
+SET revoked_at = NOW()
+WHERE user_id = $1 AND revoked_at IS NULL;
+`
+	deleteStaleSessions = `
+DELETE FROM public.sessions
+WHERE user_id = $1 AND (expires_at < NOW() OR revoked_at IS NOT NULL);
+`
+	createSession = `
+INSERT INTO public.sessions (id, user_id, refresh_hash, expires_at)
+VALUES ($1, $2, $3, $4);
+`
+	getSession = `
+SELECT * FROM public.sessions
+WHERE id = $1;
+`
+	rotateSession = `
+UPDATE public.sessions
+SET refresh_hash = $3, expires_at = $4
+WHERE id = $1 AND refresh_hash = $2 AND revoked_at IS NULL AND expires_at > NOW();
+`
+	revokeSession = `
+UPDATE public.sessions
+SET revoked_at = NOW()
+WHERE id = $1 AND revoked_at IS NULL;
+`
+	getLoginLock = `
+SELECT COALESCE(MAX(locked_until), 'epoch') FROM public.login_failures
+WHERE key IN (?);
+`
+	addLoginFailure = `
+INSERT INTO public.login_failures (key, failures, updated_at)
+VALUES ($1, 1, NOW())
+ON CONFLICT (key) DO UPDATE
+SET failures = CASE
+        WHEN login_failures.updated_at < NOW() - make_interval(secs => $2) THEN 1
+        ELSE login_failures.failures + 1
+    END,
+    updated_at = NOW()
+RETURNING failures;
+`
+	lockLogin = `
+UPDATE public.login_failures
+SET locked_until = $2
+WHERE key = $1;
+`
+	resetLoginFailures = `
+DELETE FROM public.login_failures
+WHERE key = $1;
+`
+	createSecurityEvent = `
+INSERT INTO public.security_events (user_id, kind, peer, locked_until)
+VALUES ($1, $2, $3, $4);
+`
+	getTOTP = `
+SELECT * FROM public.totp
+WHERE user_id = $1;
+`
+	saveTOTPPending = `
+INSERT INTO public.totp (user_id, pending_secret)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+SET pending_secret = EXCLUDED.pending_secret;
+`
+	confirmTOTP = `
+UPDATE public.totp
+SET secret = pending_secret, pending_secret = '', last_step = $2
+WHERE user_id = $1 AND pending_secret <> '';
+`
+	useTOTPStep = `
+UPDATE public.totp
+SET last_step = $2
+WHERE user_id = $1 AND last_step < $2;
+`
+	deleteTOTP = `
+DELETE FROM public.totp
+WHERE user_id = $1;
+`
+	deleteStaleChallenges = `
+DELETE FROM public.login_challenges
+WHERE user_id = $1 AND expires_at < NOW();
+`
+	createChallenge = `
+INSERT INTO public.login_challenges (id, user_id, secret_hash, expires_at)
+VALUES ($1, $2, $3, $4);
+`
+	getChallenge = `
+SELECT c.*, u.login
+FROM public.login_challenges c
+JOIN public.users u ON u.id = c.user_id
+WHERE c.id = $1;
+`
+	deleteChallenge = `
+DELETE FROM public.login_challenges
+WHERE id = $1;
+`
+	getSecurityEvents = `
+SELECT * FROM public.security_events
+WHERE user_id = $1
+ORDER BY id DESC
+LIMIT $2;
 `
 )
 
-// AuthPostgres реализация интерфейса usecase.IAuthorizationRepo
+// AuthPostgres реализация интерфейса usecase.IAuthorizationRepo (секреты второго фактора шифруются через Envelope)
 type AuthPostgres struct {
-	db *postgres.Postgres
+	db  *postgres.Postgres
+	env *Envelope
 }
 
 // NewAuthPostgres создаёт объект типа AuthPostgres.
-func NewAuthPostgres(db *postgres.Postgres) *AuthPostgres {
-	return &AuthPostgres{db}
+func NewAuthPostgres(db *postgres.Postgres, env *Envelope) *AuthPostgres {
+	return &AuthPostgres{db, env}
 }
 
 // CreateUser - создание пользователя с заданными логином и хэшем пароля.
 //
-// Возвращает id пользователя или ошибку (например, если логин уже существует).
-func (a *AuthPostgres) CreateUser(login, passwordHash string) (int, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+// Возвращает id пользователя или ошибку (ErrUserExist, если логин уже существует).
+func (a *AuthPostgres) CreateUser(ctx context.Context, login, passwordHash string) (int, error) {
+	ctxInner, cancel := a.db.WithTimeout(ctx)
 	defer cancel()
 
 	var id int
-	err := a.db.GetContext(ctx, &id, createUser, login, passwordHash)
+	err := a.db.GetContext(ctxInner, &id, createUser, login, passwordHash)
+	var pgErr pgx.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return 0, ErrUserExist
+	}
 	if err != nil {
 		return 0, err
 	}
@@ -50,8 +193,392 @@ func (a *AuthPostgres) CreateUser(login, passwordHash string) (int, error) {
 // GetUser - находит пользователя в БД по логину.
 //
 // Возвращает объект пользователя или ошибку (например, при отсутствии логина).
-func (a *AuthPostgres) GetUser(login string) (entity.UserDAO, error) {
+func (a *AuthPostgres) GetUser(ctx context.Context, login string) (entity.UserDAO, error) {
+	ctxInner, cancel := a.db.WithTimeout(ctx)
+	defer cancel()
+
 	var user entity.UserDAO
-	err := a.db.Get(&user, getUser, login)
+	err := a.db.GetContext(ctxInner, &user, getUser, login)
 	return user, err
 }
+
+// GetUserByID находит пользователя в БД по id.
+//
+// Возвращает ErrNotFound, если пользователь не найден.
+func (a *AuthPostgres) GetUserByID(ctx context.Context, userID int) (entity.UserDAO, error) {
+	ctxInner, cancel := a.db.WithTimeout(ctx)
+	defer cancel()
+
+	var user entity.UserDAO
+	err := a.db.GetContext(ctxInner, &user, getUserByID, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return user, ErrNotFound
+	}
+	if err != nil {
+		return user, fmt.Errorf("repo - get user by id: %w", err)
+	}
+
+	return user, nil
+}
+
+// GetPasswordHistory находит в БД limit последних прежних хэшей пароля пользователя (от новых к старым).
+func (a *AuthPostgres) GetPasswordHistory(ctx context.Context, userID, limit int) ([]string, error) {
+	ctxInner, cancel := a.db.WithTimeout(ctx)
+	defer cancel()
+
+	var hashes []string
+	if err := a.db.SelectContext(ctxInner, &hashes, getPasswordHistory, userID, limit); err != nil {
+		return nil, fmt.Errorf("repo - get password history: %w", err)
+	}
+
+	return hashes, nil
+}
+
+// ChangePassword заменяет хэш пароля пользователя на newHash и отзывает все его сессии.
+//
+// Прежний хэш сохраняется в историю паролей. Замена выполняется, только если текущий хэш
+// равен oldHash (пароль не был изменён параллельно), иначе возвращается ErrNotFound.
+func (a *AuthPostgres) ChangePassword(ctx context.Context, userID int, oldHash, newHash string) error {
+	ctxInner, cancel := a.db.WithTimeout(ctx)
+	defer cancel()
+
+	tx, err := a.db.BeginTxx(ctxInner, nil)
+	if err != nil {
+		return fmt.Errorf("repo - begin change password: %w", err)
+	}
+	// после Commit откат ничего не делает
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctxInner, archivePassword, userID, oldHash)
+	if err != nil {
+		return fmt.Errorf("repo - archive password: %w", err)
+	}
+	if err = checkAffected(res); err != nil {
+		return err
+	}
+
+	if _, err = tx.ExecContext(ctxInner, changePassword, userID, newHash); err != nil {
+		return fmt.Errorf("repo - change password: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctxInner, revokeUserSessions, userID); err != nil {
+		return fmt.Errorf("repo - revoke user sessions: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("repo - commit change password: %w", err)
+	}
+
+	return nil
+}
+
+// UpdatePasswordHash заменяет хэш того же пароля пользователя, пересчитанный по текущей схеме.
+//
+// История паролей, время смены пароля и сессии не меняются. Замена выполняется, только если
+// текущий хэш равен oldHash, иначе возвращается ErrNotFound.
+func (a *AuthPostgres) UpdatePasswordHash(ctx context.Context, userID int, oldHash, newHash string) error {
+	ctxInner, cancel := a.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := a.db.ExecContext(ctxInner, rehashPassword, userID, oldHash, newHash)
+	if err != nil {
+		return fmt.Errorf("repo - update password hash: %w", err)
+	}
+
+	return checkAffected(res)
+}
+
+// CreateSession сохраняет в БД новую сессию пользователя.
+//
+// Заодно удаляет истёкшие и отозванные сессии этого пользователя.
+func (a *AuthPostgres) CreateSession(ctx context.Context, session entity.SessionDAO) error {
+	ctxInner, cancel := a.db.WithTimeout(ctx)
+	defer cancel()
+
+	if _, err := a.db.ExecContext(ctxInner, deleteStaleSessions, session.UserID); err != nil {
+		return fmt.Errorf("repo - delete stale sessions: %w", err)
+	}
+
+	_, err := a.db.ExecContext(ctxInner, createSession, session.ID, session.UserID, session.RefreshHash, session.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("repo - create session: %w", err)
+	}
+
+	return nil
+}
+
+// GetSession находит в БД сессию по её id.
+//
+// Возвращает ErrNotFound, если сессия не найдена.
+func (a *AuthPostgres) GetSession(ctx context.Context, sessionID string) (entity.SessionDAO, error) {
+	ctxInner, cancel := a.db.WithTimeout(ctx)
+	defer cancel()
+
+	var session entity.SessionDAO
+	err := a.db.GetContext(ctxInner, &session, getSession, sessionID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return session, ErrNotFound
+	}
+	if err != nil {
+		return session, fmt.Errorf("repo - get session: %w", err)
+	}
+
+	return session, nil
+}
+
+// RotateSession заменяет хэш refresh-токена действующей сессии и продлевает её до expiresAt.
+//
+// Замена выполняется, только если текущий хэш равен oldHash, иначе возвращается ErrNotFound.
+func (a *AuthPostgres) RotateSession(ctx context.Context, sessionID, oldHash, newHash string, expiresAt time.Time) error {
+	ctxInner, cancel := a.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := a.db.ExecContext(ctxInner, rotateSession, sessionID, oldHash, newHash, expiresAt)
+	if err != nil {
+		return fmt.Errorf("repo - rotate session: %w", err)
+	}
+
+	return checkAffected(res)
+}
+
+// RevokeSession отзывает сессию (токены сессии перестают приниматься сервером).
+//
+// Возвращает ErrNotFound, если сессия не найдена или уже отозвана.
+func (a *AuthPostgres) RevokeSession(ctx context.Context, sessionID string) error {
+	ctxInner, cancel := a.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := a.db.ExecContext(ctxInner, revokeSession, sessionID)
+	if err != nil {
+		return fmt.Errorf("repo - revoke session: %w", err)
+	}
+
+	return checkAffected(res)
+}
+
+// GetLoginLock возвращает наибольшее время окончания блокировки по ключам неудачных попыток keys.
+//
+// Если блокировок нет, возвращается начало эпохи (время в прошлом).
+func (a *AuthPostgres) GetLoginLock(ctx context.Context, keys ...string) (time.Time, error) {
+	ctxInner, cancel := a.db.WithTimeout(ctx)
+	defer cancel()
+
+	query, args, err := sqlx.In(getLoginLock, keys)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("repo - get login lock: %w", err)
+	}
+
+	var lockedUntil time.Time
+	if err = a.db.GetContext(ctxInner, &lockedUntil, a.db.Rebind(query), args...); err != nil {
+		return time.Time{}, fmt.Errorf("repo - get login lock: %w", err)
+	}
+
+	return lockedUntil, nil
+}
+
+// AddLoginFailure учитывает неудачную попытку по ключу key и возвращает число неудач подряд.
+//
+// Если предыдущая неудача была раньше, чем window назад, счёт начинается заново.
+func (a *AuthPostgres) AddLoginFailure(ctx context.Context, key string, window time.Duration) (int, error) {
+	ctxInner, cancel := a.db.WithTimeout(ctx)
+	defer cancel()
+
+	var failures int
+	if err := a.db.GetContext(ctxInner, &failures, addLoginFailure, key, window.Seconds()); err != nil {
+		return 0, fmt.Errorf("repo - add login failure: %w", err)
+	}
+
+	return failures, nil
+}
+
+// LockLogin блокирует попытки по ключу key до until.
+func (a *AuthPostgres) LockLogin(ctx context.Context, key string, until time.Time) error {
+	ctxInner, cancel := a.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := a.db.ExecContext(ctxInner, lockLogin, key, until)
+	if err != nil {
+		return fmt.Errorf("repo - lock login: %w", err)
+	}
+
+	return checkAffected(res)
+}
+
+// ResetLoginFailures сбрасывает счётчик неудачных попыток по ключу key.
+func (a *AuthPostgres) ResetLoginFailures(ctx context.Context, key string) error {
+	ctxInner, cancel := a.db.WithTimeout(ctx)
+	defer cancel()
+
+	if _, err := a.db.ExecContext(ctxInner, resetLoginFailures, key); err != nil {
+		return fmt.Errorf("repo - reset login failures: %w", err)
+	}
+
+	return nil
+}
+
+// CreateSecurityEvent сохраняет в БД событие безопасности учётной записи.
+func (a *AuthPostgres) CreateSecurityEvent(ctx context.Context, event entity.SecurityEventDAO) error {
+	ctxInner, cancel := a.db.WithTimeout(ctx)
+	defer cancel()
+
+	_, err := a.db.ExecContext(ctxInner, createSecurityEvent, event.UserID, event.Kind, event.Peer, event.LockedUntil)
+	if err != nil {
+		return fmt.Errorf("repo - create security event: %w", err)
+	}
+
+	return nil
+}
+
+// GetSecurityEvents находит в БД limit последних событий безопасности пользователя (от новых к старым).
+func (a *AuthPostgres) GetSecurityEvents(ctx context.Context, userID, limit int) ([]entity.SecurityEventDAO, error) {
+	ctxInner, cancel := a.db.WithTimeout(ctx)
+	defer cancel()
+
+	var events []entity.SecurityEventDAO
+	if err := a.db.SelectContext(ctxInner, &events, getSecurityEvents, userID, limit); err != nil {
+		return nil, fmt.Errorf("repo - get security events: %w", err)
+	}
+
+	return events, nil
+}
+
+// GetTOTP находит в БД настройки второго фактора пользователя.
+//
+// Если второй фактор не подключался, возвращаются нулевые настройки (без ошибки).
+func (a *AuthPostgres) GetTOTP(ctx context.Context, userID int) (entity.TOTPDAO, error) {
+	ctxInner, cancel := a.db.WithTimeout(ctx)
+	defer cancel()
+
+	totp := entity.TOTPDAO{UserID: userID}
+	err := a.db.GetContext(ctxInner, &totp, getTOTP, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return totp, nil
+	}
+	if err != nil {
+		return totp, fmt.Errorf("repo - get totp: %w", err)
+	}
+
+	for _, secret := range []*string{&totp.Secret, &totp.PendingSecret} {
+		if *secret == "" {
+			continue
+		}
+		if err = a.env.open(ctxInner, userID, secret); err != nil {
+			return totp, fmt.Errorf("repo - open totp: %w", err)
+		}
+	}
+
+	return totp, nil
+}
+
+// SaveTOTPPending сохраняет секрет подключаемого аутентификатора (действующий не меняется).
+func (a *AuthPostgres) SaveTOTPPending(ctx context.Context, userID int, secret string) error {
+	ctxInner, cancel := a.db.WithTimeout(ctx)
+	defer cancel()
+
+	if err := a.env.seal(ctxInner, userID, &secret); err != nil {
+		return fmt.Errorf("repo - seal totp: %w", err)
+	}
+
+	if _, err := a.db.ExecContext(ctxInner, saveTOTPPending, userID, secret); err != nil {
+		return fmt.Errorf("repo - save totp: %w", err)
+	}
+
+	return nil
+}
+
+// ConfirmTOTP делает подключаемый аутентификатор действующим; step - интервал принятого кода.
+//
+// Возвращает ErrNotFound, если подключаемого аутентификатора нет.
+func (a *AuthPostgres) ConfirmTOTP(ctx context.Context, userID int, step int64) error {
+	ctxInner, cancel := a.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := a.db.ExecContext(ctxInner, confirmTOTP, userID, step)
+	if err != nil {
+		return fmt.Errorf("repo - confirm totp: %w", err)
+	}
+
+	return checkAffected(res)
+}
+
+// UseTOTPStep отмечает интервал step как использованный.
+//
+// Возвращает ErrNotFound, если код этого или более позднего интервала уже принят.
+func (a *AuthPostgres) UseTOTPStep(ctx context.Context, userID int, step int64) error {
+	ctxInner, cancel := a.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := a.db.ExecContext(ctxInner, useTOTPStep, userID, step)
+	if err != nil {
+		return fmt.Errorf("repo - use totp step: %w", err)
+	}
+
+	return checkAffected(res)
+}
+
+// DeleteTOTP отключает второй фактор пользователя.
+func (a *AuthPostgres) DeleteTOTP(ctx context.Context, userID int) error {
+	ctxInner, cancel := a.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := a.db.ExecContext(ctxInner, deleteTOTP, userID)
+	if err != nil {
+		return fmt.Errorf("repo - delete totp: %w", err)
+	}
+
+	return checkAffected(res)
+}
+
+// CreateChallenge сохраняет в БД незавершённый вход.
+//
+// Заодно удаляет истёкшие незавершённые входы этого пользователя.
+func (a *AuthPostgres) CreateChallenge(ctx context.Context, challenge entity.ChallengeDAO) error {
+	ctxInner, cancel := a.db.WithTimeout(ctx)
+	defer cancel()
+
+	if _, err := a.db.ExecContext(ctxInner, deleteStaleChallenges, challenge.UserID); err != nil {
+		return fmt.Errorf("repo - delete stale challenges: %w", err)
+	}
+
+	_, err := a.db.ExecContext(ctxInner, createChallenge,
+		challenge.ID, challenge.UserID, challenge.SecretHash, challenge.ExpiresAt)
+	if err != nil {
+		return fmt.Errorf("repo - create challenge: %w", err)
+	}
+
+	return nil
+}
+
+// GetChallenge находит в БД незавершённый вход по его id (вместе с логином пользователя).
+//
+// Возвращает ErrNotFound, если он не найден.
+func (a *AuthPostgres) GetChallenge(ctx context.Context, challengeID string) (entity.ChallengeDAO, error) {
+	ctxInner, cancel := a.db.WithTimeout(ctx)
+	defer cancel()
+
+	var challenge entity.ChallengeDAO
+	err := a.db.GetContext(ctxInner, &challenge, getChallenge, challengeID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return challenge, ErrNotFound
+	}
+	if err != nil {
+		return challenge, fmt.Errorf("repo - get challenge: %w", err)
+	}
+
+	return challenge, nil
+}
+
+// DeleteChallenge удаляет незавершённый вход.
+//
+// Возвращает ErrNotFound, если он уже удалён.
+func (a *AuthPostgres) DeleteChallenge(ctx context.Context, challengeID string) error {
+	ctxInner, cancel := a.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := a.db.ExecContext(ctxInner, deleteChallenge, challengeID)
+	if err != nil {
+		return fmt.Errorf("repo - delete challenge: %w", err)
+	}
+
+	return checkAffected(res)
+}
@@ -2,41 +2,165 @@ package repo
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
-	"time"
 
 	"github.com/PaulYakow/gophkeeper/internal/entity"
 	"github.com/PaulYakow/gophkeeper/pkg/postgres"
 )
 
+// listCardsQuery запросы страницы списка банковских карт для каждого порядка сортировки.
+var listCardsQuery = listQueries("*", "resources.bank_data")
+
 const (
 	getCardsByUserID = `
 SELECT * FROM resources.bank_data
 WHERE user_id = $1
 ORDER BY id;
+`
+	createCard = `
+WITH rev AS (
+    INSERT INTO public.revisions (user_id, revision)
+    SELECT $1, 1 WHERE resources.labels_valid($1, $6, $7)
+    ON CONFLICT (user_id) DO UPDATE SET revision = revisions.revision + 1
+    RETURNING revision
+)
+INSERT INTO resources.bank_data (user_id, card_holder, number, expiration_date, metadata, folder_id, tag_ids, revision)
+SELECT $1, $2, $3, $4, $5, $6, $7, rev.revision FROM rev
+RETURNING id;
+`
+	updateCard = `
+WITH rev AS (
+    INSERT INTO public.revisions (user_id, revision)
+    SELECT user_id, 1 FROM resources.bank_data
+    WHERE id = $1 AND user_id = $2 AND resources.labels_valid($2, $7, $8)
+    ON CONFLICT (user_id) DO UPDATE SET revision = revisions.revision + 1
+    RETURNING revision
+)
+UPDATE resources.bank_data
+SET card_holder = $3, number = $4, expiration_date = $5, metadata = $6, folder_id = $7, tag_ids = $8, revision = rev.revision
+FROM rev
+WHERE id = $1 AND user_id = $2;
+`
+	deleteCard = `
+WITH rev AS (
+    INSERT INTO public.revisions (user_id, revision)
+    SELECT user_id, 1 FROM resources.bank_data WHERE id = $1 AND user_id = $2
+    ON CONFLICT (user_id) DO UPDATE SET revision = revisions.revision + 1
+    RETURNING revision
+), deleted AS (
+    DELETE FROM resources.bank_data
+    WHERE id = $1 AND user_id = $2
+    RETURNING id
+)
+INSERT INTO resources.tombstones (user_id, kind, item_id, revision)
+SELECT $2, 'card', deleted.id, rev.revision FROM deleted, rev;
 `
 )
 
 // BankPostgres реализация интерфейса usecase.IBankRepo
 type BankPostgres struct {
-	db *postgres.Postgres
+	db  *postgres.Postgres
+	env *Envelope
 }
 
 // NewBankPostgres создаёт объект типа BankPostgres.
-func NewBankPostgres(pg *postgres.Postgres) *BankPostgres {
-	return &BankPostgres{pg}
+func NewBankPostgres(pg *postgres.Postgres, env *Envelope) *BankPostgres {
+	return &BankPostgres{pg, env}
 }
 
-// GetAllCards находит в БД все записи банковских карт принадлежащие конкретному пользователю (userID).
-func (p *BankPostgres) GetAllCards(ctx context.Context, userID int) ([]entity.BankDAO, error) {
+// ListCards находит в БД страницу списка записей банковских карт, принадлежащих конкретному пользователю (userID).
+//
+// Страница ограничена фильтром и курсором query (размер, диапазон времени создания, порядок, последняя запись).
+func (p *BankPostgres) ListCards(ctx context.Context, userID int, query entity.ListQuery) ([]entity.BankDAO, error) {
 	var result []entity.BankDAO
 
-	ctxInner, cancel := context.WithTimeout(ctx, time.Second)
+	ctxInner, cancel := p.db.WithTimeout(ctx)
 	defer cancel()
 
-	if err := p.db.SelectContext(ctxInner, &result, getCardsByUserID, userID); err != nil {
-		return nil, fmt.Errorf("repo - get all cards by user: %w", err)
+	err := p.db.SelectContext(ctxInner, &result, listCardsQuery[query.Order], listArgs(userID, query)...)
+	if err != nil {
+		return nil, fmt.Errorf("repo - list cards: %w", err)
+	}
+
+	if err := openCards(ctx, p.env, userID, result); err != nil {
+		return nil, err
 	}
 
 	return result, nil
 }
+
+// CreateCard сохраняет в БД новую запись банковской карты.
+//
+// Возвращает id созданной записи или ошибку (ErrNotFound, если папка или метка записи не найдена).
+func (p *BankPostgres) CreateCard(ctx context.Context, card entity.BankDAO) (int, error) {
+	err := p.env.seal(ctx, card.UserID, &card.CardHolder, &card.Number, &card.ExpirationDate, &card.Metadata)
+	if err != nil {
+		return 0, err
+	}
+
+	ctxInner, cancel := p.db.WithTimeout(ctx)
+	defer cancel()
+
+	var id int
+	err = p.db.GetContext(ctxInner, &id, createCard,
+		card.UserID, card.CardHolder, card.Number, card.ExpirationDate, card.Metadata, card.FolderID, card.TagIDs)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("repo - create card: %w", err)
+	}
+
+	return id, nil
+}
+
+// UpdateCard изменяет в БД запись банковской карты (поиск по id и user_id).
+//
+// Возвращает ErrNotFound, если не найдена запись, её папка или метка.
+func (p *BankPostgres) UpdateCard(ctx context.Context, card entity.BankDAO) error {
+	err := p.env.seal(ctx, card.UserID, &card.CardHolder, &card.Number, &card.ExpirationDate, &card.Metadata)
+	if err != nil {
+		return err
+	}
+
+	ctxInner, cancel := p.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := p.db.ExecContext(ctxInner, updateCard,
+		card.ID, card.UserID, card.CardHolder, card.Number, card.ExpirationDate, card.Metadata, card.FolderID, card.TagIDs)
+	if err != nil {
+		return fmt.Errorf("repo - update card: %w", err)
+	}
+
+	return checkAffected(res)
+}
+
+// DeleteCard удаляет из БД запись банковской карты принадлежащую конкретному пользователю (userID).
+//
+// Возвращает ошибку, если запись не найдена.
+func (p *BankPostgres) DeleteCard(ctx context.Context, userID, cardID int) error {
+	ctxInner, cancel := p.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := p.db.ExecContext(ctxInner, deleteCard, cardID, userID)
+	if err != nil {
+		return fmt.Errorf("repo - delete card: %w", err)
+	}
+
+	return checkAffected(res)
+}
+
+// openCards расшифровывает поля банковских карт пользователя (userID).
+func openCards(ctx context.Context, env *Envelope, userID int, items []entity.BankDAO) error {
+	for i := range items {
+		err := env.open(ctx, userID,
+			&items[i].CardHolder, &items[i].Number, &items[i].ExpirationDate, &items[i].Metadata)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
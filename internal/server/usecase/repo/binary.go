@@ -0,0 +1,160 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	"github.com/PaulYakow/gophkeeper/pkg/postgres"
+)
+
+// listBinariesQuery запросы страницы списка описаний файлов (без содержимого) для каждого порядка сортировки.
+var listBinariesQuery = listQueries("id, user_id, filename, size, metadata, folder_id, tag_ids, revision, created_at", "resources.binary_data")
+
+const (
+	createBinary = `
+WITH rev AS (
+    INSERT INTO public.revisions (user_id, revision)
+    SELECT $1, 1 WHERE resources.labels_valid($1, $6, $7)
+    ON CONFLICT (user_id) DO UPDATE SET revision = revisions.revision + 1
+    RETURNING revision
+)
+INSERT INTO resources.binary_data (user_id, filename, size, data, metadata, folder_id, tag_ids, revision)
+SELECT $1, $2, $3, $4, $5, $6, $7, rev.revision FROM rev
+RETURNING id;
+`
+	getBinary = `
+SELECT * FROM resources.binary_data
+WHERE id = $1 AND user_id = $2;
+`
+	deleteBinary = `
+WITH rev AS (
+    INSERT INTO public.revisions (user_id, revision)
+    SELECT user_id, 1 FROM resources.binary_data WHERE id = $1 AND user_id = $2
+    ON CONFLICT (user_id) DO UPDATE SET revision = revisions.revision + 1
+    RETURNING revision
+), deleted AS (
+    DELETE FROM resources.binary_data
+    WHERE id = $1 AND user_id = $2
+    RETURNING id
+)
+INSERT INTO resources.tombstones (user_id, kind, item_id, revision)
+SELECT $2, 'binary', deleted.id, rev.revision FROM deleted, rev;
+`
+)
+
+// BinaryPostgres реализация интерфейса usecase.IBinaryRepo
+type BinaryPostgres struct {
+	db  *postgres.Postgres
+	env *Envelope
+}
+
+// NewBinaryPostgres создаёт объект типа BinaryPostgres.
+func NewBinaryPostgres(pg *postgres.Postgres, env *Envelope) *BinaryPostgres {
+	return &BinaryPostgres{pg, env}
+}
+
+// ListBinaries находит в БД страницу списка описаний файлов, принадлежащих конкретному пользователю (userID).
+//
+// Страница ограничена фильтром и курсором query (размер, диапазон времени создания, порядок, последняя запись).
+//
+// Содержимое файлов (Data) не загружается.
+func (p *BinaryPostgres) ListBinaries(ctx context.Context, userID int, query entity.ListQuery) ([]entity.BinaryDAO, error) {
+	var result []entity.BinaryDAO
+
+	ctxInner, cancel := p.db.WithTimeout(ctx)
+	defer cancel()
+
+	err := p.db.SelectContext(ctxInner, &result, listBinariesQuery[query.Order], listArgs(userID, query)...)
+	if err != nil {
+		return nil, fmt.Errorf("repo - list binaries: %w", err)
+	}
+
+	if err := openBinaries(ctx, p.env, userID, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CreateBinary сохраняет в БД новый файл.
+//
+// Возвращает id созданной записи или ошибку (ErrNotFound, если папка или метка записи не найдена).
+func (p *BinaryPostgres) CreateBinary(ctx context.Context, binary entity.BinaryDAO) (int, error) {
+	if err := p.env.seal(ctx, binary.UserID, &binary.Filename, &binary.Metadata); err != nil {
+		return 0, err
+	}
+
+	data, err := p.env.sealBytes(ctx, binary.UserID, binary.Data)
+	if err != nil {
+		return 0, err
+	}
+
+	ctxInner, cancel := p.db.WithTimeout(ctx)
+	defer cancel()
+
+	var id int
+	err = p.db.GetContext(ctxInner, &id, createBinary,
+		binary.UserID, binary.Filename, binary.Size, data, binary.Metadata, binary.FolderID, binary.TagIDs)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("repo - create binary: %w", err)
+	}
+
+	return id, nil
+}
+
+// GetBinary находит в БД файл (вместе с содержимым) принадлежащий конкретному пользователю (userID).
+//
+// Возвращает ErrNotFound, если не найдена запись, её папка или метка.
+func (p *BinaryPostgres) GetBinary(ctx context.Context, userID, binaryID int) (entity.BinaryDAO, error) {
+	var result entity.BinaryDAO
+
+	ctxInner, cancel := p.db.WithTimeout(ctx)
+	defer cancel()
+
+	err := p.db.GetContext(ctxInner, &result, getBinary, binaryID, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return result, ErrNotFound
+	}
+	if err != nil {
+		return result, fmt.Errorf("repo - get binary: %w", err)
+	}
+
+	if err = p.env.open(ctx, userID, &result.Filename, &result.Metadata); err != nil {
+		return result, err
+	}
+
+	result.Data, err = p.env.openBytes(ctx, userID, result.Data)
+	return result, err
+}
+
+// DeleteBinary удаляет из БД файл принадлежащий конкретному пользователю (userID).
+//
+// Возвращает ошибку, если запись не найдена.
+func (p *BinaryPostgres) DeleteBinary(ctx context.Context, userID, binaryID int) error {
+	ctxInner, cancel := p.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := p.db.ExecContext(ctxInner, deleteBinary, binaryID, userID)
+	if err != nil {
+		return fmt.Errorf("repo - delete binary: %w", err)
+	}
+
+	return checkAffected(res)
+}
+
+// openBinaries расшифровывает поля описаний файлов пользователя (userID).
+func openBinaries(ctx context.Context, env *Envelope, userID int, items []entity.BinaryDAO) error {
+	for i := range items {
+		if err := env.open(ctx, userID, &items[i].Filename, &items[i].Metadata); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
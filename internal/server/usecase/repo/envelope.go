@@ -0,0 +1,505 @@
+package repo
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/chacha20poly1305"
+
+	"github.com/PaulYakow/gophkeeper/internal/utils/encryption"
+	"github.com/PaulYakow/gophkeeper/pkg/postgres"
+)
+
+const (
+	getDataKey = `
+SELECT kek_id, wrapped_key FROM public.data_keys
+WHERE user_id = $1;
+`
+	createDataKey = `
+INSERT INTO public.data_keys (user_id, kek_id, wrapped_key)
+VALUES ($1, $2, $3)
+ON CONFLICT (user_id) DO NOTHING;
+`
+	getStaleDataKeys = `
+SELECT user_id, kek_id, wrapped_key FROM public.data_keys
+WHERE kek_id <> $1;
+`
+	rewrapDataKey = `
+UPDATE public.data_keys
+SET kek_id = $3, wrapped_key = $4
+WHERE user_id = $1 AND kek_id = $2;
+`
+)
+
+// ErrInvalidDataKey ошибка разворачивания ключа данных пользователя.
+var ErrInvalidDataKey = errors.New("repo - invalid data key")
+
+type dataKeyDAO struct {
+	UserID     int    `db:"user_id"`
+	KEKID      string `db:"kek_id"`
+	WrappedKey []byte `db:"wrapped_key"`
+}
+
+// Envelope прозрачное шифрование хранимых данных (envelope encryption).
+//
+// Каждому пользователю при первой записи создаётся ключ данных (DEK), которым шифруются поля записей.
+// DEK хранится в public.data_keys обёрнутым ключом шифрования ключей (KEK) из KeyProvider.
+// Развёрнутые DEK кешируются в памяти; кеш пользователя очищается при удалении его учётной записи,
+// весь кеш - при ротации KEK. Значение nil означает, что шифрование отключено и данные хранятся как есть.
+type Envelope struct {
+	db   *postgres.Postgres
+	keys encryption.KeyProvider
+
+	mu   sync.RWMutex
+	deks map[int]*encryption.Cipher
+}
+
+// NewEnvelope создаёт объект Envelope.
+func NewEnvelope(pg *postgres.Postgres, keys encryption.KeyProvider) *Envelope {
+	return &Envelope{
+		db:   pg,
+		keys: keys,
+		deks: make(map[int]*encryption.Cipher),
+	}
+}
+
+// RotateKEK заново оборачивает актуальным KEK все ключи данных, обёрнутые предыдущими KEK.
+//
+// Сами ключи данных (а значит и зашифрованные записи) не меняются, поэтому ротация
+// выполняется на работающем сервере. Возвращает количество обёрнутых заново ключей.
+func (e *Envelope) RotateKEK(ctx context.Context) (int, error) {
+	currentID, kek, err := e.keys.CurrentKey()
+	if err != nil {
+		return 0, err
+	}
+
+	var stale []dataKeyDAO
+	if err = e.db.SelectContext(ctx, &stale, getStaleDataKeys, currentID); err != nil {
+		return 0, fmt.Errorf("repo - get stale data keys: %w", err)
+	}
+
+	// ключи данных при ротации не меняются, кеш очищается, чтобы ключи заново проверялись при развёртывании
+	defer e.forgetAll()
+
+	var rotated int
+	for _, item := range stale {
+		dek, err := e.unwrap(item)
+		if err != nil {
+			return rotated, err
+		}
+
+		wrapped, err := wrap(kek, dek)
+		if err != nil {
+			return rotated, err
+		}
+
+		// условие по старому kek_id защищает от одновременной ротации
+		res, err := e.db.ExecContext(ctx, rewrapDataKey, item.UserID, item.KEKID, currentID, wrapped)
+		if err != nil {
+			return rotated, fmt.Errorf("repo - rewrap data key: %w", err)
+		}
+
+		if checkAffected(res) == nil {
+			rotated++
+		}
+	}
+
+	return rotated, nil
+}
+
+// seal шифрует переданные поля записи пользователя на месте.
+func (e *Envelope) seal(ctx context.Context, userID int, fields ...*string) error {
+	if e == nil {
+		return nil
+	}
+
+	c, err := e.dataKey(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		if *field, err = c.EncryptString(*field); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// open расшифровывает переданные поля записи пользователя на месте.
+//
+// Значения, сохранённые до включения шифрования, возвращаются без изменений (их шифрует SealLegacy).
+func (e *Envelope) open(ctx context.Context, userID int, fields ...*string) error {
+	if e == nil {
+		return nil
+	}
+
+	c, err := e.dataKey(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	for _, field := range fields {
+		if !encryption.IsRestEncrypted(*field) {
+			continue
+		}
+		if *field, err = c.DecryptString(*field); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (e *Envelope) sealBytes(ctx context.Context, userID int, data []byte) ([]byte, error) {
+	if e == nil {
+		return data, nil
+	}
+
+	c, err := e.dataKey(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Encrypt(data)
+}
+
+func (e *Envelope) openBytes(ctx context.Context, userID int, data []byte) ([]byte, error) {
+	if e == nil || !encryption.IsRestEncryptedBytes(data) {
+		return data, nil
+	}
+
+	c, err := e.dataKey(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Decrypt(data)
+}
+
+// dataKey возвращает ключ данных пользователя (создаёт его при первом обращении).
+func (e *Envelope) dataKey(ctx context.Context, userID int) (*encryption.Cipher, error) {
+	e.mu.RLock()
+	c, ok := e.deks[userID]
+	e.mu.RUnlock()
+	if ok {
+		return c, nil
+	}
+
+	ctxInner, cancel := e.db.WithTimeout(ctx)
+	defer cancel()
+
+	item := dataKeyDAO{UserID: userID}
+	err := e.db.GetContext(ctxInner, &item, getDataKey, userID)
+	if errors.Is(err, sql.ErrNoRows) {
+		item, err = e.createDataKey(ctxInner, userID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("repo - get data key: %w", err)
+	}
+
+	dek, err := e.unwrap(item)
+	if err != nil {
+		return nil, err
+	}
+
+	if c, err = encryption.NewRestCipher(dek); err != nil {
+		return nil, err
+	}
+
+	e.mu.Lock()
+	e.deks[userID] = c
+	e.mu.Unlock()
+
+	return c, nil
+}
+
+// forget удаляет из кеша ключ данных пользователя (например, после удаления учётной записи).
+func (e *Envelope) forget(userID int) {
+	if e == nil {
+		return
+	}
+
+	e.mu.Lock()
+	delete(e.deks, userID)
+	e.mu.Unlock()
+}
+
+// forgetAll очищает кеш ключей данных.
+func (e *Envelope) forgetAll() {
+	e.mu.Lock()
+	e.deks = make(map[int]*encryption.Cipher)
+	e.mu.Unlock()
+}
+
+// createDataKey создаёт новый ключ данных пользователя.
+//
+// При одновременном создании сохраняется только один ключ - он и возвращается.
+func (e *Envelope) createDataKey(ctx context.Context, userID int) (dataKeyDAO, error) {
+	item := dataKeyDAO{UserID: userID}
+
+	kekID, kek, err := e.keys.CurrentKey()
+	if err != nil {
+		return item, err
+	}
+
+	dek := make([]byte, chacha20poly1305.KeySize)
+	if _, err = rand.Read(dek); err != nil {
+		return item, err
+	}
+
+	wrapped, err := wrap(kek, dek)
+	if err != nil {
+		return item, err
+	}
+
+	if _, err = e.db.ExecContext(ctx, createDataKey, userID, kekID, wrapped); err != nil {
+		return item, err
+	}
+
+	err = e.db.GetContext(ctx, &item, getDataKey, userID)
+	return item, err
+}
+
+func (e *Envelope) unwrap(item dataKeyDAO) ([]byte, error) {
+	kek, err := e.keys.Key(item.KEKID)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := encryption.NewRestCipher(kek)
+	if err != nil {
+		return nil, err
+	}
+
+	// обёрнутый ключ обязан быть шифротекстом сервера на этом KEK: открытое значение ключом не считается
+	if !c.Owns(item.WrappedKey) {
+		return nil, fmt.Errorf("%w: user %d, kek %q: not wrapped by this key", ErrInvalidDataKey, item.UserID, item.KEKID)
+	}
+
+	dek, err := c.Decrypt(item.WrappedKey)
+	if err != nil || len(dek) != chacha20poly1305.KeySize {
+		return nil, fmt.Errorf("%w: user %d, kek %q", ErrInvalidDataKey, item.UserID, item.KEKID)
+	}
+
+	return dek, nil
+}
+
+func wrap(kek, dek []byte) ([]byte, error) {
+	c, err := encryption.NewRestCipher(kek)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.Encrypt(dek)
+}
+
+// legacyBatch количество записей, читаемых одним запросом SealLegacy.
+const legacyBatch = 100
+
+// legacyTable таблица с полями, шифруемыми при сохранении.
+//
+// key - столбец-идентификатор записи, fields - строковые поля, data - бинарное поле (пустое - нет).
+// В public.totp пустой секрет означает отсутствие аутентификатора и не шифруется (keepEmpty).
+type legacyTable struct {
+	name      string
+	key       string
+	fields    []string
+	data      string
+	keepEmpty bool
+}
+
+var legacyTables = []legacyTable{
+	{name: "resources.pairs_data", key: "id", fields: []string{"login", "password", "metadata"}},
+	{name: "resources.bank_data", key: "id", fields: []string{"card_holder", "number", "expiration_date", "metadata"}},
+	{name: "resources.text_data", key: "id", fields: []string{"note", "metadata"}},
+	{name: "resources.binary_data", key: "id", fields: []string{"filename", "metadata"}, data: "data"},
+	{name: "resources.otp_data", key: "id", fields: []string{"secret", "issuer", "metadata"}},
+	{name: "resources.folders", key: "id", fields: []string{"name"}},
+	{name: "resources.tags", key: "id", fields: []string{"name"}},
+	{name: "public.totp", key: "user_id", fields: []string{"secret", "pending_secret"}, keepEmpty: true},
+}
+
+// legacyRow запись с открытыми значениями.
+type legacyRow struct {
+	id     int
+	userID int
+	fields []string
+	data   []byte
+}
+
+// LegacyCount количество записей таблицы, сохранённых без шифрования.
+type LegacyCount struct {
+	Table string
+	Rows  int
+}
+
+// CountLegacy возвращает по каждой таблице количество записей со значениями, сохранёнными
+// до включения шифрования (без заголовка шифротекста сервера).
+func (e *Envelope) CountLegacy(ctx context.Context) ([]LegacyCount, error) {
+	out := make([]LegacyCount, 0, len(legacyTables))
+	for _, t := range legacyTables {
+		item := LegacyCount{Table: t.name}
+		query := fmt.Sprintf("SELECT count(*) FROM %s WHERE %s;", t.name, t.plainCondition())
+		if err := e.db.GetContext(ctx, &item.Rows, query); err != nil {
+			return nil, fmt.Errorf("repo - count legacy %s: %w", t.name, err)
+		}
+		out = append(out, item)
+	}
+
+	return out, nil
+}
+
+// SealLegacy шифрует значения, сохранённые до включения шифрования, ключами данных их владельцев.
+//
+// Ревизии записей не меняются (содержимое для клиента прежнее), поэтому шифрование выполняется
+// на работающем сервере: запись, изменённая после чтения, пропускается - её уже сохранил сервер.
+// Возвращает количество зашифрованных записей.
+func (e *Envelope) SealLegacy(ctx context.Context) (int, error) {
+	var sealed int
+	for _, t := range legacyTables {
+		n, err := e.sealLegacyTable(ctx, t)
+		sealed += n
+		if err != nil {
+			return sealed, err
+		}
+	}
+
+	return sealed, nil
+}
+
+func (e *Envelope) sealLegacyTable(ctx context.Context, t legacyTable) (int, error) {
+	var sealed, last int
+	for {
+		rows, err := e.legacyRows(ctx, t, last)
+		if err != nil {
+			return sealed, err
+		}
+		if len(rows) == 0 {
+			return sealed, nil
+		}
+
+		for _, row := range rows {
+			last = row.id
+			ok, err := e.sealLegacyRow(ctx, t, row)
+			if err != nil {
+				return sealed, err
+			}
+			if ok {
+				sealed++
+			}
+		}
+	}
+}
+
+// legacyRows читает очередную порцию записей с открытыми значениями (id больше after).
+func (e *Envelope) legacyRows(ctx context.Context, t legacyTable, after int) ([]legacyRow, error) {
+	columns := make([]string, 0, len(t.fields)+1)
+	for _, field := range t.fields {
+		columns = append(columns, fmt.Sprintf("COALESCE(%s, '')", field))
+	}
+	if t.data != "" {
+		columns = append(columns, t.data)
+	}
+
+	query := fmt.Sprintf("SELECT %s, user_id, %s FROM %s WHERE %s > $1 AND %s ORDER BY %s LIMIT %d;",
+		t.key, strings.Join(columns, ", "), t.name, t.key, t.plainCondition(), t.key, legacyBatch)
+
+	ctxInner, cancel := e.db.WithTimeout(ctx)
+	defer cancel()
+
+	rows, err := e.db.QueryContext(ctxInner, query, after)
+	if err != nil {
+		return nil, fmt.Errorf("repo - get legacy %s: %w", t.name, err)
+	}
+	defer rows.Close()
+
+	var out []legacyRow
+	for rows.Next() {
+		row := legacyRow{fields: make([]string, len(t.fields))}
+		dest := []any{&row.id, &row.userID}
+		for i := range row.fields {
+			dest = append(dest, &row.fields[i])
+		}
+		if t.data != "" {
+			dest = append(dest, &row.data)
+		}
+
+		if err = rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("repo - get legacy %s: %w", t.name, err)
+		}
+		out = append(out, row)
+	}
+
+	return out, rows.Err()
+}
+
+// sealLegacyRow шифрует открытые значения записи.
+//
+// Возвращает false, если запись изменилась после чтения.
+func (e *Envelope) sealLegacyRow(ctx context.Context, t legacyTable, row legacyRow) (bool, error) {
+	set := make([]string, 0, len(t.fields)+1)
+	where := []string{fmt.Sprintf("%s = $1", t.key)}
+	args := []any{row.id}
+
+	// условие по прежним значениям защищает данные, сохранённые сервером после чтения записи
+	for i, field := range t.fields {
+		value := row.fields[i]
+		if encryption.IsRestEncrypted(value) || (value == "" && t.keepEmpty) {
+			continue
+		}
+		if err := e.seal(ctx, row.userID, &value); err != nil {
+			return false, fmt.Errorf("repo - seal legacy %s: %w", t.name, err)
+		}
+
+		args = append(args, row.fields[i], value)
+		where = append(where, fmt.Sprintf("COALESCE(%s, '') = $%d", field, len(args)-1))
+		set = append(set, fmt.Sprintf("%s = $%d", field, len(args)))
+	}
+	if t.data != "" && !encryption.IsRestEncryptedBytes(row.data) {
+		value, err := e.sealBytes(ctx, row.userID, row.data)
+		if err != nil {
+			return false, fmt.Errorf("repo - seal legacy %s: %w", t.name, err)
+		}
+
+		args = append(args, row.data, value)
+		where = append(where, fmt.Sprintf("%s = $%d", t.data, len(args)-1))
+		set = append(set, fmt.Sprintf("%s = $%d", t.data, len(args)))
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET %s WHERE %s;", t.name, strings.Join(set, ", "), strings.Join(where, " AND "))
+
+	ctxInner, cancel := e.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := e.db.ExecContext(ctxInner, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("repo - seal legacy %s: %w", t.name, err)
+	}
+
+	return checkAffected(res) == nil, nil
+}
+
+// plainCondition условие наличия в записи открытого значения.
+func (t legacyTable) plainCondition() string {
+	conds := make([]string, 0, len(t.fields)+1)
+	for _, field := range t.fields {
+		cond := fmt.Sprintf("COALESCE(%s, '') NOT LIKE '%s%%'", field, encryption.RestPrefix)
+		if t.keepEmpty {
+			cond = fmt.Sprintf("(%s <> '' AND %s NOT LIKE '%s%%')", field, field, encryption.RestPrefix)
+		}
+		conds = append(conds, cond)
+	}
+	if t.data != "" {
+		conds = append(conds, fmt.Sprintf(`substring(%s FROM 1 FOR %d) <> '\x%x'::bytea`,
+			t.data, len(encryption.RestMagic), encryption.RestMagic))
+	}
+
+	return "(" + strings.Join(conds, " OR ") + ")"
+}
@@ -2,6 +2,9 @@ package repo
 
 const (
 	ErrUserExist = Err("user already exists")
+	ErrNotFound  = Err("record not found")
+	// ErrSchemaOutdated схема БД не соответствует версии сервера (нужно выполнить migrate up).
+	ErrSchemaOutdated = Err("database schema is not up to date")
 )
 
 type Err string
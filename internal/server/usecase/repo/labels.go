@@ -0,0 +1,279 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	"github.com/PaulYakow/gophkeeper/pkg/postgres"
+)
+
+// resourceTables таблицы записей всех типов данных.
+var resourceTables = []string{"pairs_data", "bank_data", "text_data", "binary_data", "otp_data"}
+
+const (
+	getFoldersByUserID = `
+SELECT id, user_id, COALESCE(parent_id, 0) AS parent_id, name, created_at FROM resources.folders
+WHERE user_id = $1
+ORDER BY id;
+`
+	createFolder = `
+INSERT INTO resources.folders (user_id, parent_id, name)
+SELECT $1, NULLIF($2::int, 0), $3
+WHERE $2::int = 0 OR EXISTS (SELECT 1 FROM resources.folders WHERE id = $2 AND user_id = $1)
+RETURNING id;
+`
+	renameFolder = `
+UPDATE resources.folders
+SET name = $3
+WHERE id = $1 AND user_id = $2;
+`
+	getTagsByUserID = `
+SELECT * FROM resources.tags
+WHERE user_id = $1
+ORDER BY id;
+`
+	createTag = `
+INSERT INTO resources.tags (user_id, name)
+VALUES ($1, $2)
+RETURNING id;
+`
+	renameTag = `
+UPDATE resources.tags
+SET name = $3
+WHERE id = $1 AND user_id = $2;
+`
+)
+
+// deleteFolder удаляет папку ($1) пользователя ($2) вместе с вложенными папками (ON DELETE CASCADE).
+// Записи удаляемых папок переносятся в родительскую папку удаляемой с новой ревизией.
+var deleteFolder = `
+WITH RECURSIVE target AS (
+    SELECT id, COALESCE(parent_id, 0) AS parent_id FROM resources.folders
+    WHERE id = $1 AND user_id = $2
+), subtree AS (
+    SELECT id FROM target
+    UNION ALL
+    SELECT f.id FROM resources.folders f JOIN subtree s ON f.parent_id = s.id
+), rev AS (
+    INSERT INTO public.revisions (user_id, revision)
+    SELECT $2, 1 FROM target
+    ON CONFLICT (user_id) DO UPDATE SET revision = revisions.revision + 1
+    RETURNING revision
+)` + updateResources("folder_id = target.parent_id", "d.folder_id IN (SELECT id FROM subtree)") + `
+DELETE FROM resources.folders
+WHERE id = $1 AND user_id = $2;
+`
+
+// deleteTag удаляет метку ($1) пользователя ($2) и снимает её с записей с новой ревизией.
+var deleteTag = `
+WITH target AS (
+    SELECT id FROM resources.tags
+    WHERE id = $1 AND user_id = $2
+), rev AS (
+    INSERT INTO public.revisions (user_id, revision)
+    SELECT $2, 1 FROM target
+    ON CONFLICT (user_id) DO UPDATE SET revision = revisions.revision + 1
+    RETURNING revision
+)` + updateResources("tag_ids = array_remove(d.tag_ids, target.id)", "d.tag_ids @> ARRAY[target.id]") + `
+DELETE FROM resources.tags
+WHERE id = $1 AND user_id = $2;
+`
+
+// updateResources формирует CTE изменения (set) отобранных (where) записей пользователя ($2) во всех таблицах
+// данных. Записи получают ревизию из CTE rev, CTE target - удаляемая папка или метка.
+func updateResources(set, where string) string {
+	var sb strings.Builder
+	for _, table := range resourceTables {
+		fmt.Fprintf(&sb, `, upd_%s AS (
+    UPDATE resources.%s d
+    SET %s, revision = rev.revision
+    FROM target, rev
+    WHERE d.user_id = $2 AND %s
+)`, table, table, set, where)
+	}
+
+	return sb.String()
+}
+
+// LabelsPostgres реализация интерфейса usecase.ILabelsRepo
+type LabelsPostgres struct {
+	db  *postgres.Postgres
+	env *Envelope
+}
+
+// NewLabelsPostgres создаёт объект типа LabelsPostgres.
+func NewLabelsPostgres(pg *postgres.Postgres, env *Envelope) *LabelsPostgres {
+	return &LabelsPostgres{pg, env}
+}
+
+// ListFolders находит в БД все папки пользователя (userID) по возрастанию id.
+func (p *LabelsPostgres) ListFolders(ctx context.Context, userID int) ([]entity.FolderDAO, error) {
+	var result []entity.FolderDAO
+
+	ctxInner, cancel := p.db.WithTimeout(ctx)
+	defer cancel()
+
+	if err := p.db.SelectContext(ctxInner, &result, getFoldersByUserID, userID); err != nil {
+		return nil, fmt.Errorf("repo - list folders: %w", err)
+	}
+
+	if err := openFolders(ctx, p.env, userID, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CreateFolder сохраняет в БД новую папку.
+//
+// Возвращает id созданной папки или ErrNotFound, если родительская папка не найдена.
+func (p *LabelsPostgres) CreateFolder(ctx context.Context, folder entity.FolderDAO) (int, error) {
+	if err := p.env.seal(ctx, folder.UserID, &folder.Name); err != nil {
+		return 0, err
+	}
+
+	ctxInner, cancel := p.db.WithTimeout(ctx)
+	defer cancel()
+
+	var id int
+	err := p.db.GetContext(ctxInner, &id, createFolder, folder.UserID, folder.ParentID, folder.Name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("repo - create folder: %w", err)
+	}
+
+	return id, nil
+}
+
+// RenameFolder изменяет название папки пользователя (userID).
+//
+// Возвращает ErrNotFound, если папка не найдена.
+func (p *LabelsPostgres) RenameFolder(ctx context.Context, userID, folderID int, name string) error {
+	if err := p.env.seal(ctx, userID, &name); err != nil {
+		return err
+	}
+
+	ctxInner, cancel := p.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := p.db.ExecContext(ctxInner, renameFolder, folderID, userID, name)
+	if err != nil {
+		return fmt.Errorf("repo - rename folder: %w", err)
+	}
+
+	return checkAffected(res)
+}
+
+// DeleteFolder удаляет папку пользователя (userID) вместе с вложенными папками.
+//
+// Записи удалённых папок переносятся в родительскую папку удалённой. Возвращает ErrNotFound, если папка не найдена.
+func (p *LabelsPostgres) DeleteFolder(ctx context.Context, userID, folderID int) error {
+	ctxInner, cancel := p.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := p.db.ExecContext(ctxInner, deleteFolder, folderID, userID)
+	if err != nil {
+		return fmt.Errorf("repo - delete folder: %w", err)
+	}
+
+	return checkAffected(res)
+}
+
+// ListTags находит в БД все метки пользователя (userID) по возрастанию id.
+func (p *LabelsPostgres) ListTags(ctx context.Context, userID int) ([]entity.TagDAO, error) {
+	var result []entity.TagDAO
+
+	ctxInner, cancel := p.db.WithTimeout(ctx)
+	defer cancel()
+
+	if err := p.db.SelectContext(ctxInner, &result, getTagsByUserID, userID); err != nil {
+		return nil, fmt.Errorf("repo - list tags: %w", err)
+	}
+
+	if err := openTags(ctx, p.env, userID, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CreateTag сохраняет в БД новую метку.
+//
+// Возвращает id созданной метки или ошибку.
+func (p *LabelsPostgres) CreateTag(ctx context.Context, tag entity.TagDAO) (int, error) {
+	if err := p.env.seal(ctx, tag.UserID, &tag.Name); err != nil {
+		return 0, err
+	}
+
+	ctxInner, cancel := p.db.WithTimeout(ctx)
+	defer cancel()
+
+	var id int
+	if err := p.db.GetContext(ctxInner, &id, createTag, tag.UserID, tag.Name); err != nil {
+		return 0, fmt.Errorf("repo - create tag: %w", err)
+	}
+
+	return id, nil
+}
+
+// RenameTag изменяет название метки пользователя (userID).
+//
+// Возвращает ErrNotFound, если метка не найдена.
+func (p *LabelsPostgres) RenameTag(ctx context.Context, userID, tagID int, name string) error {
+	if err := p.env.seal(ctx, userID, &name); err != nil {
+		return err
+	}
+
+	ctxInner, cancel := p.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := p.db.ExecContext(ctxInner, renameTag, tagID, userID, name)
+	if err != nil {
+		return fmt.Errorf("repo - rename tag: %w", err)
+	}
+
+	return checkAffected(res)
+}
+
+// DeleteTag удаляет метку пользователя (userID) и снимает её со всех записей.
+//
+// Возвращает ErrNotFound, если метка не найдена.
+func (p *LabelsPostgres) DeleteTag(ctx context.Context, userID, tagID int) error {
+	ctxInner, cancel := p.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := p.db.ExecContext(ctxInner, deleteTag, tagID, userID)
+	if err != nil {
+		return fmt.Errorf("repo - delete tag: %w", err)
+	}
+
+	return checkAffected(res)
+}
+
+// openFolders расшифровывает названия папок пользователя (userID).
+func openFolders(ctx context.Context, env *Envelope, userID int, items []entity.FolderDAO) error {
+	for i := range items {
+		if err := env.open(ctx, userID, &items[i].Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// openTags расшифровывает названия меток пользователя (userID).
+func openTags(ctx context.Context, env *Envelope, userID int, items []entity.TagDAO) error {
+	for i := range items {
+		if err := env.open(ctx, userID, &items[i].Name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,112 @@
+package memory
+
+import (
+	"context"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	"github.com/PaulYakow/gophkeeper/internal/server/usecase/repo"
+)
+
+// ExportAccount находит все данные пользователя (userID), включая содержимое файлов.
+//
+// Возвращает repo.ErrNotFound, если пользователь не найден.
+func (m *Memory) ExportAccount(_ context.Context, userID int) (entity.AccountDAO, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	user, ok := m.users[userID]
+	if !ok {
+		return entity.AccountDAO{}, repo.ErrNotFound
+	}
+
+	return entity.AccountDAO{
+		User:     user,
+		Pairs:    m.pairsSince(userID, 0),
+		Cards:    m.cardsSince(userID, 0),
+		Notes:    m.notesSince(userID, 0),
+		Binaries: m.binariesSince(userID, 0, true),
+		OTPs:     m.otpsSince(userID, 0),
+		Folders:  m.userFolders(userID),
+		Tags:     m.userTags(userID),
+	}, nil
+}
+
+// DeleteAccount удаляет пользователя (userID) вместе со всеми его данными, ключами, сессиями и историей.
+//
+// Удаление выполняется, только если текущий хэш пароля равен passwordHash, иначе возвращается repo.ErrNotFound.
+func (m *Memory) DeleteAccount(_ context.Context, userID int, passwordHash string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, ok := m.users[userID]
+	if !ok || user.PasswordHash != passwordHash {
+		return repo.ErrNotFound
+	}
+
+	delete(m.users, userID)
+	delete(m.passwordHistory, userID)
+	delete(m.totp, userID)
+	delete(m.revisions, userID)
+
+	for id, session := range m.sessions {
+		if session.UserID == userID {
+			delete(m.sessions, id)
+		}
+	}
+	for id, challenge := range m.challenges {
+		if challenge.UserID == userID {
+			delete(m.challenges, id)
+		}
+	}
+
+	events := m.securityEvents[:0]
+	for _, event := range m.securityEvents {
+		if event.UserID != userID {
+			events = append(events, event)
+		}
+	}
+	m.securityEvents = events
+
+	for id, pair := range m.pairs {
+		if pair.UserID == userID {
+			delete(m.pairs, id)
+		}
+	}
+	for id, card := range m.cards {
+		if card.UserID == userID {
+			delete(m.cards, id)
+		}
+	}
+	for id, note := range m.notes {
+		if note.UserID == userID {
+			delete(m.notes, id)
+		}
+	}
+	for id, binary := range m.binaries {
+		if binary.UserID == userID {
+			delete(m.binaries, id)
+		}
+	}
+	for id, otp := range m.otps {
+		if otp.UserID == userID {
+			delete(m.otps, id)
+		}
+	}
+	for id, folder := range m.folders {
+		if folder.UserID == userID {
+			delete(m.folders, id)
+		}
+	}
+	for id, tag := range m.tags {
+		if tag.UserID == userID {
+			delete(m.tags, id)
+		}
+	}
+	for key, t := range m.tombstones {
+		if t.userID == userID {
+			delete(m.tombstones, key)
+		}
+	}
+
+	return nil
+}
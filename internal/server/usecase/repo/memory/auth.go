@@ -0,0 +1,451 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	"github.com/PaulYakow/gophkeeper/internal/server/usecase/repo"
+)
+
+// CreateUser - создание пользователя с заданными логином и хэшем пароля.
+//
+// Возвращает id пользователя или repo.ErrUserExist, если логин уже существует.
+func (m *Memory) CreateUser(_ context.Context, login, passwordHash string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, user := range m.users {
+		if user.Login == login {
+			return 0, repo.ErrUserExist
+		}
+	}
+
+	id := m.nextID("users")
+	m.users[id] = entity.UserDAO{
+		ID:                id,
+		Login:             login,
+		PasswordHash:      passwordHash,
+		PasswordChangedAt: time.Now(),
+	}
+
+	return id, nil
+}
+
+// GetUser - находит пользователя по логину.
+//
+// Возвращает repo.ErrNotFound при отсутствии логина.
+func (m *Memory) GetUser(_ context.Context, login string) (entity.UserDAO, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, user := range m.users {
+		if user.Login == login {
+			return user, nil
+		}
+	}
+
+	return entity.UserDAO{}, repo.ErrNotFound
+}
+
+// GetUserByID находит пользователя по id.
+//
+// Возвращает repo.ErrNotFound, если пользователь не найден.
+func (m *Memory) GetUserByID(_ context.Context, userID int) (entity.UserDAO, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	user, ok := m.users[userID]
+	if !ok {
+		return entity.UserDAO{}, repo.ErrNotFound
+	}
+
+	return user, nil
+}
+
+// GetPasswordHistory находит limit последних прежних хэшей пароля пользователя (от новых к старым).
+func (m *Memory) GetPasswordHistory(_ context.Context, userID, limit int) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	history := m.passwordHistory[userID]
+
+	var hashes []string
+	for i := len(history) - 1; i >= 0 && len(hashes) < limit; i-- {
+		hashes = append(hashes, history[i])
+	}
+
+	return hashes, nil
+}
+
+// ChangePassword заменяет хэш пароля пользователя на newHash и отзывает все его сессии.
+//
+// Прежний хэш сохраняется в историю паролей. Замена выполняется, только если текущий хэш
+// равен oldHash, иначе возвращается repo.ErrNotFound.
+func (m *Memory) ChangePassword(_ context.Context, userID int, oldHash, newHash string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, ok := m.users[userID]
+	if !ok || user.PasswordHash != oldHash {
+		return repo.ErrNotFound
+	}
+
+	now := time.Now()
+	m.passwordHistory[userID] = append(m.passwordHistory[userID], oldHash)
+	user.PasswordHash = newHash
+	user.PasswordChangedAt = now
+	m.users[userID] = user
+
+	for id, session := range m.sessions {
+		if session.UserID == userID && session.RevokedAt == nil {
+			session.RevokedAt = &now
+			m.sessions[id] = session
+		}
+	}
+
+	return nil
+}
+
+// UpdatePasswordHash заменяет хэш того же пароля пользователя, пересчитанный по текущей схеме.
+//
+// Замена выполняется, только если текущий хэш равен oldHash, иначе возвращается repo.ErrNotFound.
+func (m *Memory) UpdatePasswordHash(_ context.Context, userID int, oldHash, newHash string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	user, ok := m.users[userID]
+	if !ok || user.PasswordHash != oldHash {
+		return repo.ErrNotFound
+	}
+
+	user.PasswordHash = newHash
+	m.users[userID] = user
+
+	return nil
+}
+
+// CreateSession сохраняет новую сессию пользователя.
+//
+// Заодно удаляет истёкшие и отозванные сессии этого пользователя.
+func (m *Memory) CreateSession(_ context.Context, session entity.SessionDAO) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.users[session.UserID]; !ok {
+		return fmt.Errorf("memory - create session: user %d: %w", session.UserID, repo.ErrNotFound)
+	}
+
+	now := time.Now()
+	for id, s := range m.sessions {
+		if s.UserID == session.UserID && (s.ExpiresAt.Before(now) || s.RevokedAt != nil) {
+			delete(m.sessions, id)
+		}
+	}
+
+	if _, ok := m.sessions[session.ID]; ok {
+		return fmt.Errorf("memory - create session: session %q already exists", session.ID)
+	}
+
+	session.RevokedAt = nil
+	session.CreatedAt = now
+	m.sessions[session.ID] = session
+
+	return nil
+}
+
+// GetSession находит сессию по её id.
+//
+// Возвращает repo.ErrNotFound, если сессия не найдена.
+func (m *Memory) GetSession(_ context.Context, sessionID string) (entity.SessionDAO, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	session, ok := m.sessions[sessionID]
+	if !ok {
+		return entity.SessionDAO{}, repo.ErrNotFound
+	}
+
+	return session, nil
+}
+
+// RotateSession заменяет хэш refresh-токена действующей сессии и продлевает её до expiresAt.
+//
+// Замена выполняется, только если текущий хэш равен oldHash, иначе возвращается repo.ErrNotFound.
+func (m *Memory) RotateSession(_ context.Context, sessionID, oldHash, newHash string, expiresAt time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session, ok := m.sessions[sessionID]
+	if !ok || session.RefreshHash != oldHash || session.RevokedAt != nil || !session.ExpiresAt.After(time.Now()) {
+		return repo.ErrNotFound
+	}
+
+	session.RefreshHash = newHash
+	session.ExpiresAt = expiresAt
+	m.sessions[sessionID] = session
+
+	return nil
+}
+
+// RevokeSession отзывает сессию.
+//
+// Возвращает repo.ErrNotFound, если сессия не найдена или уже отозвана.
+func (m *Memory) RevokeSession(_ context.Context, sessionID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	session, ok := m.sessions[sessionID]
+	if !ok || session.RevokedAt != nil {
+		return repo.ErrNotFound
+	}
+
+	now := time.Now()
+	session.RevokedAt = &now
+	m.sessions[sessionID] = session
+
+	return nil
+}
+
+// GetLoginLock возвращает наибольшее время окончания блокировки по ключам неудачных попыток keys.
+//
+// Если блокировок нет, возвращается начало эпохи (время в прошлом).
+func (m *Memory) GetLoginLock(_ context.Context, keys ...string) (time.Time, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	lockedUntil := time.Unix(0, 0)
+	for _, key := range keys {
+		if until := m.loginFailures[key].lockedUntil; until.After(lockedUntil) {
+			lockedUntil = until
+		}
+	}
+
+	return lockedUntil, nil
+}
+
+// AddLoginFailure учитывает неудачную попытку по ключу key и возвращает число неудач подряд.
+//
+// Если предыдущая неудача была раньше, чем window назад, счёт начинается заново.
+func (m *Memory) AddLoginFailure(_ context.Context, key string, window time.Duration) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	failure, ok := m.loginFailures[key]
+	if !ok || failure.updatedAt.Before(now.Add(-window)) {
+		failure.failures = 0
+	}
+
+	failure.failures++
+	failure.updatedAt = now
+	m.loginFailures[key] = failure
+
+	return failure.failures, nil
+}
+
+// LockLogin блокирует попытки по ключу key до until.
+//
+// Возвращает repo.ErrNotFound, если неудачных попыток по ключу не было.
+func (m *Memory) LockLogin(_ context.Context, key string, until time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	failure, ok := m.loginFailures[key]
+	if !ok {
+		return repo.ErrNotFound
+	}
+
+	failure.lockedUntil = until
+	m.loginFailures[key] = failure
+
+	return nil
+}
+
+// ResetLoginFailures сбрасывает счётчик неудачных попыток по ключу key.
+func (m *Memory) ResetLoginFailures(_ context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.loginFailures, key)
+
+	return nil
+}
+
+// CreateSecurityEvent сохраняет событие безопасности учётной записи.
+func (m *Memory) CreateSecurityEvent(_ context.Context, event entity.SecurityEventDAO) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.users[event.UserID]; !ok {
+		return fmt.Errorf("memory - create security event: user %d: %w", event.UserID, repo.ErrNotFound)
+	}
+
+	event.ID = m.nextID("security_events")
+	event.CreatedAt = time.Now()
+	m.securityEvents = append(m.securityEvents, event)
+
+	return nil
+}
+
+// GetSecurityEvents находит limit последних событий безопасности пользователя (от новых к старым).
+func (m *Memory) GetSecurityEvents(_ context.Context, userID, limit int) ([]entity.SecurityEventDAO, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	var events []entity.SecurityEventDAO
+	for i := len(m.securityEvents) - 1; i >= 0 && len(events) < limit; i-- {
+		if m.securityEvents[i].UserID == userID {
+			events = append(events, m.securityEvents[i])
+		}
+	}
+
+	return events, nil
+}
+
+// GetTOTP находит настройки второго фактора пользователя.
+//
+// Если второй фактор не подключался, возвращаются нулевые настройки (без ошибки).
+func (m *Memory) GetTOTP(_ context.Context, userID int) (entity.TOTPDAO, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	totp, ok := m.totp[userID]
+	if !ok {
+		return entity.TOTPDAO{UserID: userID}, nil
+	}
+
+	return totp, nil
+}
+
+// SaveTOTPPending сохраняет секрет подключаемого аутентификатора (действующий не меняется).
+func (m *Memory) SaveTOTPPending(_ context.Context, userID int, secret string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.users[userID]; !ok {
+		return fmt.Errorf("memory - save totp: user %d: %w", userID, repo.ErrNotFound)
+	}
+
+	totp := m.totp[userID]
+	totp.UserID = userID
+	totp.PendingSecret = secret
+	m.totp[userID] = totp
+
+	return nil
+}
+
+// ConfirmTOTP делает подключаемый аутентификатор действующим; step - интервал принятого кода.
+//
+// Возвращает repo.ErrNotFound, если подключаемого аутентификатора нет.
+func (m *Memory) ConfirmTOTP(_ context.Context, userID int, step int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	totp, ok := m.totp[userID]
+	if !ok || totp.PendingSecret == "" {
+		return repo.ErrNotFound
+	}
+
+	totp.Secret = totp.PendingSecret
+	totp.PendingSecret = ""
+	totp.LastStep = step
+	m.totp[userID] = totp
+
+	return nil
+}
+
+// UseTOTPStep отмечает интервал step как использованный.
+//
+// Возвращает repo.ErrNotFound, если код этого или более позднего интервала уже принят.
+func (m *Memory) UseTOTPStep(_ context.Context, userID int, step int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	totp, ok := m.totp[userID]
+	if !ok || totp.LastStep >= step {
+		return repo.ErrNotFound
+	}
+
+	totp.LastStep = step
+	m.totp[userID] = totp
+
+	return nil
+}
+
+// DeleteTOTP отключает второй фактор пользователя.
+func (m *Memory) DeleteTOTP(_ context.Context, userID int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.totp[userID]; !ok {
+		return repo.ErrNotFound
+	}
+
+	delete(m.totp, userID)
+
+	return nil
+}
+
+// CreateChallenge сохраняет незавершённый вход.
+//
+// Заодно удаляет истёкшие незавершённые входы этого пользователя.
+func (m *Memory) CreateChallenge(_ context.Context, challenge entity.ChallengeDAO) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.users[challenge.UserID]; !ok {
+		return fmt.Errorf("memory - create challenge: user %d: %w", challenge.UserID, repo.ErrNotFound)
+	}
+
+	now := time.Now()
+	for id, c := range m.challenges {
+		if c.UserID == challenge.UserID && c.ExpiresAt.Before(now) {
+			delete(m.challenges, id)
+		}
+	}
+
+	if _, ok := m.challenges[challenge.ID]; ok {
+		return fmt.Errorf("memory - create challenge: challenge %q already exists", challenge.ID)
+	}
+
+	challenge.Login = ""
+	challenge.CreatedAt = now
+	m.challenges[challenge.ID] = challenge
+
+	return nil
+}
+
+// GetChallenge находит незавершённый вход по его id (вместе с логином пользователя).
+//
+// Возвращает repo.ErrNotFound, если он не найден.
+func (m *Memory) GetChallenge(_ context.Context, challengeID string) (entity.ChallengeDAO, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	challenge, ok := m.challenges[challengeID]
+	if !ok {
+		return entity.ChallengeDAO{}, repo.ErrNotFound
+	}
+
+	challenge.Login = m.users[challenge.UserID].Login
+
+	return challenge, nil
+}
+
+// DeleteChallenge удаляет незавершённый вход.
+//
+// Возвращает repo.ErrNotFound, если он уже удалён.
+func (m *Memory) DeleteChallenge(_ context.Context, challengeID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.challenges[challengeID]; !ok {
+		return repo.ErrNotFound
+	}
+
+	delete(m.challenges, challengeID)
+
+	return nil
+}
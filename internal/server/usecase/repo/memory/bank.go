@@ -0,0 +1,102 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	"github.com/PaulYakow/gophkeeper/internal/server/usecase/repo"
+)
+
+// ListCards находит страницу списка записей банковских карт, принадлежащих конкретному пользователю (userID).
+func (m *Memory) ListCards(_ context.Context, userID int, query entity.ListQuery) ([]entity.BankDAO, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return listPage(m.cardsSince(userID, 0), func(item entity.BankDAO) entity.Cursor {
+		return entity.Cursor{CreatedAt: item.CreatedAt, ID: item.ID}
+	}, func(item entity.BankDAO) entity.Labels {
+		return item.Labels
+	}, query), nil
+}
+
+// CreateCard сохраняет новую запись банковской карты.
+//
+// Возвращает id созданной записи или ошибку (repo.ErrNotFound, если папка или метка записи не найдена).
+func (m *Memory) CreateCard(_ context.Context, card entity.BankDAO) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.users[card.UserID]; !ok {
+		return 0, fmt.Errorf("memory - create card: user %d: %w", card.UserID, repo.ErrNotFound)
+	}
+	if !m.labelsValid(card.UserID, card.Labels) {
+		return 0, repo.ErrNotFound
+	}
+
+	card.ID = m.nextID(entity.CardKind)
+	card.TagIDs = cloneIDs(card.TagIDs)
+	card.Revision = m.nextRevision(card.UserID)
+	card.CreatedAt = time.Now()
+	m.cards[card.ID] = card
+
+	return card.ID, nil
+}
+
+// UpdateCard изменяет запись банковской карты (поиск по id и user_id).
+//
+// Возвращает repo.ErrNotFound, если не найдена запись, её папка или метка.
+func (m *Memory) UpdateCard(_ context.Context, card entity.BankDAO) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current, ok := m.cards[card.ID]
+	if !ok || current.UserID != card.UserID || !m.labelsValid(card.UserID, card.Labels) {
+		return repo.ErrNotFound
+	}
+
+	current.CardHolder = card.CardHolder
+	current.Number = card.Number
+	current.ExpirationDate = card.ExpirationDate
+	current.Metadata = card.Metadata
+	current.Labels = entity.Labels{FolderID: card.FolderID, TagIDs: cloneIDs(card.TagIDs)}
+	current.Revision = m.nextRevision(card.UserID)
+	m.cards[card.ID] = current
+
+	return nil
+}
+
+// DeleteCard удаляет запись банковской карты принадлежащую конкретному пользователю (userID).
+//
+// Возвращает repo.ErrNotFound, если запись не найдена.
+func (m *Memory) DeleteCard(_ context.Context, userID, cardID int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if card, ok := m.cards[cardID]; !ok || card.UserID != userID {
+		return repo.ErrNotFound
+	}
+
+	delete(m.cards, cardID)
+	m.bury(userID, entity.CardKind, cardID)
+
+	return nil
+}
+
+// cardsSince возвращает карты пользователя с ревизией больше since (по возрастанию id).
+func (m *Memory) cardsSince(userID int, since int64) []entity.BankDAO {
+	var result []entity.BankDAO
+	for _, card := range m.cards {
+		if card.UserID == userID && card.Revision > since {
+			result = append(result, card)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+
+	return result
+}
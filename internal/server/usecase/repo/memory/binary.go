@@ -0,0 +1,106 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	"github.com/PaulYakow/gophkeeper/internal/server/usecase/repo"
+)
+
+// ListBinaries находит страницу списка описаний файлов, принадлежащих конкретному пользователю (userID).
+//
+// Содержимое файлов (Data) не возвращается.
+func (m *Memory) ListBinaries(_ context.Context, userID int, query entity.ListQuery) ([]entity.BinaryDAO, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return listPage(m.binariesSince(userID, 0, false), func(item entity.BinaryDAO) entity.Cursor {
+		return entity.Cursor{CreatedAt: item.CreatedAt, ID: item.ID}
+	}, func(item entity.BinaryDAO) entity.Labels {
+		return item.Labels
+	}, query), nil
+}
+
+// CreateBinary сохраняет новый файл.
+//
+// Возвращает id созданной записи или ошибку (repo.ErrNotFound, если папка или метка записи не найдена).
+func (m *Memory) CreateBinary(_ context.Context, binary entity.BinaryDAO) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.users[binary.UserID]; !ok {
+		return 0, fmt.Errorf("memory - create binary: user %d: %w", binary.UserID, repo.ErrNotFound)
+	}
+	if !m.labelsValid(binary.UserID, binary.Labels) {
+		return 0, repo.ErrNotFound
+	}
+
+	binary.ID = m.nextID(entity.BinaryKind)
+	binary.Data = cloneBytes(binary.Data)
+	binary.TagIDs = cloneIDs(binary.TagIDs)
+	binary.Revision = m.nextRevision(binary.UserID)
+	binary.CreatedAt = time.Now()
+	m.binaries[binary.ID] = binary
+
+	return binary.ID, nil
+}
+
+// GetBinary находит файл (вместе с содержимым) принадлежащий конкретному пользователю (userID).
+//
+// Возвращает repo.ErrNotFound, если запись не найдена.
+func (m *Memory) GetBinary(_ context.Context, userID, binaryID int) (entity.BinaryDAO, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	binary, ok := m.binaries[binaryID]
+	if !ok || binary.UserID != userID {
+		return entity.BinaryDAO{}, repo.ErrNotFound
+	}
+
+	binary.Data = cloneBytes(binary.Data)
+
+	return binary, nil
+}
+
+// DeleteBinary удаляет файл принадлежащий конкретному пользователю (userID).
+//
+// Возвращает repo.ErrNotFound, если запись не найдена.
+func (m *Memory) DeleteBinary(_ context.Context, userID, binaryID int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if binary, ok := m.binaries[binaryID]; !ok || binary.UserID != userID {
+		return repo.ErrNotFound
+	}
+
+	delete(m.binaries, binaryID)
+	m.bury(userID, entity.BinaryKind, binaryID)
+
+	return nil
+}
+
+// binariesSince возвращает файлы пользователя с ревизией больше since (по возрастанию id).
+//
+// Содержимое файлов копируется, только если withData.
+func (m *Memory) binariesSince(userID int, since int64, withData bool) []entity.BinaryDAO {
+	var result []entity.BinaryDAO
+	for _, binary := range m.binaries {
+		if binary.UserID == userID && binary.Revision > since {
+			if withData {
+				binary.Data = cloneBytes(binary.Data)
+			} else {
+				binary.Data = nil
+			}
+			result = append(result, binary)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+
+	return result
+}
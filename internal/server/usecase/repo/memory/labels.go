@@ -0,0 +1,266 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	"github.com/PaulYakow/gophkeeper/internal/server/usecase/repo"
+)
+
+// Таблицы папок и меток (для выдачи id).
+const (
+	foldersTable = "folders"
+	tagsTable    = "tags"
+)
+
+// ListFolders находит все папки пользователя (userID) по возрастанию id.
+func (m *Memory) ListFolders(_ context.Context, userID int) ([]entity.FolderDAO, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.userFolders(userID), nil
+}
+
+// CreateFolder сохраняет новую папку.
+//
+// Возвращает id созданной папки или repo.ErrNotFound, если родительская папка не найдена.
+func (m *Memory) CreateFolder(_ context.Context, folder entity.FolderDAO) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.users[folder.UserID]; !ok {
+		return 0, fmt.Errorf("memory - create folder: user %d: %w", folder.UserID, repo.ErrNotFound)
+	}
+	if !m.labelsValid(folder.UserID, entity.Labels{FolderID: folder.ParentID}) {
+		return 0, repo.ErrNotFound
+	}
+
+	folder.ID = m.nextID(foldersTable)
+	folder.CreatedAt = time.Now()
+	m.folders[folder.ID] = folder
+
+	return folder.ID, nil
+}
+
+// RenameFolder изменяет название папки пользователя (userID).
+//
+// Возвращает repo.ErrNotFound, если папка не найдена.
+func (m *Memory) RenameFolder(_ context.Context, userID, folderID int, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	folder, ok := m.folders[folderID]
+	if !ok || folder.UserID != userID {
+		return repo.ErrNotFound
+	}
+
+	folder.Name = name
+	m.folders[folderID] = folder
+
+	return nil
+}
+
+// DeleteFolder удаляет папку пользователя (userID) вместе с вложенными папками.
+//
+// Записи удалённых папок переносятся в родительскую папку удалённой (с новой ревизией).
+// Возвращает repo.ErrNotFound, если папка не найдена.
+func (m *Memory) DeleteFolder(_ context.Context, userID, folderID int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	target, ok := m.folders[folderID]
+	if !ok || target.UserID != userID {
+		return repo.ErrNotFound
+	}
+
+	subtree := map[int]bool{folderID: true}
+	for found := true; found; {
+		found = false
+		for id, folder := range m.folders {
+			if !subtree[id] && subtree[folder.ParentID] {
+				subtree[id] = true
+				found = true
+			}
+		}
+	}
+
+	m.relabel(userID, func(labels *entity.Labels) bool {
+		if !subtree[labels.FolderID] {
+			return false
+		}
+		labels.FolderID = target.ParentID
+		return true
+	})
+
+	for id := range subtree {
+		delete(m.folders, id)
+	}
+
+	return nil
+}
+
+// ListTags находит все метки пользователя (userID) по возрастанию id.
+func (m *Memory) ListTags(_ context.Context, userID int) ([]entity.TagDAO, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.userTags(userID), nil
+}
+
+// CreateTag сохраняет новую метку.
+//
+// Возвращает id созданной метки или ошибку.
+func (m *Memory) CreateTag(_ context.Context, tag entity.TagDAO) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.users[tag.UserID]; !ok {
+		return 0, fmt.Errorf("memory - create tag: user %d: %w", tag.UserID, repo.ErrNotFound)
+	}
+
+	tag.ID = m.nextID(tagsTable)
+	tag.CreatedAt = time.Now()
+	m.tags[tag.ID] = tag
+
+	return tag.ID, nil
+}
+
+// RenameTag изменяет название метки пользователя (userID).
+//
+// Возвращает repo.ErrNotFound, если метка не найдена.
+func (m *Memory) RenameTag(_ context.Context, userID, tagID int, name string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	tag, ok := m.tags[tagID]
+	if !ok || tag.UserID != userID {
+		return repo.ErrNotFound
+	}
+
+	tag.Name = name
+	m.tags[tagID] = tag
+
+	return nil
+}
+
+// DeleteTag удаляет метку пользователя (userID) и снимает её со всех записей (с новой ревизией).
+//
+// Возвращает repo.ErrNotFound, если метка не найдена.
+func (m *Memory) DeleteTag(_ context.Context, userID, tagID int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if tag, ok := m.tags[tagID]; !ok || tag.UserID != userID {
+		return repo.ErrNotFound
+	}
+
+	m.relabel(userID, func(labels *entity.Labels) bool {
+		if !labels.HasTag(tagID) {
+			return false
+		}
+
+		tags := make(entity.IDs, 0, len(labels.TagIDs)-1)
+		for _, id := range labels.TagIDs {
+			if id != tagID {
+				tags = append(tags, id)
+			}
+		}
+		labels.TagIDs = tags
+		return true
+	})
+
+	delete(m.tags, tagID)
+
+	return nil
+}
+
+// labelsValid проверяет, что папка (кроме 0) и все метки labels принадлежат пользователю (userID).
+func (m *Memory) labelsValid(userID int, labels entity.Labels) bool {
+	if labels.FolderID != 0 {
+		if folder, ok := m.folders[labels.FolderID]; !ok || folder.UserID != userID {
+			return false
+		}
+	}
+
+	for _, id := range labels.TagIDs {
+		if tag, ok := m.tags[id]; !ok || tag.UserID != userID {
+			return false
+		}
+	}
+
+	return true
+}
+
+// relabel изменяет папку и метки записей всех типов данных пользователя (userID).
+//
+// change изменяет папку и метки записи и сообщает, изменились ли они; изменённые записи получают
+// общую новую ревизию (ревизия увеличивается, даже если записи не изменились - как в Postgres).
+func (m *Memory) relabel(userID int, change func(labels *entity.Labels) bool) {
+	revision := m.nextRevision(userID)
+
+	for id, pair := range m.pairs {
+		if pair.UserID == userID && change(&pair.Labels) {
+			pair.Revision = revision
+			m.pairs[id] = pair
+		}
+	}
+	for id, card := range m.cards {
+		if card.UserID == userID && change(&card.Labels) {
+			card.Revision = revision
+			m.cards[id] = card
+		}
+	}
+	for id, note := range m.notes {
+		if note.UserID == userID && change(&note.Labels) {
+			note.Revision = revision
+			m.notes[id] = note
+		}
+	}
+	for id, binary := range m.binaries {
+		if binary.UserID == userID && change(&binary.Labels) {
+			binary.Revision = revision
+			m.binaries[id] = binary
+		}
+	}
+	for id, otp := range m.otps {
+		if otp.UserID == userID && change(&otp.Labels) {
+			otp.Revision = revision
+			m.otps[id] = otp
+		}
+	}
+}
+
+// userFolders возвращает папки пользователя (по возрастанию id).
+func (m *Memory) userFolders(userID int) []entity.FolderDAO {
+	var result []entity.FolderDAO
+	for _, folder := range m.folders {
+		if folder.UserID == userID {
+			result = append(result, folder)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+
+	return result
+}
+
+// userTags возвращает метки пользователя (по возрастанию id).
+func (m *Memory) userTags(userID int) []entity.TagDAO {
+	var result []entity.TagDAO
+	for _, tag := range m.tags {
+		if tag.UserID == userID {
+			result = append(result, tag)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+
+	return result
+}
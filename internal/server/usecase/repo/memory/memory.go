@@ -0,0 +1,187 @@
+// Package memory содержит хранилище сервера в оперативной памяти.
+//
+// Хранилище повторяет поведение хранилища Postgres (пакет repo): те же ошибки (repo.ErrNotFound,
+// repo.ErrUserExist), ревизии и отметки об удалении, каскадное удаление данных пользователя.
+// Данные не сохраняются между запусками сервера - хранилище предназначено для локальной разработки,
+// демонстрации и тестов.
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	"github.com/PaulYakow/gophkeeper/internal/server/usecase"
+)
+
+// Memory реализация хранилища (usecase.IRepo) в оперативной памяти.
+//
+// Безопасно для одновременного использования: все операции выполняются под общей блокировкой,
+// поэтому чтение нескольких таблиц (синхронизация, выгрузка) видит согласованный снимок.
+type Memory struct {
+	mu sync.RWMutex
+
+	users           map[int]entity.UserDAO
+	passwordHistory map[int][]string
+	sessions        map[string]entity.SessionDAO
+	loginFailures   map[string]loginFailure
+	securityEvents  []entity.SecurityEventDAO
+	totp            map[int]entity.TOTPDAO
+	challenges      map[string]entity.ChallengeDAO
+
+	pairs      map[int]entity.PairDAO
+	cards      map[int]entity.BankDAO
+	notes      map[int]entity.TextDAO
+	binaries   map[int]entity.BinaryDAO
+	otps       map[int]entity.OTPDAO
+	folders    map[int]entity.FolderDAO
+	tags       map[int]entity.TagDAO
+	revisions  map[int]int64
+	tombstones map[tombstoneKey]tombstone
+
+	// последние выданные id (аналог SERIAL)
+	seq map[string]int
+}
+
+// loginFailure счётчик неудачных попыток (аналог public.login_failures).
+type loginFailure struct {
+	failures    int
+	lockedUntil time.Time
+	updatedAt   time.Time
+}
+
+// tombstoneKey ключ отметки об удалении (id уникальны в пределах типа данных).
+type tombstoneKey struct {
+	kind string
+	id   int
+}
+
+// tombstone отметка об удалении записи пользователя.
+type tombstone struct {
+	userID int
+	entity.Tombstone
+}
+
+var _ usecase.IRepo = (*Memory)(nil)
+
+// New создаёт пустое хранилище.
+func New() *Memory {
+	return &Memory{
+		users:           make(map[int]entity.UserDAO),
+		passwordHistory: make(map[int][]string),
+		sessions:        make(map[string]entity.SessionDAO),
+		loginFailures:   make(map[string]loginFailure),
+		totp:            make(map[int]entity.TOTPDAO),
+		challenges:      make(map[string]entity.ChallengeDAO),
+		pairs:           make(map[int]entity.PairDAO),
+		cards:           make(map[int]entity.BankDAO),
+		notes:           make(map[int]entity.TextDAO),
+		binaries:        make(map[int]entity.BinaryDAO),
+		otps:            make(map[int]entity.OTPDAO),
+		folders:         make(map[int]entity.FolderDAO),
+		tags:            make(map[int]entity.TagDAO),
+		revisions:       make(map[int]int64),
+		tombstones:      make(map[tombstoneKey]tombstone),
+		seq:             make(map[string]int),
+	}
+}
+
+// Ping - хранилище в памяти доступно всегда.
+func (m *Memory) Ping(_ context.Context) error {
+	return nil
+}
+
+// CloseConnection - для хранилища в памяти ничего не делает (данные теряются вместе с процессом).
+func (m *Memory) CloseConnection() error {
+	return nil
+}
+
+// nextID выдаёт очередной id записи таблицы table.
+func (m *Memory) nextID(table string) int {
+	m.seq[table]++
+	return m.seq[table]
+}
+
+// nextRevision увеличивает ревизию данных пользователя и возвращает её.
+func (m *Memory) nextRevision(userID int) int64 {
+	m.revisions[userID]++
+	return m.revisions[userID]
+}
+
+// bury сохраняет отметку об удалении записи kind/id пользователя (с новой ревизией).
+func (m *Memory) bury(userID int, kind string, id int) {
+	m.tombstones[tombstoneKey{kind, id}] = tombstone{
+		userID: userID,
+		Tombstone: entity.Tombstone{
+			Kind:     kind,
+			ID:       id,
+			Revision: m.nextRevision(userID),
+		},
+	}
+}
+
+// cloneIDs копирует список id (хранилище не должно разделять память с вызывающим).
+func cloneIDs(ids entity.IDs) entity.IDs {
+	if len(ids) == 0 {
+		return nil
+	}
+	return append(entity.IDs(nil), ids...)
+}
+
+// cloneBytes копирует содержимое (хранилище не должно разделять память с вызывающим).
+func cloneBytes(data []byte) []byte {
+	if data == nil {
+		return nil
+	}
+	return append([]byte(nil), data...)
+}
+
+// listPage выбирает из записей пользователя items страницу списка по фильтру и курсору query
+// (как запросы страниц хранилища Postgres); cursor - позиция записи в списке, labels - папка и метки записи.
+func listPage[T any](items []T,
+	cursor func(T) entity.Cursor,
+	labels func(T) entity.Labels,
+	query entity.ListQuery,
+) []T {
+	// less - запись a в списке раньше b
+	less := func(a, b entity.Cursor) bool {
+		if a.CreatedAt.Equal(b.CreatedAt) {
+			return a.ID < b.ID
+		}
+		return a.CreatedAt.Before(b.CreatedAt)
+	}
+	if query.Order == entity.SortCreatedDesc {
+		asc := less
+		less = func(a, b entity.Cursor) bool { return asc(b, a) }
+	}
+
+	result := make([]T, 0, len(items))
+	for _, item := range items {
+		c := cursor(item)
+		if !query.CreatedFrom.IsZero() && c.CreatedAt.Before(query.CreatedFrom) {
+			continue
+		}
+		if !query.CreatedTo.IsZero() && !c.CreatedAt.Before(query.CreatedTo) {
+			continue
+		}
+		if query.After != nil && !less(*query.After, c) {
+			continue
+		}
+		if !query.Filter.Match(labels(item)) {
+			continue
+		}
+		result = append(result, item)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return less(cursor(result[i]), cursor(result[j]))
+	})
+
+	if len(result) > query.Limit {
+		result = result[:query.Limit]
+	}
+
+	return result
+}
@@ -0,0 +1,481 @@
+package memory_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	"github.com/PaulYakow/gophkeeper/internal/server/usecase/repo"
+	"github.com/PaulYakow/gophkeeper/internal/server/usecase/repo/memory"
+)
+
+const login, passwordHash = "user", "password_hash"
+
+// allItems выборка всех записей пользователя одной страницей.
+var allItems = entity.ListQuery{Limit: 1000}
+
+func newUser(t *testing.T, m *memory.Memory) int {
+	userID, err := m.CreateUser(context.Background(), login, passwordHash)
+	require.NoError(t, err)
+	return userID
+}
+
+func TestAuthorization_Users(t *testing.T) {
+	m := memory.New()
+	userID := newUser(t, m)
+
+	t.Run("duplicate user", func(t *testing.T) {
+		id, err := m.CreateUser(context.Background(), login, "another_hash")
+		require.ErrorIs(t, err, repo.ErrUserExist)
+		assert.Empty(t, id)
+	})
+
+	t.Run("get user", func(t *testing.T) {
+		user, err := m.GetUser(context.Background(), login)
+		require.NoError(t, err)
+		assert.Equal(t, userID, user.ID)
+		assert.Equal(t, passwordHash, user.PasswordHash)
+		assert.WithinDuration(t, time.Now(), user.PasswordChangedAt, time.Second)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		_, err := m.GetUser(context.Background(), "unknown")
+		require.ErrorIs(t, err, repo.ErrNotFound)
+	})
+}
+
+func TestAuthorization_ChangePassword(t *testing.T) {
+	ctx := context.Background()
+	m := memory.New()
+	userID := newUser(t, m)
+
+	session := entity.SessionDAO{ID: "session", UserID: userID, RefreshHash: "hash", ExpiresAt: time.Now().Add(time.Hour)}
+	require.NoError(t, m.CreateSession(ctx, session))
+
+	t.Run("stale hash", func(t *testing.T) {
+		require.ErrorIs(t, m.ChangePassword(ctx, userID, "stale", "new_hash"), repo.ErrNotFound)
+	})
+
+	t.Run("change password", func(t *testing.T) {
+		require.NoError(t, m.ChangePassword(ctx, userID, passwordHash, "new_hash"))
+		require.NoError(t, m.ChangePassword(ctx, userID, "new_hash", "newest_hash"))
+
+		history, err := m.GetPasswordHistory(ctx, userID, 5)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"new_hash", passwordHash}, history)
+
+		got, err := m.GetSession(ctx, session.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, got.RevokedAt)
+	})
+
+	t.Run("update password hash", func(t *testing.T) {
+		require.ErrorIs(t, m.UpdatePasswordHash(ctx, userID, "stale", "rehashed"), repo.ErrNotFound)
+		require.NoError(t, m.UpdatePasswordHash(ctx, userID, "newest_hash", "rehashed"))
+
+		history, err := m.GetPasswordHistory(ctx, userID, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"new_hash"}, history)
+	})
+}
+
+func TestAuthorization_Sessions(t *testing.T) {
+	ctx := context.Background()
+	m := memory.New()
+	userID := newUser(t, m)
+
+	session := entity.SessionDAO{ID: "session", UserID: userID, RefreshHash: "hash", ExpiresAt: time.Now().Add(time.Hour)}
+	require.NoError(t, m.CreateSession(ctx, session))
+
+	t.Run("rotate", func(t *testing.T) {
+		expiresAt := time.Now().Add(2 * time.Hour)
+		require.NoError(t, m.RotateSession(ctx, session.ID, "hash", "new_hash", expiresAt))
+		require.ErrorIs(t, m.RotateSession(ctx, session.ID, "hash", "other_hash", expiresAt), repo.ErrNotFound)
+
+		got, err := m.GetSession(ctx, session.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "new_hash", got.RefreshHash)
+		assert.Equal(t, expiresAt, got.ExpiresAt)
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		require.NoError(t, m.RevokeSession(ctx, session.ID))
+		require.ErrorIs(t, m.RevokeSession(ctx, session.ID), repo.ErrNotFound)
+		require.ErrorIs(t, m.RotateSession(ctx, session.ID, "new_hash", "hash", time.Now().Add(time.Hour)), repo.ErrNotFound)
+	})
+
+	t.Run("stale sessions removed", func(t *testing.T) {
+		require.NoError(t, m.CreateSession(ctx, entity.SessionDAO{ID: "another", UserID: userID, ExpiresAt: time.Now().Add(time.Hour)}))
+		_, err := m.GetSession(ctx, session.ID)
+		require.ErrorIs(t, err, repo.ErrNotFound)
+	})
+
+	t.Run("unknown user", func(t *testing.T) {
+		require.ErrorIs(t, m.CreateSession(ctx, entity.SessionDAO{ID: "orphan", UserID: userID + 1}), repo.ErrNotFound)
+	})
+}
+
+func TestAuthorization_LoginFailures(t *testing.T) {
+	ctx := context.Background()
+	m := memory.New()
+	loginKey, peerKey := "login:user", "peer:10.0.0.1"
+
+	lockedUntil, err := m.GetLoginLock(ctx, loginKey, peerKey)
+	require.NoError(t, err)
+	assert.True(t, lockedUntil.Before(time.Now()))
+
+	for i := 1; i <= 3; i++ {
+		failures, err := m.AddLoginFailure(ctx, loginKey, time.Hour)
+		require.NoError(t, err)
+		assert.Equal(t, i, failures)
+	}
+
+	require.ErrorIs(t, m.LockLogin(ctx, peerKey, time.Now().Add(time.Hour)), repo.ErrNotFound)
+
+	until := time.Now().Add(time.Minute)
+	require.NoError(t, m.LockLogin(ctx, loginKey, until))
+	lockedUntil, err = m.GetLoginLock(ctx, loginKey, peerKey)
+	require.NoError(t, err)
+	assert.Equal(t, until, lockedUntil)
+
+	time.Sleep(10 * time.Millisecond)
+	failures, err := m.AddLoginFailure(ctx, loginKey, time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, 1, failures)
+
+	require.NoError(t, m.ResetLoginFailures(ctx, loginKey))
+	lockedUntil, err = m.GetLoginLock(ctx, loginKey)
+	require.NoError(t, err)
+	assert.True(t, lockedUntil.Before(time.Now()))
+}
+
+func TestAuthorization_SecondFactor(t *testing.T) {
+	ctx := context.Background()
+	m := memory.New()
+	userID := newUser(t, m)
+
+	totp, err := m.GetTOTP(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.TOTPDAO{UserID: userID}, totp)
+	require.ErrorIs(t, m.ConfirmTOTP(ctx, userID, 1), repo.ErrNotFound)
+
+	require.NoError(t, m.SaveTOTPPending(ctx, userID, "secret"))
+	require.NoError(t, m.ConfirmTOTP(ctx, userID, 10))
+	require.ErrorIs(t, m.UseTOTPStep(ctx, userID, 10), repo.ErrNotFound)
+	require.NoError(t, m.UseTOTPStep(ctx, userID, 11))
+
+	require.NoError(t, m.SaveTOTPPending(ctx, userID, "new_secret"))
+	totp, err = m.GetTOTP(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, entity.TOTPDAO{UserID: userID, Secret: "secret", PendingSecret: "new_secret", LastStep: 11}, totp)
+
+	challenge := entity.ChallengeDAO{ID: "challenge", UserID: userID, SecretHash: "hash", ExpiresAt: time.Now().Add(time.Minute)}
+	require.NoError(t, m.CreateChallenge(ctx, challenge))
+	got, err := m.GetChallenge(ctx, challenge.ID)
+	require.NoError(t, err)
+	assert.Equal(t, login, got.Login)
+	require.NoError(t, m.DeleteChallenge(ctx, challenge.ID))
+	require.ErrorIs(t, m.DeleteChallenge(ctx, challenge.ID), repo.ErrNotFound)
+
+	require.NoError(t, m.DeleteTOTP(ctx, userID))
+	require.ErrorIs(t, m.DeleteTOTP(ctx, userID), repo.ErrNotFound)
+}
+
+func TestSync(t *testing.T) {
+	ctx := context.Background()
+	m := memory.New()
+	userID := newUser(t, m)
+	otherID, err := m.CreateUser(context.Background(), "other", passwordHash)
+	require.NoError(t, err)
+
+	pairID, err := m.CreatePair(ctx, entity.PairDAO{UserID: userID, Login: "login", Password: "password"})
+	require.NoError(t, err)
+	noteID, err := m.CreateNote(ctx, entity.TextDAO{UserID: userID, Note: "note"})
+	require.NoError(t, err)
+	_, err = m.CreateCard(ctx, entity.BankDAO{UserID: otherID, Number: "4242"})
+	require.NoError(t, err)
+
+	changes, err := m.GetChanges(ctx, userID, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), changes.Revision)
+	require.Len(t, changes.Pairs, 1)
+	require.Len(t, changes.Notes, 1)
+	assert.Empty(t, changes.Cards)
+
+	t.Run("update of another user record", func(t *testing.T) {
+		require.ErrorIs(t, m.UpdatePair(ctx, entity.PairDAO{ID: pairID, UserID: otherID}), repo.ErrNotFound)
+		require.ErrorIs(t, m.DeleteNote(ctx, otherID, noteID), repo.ErrNotFound)
+	})
+
+	t.Run("changes since revision", func(t *testing.T) {
+		require.NoError(t, m.UpdatePair(ctx, entity.PairDAO{ID: pairID, UserID: userID, Login: "new_login"}))
+		require.NoError(t, m.DeleteNote(ctx, userID, noteID))
+
+		changes, err := m.GetChanges(ctx, userID, 2)
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), changes.Revision)
+		require.Len(t, changes.Pairs, 1)
+		assert.Equal(t, "new_login", changes.Pairs[0].Login)
+		assert.Equal(t, int64(3), changes.Pairs[0].Revision)
+		assert.Empty(t, changes.Notes)
+		assert.Equal(t, []entity.Tombstone{{Kind: entity.NoteKind, ID: noteID, Revision: 4}}, changes.Deleted)
+	})
+}
+
+func TestBinaries(t *testing.T) {
+	ctx := context.Background()
+	m := memory.New()
+	userID := newUser(t, m)
+
+	data := []byte("content")
+	binaryID, err := m.CreateBinary(ctx, entity.BinaryDAO{UserID: userID, Filename: "file.txt", Size: int64(len(data)), Data: data})
+	require.NoError(t, err)
+	data[0] = 'C'
+
+	all, err := m.ListBinaries(ctx, userID, allItems)
+	require.NoError(t, err)
+	require.Len(t, all, 1)
+	assert.Nil(t, all[0].Data)
+
+	binary, err := m.GetBinary(ctx, userID, binaryID)
+	require.NoError(t, err)
+	assert.Equal(t, []byte("content"), binary.Data)
+
+	require.NoError(t, m.DeleteBinary(ctx, userID, binaryID))
+	_, err = m.GetBinary(ctx, userID, binaryID)
+	require.ErrorIs(t, err, repo.ErrNotFound)
+}
+
+func TestListPage(t *testing.T) {
+	ctx := context.Background()
+	m := memory.New()
+	userID := newUser(t, m)
+
+	var ids []int
+	for _, note := range []string{"first", "second", "third"} {
+		id, err := m.CreateNote(ctx, entity.TextDAO{UserID: userID, Note: note})
+		require.NoError(t, err)
+		ids = append(ids, id)
+	}
+	all, err := m.ListNotes(ctx, userID, allItems)
+	require.NoError(t, err)
+	require.Len(t, all, 3)
+
+	noteIDs := func(notes []entity.TextDAO) []int {
+		var result []int
+		for _, note := range notes {
+			result = append(result, note.ID)
+		}
+		return result
+	}
+
+	t.Run("limit and cursor", func(t *testing.T) {
+		page, err := m.ListNotes(ctx, userID, entity.ListQuery{Limit: 2})
+		require.NoError(t, err)
+		assert.Equal(t, ids[:2], noteIDs(page))
+
+		after := entity.Cursor{CreatedAt: page[1].CreatedAt, ID: page[1].ID}
+		page, err = m.ListNotes(ctx, userID, entity.ListQuery{Limit: 2, After: &after})
+		require.NoError(t, err)
+		assert.Equal(t, ids[2:], noteIDs(page))
+	})
+
+	t.Run("descending order", func(t *testing.T) {
+		page, err := m.ListNotes(ctx, userID, entity.ListQuery{Limit: 2, Order: entity.SortCreatedDesc})
+		require.NoError(t, err)
+		assert.Equal(t, []int{ids[2], ids[1]}, noteIDs(page))
+	})
+
+	t.Run("creation time range", func(t *testing.T) {
+		page, err := m.ListNotes(ctx, userID, entity.ListQuery{
+			Limit:       10,
+			CreatedFrom: all[1].CreatedAt,
+			CreatedTo:   all[2].CreatedAt,
+		})
+		require.NoError(t, err)
+		if all[1].CreatedAt.Equal(all[2].CreatedAt) {
+			assert.Empty(t, page)
+		} else {
+			assert.Equal(t, ids[1:2], noteIDs(page))
+		}
+	})
+
+	t.Run("another user", func(t *testing.T) {
+		page, err := m.ListNotes(ctx, userID+1, allItems)
+		require.NoError(t, err)
+		assert.Empty(t, page)
+	})
+}
+
+func TestAccount(t *testing.T) {
+	ctx := context.Background()
+	m := memory.New()
+	userID := newUser(t, m)
+
+	_, err := m.CreateOTP(ctx, entity.OTPDAO{UserID: userID, Kind: "totp", Secret: "secret"})
+	require.NoError(t, err)
+	_, err = m.CreateBinary(ctx, entity.BinaryDAO{UserID: userID, Filename: "file", Data: []byte("data")})
+	require.NoError(t, err)
+	require.NoError(t, m.CreateSession(ctx, entity.SessionDAO{ID: "session", UserID: userID, ExpiresAt: time.Now().Add(time.Hour)}))
+
+	account, err := m.ExportAccount(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, login, account.User.Login)
+	require.Len(t, account.OTPs, 1)
+	require.Len(t, account.Binaries, 1)
+	assert.Equal(t, []byte("data"), account.Binaries[0].Data)
+
+	require.ErrorIs(t, m.DeleteAccount(ctx, userID, "wrong_hash"), repo.ErrNotFound)
+	require.NoError(t, m.DeleteAccount(ctx, userID, passwordHash))
+
+	_, err = m.GetUserByID(ctx, userID)
+	require.ErrorIs(t, err, repo.ErrNotFound)
+	_, err = m.GetSession(ctx, "session")
+	require.ErrorIs(t, err, repo.ErrNotFound)
+	changes, err := m.GetChanges(ctx, userID, 0)
+	require.NoError(t, err)
+	assert.Equal(t, entity.ChangesDAO{}, changes)
+
+	// логин освобождается
+	_, err = m.CreateUser(context.Background(), login, passwordHash)
+	require.NoError(t, err)
+}
+
+func TestLabels(t *testing.T) {
+	ctx := context.Background()
+	m := memory.New()
+	userID := newUser(t, m)
+	otherID, err := m.CreateUser(ctx, "other", passwordHash)
+	require.NoError(t, err)
+
+	workID, err := m.CreateFolder(ctx, entity.FolderDAO{UserID: userID, Name: "work"})
+	require.NoError(t, err)
+	projectID, err := m.CreateFolder(ctx, entity.FolderDAO{UserID: userID, ParentID: workID, Name: "project"})
+	require.NoError(t, err)
+	otherFolderID, err := m.CreateFolder(ctx, entity.FolderDAO{UserID: otherID, Name: "work"})
+	require.NoError(t, err)
+	tagID, err := m.CreateTag(ctx, entity.TagDAO{UserID: userID, Name: "vpn"})
+	require.NoError(t, err)
+
+	t.Run("labels of another user", func(t *testing.T) {
+		_, err := m.CreateFolder(ctx, entity.FolderDAO{UserID: userID, ParentID: otherFolderID, Name: "sub"})
+		require.ErrorIs(t, err, repo.ErrNotFound)
+		_, err = m.CreatePair(ctx, entity.PairDAO{UserID: userID, Labels: entity.Labels{FolderID: otherFolderID}})
+		require.ErrorIs(t, err, repo.ErrNotFound)
+		_, err = m.CreateNote(ctx, entity.TextDAO{UserID: otherID, Labels: entity.Labels{TagIDs: entity.IDs{tagID}}})
+		require.ErrorIs(t, err, repo.ErrNotFound)
+		require.ErrorIs(t, m.RenameTag(ctx, otherID, tagID, "x"), repo.ErrNotFound)
+	})
+
+	pairID, err := m.CreatePair(ctx, entity.PairDAO{UserID: userID, Login: "vpn",
+		Labels: entity.Labels{FolderID: projectID, TagIDs: entity.IDs{tagID}}})
+	require.NoError(t, err)
+	noteID, err := m.CreateNote(ctx, entity.TextDAO{UserID: userID, Note: "note",
+		Labels: entity.Labels{FolderID: workID}})
+	require.NoError(t, err)
+	_, err = m.CreateNote(ctx, entity.TextDAO{UserID: userID, Note: "loose"})
+	require.NoError(t, err)
+
+	t.Run("list filter", func(t *testing.T) {
+		query := allItems
+		query.Filter = entity.LabelFilter{FolderID: &workID}
+		notes, err := m.ListNotes(ctx, userID, query)
+		require.NoError(t, err)
+		require.Len(t, notes, 1)
+		assert.Equal(t, noteID, notes[0].ID)
+
+		noFolder := 0
+		query.Filter = entity.LabelFilter{FolderID: &noFolder}
+		notes, err = m.ListNotes(ctx, userID, query)
+		require.NoError(t, err)
+		require.Len(t, notes, 1)
+		assert.Equal(t, "loose", notes[0].Note)
+
+		query.Filter = entity.LabelFilter{TagID: tagID}
+		pairs, err := m.ListPairs(ctx, userID, query)
+		require.NoError(t, err)
+		require.Len(t, pairs, 1)
+		assert.Equal(t, pairID, pairs[0].ID)
+	})
+
+	t.Run("rename", func(t *testing.T) {
+		require.NoError(t, m.RenameFolder(ctx, userID, projectID, "project-x"))
+		require.NoError(t, m.RenameTag(ctx, userID, tagID, "network"))
+
+		folders, err := m.ListFolders(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, folders, 2)
+		assert.Equal(t, "project-x", folders[1].Name)
+		assert.Equal(t, workID, folders[1].ParentID)
+
+		tags, err := m.ListTags(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, tags, 1)
+		assert.Equal(t, "network", tags[0].Name)
+	})
+
+	t.Run("delete folder moves records to parent", func(t *testing.T) {
+		changes, err := m.GetChanges(ctx, userID, 0)
+		require.NoError(t, err)
+		since := changes.Revision
+
+		require.NoError(t, m.DeleteFolder(ctx, userID, workID))
+
+		folders, err := m.ListFolders(ctx, userID)
+		require.NoError(t, err)
+		assert.Empty(t, folders)
+
+		changes, err = m.GetChanges(ctx, userID, since)
+		require.NoError(t, err)
+		require.Len(t, changes.Pairs, 1)
+		assert.Equal(t, 0, changes.Pairs[0].FolderID)
+		require.Len(t, changes.Notes, 1)
+		assert.Equal(t, 0, changes.Notes[0].FolderID)
+	})
+
+	t.Run("delete tag removes it from records", func(t *testing.T) {
+		require.NoError(t, m.DeleteTag(ctx, userID, tagID))
+		require.ErrorIs(t, m.DeleteTag(ctx, userID, tagID), repo.ErrNotFound)
+
+		pairs, err := m.ListPairs(ctx, userID, allItems)
+		require.NoError(t, err)
+		require.Len(t, pairs, 1)
+		assert.Empty(t, pairs[0].TagIDs)
+	})
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	ctx := context.Background()
+	m := memory.New()
+	userID := newUser(t, m)
+
+	const workers, perWorker = 8, 50
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < perWorker; i++ {
+				_, err := m.CreatePair(ctx, entity.PairDAO{UserID: userID, Login: "login"})
+				assert.NoError(t, err)
+				_, err = m.GetChanges(ctx, userID, 0)
+				assert.NoError(t, err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	pairs, err := m.ListPairs(ctx, userID, allItems)
+	require.NoError(t, err)
+	require.Len(t, pairs, workers*perWorker)
+
+	changes, err := m.GetChanges(ctx, userID, 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(workers*perWorker), changes.Revision)
+}
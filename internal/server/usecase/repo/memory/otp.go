@@ -0,0 +1,99 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	"github.com/PaulYakow/gophkeeper/internal/server/usecase/repo"
+)
+
+// ListOTPs находит страницу списка одноразовых паролей, принадлежащих конкретному пользователю (userID).
+func (m *Memory) ListOTPs(_ context.Context, userID int, query entity.ListQuery) ([]entity.OTPDAO, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return listPage(m.otpsSince(userID, 0), func(item entity.OTPDAO) entity.Cursor {
+		return entity.Cursor{CreatedAt: item.CreatedAt, ID: item.ID}
+	}, func(item entity.OTPDAO) entity.Labels {
+		return item.Labels
+	}, query), nil
+}
+
+// CreateOTP сохраняет новый одноразовый пароль.
+//
+// Возвращает id созданной записи или ошибку (repo.ErrNotFound, если папка или метка записи не найдена).
+func (m *Memory) CreateOTP(_ context.Context, otp entity.OTPDAO) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.users[otp.UserID]; !ok {
+		return 0, fmt.Errorf("memory - create otp: user %d: %w", otp.UserID, repo.ErrNotFound)
+	}
+	if !m.labelsValid(otp.UserID, otp.Labels) {
+		return 0, repo.ErrNotFound
+	}
+
+	otp.ID = m.nextID(entity.OTPKind)
+	otp.TagIDs = cloneIDs(otp.TagIDs)
+	otp.Revision = m.nextRevision(otp.UserID)
+	otp.CreatedAt = time.Now()
+	m.otps[otp.ID] = otp
+
+	return otp.ID, nil
+}
+
+// UpdateOTP изменяет одноразовый пароль (поиск по id и user_id).
+//
+// Возвращает repo.ErrNotFound, если не найдена запись, её папка или метка.
+func (m *Memory) UpdateOTP(_ context.Context, otp entity.OTPDAO) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current, ok := m.otps[otp.ID]
+	if !ok || current.UserID != otp.UserID || !m.labelsValid(otp.UserID, otp.Labels) {
+		return repo.ErrNotFound
+	}
+
+	otp.CreatedAt = current.CreatedAt
+	otp.TagIDs = cloneIDs(otp.TagIDs)
+	otp.Revision = m.nextRevision(otp.UserID)
+	m.otps[otp.ID] = otp
+
+	return nil
+}
+
+// DeleteOTP удаляет одноразовый пароль принадлежащий конкретному пользователю (userID).
+//
+// Возвращает repo.ErrNotFound, если запись не найдена.
+func (m *Memory) DeleteOTP(_ context.Context, userID, otpID int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if otp, ok := m.otps[otpID]; !ok || otp.UserID != userID {
+		return repo.ErrNotFound
+	}
+
+	delete(m.otps, otpID)
+	m.bury(userID, entity.OTPKind, otpID)
+
+	return nil
+}
+
+// otpsSince возвращает одноразовые пароли пользователя с ревизией больше since (по возрастанию id).
+func (m *Memory) otpsSince(userID int, since int64) []entity.OTPDAO {
+	var result []entity.OTPDAO
+	for _, otp := range m.otps {
+		if otp.UserID == userID && otp.Revision > since {
+			result = append(result, otp)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+
+	return result
+}
@@ -0,0 +1,101 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	"github.com/PaulYakow/gophkeeper/internal/server/usecase/repo"
+)
+
+// ListPairs находит страницу списка записей типа логин/пароль, принадлежащих конкретному пользователю (userID).
+func (m *Memory) ListPairs(_ context.Context, userID int, query entity.ListQuery) ([]entity.PairDAO, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return listPage(m.pairsSince(userID, 0), func(item entity.PairDAO) entity.Cursor {
+		return entity.Cursor{CreatedAt: item.CreatedAt, ID: item.ID}
+	}, func(item entity.PairDAO) entity.Labels {
+		return item.Labels
+	}, query), nil
+}
+
+// CreatePair сохраняет новую запись типа логин/пароль.
+//
+// Возвращает id созданной записи или ошибку (repo.ErrNotFound, если папка или метка записи не найдена).
+func (m *Memory) CreatePair(_ context.Context, pair entity.PairDAO) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.users[pair.UserID]; !ok {
+		return 0, fmt.Errorf("memory - create pair: user %d: %w", pair.UserID, repo.ErrNotFound)
+	}
+	if !m.labelsValid(pair.UserID, pair.Labels) {
+		return 0, repo.ErrNotFound
+	}
+
+	pair.ID = m.nextID(entity.PairKind)
+	pair.TagIDs = cloneIDs(pair.TagIDs)
+	pair.Revision = m.nextRevision(pair.UserID)
+	pair.CreatedAt = time.Now()
+	m.pairs[pair.ID] = pair
+
+	return pair.ID, nil
+}
+
+// UpdatePair изменяет запись типа логин/пароль (поиск по id и user_id).
+//
+// Возвращает repo.ErrNotFound, если не найдена запись, её папка или метка.
+func (m *Memory) UpdatePair(_ context.Context, pair entity.PairDAO) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current, ok := m.pairs[pair.ID]
+	if !ok || current.UserID != pair.UserID || !m.labelsValid(pair.UserID, pair.Labels) {
+		return repo.ErrNotFound
+	}
+
+	current.Login = pair.Login
+	current.Password = pair.Password
+	current.Metadata = pair.Metadata
+	current.Labels = entity.Labels{FolderID: pair.FolderID, TagIDs: cloneIDs(pair.TagIDs)}
+	current.Revision = m.nextRevision(pair.UserID)
+	m.pairs[pair.ID] = current
+
+	return nil
+}
+
+// DeletePair удаляет запись типа логин/пароль принадлежащую конкретному пользователю (userID).
+//
+// Возвращает repo.ErrNotFound, если запись не найдена.
+func (m *Memory) DeletePair(_ context.Context, userID, pairID int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if pair, ok := m.pairs[pairID]; !ok || pair.UserID != userID {
+		return repo.ErrNotFound
+	}
+
+	delete(m.pairs, pairID)
+	m.bury(userID, entity.PairKind, pairID)
+
+	return nil
+}
+
+// pairsSince возвращает пары пользователя с ревизией больше since (по возрастанию id).
+func (m *Memory) pairsSince(userID int, since int64) []entity.PairDAO {
+	var result []entity.PairDAO
+	for _, pair := range m.pairs {
+		if pair.UserID == userID && pair.Revision > since {
+			result = append(result, pair)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+
+	return result
+}
@@ -0,0 +1,38 @@
+package memory
+
+import (
+	"context"
+	"sort"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+)
+
+// GetChanges находит все записи и отметки об удалении пользователя (userID) с ревизией больше since.
+//
+// Данные читаются под общей блокировкой, поэтому возвращаемая ревизия соответствует набору изменений.
+// Содержимое файлов не возвращается.
+func (m *Memory) GetChanges(_ context.Context, userID int, since int64) (entity.ChangesDAO, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	result := entity.ChangesDAO{
+		Revision: m.revisions[userID],
+		Pairs:    m.pairsSince(userID, since),
+		Cards:    m.cardsSince(userID, since),
+		Notes:    m.notesSince(userID, since),
+		Binaries: m.binariesSince(userID, since, false),
+		OTPs:     m.otpsSince(userID, since),
+	}
+
+	for _, t := range m.tombstones {
+		if t.userID == userID && t.Revision > since {
+			result.Deleted = append(result.Deleted, t.Tombstone)
+		}
+	}
+
+	sort.Slice(result.Deleted, func(i, j int) bool {
+		return result.Deleted[i].Revision < result.Deleted[j].Revision
+	})
+
+	return result, nil
+}
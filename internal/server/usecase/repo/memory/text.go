@@ -0,0 +1,100 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	"github.com/PaulYakow/gophkeeper/internal/server/usecase/repo"
+)
+
+// ListNotes находит страницу списка заметок, принадлежащих конкретному пользователю (userID).
+func (m *Memory) ListNotes(_ context.Context, userID int, query entity.ListQuery) ([]entity.TextDAO, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return listPage(m.notesSince(userID, 0), func(item entity.TextDAO) entity.Cursor {
+		return entity.Cursor{CreatedAt: item.CreatedAt, ID: item.ID}
+	}, func(item entity.TextDAO) entity.Labels {
+		return item.Labels
+	}, query), nil
+}
+
+// CreateNote сохраняет новую заметку.
+//
+// Возвращает id созданной записи или ошибку (repo.ErrNotFound, если папка или метка записи не найдена).
+func (m *Memory) CreateNote(_ context.Context, note entity.TextDAO) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.users[note.UserID]; !ok {
+		return 0, fmt.Errorf("memory - create note: user %d: %w", note.UserID, repo.ErrNotFound)
+	}
+	if !m.labelsValid(note.UserID, note.Labels) {
+		return 0, repo.ErrNotFound
+	}
+
+	note.ID = m.nextID(entity.NoteKind)
+	note.TagIDs = cloneIDs(note.TagIDs)
+	note.Revision = m.nextRevision(note.UserID)
+	note.CreatedAt = time.Now()
+	m.notes[note.ID] = note
+
+	return note.ID, nil
+}
+
+// UpdateNote изменяет заметку (поиск по id и user_id).
+//
+// Возвращает repo.ErrNotFound, если не найдена запись, её папка или метка.
+func (m *Memory) UpdateNote(_ context.Context, note entity.TextDAO) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	current, ok := m.notes[note.ID]
+	if !ok || current.UserID != note.UserID || !m.labelsValid(note.UserID, note.Labels) {
+		return repo.ErrNotFound
+	}
+
+	current.Note = note.Note
+	current.Metadata = note.Metadata
+	current.Labels = entity.Labels{FolderID: note.FolderID, TagIDs: cloneIDs(note.TagIDs)}
+	current.Revision = m.nextRevision(note.UserID)
+	m.notes[note.ID] = current
+
+	return nil
+}
+
+// DeleteNote удаляет заметку принадлежащую конкретному пользователю (userID).
+//
+// Возвращает repo.ErrNotFound, если запись не найдена.
+func (m *Memory) DeleteNote(_ context.Context, userID, noteID int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if note, ok := m.notes[noteID]; !ok || note.UserID != userID {
+		return repo.ErrNotFound
+	}
+
+	delete(m.notes, noteID)
+	m.bury(userID, entity.NoteKind, noteID)
+
+	return nil
+}
+
+// notesSince возвращает заметки пользователя с ревизией больше since (по возрастанию id).
+func (m *Memory) notesSince(userID int, since int64) []entity.TextDAO {
+	var result []entity.TextDAO
+	for _, note := range m.notes {
+		if note.UserID == userID && note.Revision > since {
+			result = append(result, note)
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].ID < result[j].ID
+	})
+
+	return result
+}
@@ -0,0 +1,319 @@
+package repo
+
+import (
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	"github.com/PaulYakow/gophkeeper/pkg/postgres"
+)
+
+// migrationsLock ключ advisory-блокировки Postgres: миграции не выполняются одновременно несколькими процессами.
+const migrationsLock = 7_246_531_001
+
+const (
+	createMigrationsTable = `
+CREATE TABLE IF NOT EXISTS public.schema_migrations
+(
+    version    INT PRIMARY KEY,
+    name       VARCHAR NOT NULL,
+    applied_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+`
+	existsMigrationsTable = `
+SELECT to_regclass('public.schema_migrations') IS NOT NULL;
+`
+	getAppliedMigrations = `
+SELECT version, applied_at FROM public.schema_migrations
+ORDER BY version;
+`
+	lockMigrations = `
+SELECT pg_advisory_xact_lock($1);
+`
+	addMigration = `
+INSERT INTO public.schema_migrations (version, name)
+VALUES ($1, $2);
+`
+	deleteMigration = `
+DELETE FROM public.schema_migrations
+WHERE version = $1;
+`
+)
+
+// Файлы миграций: <версия>_<название>.up.sql и <версия>_<название>.down.sql.
+//
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migration версия схемы БД: запросы перехода на неё (up) и отката (down).
+type Migration struct {
+	Version int
+	Name    string
+	up      string
+	down    string
+}
+
+// MigrationStatus состояние миграции в БД (AppliedAt == nil - не применена).
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+}
+
+// Migrator управляет версией схемы БД.
+//
+// Применённые миграции записываются в public.schema_migrations. Каждая миграция выполняется
+// в отдельной транзакции вместе с записью о ней, поэтому при ошибке схема остаётся на предыдущей версии.
+type Migrator struct {
+	db         *postgres.Postgres
+	migrations []Migration
+}
+
+// NewMigrator создаёт объект Migrator со встроенными в сервер миграциями.
+func NewMigrator(db *postgres.Postgres) (*Migrator, error) {
+	migrations, err := loadMigrations(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{db, migrations}, nil
+}
+
+// loadMigrations читает файлы миграций из каталога dir и упорядочивает их по версии.
+func loadMigrations(files fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(files, dir)
+	if err != nil {
+		return nil, fmt.Errorf("repo - read migrations: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		name := entry.Name()
+
+		base, direction, ok := cutDirection(name)
+		if !ok {
+			return nil, fmt.Errorf("repo - migration %q: expected *.up.sql or *.down.sql", name)
+		}
+
+		num, title, _ := strings.Cut(base, "_")
+		version, err := strconv.Atoi(num)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("repo - migration %q: invalid version", name)
+		}
+
+		data, err := fs.ReadFile(files, path.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("repo - read migration %q: %w", name, err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: title}
+			byVersion[version] = m
+		}
+		if m.Name != title {
+			return nil, fmt.Errorf("repo - migration %d: different names %q and %q", version, m.Name, title)
+		}
+
+		if direction == "up" {
+			m.up = string(data)
+		} else {
+			m.down = string(data)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.up == "" || m.down == "" {
+			return nil, fmt.Errorf("repo - migration %d: both up and down files required", m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// cutDirection отделяет от имени файла миграции направление (up или down).
+func cutDirection(name string) (string, string, bool) {
+	for _, direction := range []string{"up", "down"} {
+		suffix := "." + direction + ".sql"
+		if strings.HasSuffix(name, suffix) {
+			return strings.TrimSuffix(name, suffix), direction, true
+		}
+	}
+	return "", "", false
+}
+
+// Status возвращает все известные серверу миграции и время их применения.
+//
+// Версии, применённые в БД, но неизвестные серверу (схема новее сервера), тоже включаются (без названия).
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := m.applied(ctx, m.db)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{Version: migration.Version, Name: migration.Name}
+		if at, ok := applied[migration.Version]; ok {
+			at := at
+			status.AppliedAt = &at
+			delete(applied, migration.Version)
+		}
+		result = append(result, status)
+	}
+
+	for version, at := range applied {
+		at := at
+		result = append(result, MigrationStatus{Version: version, AppliedAt: &at})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Version < result[j].Version
+	})
+
+	return result, nil
+}
+
+// Check проверяет, что схема БД соответствует серверу: все миграции применены и неизвестных версий нет.
+//
+// Иначе возвращает ErrSchemaOutdated.
+func (m *Migrator) Check(ctx context.Context) error {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range status {
+		if s.AppliedAt == nil {
+			return fmt.Errorf("%w: migration %d (%s) is not applied", ErrSchemaOutdated, s.Version, s.Name)
+		}
+		if s.Name == "" {
+			return fmt.Errorf("%w: unknown migration %d is applied", ErrSchemaOutdated, s.Version)
+		}
+	}
+
+	return nil
+}
+
+// Up применяет по порядку все ещё не применённые миграции. Возвращает применённые миграции.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	var done []Migration
+
+	for _, migration := range m.migrations {
+		ok, err := m.step(ctx, migration, true)
+		if err != nil {
+			return done, err
+		}
+		if ok {
+			done = append(done, migration)
+		}
+	}
+
+	return done, nil
+}
+
+// Down откатывает n последних применённых миграций (от новых к старым). Возвращает откаченные миграции.
+func (m *Migrator) Down(ctx context.Context, n int) ([]Migration, error) {
+	var done []Migration
+
+	for i := len(m.migrations) - 1; i >= 0 && len(done) < n; i-- {
+		ok, err := m.step(ctx, m.migrations[i], false)
+		if err != nil {
+			return done, err
+		}
+		if ok {
+			done = append(done, m.migrations[i])
+		}
+	}
+
+	return done, nil
+}
+
+// step применяет (up) или откатывает миграцию в отдельной транзакции.
+//
+// Возвращает false, если миграция уже в нужном состоянии (например, её выполнил другой процесс).
+func (m *Migrator) step(ctx context.Context, migration Migration, up bool) (bool, error) {
+	if _, err := m.db.ExecContext(ctx, createMigrationsTable); err != nil {
+		return false, fmt.Errorf("repo - create migrations table: %w", err)
+	}
+
+	tx, err := m.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return false, fmt.Errorf("repo - begin migration: %w", err)
+	}
+	// после Commit откат ничего не делает
+	defer tx.Rollback()
+
+	if _, err = tx.ExecContext(ctx, lockMigrations, migrationsLock); err != nil {
+		return false, fmt.Errorf("repo - lock migrations: %w", err)
+	}
+
+	applied, err := m.applied(ctx, tx)
+	if err != nil {
+		return false, err
+	}
+
+	if _, ok := applied[migration.Version]; ok == up {
+		return false, nil
+	}
+
+	query, record, args := migration.up, addMigration, []any{migration.Version, migration.Name}
+	if !up {
+		query, record, args = migration.down, deleteMigration, []any{migration.Version}
+	}
+
+	if _, err = tx.ExecContext(ctx, query); err != nil {
+		return false, fmt.Errorf("repo - migration %d (%s): %w", migration.Version, migration.Name, err)
+	}
+
+	if _, err = tx.ExecContext(ctx, record, args...); err != nil {
+		return false, fmt.Errorf("repo - record migration %d: %w", migration.Version, err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, fmt.Errorf("repo - commit migration %d: %w", migration.Version, err)
+	}
+
+	return true, nil
+}
+
+// applied возвращает применённые в БД версии и время их применения.
+func (m *Migrator) applied(ctx context.Context, q sqlx.QueryerContext) (map[int]time.Time, error) {
+	var exists bool
+	if err := sqlx.GetContext(ctx, q, &exists, existsMigrationsTable); err != nil {
+		return nil, fmt.Errorf("repo - check migrations table: %w", err)
+	}
+
+	applied := make(map[int]time.Time)
+	if !exists {
+		return applied, nil
+	}
+
+	var rows []struct {
+		Version   int       `db:"version"`
+		AppliedAt time.Time `db:"applied_at"`
+	}
+	if err := sqlx.SelectContext(ctx, q, &rows, getAppliedMigrations); err != nil {
+		return nil, fmt.Errorf("repo - get applied migrations: %w", err)
+	}
+
+	for _, row := range rows {
+		applied[row.Version] = row.AppliedAt
+	}
+
+	return applied, nil
+}
@@ -0,0 +1,17 @@
+DROP TABLE IF EXISTS resources.tombstones;
+DROP TABLE IF EXISTS resources.otp_data;
+DROP TABLE IF EXISTS resources.binary_data;
+DROP TABLE IF EXISTS resources.text_data;
+DROP TABLE IF EXISTS resources.bank_data;
+DROP TABLE IF EXISTS resources.pairs_data;
+DROP TABLE IF EXISTS public.revisions;
+DROP TABLE IF EXISTS public.security_events;
+DROP TABLE IF EXISTS public.login_failures;
+DROP TABLE IF EXISTS public.login_challenges;
+DROP TABLE IF EXISTS public.totp;
+DROP TABLE IF EXISTS public.sessions;
+DROP TABLE IF EXISTS public.data_keys;
+DROP TABLE IF EXISTS public.password_history;
+DROP TABLE IF EXISTS public.users;
+
+DROP SCHEMA IF EXISTS resources;
@@ -0,0 +1,161 @@
+-- Исходная схема БД. IF NOT EXISTS - база, созданная версиями сервера без миграций,
+-- принимает эту версию без потери данных (недостающие столбцы добавляются).
+CREATE SCHEMA IF NOT EXISTS resources;
+
+CREATE TABLE IF NOT EXISTS public.users
+(
+    id            SERIAL PRIMARY KEY,
+    login               VARCHAR NOT NULL UNIQUE,
+    password_hash       VARCHAR NOT NULL,
+    password_changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+ALTER TABLE public.users ADD COLUMN IF NOT EXISTS password_changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW();
+
+CREATE TABLE IF NOT EXISTS public.password_history
+(
+    id            SERIAL PRIMARY KEY,
+    user_id       INT NOT NULL REFERENCES public.users (id) ON DELETE CASCADE,
+    password_hash VARCHAR NOT NULL,
+    created_at    TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS password_history_user_idx ON public.password_history (user_id, id);
+
+CREATE TABLE IF NOT EXISTS public.data_keys
+(
+    user_id     INT PRIMARY KEY REFERENCES public.users (id) ON DELETE CASCADE,
+    kek_id      VARCHAR NOT NULL,
+    wrapped_key BYTEA   NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS public.sessions
+(
+    id           VARCHAR PRIMARY KEY,
+    user_id      INT NOT NULL REFERENCES public.users (id) ON DELETE CASCADE,
+    refresh_hash VARCHAR NOT NULL,
+    expires_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at   TIMESTAMP WITH TIME ZONE,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS public.totp
+(
+    user_id        INT PRIMARY KEY REFERENCES public.users (id) ON DELETE CASCADE,
+    secret         VARCHAR NOT NULL DEFAULT '',
+    pending_secret VARCHAR NOT NULL DEFAULT '',
+    last_step      BIGINT NOT NULL DEFAULT 0
+);
+
+CREATE TABLE IF NOT EXISTS public.login_challenges
+(
+    id          VARCHAR PRIMARY KEY,
+    user_id     INT NOT NULL REFERENCES public.users (id) ON DELETE CASCADE,
+    secret_hash VARCHAR NOT NULL,
+    expires_at  TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS public.login_failures
+(
+    key          VARCHAR PRIMARY KEY,
+    failures     INT NOT NULL,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    updated_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS public.security_events
+(
+    id           SERIAL PRIMARY KEY,
+    user_id      INT NOT NULL REFERENCES public.users (id) ON DELETE CASCADE,
+    kind         VARCHAR NOT NULL,
+    peer         VARCHAR NOT NULL DEFAULT '',
+    locked_until TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS security_events_user_idx ON public.security_events (user_id, id);
+
+CREATE TABLE IF NOT EXISTS public.revisions
+(
+    user_id  INT PRIMARY KEY REFERENCES public.users (id) ON DELETE CASCADE,
+    revision BIGINT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS resources.pairs_data
+(
+    id         SERIAL PRIMARY KEY,
+    user_id	   INT REFERENCES public.users (id) ON DELETE CASCADE,
+    login      VARCHAR NOT NULL,
+    password   VARCHAR NOT NULL,
+    metadata   TEXT,
+    revision   BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS resources.bank_data
+(
+    id         SERIAL PRIMARY KEY,
+    user_id	   INT REFERENCES public.users (id) ON DELETE CASCADE,
+	card_holder VARCHAR NOT NULL,
+	number VARCHAR NOT NULL,
+	expiration_date VARCHAR NOT NULL,
+    metadata   TEXT,
+    revision   BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS resources.text_data
+(
+    id         SERIAL PRIMARY KEY,
+    user_id	   INT REFERENCES public.users (id) ON DELETE CASCADE,
+	note       TEXT,
+    metadata   TEXT,
+    revision   BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS resources.binary_data
+(
+    id         SERIAL PRIMARY KEY,
+    user_id	   INT REFERENCES public.users (id) ON DELETE CASCADE,
+    filename   VARCHAR NOT NULL,
+    size       BIGINT NOT NULL,
+    data       BYTEA NOT NULL,
+    metadata   TEXT,
+    revision   BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS resources.otp_data
+(
+    id         SERIAL PRIMARY KEY,
+    user_id	   INT REFERENCES public.users (id) ON DELETE CASCADE,
+    kind       VARCHAR(4) NOT NULL,
+    secret     VARCHAR NOT NULL,
+    algorithm  VARCHAR(6) NOT NULL,
+    digits     INT NOT NULL,
+    period     INT NOT NULL DEFAULT 0,
+    counter    BIGINT NOT NULL DEFAULT 0,
+    issuer     VARCHAR NOT NULL DEFAULT '',
+    metadata   TEXT,
+    revision   BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS resources.tombstones
+(
+    user_id  INT REFERENCES public.users (id) ON DELETE CASCADE,
+    kind     VARCHAR(8) NOT NULL,
+    item_id  INT NOT NULL,
+    revision BIGINT NOT NULL,
+    PRIMARY KEY (kind, item_id)
+);
+
+CREATE INDEX IF NOT EXISTS tombstones_user_revision_idx ON resources.tombstones (user_id, revision);
+
+ALTER TABLE resources.pairs_data ADD COLUMN IF NOT EXISTS revision BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE resources.bank_data ADD COLUMN IF NOT EXISTS revision BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE resources.text_data ADD COLUMN IF NOT EXISTS revision BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE resources.binary_data ADD COLUMN IF NOT EXISTS revision BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE resources.otp_data ADD COLUMN IF NOT EXISTS revision BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS resources.otp_data_user_created_idx;
+DROP INDEX IF EXISTS resources.binary_data_user_created_idx;
+DROP INDEX IF EXISTS resources.text_data_user_created_idx;
+DROP INDEX IF EXISTS resources.bank_data_user_created_idx;
+DROP INDEX IF EXISTS resources.pairs_data_user_created_idx;
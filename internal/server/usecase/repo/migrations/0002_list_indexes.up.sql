@@ -0,0 +1,7 @@
+-- Индексы постраничной выборки списков: фильтр по пользователю и времени создания,
+-- сортировка и курсор по (created_at, id).
+CREATE INDEX IF NOT EXISTS pairs_data_user_created_idx ON resources.pairs_data (user_id, created_at, id);
+CREATE INDEX IF NOT EXISTS bank_data_user_created_idx ON resources.bank_data (user_id, created_at, id);
+CREATE INDEX IF NOT EXISTS text_data_user_created_idx ON resources.text_data (user_id, created_at, id);
+CREATE INDEX IF NOT EXISTS binary_data_user_created_idx ON resources.binary_data (user_id, created_at, id);
+CREATE INDEX IF NOT EXISTS otp_data_user_created_idx ON resources.otp_data (user_id, created_at, id);
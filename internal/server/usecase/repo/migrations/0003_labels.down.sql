@@ -0,0 +1,15 @@
+DROP FUNCTION IF EXISTS resources.labels_valid(INT, INT, INT[]);
+
+ALTER TABLE resources.otp_data DROP COLUMN IF EXISTS tag_ids;
+ALTER TABLE resources.otp_data DROP COLUMN IF EXISTS folder_id;
+ALTER TABLE resources.binary_data DROP COLUMN IF EXISTS tag_ids;
+ALTER TABLE resources.binary_data DROP COLUMN IF EXISTS folder_id;
+ALTER TABLE resources.text_data DROP COLUMN IF EXISTS tag_ids;
+ALTER TABLE resources.text_data DROP COLUMN IF EXISTS folder_id;
+ALTER TABLE resources.bank_data DROP COLUMN IF EXISTS tag_ids;
+ALTER TABLE resources.bank_data DROP COLUMN IF EXISTS folder_id;
+ALTER TABLE resources.pairs_data DROP COLUMN IF EXISTS tag_ids;
+ALTER TABLE resources.pairs_data DROP COLUMN IF EXISTS folder_id;
+
+DROP TABLE IF EXISTS resources.tags;
+DROP TABLE IF EXISTS resources.folders;
@@ -0,0 +1,60 @@
+-- Папки (дерево) и метки записей, общие для всех типов данных.
+-- Названия хранятся в том виде, в котором их прислал клиент, а при включённом шифровании хранимых
+-- данных - зашифрованными сервером (gks:), как и остальные поля записей.
+CREATE TABLE IF NOT EXISTS resources.folders
+(
+    id         SERIAL PRIMARY KEY,
+    user_id    INT NOT NULL REFERENCES public.users (id) ON DELETE CASCADE,
+    parent_id  INT REFERENCES resources.folders (id) ON DELETE CASCADE,
+    name       TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS folders_user_idx ON resources.folders (user_id, id);
+CREATE INDEX IF NOT EXISTS folders_parent_idx ON resources.folders (parent_id);
+
+CREATE TABLE IF NOT EXISTS resources.tags
+(
+    id         SERIAL PRIMARY KEY,
+    user_id    INT NOT NULL REFERENCES public.users (id) ON DELETE CASCADE,
+    name       TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS tags_user_idx ON resources.tags (user_id, id);
+
+-- Папка (0 - запись вне папок) и метки записи. Принадлежность папки и меток владельцу записи
+-- проверяется при её сохранении (resources.labels_valid), при удалении папки или метки записи
+-- переносятся/очищаются с новой ревизией.
+ALTER TABLE resources.pairs_data ADD COLUMN IF NOT EXISTS folder_id INT NOT NULL DEFAULT 0;
+ALTER TABLE resources.pairs_data ADD COLUMN IF NOT EXISTS tag_ids INT[] NOT NULL DEFAULT '{}';
+ALTER TABLE resources.bank_data ADD COLUMN IF NOT EXISTS folder_id INT NOT NULL DEFAULT 0;
+ALTER TABLE resources.bank_data ADD COLUMN IF NOT EXISTS tag_ids INT[] NOT NULL DEFAULT '{}';
+ALTER TABLE resources.text_data ADD COLUMN IF NOT EXISTS folder_id INT NOT NULL DEFAULT 0;
+ALTER TABLE resources.text_data ADD COLUMN IF NOT EXISTS tag_ids INT[] NOT NULL DEFAULT '{}';
+ALTER TABLE resources.binary_data ADD COLUMN IF NOT EXISTS folder_id INT NOT NULL DEFAULT 0;
+ALTER TABLE resources.binary_data ADD COLUMN IF NOT EXISTS tag_ids INT[] NOT NULL DEFAULT '{}';
+ALTER TABLE resources.otp_data ADD COLUMN IF NOT EXISTS folder_id INT NOT NULL DEFAULT 0;
+ALTER TABLE resources.otp_data ADD COLUMN IF NOT EXISTS tag_ids INT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX IF NOT EXISTS pairs_data_folder_idx ON resources.pairs_data (user_id, folder_id);
+CREATE INDEX IF NOT EXISTS bank_data_folder_idx ON resources.bank_data (user_id, folder_id);
+CREATE INDEX IF NOT EXISTS text_data_folder_idx ON resources.text_data (user_id, folder_id);
+CREATE INDEX IF NOT EXISTS binary_data_folder_idx ON resources.binary_data (user_id, folder_id);
+CREATE INDEX IF NOT EXISTS otp_data_folder_idx ON resources.otp_data (user_id, folder_id);
+
+CREATE INDEX IF NOT EXISTS pairs_data_tags_idx ON resources.pairs_data USING GIN (tag_ids);
+CREATE INDEX IF NOT EXISTS bank_data_tags_idx ON resources.bank_data USING GIN (tag_ids);
+CREATE INDEX IF NOT EXISTS text_data_tags_idx ON resources.text_data USING GIN (tag_ids);
+CREATE INDEX IF NOT EXISTS binary_data_tags_idx ON resources.binary_data USING GIN (tag_ids);
+CREATE INDEX IF NOT EXISTS otp_data_tags_idx ON resources.otp_data USING GIN (tag_ids);
+
+-- Папка (0 - вне папок) и метки (без повторов) принадлежат пользователю owner.
+CREATE OR REPLACE FUNCTION resources.labels_valid(owner INT, folder INT, tags INT[]) RETURNS BOOLEAN
+    LANGUAGE sql
+    STABLE
+AS
+$$
+SELECT (folder = 0 OR EXISTS (SELECT 1 FROM resources.folders f WHERE f.id = folder AND f.user_id = owner))
+   AND (SELECT count(*) FROM resources.tags t WHERE t.user_id = owner AND t.id = ANY (tags)) = cardinality(tags);
+$$;
@@ -0,0 +1,162 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	"github.com/PaulYakow/gophkeeper/pkg/postgres"
+)
+
+// listOTPsQuery запросы страницы списка одноразовых паролей для каждого порядка сортировки.
+var listOTPsQuery = listQueries("*", "resources.otp_data")
+
+const (
+	getOTPsByUserID = `
+SELECT * FROM resources.otp_data
+WHERE user_id = $1
+ORDER BY id;
+`
+	createOTP = `
+WITH rev AS (
+    INSERT INTO public.revisions (user_id, revision)
+    SELECT $1, 1 WHERE resources.labels_valid($1, $10, $11)
+    ON CONFLICT (user_id) DO UPDATE SET revision = revisions.revision + 1
+    RETURNING revision
+)
+INSERT INTO resources.otp_data (user_id, kind, secret, algorithm, digits, period, counter, issuer, metadata, folder_id, tag_ids, revision)
+SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, rev.revision FROM rev
+RETURNING id;
+`
+	updateOTP = `
+WITH rev AS (
+    INSERT INTO public.revisions (user_id, revision)
+    SELECT user_id, 1 FROM resources.otp_data
+    WHERE id = $1 AND user_id = $2 AND resources.labels_valid($2, $11, $12)
+    ON CONFLICT (user_id) DO UPDATE SET revision = revisions.revision + 1
+    RETURNING revision
+)
+UPDATE resources.otp_data
+SET kind = $3, secret = $4, algorithm = $5, digits = $6, period = $7, counter = $8, issuer = $9, metadata = $10, folder_id = $11, tag_ids = $12, revision = rev.revision
+FROM rev
+WHERE id = $1 AND user_id = $2;
+`
+	deleteOTP = `
+WITH rev AS (
+    INSERT INTO public.revisions (user_id, revision)
+    SELECT user_id, 1 FROM resources.otp_data WHERE id = $1 AND user_id = $2
+    ON CONFLICT (user_id) DO UPDATE SET revision = revisions.revision + 1
+    RETURNING revision
+), deleted AS (
+    DELETE FROM resources.otp_data
+    WHERE id = $1 AND user_id = $2
+    RETURNING id
+)
+INSERT INTO resources.tombstones (user_id, kind, item_id, revision)
+SELECT $2, 'otp', deleted.id, rev.revision FROM deleted, rev;
+`
+)
+
+// OTPPostgres реализация интерфейса usecase.IOTPRepo
+type OTPPostgres struct {
+	db  *postgres.Postgres
+	env *Envelope
+}
+
+// NewOTPPostgres создаёт объект типа OTPPostgres.
+func NewOTPPostgres(pg *postgres.Postgres, env *Envelope) *OTPPostgres {
+	return &OTPPostgres{pg, env}
+}
+
+// ListOTPs находит в БД страницу списка одноразовых паролей, принадлежащих конкретному пользователю (userID).
+//
+// Страница ограничена фильтром и курсором query (размер, диапазон времени создания, порядок, последняя запись).
+func (p *OTPPostgres) ListOTPs(ctx context.Context, userID int, query entity.ListQuery) ([]entity.OTPDAO, error) {
+	var result []entity.OTPDAO
+
+	ctxInner, cancel := p.db.WithTimeout(ctx)
+	defer cancel()
+
+	err := p.db.SelectContext(ctxInner, &result, listOTPsQuery[query.Order], listArgs(userID, query)...)
+	if err != nil {
+		return nil, fmt.Errorf("repo - list otps: %w", err)
+	}
+
+	if err := openOTPs(ctx, p.env, userID, result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// CreateOTP сохраняет в БД новый одноразовый пароль.
+//
+// Возвращает id созданной записи или ошибку (ErrNotFound, если папка или метка записи не найдена).
+func (p *OTPPostgres) CreateOTP(ctx context.Context, otp entity.OTPDAO) (int, error) {
+	if err := p.env.seal(ctx, otp.UserID, &otp.Secret, &otp.Issuer, &otp.Metadata); err != nil {
+		return 0, err
+	}
+
+	ctxInner, cancel := p.db.WithTimeout(ctx)
+	defer cancel()
+
+	var id int
+	err := p.db.GetContext(ctxInner, &id, createOTP,
+		otp.UserID, otp.Kind, otp.Secret, otp.Algorithm, otp.Digits, otp.Period, otp.Counter, otp.Issuer, otp.Metadata, otp.FolderID, otp.TagIDs)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("repo - create otp: %w", err)
+	}
+
+	return id, nil
+}
+
+// UpdateOTP изменяет в БД одноразовый пароль (поиск по id и user_id).
+//
+// Возвращает ErrNotFound, если не найдена запись, её папка или метка.
+func (p *OTPPostgres) UpdateOTP(ctx context.Context, otp entity.OTPDAO) error {
+	if err := p.env.seal(ctx, otp.UserID, &otp.Secret, &otp.Issuer, &otp.Metadata); err != nil {
+		return err
+	}
+
+	ctxInner, cancel := p.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := p.db.ExecContext(ctxInner, updateOTP, otp.ID, otp.UserID,
+		otp.Kind, otp.Secret, otp.Algorithm, otp.Digits, otp.Period, otp.Counter, otp.Issuer, otp.Metadata, otp.FolderID, otp.TagIDs)
+	if err != nil {
+		return fmt.Errorf("repo - update otp: %w", err)
+	}
+
+	return checkAffected(res)
+}
+
+// DeleteOTP удаляет из БД одноразовый пароль принадлежащий конкретному пользователю (userID).
+//
+// Возвращает ошибку, если запись не найдена.
+func (p *OTPPostgres) DeleteOTP(ctx context.Context, userID, otpID int) error {
+	ctxInner, cancel := p.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := p.db.ExecContext(ctxInner, deleteOTP, otpID, userID)
+	if err != nil {
+		return fmt.Errorf("repo - delete otp: %w", err)
+	}
+
+	return checkAffected(res)
+}
+
+// openOTPs расшифровывает поля одноразовых паролей пользователя (userID).
+func openOTPs(ctx context.Context, env *Envelope, userID int, items []entity.OTPDAO) error {
+	for i := range items {
+		if err := env.open(ctx, userID, &items[i].Secret, &items[i].Issuer, &items[i].Metadata); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -2,41 +2,159 @@ package repo
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
-	"time"
 
 	"github.com/PaulYakow/gophkeeper/internal/entity"
 	"github.com/PaulYakow/gophkeeper/pkg/postgres"
 )
 
+// listPairsQuery запросы страницы списка пар логин/пароль для каждого порядка сортировки.
+var listPairsQuery = listQueries("*", "resources.pairs_data")
+
 const (
 	getPairsByUserID = `
 SELECT * FROM resources.pairs_data
 WHERE user_id = $1
 ORDER BY id;
+`
+	createPair = `
+WITH rev AS (
+    INSERT INTO public.revisions (user_id, revision)
+    SELECT $1, 1 WHERE resources.labels_valid($1, $5, $6)
+    ON CONFLICT (user_id) DO UPDATE SET revision = revisions.revision + 1
+    RETURNING revision
+)
+INSERT INTO resources.pairs_data (user_id, login, password, metadata, folder_id, tag_ids, revision)
+SELECT $1, $2, $3, $4, $5, $6, rev.revision FROM rev
+RETURNING id;
+`
+	updatePair = `
+WITH rev AS (
+    INSERT INTO public.revisions (user_id, revision)
+    SELECT user_id, 1 FROM resources.pairs_data
+    WHERE id = $1 AND user_id = $2 AND resources.labels_valid($2, $6, $7)
+    ON CONFLICT (user_id) DO UPDATE SET revision = revisions.revision + 1
+    RETURNING revision
+)
+UPDATE resources.pairs_data
+SET login = $3, password = $4, metadata = $5, folder_id = $6, tag_ids = $7, revision = rev.revision
+FROM rev
+WHERE id = $1 AND user_id = $2;
+`
+	deletePair = `
+WITH rev AS (
+    INSERT INTO public.revisions (user_id, revision)
+    SELECT user_id, 1 FROM resources.pairs_data WHERE id = $1 AND user_id = $2
+    ON CONFLICT (user_id) DO UPDATE SET revision = revisions.revision + 1
+    RETURNING revision
+), deleted AS (
+    DELETE FROM resources.pairs_data
+    WHERE id = $1 AND user_id = $2
+    RETURNING id
+)
+INSERT INTO resources.tombstones (user_id, kind, item_id, revision)
+SELECT $2, 'pair', deleted.id, rev.revision FROM deleted, rev;
 `
 )
 
 // PairPostgres реализация интерфейса usecase.IPairsRepo
 type PairPostgres struct {
-	db *postgres.Postgres
+	db  *postgres.Postgres
+	env *Envelope
 }
 
 // NewPairPostgres создаёт объект типа PairPostgres.
-func NewPairPostgres(pg *postgres.Postgres) *PairPostgres {
-	return &PairPostgres{pg}
+func NewPairPostgres(pg *postgres.Postgres, env *Envelope) *PairPostgres {
+	return &PairPostgres{pg, env}
 }
 
-// GetAllPairs находит в БД все записи типа логин/пароль принадлежащие конкретному пользователю (userID).
-func (p *PairPostgres) GetAllPairs(ctx context.Context, userID int) ([]entity.PairDAO, error) {
+// ListPairs находит в БД страницу списка записей типа логин/пароль, принадлежащих конкретному пользователю (userID).
+//
+// Страница ограничена фильтром и курсором query (размер, диапазон времени создания, порядок, последняя запись).
+func (p *PairPostgres) ListPairs(ctx context.Context, userID int, query entity.ListQuery) ([]entity.PairDAO, error) {
 	var result []entity.PairDAO
 
-	ctxInner, cancel := context.WithTimeout(ctx, time.Second)
+	ctxInner, cancel := p.db.WithTimeout(ctx)
 	defer cancel()
 
-	if err := p.db.SelectContext(ctxInner, &result, getPairsByUserID, userID); err != nil {
-		return nil, fmt.Errorf("repo - get all pairs by user: %w", err)
+	err := p.db.SelectContext(ctxInner, &result, listPairsQuery[query.Order], listArgs(userID, query)...)
+	if err != nil {
+		return nil, fmt.Errorf("repo - list pairs: %w", err)
+	}
+
+	if err := openPairs(ctx, p.env, userID, result); err != nil {
+		return nil, err
 	}
 
 	return result, nil
 }
+
+// CreatePair сохраняет в БД новую запись типа логин/пароль.
+//
+// Возвращает id созданной записи или ошибку (ErrNotFound, если папка или метка записи не найдена).
+func (p *PairPostgres) CreatePair(ctx context.Context, pair entity.PairDAO) (int, error) {
+	if err := p.env.seal(ctx, pair.UserID, &pair.Login, &pair.Password, &pair.Metadata); err != nil {
+		return 0, err
+	}
+
+	ctxInner, cancel := p.db.WithTimeout(ctx)
+	defer cancel()
+
+	var id int
+	err := p.db.GetContext(ctxInner, &id, createPair, pair.UserID, pair.Login, pair.Password, pair.Metadata, pair.FolderID, pair.TagIDs)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("repo - create pair: %w", err)
+	}
+
+	return id, nil
+}
+
+// UpdatePair изменяет в БД запись типа логин/пароль (поиск по id и user_id).
+//
+// Возвращает ErrNotFound, если не найдена запись, её папка или метка.
+func (p *PairPostgres) UpdatePair(ctx context.Context, pair entity.PairDAO) error {
+	if err := p.env.seal(ctx, pair.UserID, &pair.Login, &pair.Password, &pair.Metadata); err != nil {
+		return err
+	}
+
+	ctxInner, cancel := p.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := p.db.ExecContext(ctxInner, updatePair, pair.ID, pair.UserID, pair.Login, pair.Password, pair.Metadata, pair.FolderID, pair.TagIDs)
+	if err != nil {
+		return fmt.Errorf("repo - update pair: %w", err)
+	}
+
+	return checkAffected(res)
+}
+
+// DeletePair удаляет из БД запись типа логин/пароль принадлежащую конкретному пользователю (userID).
+//
+// Возвращает ошибку, если запись не найдена.
+func (p *PairPostgres) DeletePair(ctx context.Context, userID, pairID int) error {
+	ctxInner, cancel := p.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := p.db.ExecContext(ctxInner, deletePair, pairID, userID)
+	if err != nil {
+		return fmt.Errorf("repo - delete pair: %w", err)
+	}
+
+	return checkAffected(res)
+}
+
+// openPairs расшифровывает поля пар логин/пароль пользователя (userID).
+func openPairs(ctx context.Context, env *Envelope, userID int, items []entity.PairDAO) error {
+	for i := range items {
+		if err := env.open(ctx, userID, &items[i].Login, &items[i].Password, &items[i].Metadata); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -3,56 +3,15 @@ package repo
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"time"
 
+	"github.com/PaulYakow/gophkeeper/internal/entity"
 	"github.com/PaulYakow/gophkeeper/internal/server/usecase"
 	"github.com/PaulYakow/gophkeeper/pkg/postgres"
 )
 
-const (
-	schema = `
-CREATE SCHEMA IF NOT EXISTS resources;
-
-CREATE TABLE IF NOT EXISTS public.users
-(
-    id            SERIAL PRIMARY KEY,
-    login         VARCHAR NOT NULL UNIQUE,
-    password_hash VARCHAR NOT NULL
-);
-
-CREATE TABLE IF NOT EXISTS resources.pairs_data
-(
-    id         SERIAL PRIMARY KEY,
-    user_id	   INT REFERENCES public.users (id) ON DELETE CASCADE,
-    login      VARCHAR NOT NULL,
-    password   VARCHAR NOT NULL,
-    metadata   TEXT,
-    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
-);
-
-CREATE TABLE IF NOT EXISTS resources.bank_data
-(
-    id         SERIAL PRIMARY KEY,
-    user_id	   INT REFERENCES public.users (id) ON DELETE CASCADE,
-	card_holder VARCHAR NOT NULL,
-	number VARCHAR NOT NULL,
-	expiration_date VARCHAR NOT NULL,
-    metadata   TEXT,
-    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
-);
-
-CREATE TABLE IF NOT EXISTS resources.text_data
-(
-    id         SERIAL PRIMARY KEY,
-    user_id	   INT REFERENCES public.users (id) ON DELETE CASCADE,
-	note       TEXT,
-    metadata   TEXT,
-    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
-);
-`
-)
-
 // Repo реализация хранилища. Хранение в БД Postgres (драйвер - sqlx).
 //
 // Содержит реализации необходимых интерфейсов (IAuthorizationRepo, ...).
@@ -62,21 +21,38 @@ type Repo struct {
 	usecase.IPairsRepo
 	usecase.IBankRepo
 	usecase.ITextRepo
+	usecase.IBinaryRepo
+	usecase.IOTPRepo
+	usecase.ISyncRepo
+	usecase.IAccountRepo
+	usecase.ILabelsRepo
 }
 
 // New создаёт объект Repo.
+//
+// Схема БД не изменяется: если применены не все миграции (или БД новее сервера), возвращается ошибка
+// ErrSchemaOutdated - схему обновляет команда migrate (Migrator).
 func New(db *postgres.Postgres,
 	auth usecase.IAuthorizationRepo,
 	pairs usecase.IPairsRepo,
 	cards usecase.IBankRepo,
 	notes usecase.ITextRepo,
+	binaries usecase.IBinaryRepo,
+	otps usecase.IOTPRepo,
+	sync usecase.ISyncRepo,
+	account usecase.IAccountRepo,
+	labels usecase.ILabelsRepo,
 ) (*Repo, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	ctx, cancel := db.WithTimeout(context.Background())
 	defer cancel()
 
-	_, err := db.ExecContext(ctx, schema)
+	migrator, err := NewMigrator(db)
 	if err != nil {
-		return nil, fmt.Errorf("repo - create schema failed: %w", err)
+		return nil, err
+	}
+
+	if err = migrator.Check(ctx); err != nil {
+		return nil, fmt.Errorf("repo - check schema: %w", err)
 	}
 
 	return &Repo{
@@ -85,10 +61,92 @@ func New(db *postgres.Postgres,
 		pairs,
 		cards,
 		notes,
+		binaries,
+		otps,
+		sync,
+		account,
+		labels,
 	}, nil
 }
 
+// Ping - проверка доступности БД (не дольше таймаута запроса).
+func (s *Repo) Ping(ctx context.Context) error {
+	ctx, cancel := s.db.WithTimeout(ctx)
+	defer cancel()
+
+	return s.db.PingContext(ctx)
+}
+
 // CloseConnection - дожидается завершения запросов и закрывает все открытые соединения.
 func (s *Repo) CloseConnection() error {
 	return s.db.Shutdown()
 }
+
+// listQueries формирует запросы страницы списка записей пользователя (columns - выбираемые столбцы
+// таблицы table) для каждого порядка сортировки.
+//
+// Параметры запроса (listArgs): $1 - user_id, $2, $3 - диапазон времени создания (NULL - без ограничения),
+// $4, $5 - время создания и id последней записи предыдущей страницы (NULL - с начала списка),
+// $6 - размер страницы, $7 - папка, $8 - метка (NULL - без отбора).
+// Страницы выбираются по индексу (user_id, created_at, id) без OFFSET.
+func listQueries(columns, table string) map[entity.SortOrder]string {
+	query := func(cmp, dir string) string {
+		return fmt.Sprintf(`
+SELECT %s FROM %s
+WHERE user_id = $1
+  AND ($2::timestamptz IS NULL OR created_at >= $2)
+  AND ($3::timestamptz IS NULL OR created_at < $3)
+  AND ($4::timestamptz IS NULL OR (created_at, id) %s ($4, $5))
+  AND ($7::int IS NULL OR folder_id = $7)
+  AND ($8::int IS NULL OR tag_ids @> ARRAY[$8::int])
+ORDER BY created_at %s, id %s
+LIMIT $6;
+`, columns, table, cmp, dir, dir)
+	}
+
+	return map[entity.SortOrder]string{
+		entity.SortCreatedAsc:  query(">", "ASC"),
+		entity.SortCreatedDesc: query("<", "DESC"),
+	}
+}
+
+// listArgs параметры запроса страницы списка (listQueries).
+func listArgs(userID int, query entity.ListQuery) []interface{} {
+	args := []interface{}{userID, nullTime(query.CreatedFrom), nullTime(query.CreatedTo), nil, 0, query.Limit, nil, nil}
+	if query.After != nil {
+		args[3], args[4] = query.After.CreatedAt, query.After.ID
+	}
+	if query.Filter.FolderID != nil {
+		args[6] = *query.Filter.FolderID
+	}
+	if query.Filter.TagID != 0 {
+		args[7] = query.Filter.TagID
+	}
+
+	return args
+}
+
+// nullTime возвращает NULL для нулевого времени.
+func nullTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+
+	return t
+}
+
+// checkAffected проверяет, что запрос изменил хотя бы одну запись.
+//
+// Возвращает ErrNotFound, если ни одна запись не была затронута.
+func checkAffected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("repo - rows affected: %w", err)
+	}
+
+	if n == 0 {
+		return ErrNotFound
+	}
+
+	return nil
+}
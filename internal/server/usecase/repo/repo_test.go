@@ -5,13 +5,17 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/PaulYakow/gophkeeper/internal/entity"
 	"github.com/PaulYakow/gophkeeper/internal/server/usecase/repo"
+	"github.com/PaulYakow/gophkeeper/internal/utils/encryption"
 	"github.com/PaulYakow/gophkeeper/pkg/postgres"
 )
 
@@ -20,7 +24,22 @@ const (
 DROP TABLE IF EXISTS resources.pairs_data;
 DROP TABLE IF EXISTS resources.bank_data;
 DROP TABLE IF EXISTS resources.text_data;
+DROP TABLE IF EXISTS resources.binary_data;
+DROP TABLE IF EXISTS resources.otp_data;
+DROP TABLE IF EXISTS resources.tombstones;
+DROP TABLE IF EXISTS resources.tags;
+DROP TABLE IF EXISTS resources.folders;
+DROP TABLE IF EXISTS public.revisions;
+DROP TABLE IF EXISTS public.sessions;
+DROP TABLE IF EXISTS public.password_history;
+DROP TABLE IF EXISTS public.totp;
+DROP TABLE IF EXISTS public.login_challenges;
+DROP TABLE IF EXISTS public.security_events;
+DROP TABLE IF EXISTS public.login_failures;
+DROP TABLE IF EXISTS public.data_keys;
 DROP TABLE IF EXISTS public.users;
+DROP TABLE IF EXISTS public.schema_migrations;
+DROP FUNCTION IF EXISTS resources.labels_valid(INT, INT, INT[]);
 `
 	qCreateUser = `
 INSERT INTO public.users (login, password_hash)
@@ -44,12 +63,28 @@ INSERT INTO resources.text_data (user_id, note, metadata)
 VALUES ($1, $2, $3)
 RETURNING id;
 `
+
+	qGetPairLogin = `
+SELECT login FROM resources.pairs_data
+WHERE id = $1;
+`
+
+	qGetKEKIDs = `
+SELECT DISTINCT kek_id FROM public.data_keys;
+`
+
+	// ключи шифрования ключей для тестов шифрования хранимых данных
+	testKEK1 = "k1:MDEyMzQ1Njc4OWFiY2RlZjAxMjM0NTY3ODlhYmNkZWY="
+	testKEK2 = "k2:ZmVkY2JhOTg3NjU0MzIxMGZlZGNiYTk4NzY1NDMyMTA="
 )
 
 var (
 	testDB   *postgres.Postgres
 	testRepo *repo.Repo
 
+	// allItems выборка всех записей пользователя одной страницей
+	allItems = entity.ListQuery{Limit: 1000}
+
 	userDTO = entity.UserDTO{
 		Login:    "userDTO",
 		Password: "pass_hash",
@@ -138,15 +173,34 @@ func TestMain(m *testing.M) {
 	setup()
 	defer testRepo.CloseConnection()
 
-	var err error
 	var code int
 
-	auth := repo.NewAuthPostgres(testDB)
-	pairs := repo.NewPairPostgres(testDB)
-	cards := repo.NewBankPostgres(testDB)
-	notes := repo.NewTextPostgres(testDB)
+	// записи, добавленные в БД напрямую (без шифрования), должны читаться через Envelope как есть
+	keys, err := encryption.NewEnvKeyProvider(testKEK1)
+	if err != nil {
+		log.Fatal(err)
+	}
+	env := repo.NewEnvelope(testDB, keys)
 
-	testRepo, err = repo.New(testDB, auth, pairs, cards, notes)
+	migrator, err := repo.NewMigrator(testDB)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if _, err = migrator.Up(context.Background()); err != nil {
+		log.Println(fmt.Errorf("repo tests - migrate up: %w", err))
+	}
+
+	auth := repo.NewAuthPostgres(testDB, env)
+	pairs := repo.NewPairPostgres(testDB, env)
+	cards := repo.NewBankPostgres(testDB, env)
+	notes := repo.NewTextPostgres(testDB, env)
+	binaries := repo.NewBinaryPostgres(testDB, env)
+	otps := repo.NewOTPPostgres(testDB, env)
+	sync := repo.NewSyncPostgres(testDB, env)
+	account := repo.NewAccountPostgres(testDB, env, 10*time.Second)
+	labels := repo.NewLabelsPostgres(testDB, env)
+
+	testRepo, err = repo.New(testDB, auth, pairs, cards, notes, binaries, otps, sync, account, labels)
 	if err != nil {
 		log.Println(fmt.Errorf("repo tests - repo.New: %w", err))
 	}
@@ -195,24 +249,55 @@ func TestMain(m *testing.M) {
 	os.Exit(code)
 }
 
+func TestMigrations(t *testing.T) {
+	ctx := context.Background()
+	migrator, err := repo.NewMigrator(testDB)
+	require.NoError(t, err)
+
+	t.Run("all applied", func(t *testing.T) {
+		status, err := migrator.Status(ctx)
+		require.NoError(t, err)
+		require.NotEmpty(t, status)
+		for _, s := range status {
+			assert.NotEmpty(t, s.Name)
+			assert.NotNil(t, s.AppliedAt, "migration %d", s.Version)
+		}
+		require.NoError(t, migrator.Check(ctx))
+	})
+
+	t.Run("up is idempotent", func(t *testing.T) {
+		applied, err := migrator.Up(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, applied)
+	})
+
+	t.Run("unknown version", func(t *testing.T) {
+		_, err := testDB.Exec(`INSERT INTO public.schema_migrations (version, name) VALUES (99999, 'future');`)
+		require.NoError(t, err)
+		defer testDB.Exec(`DELETE FROM public.schema_migrations WHERE version = 99999;`)
+
+		require.ErrorIs(t, migrator.Check(ctx), repo.ErrSchemaOutdated)
+	})
+}
+
 func TestAuthorization_CreateUser(t *testing.T) {
 	t.Run("create new user", func(t *testing.T) {
-		userID, err := testRepo.CreateUser("new_user", userDTO.Password)
+		userID, err := testRepo.CreateUser(context.Background(), "new_user", userDTO.Password)
 		require.NoError(t, err)
 		assert.NotEmpty(t, userID)
 		assert.Greater(t, userID, 0)
 	})
 
 	t.Run("duplicate user", func(t *testing.T) {
-		userID, err := testRepo.CreateUser(userDTO.Login, userDTO.Password)
-		require.Error(t, err)
+		userID, err := testRepo.CreateUser(context.Background(), userDTO.Login, userDTO.Password)
+		require.ErrorIs(t, err, repo.ErrUserExist)
 		assert.Empty(t, userID)
 	})
 }
 
 func TestAuthorization_GetUser(t *testing.T) {
 	t.Run("get exist user", func(t *testing.T) {
-		user, err := testRepo.GetUser(userDTO.Login)
+		user, err := testRepo.GetUser(context.Background(), userDTO.Login)
 		require.NoError(t, err)
 		require.IsType(t, entity.UserDAO{}, user)
 		assert.Equal(t, userDTO.Login, user.Login)
@@ -224,15 +309,250 @@ func TestAuthorization_GetUser(t *testing.T) {
 			Login:    "userNotExist",
 			Password: "no_pass",
 		}
-		user, err := testRepo.GetUser(notExistUser.Login)
+		user, err := testRepo.GetUser(context.Background(), notExistUser.Login)
 		require.Error(t, err)
 		require.Empty(t, user)
 	})
+
+	t.Run("canceled request", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := testRepo.GetUser(ctx, userDTO.Login)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+}
+
+func TestAuthorization_ChangePassword(t *testing.T) {
+	userID, err := testRepo.CreateUser(context.Background(), "change_password_user", "hash_1")
+	require.NoError(t, err)
+
+	session := entity.SessionDAO{
+		ID:          "change_password_session",
+		UserID:      userID,
+		RefreshHash: "refresh_hash",
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+	require.NoError(t, testRepo.CreateSession(context.Background(), session))
+
+	t.Run("change password", func(t *testing.T) {
+		require.NoError(t, testRepo.ChangePassword(context.Background(), userID, "hash_1", "hash_2"))
+
+		user, err := testRepo.GetUser(context.Background(), "change_password_user")
+		require.NoError(t, err)
+		assert.Equal(t, "hash_2", user.PasswordHash)
+
+		history, err := testRepo.GetPasswordHistory(context.Background(), userID, 5)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"hash_1"}, history)
+
+		// сессии пользователя отозваны
+		got, err := testRepo.GetSession(context.Background(), session.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, got.RevokedAt)
+	})
+
+	t.Run("outdated current hash", func(t *testing.T) {
+		err := testRepo.ChangePassword(context.Background(), userID, "hash_1", "hash_3")
+		require.ErrorIs(t, err, repo.ErrNotFound)
+	})
+
+	t.Run("history from newest", func(t *testing.T) {
+		require.NoError(t, testRepo.ChangePassword(context.Background(), userID, "hash_2", "hash_3"))
+
+		history, err := testRepo.GetPasswordHistory(context.Background(), userID, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"hash_2"}, history)
+	})
+
+	t.Run("rehash keeps history", func(t *testing.T) {
+		require.NoError(t, testRepo.UpdatePasswordHash(context.Background(), userID, "hash_3", "rehash_3"))
+
+		user, err := testRepo.GetUser(context.Background(), "change_password_user")
+		require.NoError(t, err)
+		assert.Equal(t, "rehash_3", user.PasswordHash)
+
+		history, err := testRepo.GetPasswordHistory(context.Background(), userID, 5)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"hash_2", "hash_1"}, history)
+
+		err = testRepo.UpdatePasswordHash(context.Background(), userID, "hash_3", "rehash_4")
+		require.ErrorIs(t, err, repo.ErrNotFound)
+	})
+}
+
+func TestAuthorization_LoginFailures(t *testing.T) {
+	ctx := context.Background()
+	loginKey, peerKey := "login:failures_user", "peer:10.0.0.1"
+
+	t.Run("no lock", func(t *testing.T) {
+		lockedUntil, err := testRepo.GetLoginLock(ctx, loginKey, peerKey)
+		require.NoError(t, err)
+		assert.True(t, lockedUntil.Before(time.Now()))
+	})
+
+	t.Run("count failures", func(t *testing.T) {
+		for i := 1; i <= 3; i++ {
+			failures, err := testRepo.AddLoginFailure(ctx, loginKey, time.Hour)
+			require.NoError(t, err)
+			assert.Equal(t, i, failures)
+		}
+	})
+
+	t.Run("lock", func(t *testing.T) {
+		until := time.Now().Add(time.Minute)
+		require.NoError(t, testRepo.LockLogin(ctx, loginKey, until))
+
+		lockedUntil, err := testRepo.GetLoginLock(ctx, loginKey, peerKey)
+		require.NoError(t, err)
+		assert.WithinDuration(t, until, lockedUntil, time.Millisecond)
+	})
+
+	t.Run("count restarts after window", func(t *testing.T) {
+		time.Sleep(10 * time.Millisecond)
+		failures, err := testRepo.AddLoginFailure(ctx, loginKey, time.Millisecond)
+		require.NoError(t, err)
+		assert.Equal(t, 1, failures)
+	})
+
+	t.Run("reset", func(t *testing.T) {
+		require.NoError(t, testRepo.ResetLoginFailures(ctx, loginKey))
+
+		lockedUntil, err := testRepo.GetLoginLock(ctx, loginKey)
+		require.NoError(t, err)
+		assert.True(t, lockedUntil.Before(time.Now()))
+	})
+
+	t.Run("security events", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			require.NoError(t, testRepo.CreateSecurityEvent(ctx, entity.SecurityEventDAO{
+				UserID:      userDAO.ID,
+				Kind:        entity.SecurityEventLoginLocked,
+				Peer:        "10.0.0.1",
+				LockedUntil: time.Now().Add(time.Duration(i+1) * time.Minute),
+			}))
+		}
+
+		events, err := testRepo.GetSecurityEvents(ctx, userDAO.ID, 1)
+		require.NoError(t, err)
+		require.Len(t, events, 1)
+		assert.Equal(t, entity.SecurityEventLoginLocked, events[0].Kind)
+		assert.Equal(t, "10.0.0.1", events[0].Peer)
+	})
+}
+
+func TestAuthorization_SecondFactor(t *testing.T) {
+	ctx := context.Background()
+	secret, newSecret := "JBSWY3DPEHPK3PXP", "KRSXG5CTMVRXEZLU"
+
+	t.Run("not enrolled", func(t *testing.T) {
+		totp, err := testRepo.GetTOTP(ctx, userDAO.ID)
+		require.NoError(t, err)
+		assert.Empty(t, totp.Secret)
+		assert.Empty(t, totp.PendingSecret)
+		require.ErrorIs(t, testRepo.ConfirmTOTP(ctx, userDAO.ID, 1), repo.ErrNotFound)
+	})
+
+	t.Run("enroll and confirm", func(t *testing.T) {
+		require.NoError(t, testRepo.SaveTOTPPending(ctx, userDAO.ID, secret))
+		require.NoError(t, testRepo.ConfirmTOTP(ctx, userDAO.ID, 10))
+
+		totp, err := testRepo.GetTOTP(ctx, userDAO.ID)
+		require.NoError(t, err)
+		assert.Equal(t, secret, totp.Secret)
+		assert.Empty(t, totp.PendingSecret)
+		assert.Equal(t, int64(10), totp.LastStep)
+	})
+
+	t.Run("used step rejected", func(t *testing.T) {
+		require.ErrorIs(t, testRepo.UseTOTPStep(ctx, userDAO.ID, 10), repo.ErrNotFound)
+		require.NoError(t, testRepo.UseTOTPStep(ctx, userDAO.ID, 11))
+	})
+
+	t.Run("re-enroll keeps active secret", func(t *testing.T) {
+		require.NoError(t, testRepo.SaveTOTPPending(ctx, userDAO.ID, newSecret))
+
+		totp, err := testRepo.GetTOTP(ctx, userDAO.ID)
+		require.NoError(t, err)
+		assert.Equal(t, secret, totp.Secret)
+		assert.Equal(t, newSecret, totp.PendingSecret)
+	})
+
+	t.Run("challenge", func(t *testing.T) {
+		challenge := entity.ChallengeDAO{
+			ID:         "challenge_id",
+			UserID:     userDAO.ID,
+			SecretHash: "secret_hash",
+			ExpiresAt:  time.Now().Add(time.Minute),
+		}
+		require.NoError(t, testRepo.CreateChallenge(ctx, challenge))
+
+		got, err := testRepo.GetChallenge(ctx, challenge.ID)
+		require.NoError(t, err)
+		assert.Equal(t, userDAO.ID, got.UserID)
+		assert.Equal(t, userDAO.Login, got.Login)
+		assert.Equal(t, challenge.SecretHash, got.SecretHash)
+
+		require.NoError(t, testRepo.DeleteChallenge(ctx, challenge.ID))
+		require.ErrorIs(t, testRepo.DeleteChallenge(ctx, challenge.ID), repo.ErrNotFound)
+	})
+
+	t.Run("disable", func(t *testing.T) {
+		require.NoError(t, testRepo.DeleteTOTP(ctx, userDAO.ID))
+
+		totp, err := testRepo.GetTOTP(ctx, userDAO.ID)
+		require.NoError(t, err)
+		assert.Empty(t, totp.Secret)
+	})
+}
+
+func TestAuthorization_Sessions(t *testing.T) {
+	session := entity.SessionDAO{
+		ID:          "test_session",
+		UserID:      userDAO.ID,
+		RefreshHash: "hash_1",
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}
+
+	t.Run("create and get session", func(t *testing.T) {
+		require.NoError(t, testRepo.CreateSession(context.Background(), session))
+
+		got, err := testRepo.GetSession(context.Background(), session.ID)
+		require.NoError(t, err)
+		assert.Equal(t, session.UserID, got.UserID)
+		assert.Equal(t, session.RefreshHash, got.RefreshHash)
+		assert.Nil(t, got.RevokedAt)
+	})
+
+	t.Run("get not exist session", func(t *testing.T) {
+		_, err := testRepo.GetSession(context.Background(), "not_exist")
+		require.ErrorIs(t, err, repo.ErrNotFound)
+	})
+
+	t.Run("rotate session", func(t *testing.T) {
+		err := testRepo.RotateSession(context.Background(), session.ID, "hash_1", "hash_2", time.Now().Add(time.Hour))
+		require.NoError(t, err)
+
+		// старый хэш уже заменён
+		err = testRepo.RotateSession(context.Background(), session.ID, "hash_1", "hash_3", time.Now().Add(time.Hour))
+		require.ErrorIs(t, err, repo.ErrNotFound)
+	})
+
+	t.Run("revoked session can't be rotated", func(t *testing.T) {
+		require.NoError(t, testRepo.RevokeSession(context.Background(), session.ID))
+
+		got, err := testRepo.GetSession(context.Background(), session.ID)
+		require.NoError(t, err)
+		assert.NotNil(t, got.RevokedAt)
+
+		err = testRepo.RotateSession(context.Background(), session.ID, "hash_2", "hash_3", time.Now().Add(time.Hour))
+		require.ErrorIs(t, err, repo.ErrNotFound)
+	})
 }
 
 func TestPairs_GetAll(t *testing.T) {
 	t.Run("get exist pairs", func(t *testing.T) {
-		pairs, err := testRepo.GetAllPairs(context.Background(), userDAO.ID)
+		pairs, err := testRepo.ListPairs(context.Background(), userDAO.ID, allItems)
 		require.NoError(t, err)
 		require.IsType(t, []entity.PairDAO{}, pairs)
 		require.NotEmpty(t, pairs)
@@ -245,7 +565,7 @@ func TestPairs_GetAll(t *testing.T) {
 	})
 
 	t.Run("get not exist pairs (user_id not exist)", func(t *testing.T) {
-		pairs, err := testRepo.GetAllPairs(context.Background(), 777)
+		pairs, err := testRepo.ListPairs(context.Background(), 777, allItems)
 		require.NoError(t, err)
 		require.Empty(t, pairs)
 	})
@@ -253,7 +573,7 @@ func TestPairs_GetAll(t *testing.T) {
 
 func TestCards_GetAll(t *testing.T) {
 	t.Run("get exist cards", func(t *testing.T) {
-		cards, err := testRepo.GetAllCards(context.Background(), userDAO.ID)
+		cards, err := testRepo.ListCards(context.Background(), userDAO.ID, allItems)
 		require.NoError(t, err)
 		require.IsType(t, []entity.BankDAO{}, cards)
 		require.NotEmpty(t, cards)
@@ -267,7 +587,7 @@ func TestCards_GetAll(t *testing.T) {
 	})
 
 	t.Run("get not exist cards (user_id not exist)", func(t *testing.T) {
-		cards, err := testRepo.GetAllCards(context.Background(), 777)
+		cards, err := testRepo.ListCards(context.Background(), 777, allItems)
 		require.NoError(t, err)
 		require.Empty(t, cards)
 	})
@@ -275,7 +595,7 @@ func TestCards_GetAll(t *testing.T) {
 
 func TestNotes_GetAll(t *testing.T) {
 	t.Run("get exist notes", func(t *testing.T) {
-		notes, err := testRepo.GetAllNotes(context.Background(), userDAO.ID)
+		notes, err := testRepo.ListNotes(context.Background(), userDAO.ID, allItems)
 		require.NoError(t, err)
 		require.IsType(t, []entity.TextDAO{}, notes)
 		require.NotEmpty(t, notes)
@@ -287,8 +607,572 @@ func TestNotes_GetAll(t *testing.T) {
 	})
 
 	t.Run("get not exist notes (user_id not exist)", func(t *testing.T) {
-		notes, err := testRepo.GetAllCards(context.Background(), 777)
+		notes, err := testRepo.ListCards(context.Background(), 777, allItems)
 		require.NoError(t, err)
 		require.Empty(t, notes)
 	})
 }
+
+func TestPairs_Page(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("limit and cursor", func(t *testing.T) {
+		page, err := testRepo.ListPairs(ctx, userDAO.ID, entity.ListQuery{Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		require.Equal(t, testPairs[0].ID, page[0].ID)
+
+		after := entity.Cursor{CreatedAt: page[0].CreatedAt, ID: page[0].ID}
+		page, err = testRepo.ListPairs(ctx, userDAO.ID, entity.ListQuery{Limit: 1, After: &after})
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		require.Equal(t, testPairs[1].ID, page[0].ID)
+	})
+
+	t.Run("descending order", func(t *testing.T) {
+		page, err := testRepo.ListPairs(ctx, userDAO.ID, entity.ListQuery{Limit: 1, Order: entity.SortCreatedDesc})
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		require.Equal(t, testPairs[len(testPairs)-1].ID, page[0].ID)
+	})
+
+	t.Run("creation time range", func(t *testing.T) {
+		all, err := testRepo.ListPairs(ctx, userDAO.ID, allItems)
+		require.NoError(t, err)
+
+		page, err := testRepo.ListPairs(ctx, userDAO.ID, entity.ListQuery{
+			Limit:     10,
+			CreatedTo: all[0].CreatedAt,
+		})
+		require.NoError(t, err)
+		require.Empty(t, page)
+
+		page, err = testRepo.ListPairs(ctx, userDAO.ID, entity.ListQuery{
+			Limit:       10,
+			CreatedFrom: all[0].CreatedAt,
+		})
+		require.NoError(t, err)
+		require.Len(t, page, len(all))
+	})
+}
+
+func TestPairs_Modify(t *testing.T) {
+	pair := entity.PairDAO{
+		UserID:   userDAO.ID,
+		Login:    "pairModify",
+		Password: "pairPass",
+		Metadata: "tag #1: modify;",
+	}
+
+	t.Run("create pair", func(t *testing.T) {
+		id, err := testRepo.CreatePair(context.Background(), pair)
+		require.NoError(t, err)
+		assert.Greater(t, id, 0)
+		pair.ID = id
+	})
+
+	t.Run("update pair", func(t *testing.T) {
+		pair.Password = "newPass"
+		err := testRepo.UpdatePair(context.Background(), pair)
+		require.NoError(t, err)
+
+		pairs, err := testRepo.ListPairs(context.Background(), userDAO.ID, allItems)
+		require.NoError(t, err)
+		require.Equal(t, pair.Password, pairs[len(pairs)-1].Password)
+	})
+
+	t.Run("update pair of another user", func(t *testing.T) {
+		alien := pair
+		alien.UserID = 777
+		err := testRepo.UpdatePair(context.Background(), alien)
+		require.ErrorIs(t, err, repo.ErrNotFound)
+	})
+
+	t.Run("delete pair of another user", func(t *testing.T) {
+		err := testRepo.DeletePair(context.Background(), 777, pair.ID)
+		require.ErrorIs(t, err, repo.ErrNotFound)
+	})
+
+	t.Run("delete pair", func(t *testing.T) {
+		err := testRepo.DeletePair(context.Background(), userDAO.ID, pair.ID)
+		require.NoError(t, err)
+
+		err = testRepo.DeletePair(context.Background(), userDAO.ID, pair.ID)
+		require.ErrorIs(t, err, repo.ErrNotFound)
+	})
+}
+
+func TestCards_Modify(t *testing.T) {
+	card := entity.BankDAO{
+		UserID:         userDAO.ID,
+		CardHolder:     "Sidorov Sidr",
+		Number:         "1111 2222 3333 4444",
+		ExpirationDate: "12/30",
+		Metadata:       "tag #1: modify;",
+	}
+
+	t.Run("create card", func(t *testing.T) {
+		id, err := testRepo.CreateCard(context.Background(), card)
+		require.NoError(t, err)
+		assert.Greater(t, id, 0)
+		card.ID = id
+	})
+
+	t.Run("update card", func(t *testing.T) {
+		card.ExpirationDate = "01/31"
+		err := testRepo.UpdateCard(context.Background(), card)
+		require.NoError(t, err)
+	})
+
+	t.Run("delete card of another user", func(t *testing.T) {
+		err := testRepo.DeleteCard(context.Background(), 777, card.ID)
+		require.ErrorIs(t, err, repo.ErrNotFound)
+	})
+
+	t.Run("delete card", func(t *testing.T) {
+		err := testRepo.DeleteCard(context.Background(), userDAO.ID, card.ID)
+		require.NoError(t, err)
+	})
+}
+
+func TestNotes_Modify(t *testing.T) {
+	note := entity.TextDAO{
+		UserID:   userDAO.ID,
+		Note:     "note to modify",
+		Metadata: "tag #1: modify;",
+	}
+
+	t.Run("create note", func(t *testing.T) {
+		id, err := testRepo.CreateNote(context.Background(), note)
+		require.NoError(t, err)
+		assert.Greater(t, id, 0)
+		note.ID = id
+	})
+
+	t.Run("update note of another user", func(t *testing.T) {
+		alien := note
+		alien.UserID = 777
+		err := testRepo.UpdateNote(context.Background(), alien)
+		require.ErrorIs(t, err, repo.ErrNotFound)
+	})
+
+	t.Run("delete note", func(t *testing.T) {
+		err := testRepo.DeleteNote(context.Background(), userDAO.ID, note.ID)
+		require.NoError(t, err)
+	})
+}
+
+func TestBinaries(t *testing.T) {
+	binary := entity.BinaryDAO{
+		UserID:   userDAO.ID,
+		Filename: "test.bin",
+		Size:     4,
+		Data:     []byte{0xDE, 0xAD, 0xBE, 0xEF},
+		Metadata: "tag #1: binary;",
+	}
+
+	t.Run("create binary", func(t *testing.T) {
+		id, err := testRepo.CreateBinary(context.Background(), binary)
+		require.NoError(t, err)
+		assert.Greater(t, id, 0)
+		binary.ID = id
+	})
+
+	t.Run("get all binaries without data", func(t *testing.T) {
+		binaries, err := testRepo.ListBinaries(context.Background(), userDAO.ID, allItems)
+		require.NoError(t, err)
+		require.Len(t, binaries, 1)
+		require.Equal(t, binary.Filename, binaries[0].Filename)
+		require.Equal(t, binary.Size, binaries[0].Size)
+		require.Empty(t, binaries[0].Data)
+	})
+
+	t.Run("get binary with data", func(t *testing.T) {
+		got, err := testRepo.GetBinary(context.Background(), userDAO.ID, binary.ID)
+		require.NoError(t, err)
+		require.Equal(t, binary.Data, got.Data)
+	})
+
+	t.Run("get binary of another user", func(t *testing.T) {
+		_, err := testRepo.GetBinary(context.Background(), 777, binary.ID)
+		require.ErrorIs(t, err, repo.ErrNotFound)
+	})
+
+	t.Run("delete binary", func(t *testing.T) {
+		err := testRepo.DeleteBinary(context.Background(), userDAO.ID, binary.ID)
+		require.NoError(t, err)
+	})
+}
+
+func TestOTPs(t *testing.T) {
+	item := entity.OTPDAO{
+		UserID:    userDAO.ID,
+		Kind:      "totp",
+		Secret:    "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ",
+		Algorithm: "SHA1",
+		Digits:    6,
+		Period:    30,
+		Issuer:    "example.com",
+		Metadata:  "tag #1: otp;",
+	}
+
+	t.Run("create otp", func(t *testing.T) {
+		id, err := testRepo.CreateOTP(context.Background(), item)
+		require.NoError(t, err)
+		assert.Greater(t, id, 0)
+		item.ID = id
+	})
+
+	t.Run("update otp", func(t *testing.T) {
+		item.Issuer = "example.org"
+		err := testRepo.UpdateOTP(context.Background(), item)
+		require.NoError(t, err)
+
+		otps, err := testRepo.ListOTPs(context.Background(), userDAO.ID, allItems)
+		require.NoError(t, err)
+		require.Len(t, otps, 1)
+		require.Equal(t, item.Issuer, otps[0].Issuer)
+		require.Equal(t, item.Secret, otps[0].Secret)
+	})
+
+	t.Run("update otp of another user", func(t *testing.T) {
+		other := item
+		other.UserID = 777
+		err := testRepo.UpdateOTP(context.Background(), other)
+		require.ErrorIs(t, err, repo.ErrNotFound)
+	})
+
+	t.Run("delete otp", func(t *testing.T) {
+		err := testRepo.DeleteOTP(context.Background(), userDAO.ID, item.ID)
+		require.NoError(t, err)
+	})
+}
+
+func TestEnvelope(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys")
+	require.NoError(t, os.WriteFile(path, []byte(testKEK1+"\n"), 0o600))
+
+	keys, err := encryption.NewFileKeyProvider(path)
+	require.NoError(t, err)
+
+	env := repo.NewEnvelope(testDB, keys)
+	pairs := repo.NewPairPostgres(testDB, env)
+
+	pair := entity.PairDAO{
+		UserID:   userDAO.ID,
+		Login:    "envelopeLogin",
+		Password: "envelopePass",
+		Metadata: "tag #1: envelope;",
+	}
+
+	t.Run("stored encrypted", func(t *testing.T) {
+		pair.ID, err = pairs.CreatePair(context.Background(), pair)
+		require.NoError(t, err)
+
+		var stored string
+		require.NoError(t, testDB.Get(&stored, qGetPairLogin, pair.ID))
+		require.True(t, strings.HasPrefix(stored, encryption.RestPrefix))
+		require.NotContains(t, stored, pair.Login)
+	})
+
+	t.Run("rotate KEK", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte(testKEK1+"\n"+testKEK2+"\n"), 0o600))
+		require.NoError(t, keys.Reload())
+
+		n, err := env.RotateKEK(context.Background())
+		require.NoError(t, err)
+		require.Greater(t, n, 0)
+
+		var ids []string
+		require.NoError(t, testDB.Select(&ids, qGetKEKIDs))
+		require.Equal(t, []string{"k2"}, ids)
+
+		n, err = env.RotateKEK(context.Background())
+		require.NoError(t, err)
+		require.Zero(t, n)
+	})
+
+	t.Run("read after rotation", func(t *testing.T) {
+		// новый Envelope без кеша ключей данных разворачивает их новым KEK
+		fresh := repo.NewPairPostgres(testDB, repo.NewEnvelope(testDB, keys))
+
+		result, err := fresh.ListPairs(context.Background(), userDAO.ID, allItems)
+		require.NoError(t, err)
+
+		var found bool
+		for _, item := range result {
+			if item.ID == pair.ID {
+				found = true
+				require.Equal(t, pair.Login, item.Login)
+				require.Equal(t, pair.Password, item.Password)
+				require.Equal(t, pair.Metadata, item.Metadata)
+			}
+		}
+		require.True(t, found)
+	})
+
+	t.Run("old KEK removed", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte(testKEK2+"\n"), 0o600))
+		require.NoError(t, keys.Reload())
+
+		fresh := repo.NewPairPostgres(testDB, repo.NewEnvelope(testDB, keys))
+		_, err := fresh.ListPairs(context.Background(), userDAO.ID, allItems)
+		require.NoError(t, err)
+
+		require.NoError(t, fresh.DeletePair(context.Background(), userDAO.ID, pair.ID))
+	})
+
+	t.Run("unwrapped data key rejected", func(t *testing.T) {
+		userID, err := testRepo.CreateUser(context.Background(), "raw_key_user", "raw_key_hash")
+		require.NoError(t, err)
+
+		// ключ данных в открытом виде вместо обёрнутого KEK
+		_, err = testDB.Exec(`INSERT INTO public.data_keys (user_id, kek_id, wrapped_key) VALUES ($1, 'k2', $2)`,
+			userID, []byte(strings.Repeat("r", 32)))
+		require.NoError(t, err)
+
+		fresh := repo.NewPairPostgres(testDB, repo.NewEnvelope(testDB, keys))
+		_, err = fresh.CreatePair(context.Background(), entity.PairDAO{UserID: userID, Login: "raw"})
+		require.ErrorIs(t, err, repo.ErrInvalidDataKey)
+
+		require.NoError(t, testRepo.DeleteAccount(context.Background(), userID, "raw_key_hash"))
+	})
+}
+
+func TestSync(t *testing.T) {
+	start, err := testRepo.GetChanges(context.Background(), userDAO.ID, 0)
+	require.NoError(t, err)
+
+	note := entity.TextDAO{
+		UserID:   userDAO.ID,
+		Note:     "sync note",
+		Metadata: "tag #1: sync;",
+	}
+
+	t.Run("created record has new revision", func(t *testing.T) {
+		id, err := testRepo.CreateNote(context.Background(), note)
+		require.NoError(t, err)
+		note.ID = id
+
+		changes, err := testRepo.GetChanges(context.Background(), userDAO.ID, start.Revision)
+		require.NoError(t, err)
+		assert.Greater(t, changes.Revision, start.Revision)
+		require.Len(t, changes.Notes, 1)
+		assert.Equal(t, note.Note, changes.Notes[0].Note)
+		assert.Equal(t, changes.Revision, changes.Notes[0].Revision)
+		assert.Empty(t, changes.Pairs)
+		assert.Empty(t, changes.Deleted)
+	})
+
+	t.Run("no changes after current revision", func(t *testing.T) {
+		current, err := testRepo.GetChanges(context.Background(), userDAO.ID, 0)
+		require.NoError(t, err)
+
+		changes, err := testRepo.GetChanges(context.Background(), userDAO.ID, current.Revision)
+		require.NoError(t, err)
+		assert.Equal(t, current.Revision, changes.Revision)
+		assert.Empty(t, changes.Notes)
+		assert.Empty(t, changes.Deleted)
+	})
+
+	t.Run("update and delete produce increasing revisions", func(t *testing.T) {
+		before, err := testRepo.GetChanges(context.Background(), userDAO.ID, 0)
+		require.NoError(t, err)
+
+		note.Note = "changed sync note"
+		require.NoError(t, testRepo.UpdateNote(context.Background(), note))
+
+		changes, err := testRepo.GetChanges(context.Background(), userDAO.ID, before.Revision)
+		require.NoError(t, err)
+		require.Len(t, changes.Notes, 1)
+		assert.Equal(t, note.Note, changes.Notes[0].Note)
+
+		require.NoError(t, testRepo.DeleteNote(context.Background(), userDAO.ID, note.ID))
+
+		deleted, err := testRepo.GetChanges(context.Background(), userDAO.ID, changes.Revision)
+		require.NoError(t, err)
+		assert.Greater(t, deleted.Revision, changes.Revision)
+		assert.Empty(t, deleted.Notes)
+		require.Len(t, deleted.Deleted, 1)
+		assert.Equal(t, entity.NoteKind, deleted.Deleted[0].Kind)
+		assert.Equal(t, note.ID, deleted.Deleted[0].ID)
+	})
+
+	t.Run("revisions of other users are separate", func(t *testing.T) {
+		changes, err := testRepo.GetChanges(context.Background(), 777, 0)
+		require.NoError(t, err)
+		assert.Zero(t, changes.Revision)
+		assert.Empty(t, changes.Notes)
+	})
+}
+
+func TestAccount(t *testing.T) {
+	userID, err := testRepo.CreateUser(context.Background(), "account_user", "account_hash")
+	require.NoError(t, err)
+
+	note := entity.TextDAO{UserID: userID, Note: "account note", Metadata: "tag #1: account;"}
+	_, err = testRepo.CreateNote(context.Background(), note)
+	require.NoError(t, err)
+
+	binary := entity.BinaryDAO{UserID: userID, Filename: "account.bin", Size: 3, Data: []byte{1, 2, 3}}
+	_, err = testRepo.CreateBinary(context.Background(), binary)
+	require.NoError(t, err)
+
+	t.Run("export account", func(t *testing.T) {
+		account, err := testRepo.ExportAccount(context.Background(), userID)
+		require.NoError(t, err)
+		assert.Equal(t, "account_user", account.User.Login)
+		require.Len(t, account.Notes, 1)
+		assert.Equal(t, note.Note, account.Notes[0].Note)
+		require.Len(t, account.Binaries, 1)
+		assert.Equal(t, binary.Data, account.Binaries[0].Data)
+		assert.Empty(t, account.Pairs)
+	})
+
+	t.Run("delete with outdated password hash", func(t *testing.T) {
+		err := testRepo.DeleteAccount(context.Background(), userID, "wrong_hash")
+		require.ErrorIs(t, err, repo.ErrNotFound)
+	})
+
+	t.Run("delete account with all data", func(t *testing.T) {
+		require.NoError(t, testRepo.DeleteAccount(context.Background(), userID, "account_hash"))
+
+		_, err := testRepo.GetUserByID(context.Background(), userID)
+		require.ErrorIs(t, err, repo.ErrNotFound)
+
+		notes, err := testRepo.ListNotes(context.Background(), userID, allItems)
+		require.NoError(t, err)
+		assert.Empty(t, notes)
+	})
+}
+
+func TestLabels(t *testing.T) {
+	ctx := context.Background()
+	userID, err := testRepo.CreateUser(ctx, "labels_user", "labels_hash")
+	require.NoError(t, err)
+
+	workID, err := testRepo.CreateFolder(ctx, entity.FolderDAO{UserID: userID, Name: "work"})
+	require.NoError(t, err)
+	projectID, err := testRepo.CreateFolder(ctx, entity.FolderDAO{UserID: userID, ParentID: workID, Name: "project"})
+	require.NoError(t, err)
+	tagID, err := testRepo.CreateTag(ctx, entity.TagDAO{UserID: userID, Name: "vpn"})
+	require.NoError(t, err)
+
+	pair := entity.PairDAO{UserID: userID, Login: "labels", Password: "pass",
+		Labels: entity.Labels{FolderID: projectID, TagIDs: entity.IDs{tagID}}}
+	pair.ID, err = testRepo.CreatePair(ctx, pair)
+	require.NoError(t, err)
+
+	t.Run("labels of another user", func(t *testing.T) {
+		_, err := testRepo.CreatePair(ctx, entity.PairDAO{UserID: userDAO.ID, Login: "foreign",
+			Labels: entity.Labels{FolderID: workID}})
+		require.ErrorIs(t, err, repo.ErrNotFound)
+		_, err = testRepo.CreateFolder(ctx, entity.FolderDAO{UserID: userDAO.ID, ParentID: workID, Name: "sub"})
+		require.ErrorIs(t, err, repo.ErrNotFound)
+	})
+
+	t.Run("list filter", func(t *testing.T) {
+		query := allItems
+		query.Filter = entity.LabelFilter{FolderID: &projectID, TagID: tagID}
+		pairs, err := testRepo.ListPairs(ctx, userID, query)
+		require.NoError(t, err)
+		require.Len(t, pairs, 1)
+		assert.Equal(t, pair.Labels, pairs[0].Labels)
+
+		query.Filter = entity.LabelFilter{FolderID: &workID}
+		pairs, err = testRepo.ListPairs(ctx, userID, query)
+		require.NoError(t, err)
+		assert.Empty(t, pairs)
+	})
+
+	t.Run("rename", func(t *testing.T) {
+		require.NoError(t, testRepo.RenameFolder(ctx, userID, projectID, "project-x"))
+		require.NoError(t, testRepo.RenameTag(ctx, userID, tagID, "network"))
+		require.ErrorIs(t, testRepo.RenameTag(ctx, userDAO.ID, tagID, "x"), repo.ErrNotFound)
+
+		folders, err := testRepo.ListFolders(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, folders, 2)
+		assert.Equal(t, "project-x", folders[1].Name)
+
+		tags, err := testRepo.ListTags(ctx, userID)
+		require.NoError(t, err)
+		require.Len(t, tags, 1)
+		assert.Equal(t, "network", tags[0].Name)
+	})
+
+	t.Run("delete folder and tag", func(t *testing.T) {
+		require.NoError(t, testRepo.DeleteFolder(ctx, userID, workID))
+		require.NoError(t, testRepo.DeleteTag(ctx, userID, tagID))
+
+		folders, err := testRepo.ListFolders(ctx, userID)
+		require.NoError(t, err)
+		assert.Empty(t, folders)
+
+		pairs, err := testRepo.ListPairs(ctx, userID, allItems)
+		require.NoError(t, err)
+		require.Len(t, pairs, 1)
+		assert.Equal(t, 0, pairs[0].FolderID)
+		assert.Empty(t, pairs[0].TagIDs)
+	})
+
+	require.NoError(t, testRepo.DeleteAccount(ctx, userID, "labels_hash"))
+}
+
+func TestEnvelope_SealLegacy(t *testing.T) {
+	ctx := context.Background()
+
+	userID, err := testRepo.CreateUser(ctx, "legacy_user", "legacy_hash")
+	require.NoError(t, err)
+
+	// записи, сохранённые до включения шифрования
+	var pairID int
+	require.NoError(t, testDB.Get(&pairID, qCreatePair, userID, "legacyLogin", "legacyPass", "legacy;"))
+	_, err = testDB.Exec(`INSERT INTO public.totp (user_id, secret) VALUES ($1, 'LEGACYSECRET')`, userID)
+	require.NoError(t, err)
+
+	// ключи данных других пользователей могут быть обёрнуты любым из тестовых KEK
+	keys, err := encryption.NewEnvKeyProvider(testKEK1 + "," + testKEK2)
+	require.NoError(t, err)
+	env := repo.NewEnvelope(testDB, keys)
+
+	legacy := func() int {
+		counts, err := env.CountLegacy(ctx)
+		require.NoError(t, err)
+
+		var total int
+		for _, c := range counts {
+			total += c.Rows
+		}
+		return total
+	}
+	require.Greater(t, legacy(), 0)
+
+	n, err := env.SealLegacy(ctx)
+	require.NoError(t, err)
+	require.Greater(t, n, 0)
+	require.Zero(t, legacy())
+
+	var stored string
+	require.NoError(t, testDB.Get(&stored, qGetPairLogin, pairID))
+	require.True(t, strings.HasPrefix(stored, encryption.RestPrefix))
+
+	fresh := repo.NewAuthPostgres(testDB, repo.NewEnvelope(testDB, keys))
+	totp, err := fresh.GetTOTP(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, "LEGACYSECRET", totp.Secret)
+	assert.Empty(t, totp.PendingSecret)
+
+	pairs, err := repo.NewPairPostgres(testDB, env).ListPairs(ctx, userID, allItems)
+	require.NoError(t, err)
+	require.Len(t, pairs, 1)
+	assert.Equal(t, "legacyLogin", pairs[0].Login)
+	assert.Equal(t, "legacyPass", pairs[0].Password)
+
+	// повторный запуск ничего не меняет
+	n, err = env.SealLegacy(ctx)
+	require.NoError(t, err)
+	require.Zero(t, n)
+
+	require.NoError(t, testRepo.DeleteAccount(ctx, userID, "legacy_hash"))
+}
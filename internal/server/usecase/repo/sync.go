@@ -0,0 +1,120 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	"github.com/PaulYakow/gophkeeper/pkg/postgres"
+)
+
+const (
+	getRevision = `
+SELECT COALESCE((SELECT revision FROM public.revisions WHERE user_id = $1), 0);
+`
+	getPairsSince = `
+SELECT * FROM resources.pairs_data
+WHERE user_id = $1 AND revision > $2
+ORDER BY id;
+`
+	getCardsSince = `
+SELECT * FROM resources.bank_data
+WHERE user_id = $1 AND revision > $2
+ORDER BY id;
+`
+	getNotesSince = `
+SELECT * FROM resources.text_data
+WHERE user_id = $1 AND revision > $2
+ORDER BY id;
+`
+	getBinariesSince = `
+SELECT id, user_id, filename, size, metadata, folder_id, tag_ids, revision, created_at
+FROM resources.binary_data
+WHERE user_id = $1 AND revision > $2
+ORDER BY id;
+`
+	getOTPsSince = `
+SELECT * FROM resources.otp_data
+WHERE user_id = $1 AND revision > $2
+ORDER BY id;
+`
+	getTombstonesSince = `
+SELECT kind, item_id, revision FROM resources.tombstones
+WHERE user_id = $1 AND revision > $2
+ORDER BY revision;
+`
+)
+
+// SyncPostgres реализация интерфейса usecase.ISyncRepo
+type SyncPostgres struct {
+	db  *postgres.Postgres
+	env *Envelope
+}
+
+// NewSyncPostgres создаёт объект типа SyncPostgres.
+func NewSyncPostgres(pg *postgres.Postgres, env *Envelope) *SyncPostgres {
+	return &SyncPostgres{pg, env}
+}
+
+// GetChanges находит в БД все записи и отметки об удалении пользователя (userID) с ревизией больше since.
+//
+// Все данные читаются из одного снимка БД, поэтому возвращаемая ревизия соответствует набору изменений.
+// Содержимое файлов не загружается.
+func (p *SyncPostgres) GetChanges(ctx context.Context, userID int, since int64) (entity.ChangesDAO, error) {
+	var result entity.ChangesDAO
+
+	ctxInner, cancel := p.db.WithTimeout(ctx)
+	defer cancel()
+
+	tx, err := p.db.BeginTxx(ctxInner, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return result, fmt.Errorf("repo - begin sync: %w", err)
+	}
+	// после Commit откат ничего не делает
+	defer tx.Rollback()
+
+	if err = tx.GetContext(ctxInner, &result.Revision, getRevision, userID); err != nil {
+		return result, fmt.Errorf("repo - get revision: %w", err)
+	}
+
+	queries := []struct {
+		dest  any
+		query string
+		name  string
+	}{
+		{&result.Pairs, getPairsSince, "pairs"},
+		{&result.Cards, getCardsSince, "cards"},
+		{&result.Notes, getNotesSince, "notes"},
+		{&result.Binaries, getBinariesSince, "binaries"},
+		{&result.OTPs, getOTPsSince, "otps"},
+		{&result.Deleted, getTombstonesSince, "tombstones"},
+	}
+	for _, q := range queries {
+		if err = tx.SelectContext(ctxInner, q.dest, q.query, userID, since); err != nil {
+			return result, fmt.Errorf("repo - get changed %s: %w", q.name, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return result, fmt.Errorf("repo - commit sync: %w", err)
+	}
+
+	if err = openPairs(ctx, p.env, userID, result.Pairs); err != nil {
+		return result, err
+	}
+	if err = openCards(ctx, p.env, userID, result.Cards); err != nil {
+		return result, err
+	}
+	if err = openNotes(ctx, p.env, userID, result.Notes); err != nil {
+		return result, err
+	}
+	if err = openBinaries(ctx, p.env, userID, result.Binaries); err != nil {
+		return result, err
+	}
+	if err = openOTPs(ctx, p.env, userID, result.OTPs); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
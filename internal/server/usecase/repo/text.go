@@ -2,41 +2,159 @@ package repo
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 	"fmt"
-	"time"
 
 	"github.com/PaulYakow/gophkeeper/internal/entity"
 	"github.com/PaulYakow/gophkeeper/pkg/postgres"
 )
 
+// listNotesQuery запросы страницы списка заметок для каждого порядка сортировки.
+var listNotesQuery = listQueries("*", "resources.text_data")
+
 const (
 	getNotesByUserID = `
 SELECT * FROM resources.text_data
 WHERE user_id = $1
 ORDER BY id;
+`
+	createNote = `
+WITH rev AS (
+    INSERT INTO public.revisions (user_id, revision)
+    SELECT $1, 1 WHERE resources.labels_valid($1, $4, $5)
+    ON CONFLICT (user_id) DO UPDATE SET revision = revisions.revision + 1
+    RETURNING revision
+)
+INSERT INTO resources.text_data (user_id, note, metadata, folder_id, tag_ids, revision)
+SELECT $1, $2, $3, $4, $5, rev.revision FROM rev
+RETURNING id;
+`
+	updateNote = `
+WITH rev AS (
+    INSERT INTO public.revisions (user_id, revision)
+    SELECT user_id, 1 FROM resources.text_data
+    WHERE id = $1 AND user_id = $2 AND resources.labels_valid($2, $5, $6)
+    ON CONFLICT (user_id) DO UPDATE SET revision = revisions.revision + 1
+    RETURNING revision
+)
+UPDATE resources.text_data
+SET note = $3, metadata = $4, folder_id = $5, tag_ids = $6, revision = rev.revision
+FROM rev
+WHERE id = $1 AND user_id = $2;
+`
+	deleteNote = `
+WITH rev AS (
+    INSERT INTO public.revisions (user_id, revision)
+    SELECT user_id, 1 FROM resources.text_data WHERE id = $1 AND user_id = $2
+    ON CONFLICT (user_id) DO UPDATE SET revision = revisions.revision + 1
+    RETURNING revision
+), deleted AS (
+    DELETE FROM resources.text_data
+    WHERE id = $1 AND user_id = $2
+    RETURNING id
+)
+INSERT INTO resources.tombstones (user_id, kind, item_id, revision)
+SELECT $2, 'note', deleted.id, rev.revision FROM deleted, rev;
 `
 )
 
 // TextPostgres реализация интерфейса usecase.ITextRepo
 type TextPostgres struct {
-	db *postgres.Postgres
+	db  *postgres.Postgres
+	env *Envelope
 }
 
 // NewTextPostgres создаёт объект типа TextPostgres.
-func NewTextPostgres(pg *postgres.Postgres) *TextPostgres {
-	return &TextPostgres{pg}
+func NewTextPostgres(pg *postgres.Postgres, env *Envelope) *TextPostgres {
+	return &TextPostgres{pg, env}
 }
 
-// GetAllNotes находит в БД все записи заметок принадлежащие конкретному пользователю (userID).
-func (p *TextPostgres) GetAllNotes(ctx context.Context, userID int) ([]entity.TextDAO, error) {
+// ListNotes находит в БД страницу списка заметок, принадлежащих конкретному пользователю (userID).
+//
+// Страница ограничена фильтром и курсором query (размер, диапазон времени создания, порядок, последняя запись).
+func (p *TextPostgres) ListNotes(ctx context.Context, userID int, query entity.ListQuery) ([]entity.TextDAO, error) {
 	var result []entity.TextDAO
 
-	ctxInner, cancel := context.WithTimeout(ctx, time.Second)
+	ctxInner, cancel := p.db.WithTimeout(ctx)
 	defer cancel()
 
-	if err := p.db.SelectContext(ctxInner, &result, getNotesByUserID, userID); err != nil {
-		return nil, fmt.Errorf("repo - get all cards by user: %w", err)
+	err := p.db.SelectContext(ctxInner, &result, listNotesQuery[query.Order], listArgs(userID, query)...)
+	if err != nil {
+		return nil, fmt.Errorf("repo - list notes: %w", err)
+	}
+
+	if err := openNotes(ctx, p.env, userID, result); err != nil {
+		return nil, err
 	}
 
 	return result, nil
 }
+
+// CreateNote сохраняет в БД новую заметку.
+//
+// Возвращает id созданной записи или ошибку (ErrNotFound, если папка или метка записи не найдена).
+func (p *TextPostgres) CreateNote(ctx context.Context, note entity.TextDAO) (int, error) {
+	if err := p.env.seal(ctx, note.UserID, &note.Note, &note.Metadata); err != nil {
+		return 0, err
+	}
+
+	ctxInner, cancel := p.db.WithTimeout(ctx)
+	defer cancel()
+
+	var id int
+	err := p.db.GetContext(ctxInner, &id, createNote, note.UserID, note.Note, note.Metadata, note.FolderID, note.TagIDs)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrNotFound
+	}
+	if err != nil {
+		return 0, fmt.Errorf("repo - create note: %w", err)
+	}
+
+	return id, nil
+}
+
+// UpdateNote изменяет в БД заметку (поиск по id и user_id).
+//
+// Возвращает ErrNotFound, если не найдена запись, её папка или метка.
+func (p *TextPostgres) UpdateNote(ctx context.Context, note entity.TextDAO) error {
+	if err := p.env.seal(ctx, note.UserID, &note.Note, &note.Metadata); err != nil {
+		return err
+	}
+
+	ctxInner, cancel := p.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := p.db.ExecContext(ctxInner, updateNote, note.ID, note.UserID, note.Note, note.Metadata, note.FolderID, note.TagIDs)
+	if err != nil {
+		return fmt.Errorf("repo - update note: %w", err)
+	}
+
+	return checkAffected(res)
+}
+
+// DeleteNote удаляет из БД заметку принадлежащую конкретному пользователю (userID).
+//
+// Возвращает ошибку, если запись не найдена.
+func (p *TextPostgres) DeleteNote(ctx context.Context, userID, noteID int) error {
+	ctxInner, cancel := p.db.WithTimeout(ctx)
+	defer cancel()
+
+	res, err := p.db.ExecContext(ctxInner, deleteNote, noteID, userID)
+	if err != nil {
+		return fmt.Errorf("repo - delete note: %w", err)
+	}
+
+	return checkAffected(res)
+}
+
+// openNotes расшифровывает поля заметок пользователя (userID).
+func openNotes(ctx context.Context, env *Envelope, userID int, items []entity.TextDAO) error {
+	for i := range items {
+		if err := env.open(ctx, userID, &items[i].Note, &items[i].Metadata); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
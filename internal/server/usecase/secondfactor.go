@@ -0,0 +1,260 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base32"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+	"github.com/PaulYakow/gophkeeper/internal/utils/otp"
+)
+
+const (
+	// challengeDuration время действия токена незавершённого входа.
+	challengeDuration = 5 * time.Minute
+	// totpSecretSize размер секрета аутентификатора (в байтах, до кодирования).
+	totpSecretSize = 20
+	// totpSkew допустимое расхождение часов клиента и сервера (в интервалах TOTP).
+	totpSkew = 1
+)
+
+// totpKey ключ учёта неудачных проверок кода при подключении/отключении второго фактора.
+func totpKey(userID int) string {
+	return "totp:" + strconv.Itoa(userID)
+}
+
+// authenticated завершает вход пользователя с проверенным паролем.
+//
+// Если подключён второй фактор, вместо сессии создаётся незавершённый вход и возвращается только
+// его токен (TokensDTO.Challenge), счётчик неудач при этом не сбрасывается (пароль без кода не
+// завершает вход). Иначе открывается сессия.
+func (s *AuthService) authenticated(ctx context.Context, user entity.UserDAO) (entity.TokensDTO, error) {
+	totp, err := s.repo.GetTOTP(ctx, user.ID)
+	if err != nil {
+		return entity.TokensDTO{}, err
+	}
+
+	if totp.Secret == "" {
+		s.loginSucceeded(ctx, user.Login)
+		return s.openSession(ctx, user.ID)
+	}
+
+	challengeID, err := randomString(sessionIDSize)
+	if err != nil {
+		return entity.TokensDTO{}, err
+	}
+
+	secret, err := randomString(refreshSecretSize)
+	if err != nil {
+		return entity.TokensDTO{}, err
+	}
+
+	err = s.repo.CreateChallenge(ctx, entity.ChallengeDAO{
+		ID:         challengeID,
+		UserID:     user.ID,
+		SecretHash: hashSecret(secret),
+		ExpiresAt:  time.Now().Add(challengeDuration),
+	})
+	if err != nil {
+		return entity.TokensDTO{}, err
+	}
+
+	return entity.TokensDTO{Challenge: challengeID + "." + secret}, nil
+}
+
+// VerifySecondFactor - завершение входа кодом аутентификатора (TOTP).
+//
+// Обменивает токен незавершённого входа (challenge) и код на токены новой сессии. Неверные коды
+// учитываются как неудачные попытки входа (блокировка логина и адреса peer), каждый код принимается
+// только один раз.
+func (s *AuthService) VerifySecondFactor(ctx context.Context, challenge, code, peer string) (entity.TokensDTO, error) {
+	challengeID, secret, ok := strings.Cut(challenge, ".")
+	if !ok {
+		return entity.TokensDTO{}, ErrInvalidChallenge
+	}
+
+	ch, err := s.repo.GetChallenge(ctx, challengeID)
+	if err != nil || !time.Now().Before(ch.ExpiresAt) ||
+		subtle.ConstantTimeCompare([]byte(hashSecret(secret)), []byte(ch.SecretHash)) != 1 {
+		return entity.TokensDTO{}, ErrInvalidChallenge
+	}
+
+	keys := []string{loginKey(ch.Login)}
+	if peer != "" {
+		keys = append(keys, peerKey(peer))
+	}
+
+	lockedUntil, err := s.repo.GetLoginLock(ctx, keys...)
+	if err != nil {
+		return entity.TokensDTO{}, err
+	}
+	if time.Now().Before(lockedUntil) {
+		return entity.TokensDTO{}, ErrTooManyAttempts
+	}
+
+	totp, err := s.repo.GetTOTP(ctx, ch.UserID)
+	if err != nil {
+		return entity.TokensDTO{}, err
+	}
+	if totp.Secret == "" {
+		// второй фактор отключён после начала входа
+		return entity.TokensDTO{}, ErrInvalidChallenge
+	}
+
+	if err = s.useCode(ctx, totp, ch.Login, code, peer); err != nil {
+		return entity.TokensDTO{}, err
+	}
+
+	if err = s.repo.DeleteChallenge(ctx, challengeID); err != nil {
+		return entity.TokensDTO{}, ErrInvalidChallenge
+	}
+
+	s.loginSucceeded(ctx, ch.Login)
+
+	return s.openSession(ctx, ch.UserID)
+}
+
+// useCode проверяет код действующего аутентификатора пользователя и отмечает его использованным.
+//
+// Неверный код учитывается как неудачная попытка входа (логин login, адрес peer).
+func (s *AuthService) useCode(ctx context.Context, totp entity.TOTPDAO, login, code, peer string) error {
+	step, ok := matchTOTP(totp.Secret, code, totp.LastStep, time.Now())
+	if !ok {
+		s.loginFailed(ctx, totp.UserID, login, peer)
+		return ErrInvalidCode
+	}
+
+	// при одновременной проверке одного кода успешна только первая
+	if err := s.repo.UseTOTPStep(ctx, totp.UserID, step); err != nil {
+		return ErrInvalidCode
+	}
+
+	return nil
+}
+
+// EnrollTOTP - подключение (или замена) аутентификатора: создаёт новый секрет.
+//
+// Требуется текущий пароль и, если второй фактор уже подключён, код действующего аутентификатора:
+// иначе похищенный токен доступа позволял бы заменить аутентификатор владельца своим. Проверка
+// ограничена так же, как вход (ErrMismatchPassword, ErrSecondFactorRequired, ErrInvalidCode, ErrTooManyAttempts).
+// Новый секрет начинает действовать только после подтверждения кодом (ConfirmTOTP),
+// до этого вход выполняется с прежними настройками.
+func (s *AuthService) EnrollTOTP(ctx context.Context, userID int, pass, code, peer string) (entity.TOTPEnrollmentDTO, error) {
+	if _, err := s.confirmUser(ctx, userID, pass, code, peer); err != nil {
+		return entity.TOTPEnrollmentDTO{}, err
+	}
+
+	buf := make([]byte, totpSecretSize)
+	if _, err := rand.Read(buf); err != nil {
+		return entity.TOTPEnrollmentDTO{}, fmt.Errorf("generate totp secret: %w", err)
+	}
+
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(buf)
+	if err := s.repo.SaveTOTPPending(ctx, userID, secret); err != nil {
+		return entity.TOTPEnrollmentDTO{}, err
+	}
+
+	return entity.TOTPEnrollmentDTO{
+		Secret:    secret,
+		Algorithm: otp.AlgorithmSHA1,
+		Digits:    otp.DefaultDigits,
+		Period:    otp.DefaultPeriod,
+	}, nil
+}
+
+// ConfirmTOTP - подтверждение подключаемого аутентификатора кодом: с этого момента вход требует второй фактор.
+//
+// Подключаемый секрет сохраняет только EnrollTOTP после проверки пароля и действующего аутентификатора,
+// а подтверждение его сбрасывает, поэтому здесь проверяется только код нового аутентификатора.
+func (s *AuthService) ConfirmTOTP(ctx context.Context, userID int, code string) error {
+	totp, err := s.guardTOTP(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if totp.PendingSecret == "" {
+		return ErrTOTPNotEnrolled
+	}
+
+	step, ok := matchTOTP(totp.PendingSecret, code, 0, time.Now())
+	if !ok {
+		s.countFailure(ctx, totpKey(userID), s.lockout.MaxFailures)
+		return ErrInvalidCode
+	}
+
+	if err = s.repo.ConfirmTOTP(ctx, userID, step); err != nil {
+		return err
+	}
+
+	_ = s.repo.ResetLoginFailures(ctx, totpKey(userID))
+	return nil
+}
+
+// DisableTOTP - отключение второго фактора (требуется ещё не использованный код действующего аутентификатора).
+func (s *AuthService) DisableTOTP(ctx context.Context, userID int, code string) error {
+	totp, err := s.guardTOTP(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if totp.Secret == "" {
+		return ErrTOTPNotEnrolled
+	}
+
+	step, ok := matchTOTP(totp.Secret, code, totp.LastStep, time.Now())
+	if !ok {
+		s.countFailure(ctx, totpKey(userID), s.lockout.MaxFailures)
+		return ErrInvalidCode
+	}
+
+	// код отмечается использованным, как и при входе: код, уже принятый при входе, повторно не принимается
+	if err = s.repo.UseTOTPStep(ctx, userID, step); err != nil {
+		return ErrInvalidCode
+	}
+
+	if err = s.repo.DeleteTOTP(ctx, userID); err != nil {
+		return err
+	}
+
+	_ = s.repo.ResetLoginFailures(ctx, totpKey(userID))
+	return nil
+}
+
+// guardTOTP проверяет блокировку подбора кодов пользователя и возвращает его настройки второго фактора.
+func (s *AuthService) guardTOTP(ctx context.Context, userID int) (entity.TOTPDAO, error) {
+	lockedUntil, err := s.repo.GetLoginLock(ctx, totpKey(userID))
+	if err != nil {
+		return entity.TOTPDAO{}, err
+	}
+	if time.Now().Before(lockedUntil) {
+		return entity.TOTPDAO{}, ErrTooManyAttempts
+	}
+
+	return s.repo.GetTOTP(ctx, userID)
+}
+
+// matchTOTP проверяет код аутентификатора для момента t (с учётом расхождения часов totpSkew).
+//
+// Коды интервалов не позже lastStep (уже использованные) не принимаются. Возвращает номер интервала кода.
+func matchTOTP(secret, code string, lastStep int64, t time.Time) (int64, bool) {
+	current := t.Unix() / otp.DefaultPeriod
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if step <= lastStep {
+			continue
+		}
+
+		expected, err := otp.HOTP(secret, uint64(step), otp.AlgorithmSHA1, otp.DefaultDigits)
+		if err != nil {
+			return 0, false
+		}
+
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/PaulYakow/gophkeeper/internal/entity"
+)
+
+// SyncService сервис синхронизации данных пользователя по ревизиям.
+type SyncService struct {
+	repo ISyncRepo
+}
+
+// NewSyncService создаёт объект типа SyncService.
+func NewSyncService(repo ISyncRepo) *SyncService {
+	return &SyncService{
+		repo: repo,
+	}
+}
+
+// Sync получение изменений всех типов данных пользователя после ревизии since (0 - все данные).
+//
+// Если since больше текущей ревизии сервера (например, БД восстановлена из резервной копии),
+// возвращаются все данные с признаком Full - клиент должен заменить своё состояние целиком.
+func (s *SyncService) Sync(ctx context.Context, userID int, since int64) (entity.ChangesDTO, error) {
+	full := since <= 0
+	if full {
+		// записи, созданные до появления ревизий, имеют ревизию 0
+		since = -1
+	}
+
+	changes, err := s.repo.GetChanges(ctx, userID, since)
+	if err != nil {
+		return entity.ChangesDTO{}, err
+	}
+
+	if !full && changes.Revision < since {
+		full = true
+		if changes, err = s.repo.GetChanges(ctx, userID, -1); err != nil {
+			return entity.ChangesDTO{}, err
+		}
+	}
+
+	result := entity.ChangesDTO{
+		Revision: changes.Revision,
+		Full:     full,
+		Pairs:    pairsToDTO(changes.Pairs),
+		Cards:    cardsToDTO(changes.Cards),
+		Notes:    notesToDTO(changes.Notes),
+		Binaries: binariesToDTO(changes.Binaries),
+		OTPs:     otpsToDTO(changes.OTPs),
+	}
+
+	// при полной выгрузке отметки об удалении не нужны
+	if !full {
+		result.Deleted = changes.Deleted
+	}
+
+	return result, nil
+}
@@ -18,21 +18,66 @@ func NewTextService(repo ITextRepo) *TextService {
 	}
 }
 
-// ViewAllNotes получение всех значений заметок.
-func (s *TextService) ViewAllNotes(userID int) ([]entity.TextDTO, error) {
-	notesDAO, err := s.repo.GetAllNotes(context.Background(), userID)
+// ViewNotes получение страницы списка заметок.
+//
+// Возвращает записи страницы и токен следующей страницы (пустой - страница последняя).
+func (s *TextService) ViewNotes(ctx context.Context, userID int, page entity.PageRequest) ([]entity.TextDTO, string, error) {
+	return viewPage(page, func(query entity.ListQuery) ([]entity.TextDAO, error) {
+		return s.repo.ListNotes(ctx, userID, query)
+	}, func(item entity.TextDAO) entity.Cursor {
+		return entity.Cursor{CreatedAt: item.CreatedAt, ID: item.ID}
+	}, notesToDTO)
+}
+
+// CreateNote создание новой заметки пользователя.
+//
+// Возвращает id созданной записи или ошибку.
+func (s *TextService) CreateNote(ctx context.Context, userID int, note entity.TextDTO) (int, error) {
+	labels, err := normalizeLabels(note.Labels)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
-	notesDTO := make([]entity.TextDTO, len(notesDAO))
-	for i, card := range notesDAO {
-		notesDTO[i] = entity.TextDTO{
-			ID:       card.ID,
-			Note:     card.Note,
-			Metadata: card.Metadata,
+	return s.repo.CreateNote(ctx, entity.TextDAO{
+		UserID:   userID,
+		Note:     note.Note,
+		Metadata: note.Metadata,
+		Labels:   labels,
+	})
+}
+
+// UpdateNote изменение существующей заметки пользователя.
+func (s *TextService) UpdateNote(ctx context.Context, userID int, note entity.TextDTO) error {
+	labels, err := normalizeLabels(note.Labels)
+	if err != nil {
+		return err
+	}
+
+	return s.repo.UpdateNote(ctx, entity.TextDAO{
+		ID:       note.ID,
+		UserID:   userID,
+		Note:     note.Note,
+		Metadata: note.Metadata,
+		Labels:   labels,
+	})
+}
+
+// DeleteNote удаление заметки пользователя.
+func (s *TextService) DeleteNote(ctx context.Context, userID, noteID int) error {
+	return s.repo.DeleteNote(ctx, userID, noteID)
+}
+
+// notesToDTO преобразует записи заметок из БД в объекты для API.
+func notesToDTO(items []entity.TextDAO) []entity.TextDTO {
+	out := make([]entity.TextDTO, len(items))
+	for i, note := range items {
+		out[i] = entity.TextDTO{
+			ID:       note.ID,
+			Note:     note.Note,
+			Metadata: note.Metadata,
+			Labels:   note.Labels,
 		}
 	}
 
-	return notesDTO, nil
+	return out
 }
@@ -10,6 +10,11 @@ type Usecase struct {
 	IPairsService
 	IBankService
 	ITextService
+	IBinaryService
+	IOTPService
+	ISyncService
+	IAccountService
+	ILabelsService
 }
 
 // New создаёт объект Usecase.
@@ -17,11 +22,21 @@ func New(auth IAuthorizationService,
 	pairs IPairsService,
 	cards IBankService,
 	notes ITextService,
+	binaries IBinaryService,
+	otps IOTPService,
+	sync ISyncService,
+	account IAccountService,
+	labels ILabelsService,
 ) (*Usecase, error) {
 	return &Usecase{
 		auth,
 		pairs,
 		cards,
 		notes,
+		binaries,
+		otps,
+		sync,
+		account,
+		labels,
 	}, nil
 }
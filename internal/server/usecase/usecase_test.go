@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,6 +16,11 @@ import (
 	"github.com/PaulYakow/gophkeeper/internal/server/mocks"
 	"github.com/PaulYakow/gophkeeper/internal/server/usecase"
 	"github.com/PaulYakow/gophkeeper/internal/server/usecase/repo"
+	"github.com/PaulYakow/gophkeeper/internal/server/usecase/repo/memory"
+	"github.com/PaulYakow/gophkeeper/internal/utils/encryption"
+	"github.com/PaulYakow/gophkeeper/internal/utils/otp"
+	passwordhash "github.com/PaulYakow/gophkeeper/internal/utils/password"
+	"github.com/PaulYakow/gophkeeper/internal/utils/token"
 )
 
 var serverMock = struct {
@@ -49,12 +55,19 @@ func TestNew(t *testing.T) {
 
 	var err error
 
-	auth := usecase.NewAuthService(serverMock.repo, serverMock.hasher, serverMock.maker)
+	// хэш-заглушка для несуществующих логинов вычисляется при создании сервиса, а не при первом входе
+	serverMock.hasher.EXPECT().Hash(gomock.Any()).Return("dummy_hash", nil)
+	auth := usecase.NewAuthService(serverMock.repo, serverMock.hasher, serverMock.maker, accessDuration, refreshDuration, policy, lockout)
 	pairs := usecase.NewPairsService(serverMock.repo)
 	cards := usecase.NewBankService(serverMock.repo)
 	notes := usecase.NewTextService(serverMock.repo)
+	binaries := usecase.NewBinaryService(serverMock.repo, maxBinarySize)
+	otps := usecase.NewOTPService(serverMock.repo)
+	sync := usecase.NewSyncService(serverMock.repo)
+	account := usecase.NewAccountService(serverMock.repo, auth)
+	labels := usecase.NewLabelsService(serverMock.repo)
 
-	serverMock.uc, err = usecase.New(auth, pairs, cards, notes)
+	serverMock.uc, err = usecase.New(auth, pairs, cards, notes, binaries, otps, sync, account, labels)
 
 	t.Run("proper usecase create", func(t *testing.T) {
 		require.NoError(t, err)
@@ -65,84 +78,679 @@ func TestNew(t *testing.T) {
 const (
 	login, password = "user", "password"
 	hashedPassword  = "hashed_password"
+	accessDuration  = 15 * time.Minute
+	refreshDuration = 720 * time.Hour
+	peer            = "10.0.0.1"
+	maxBinarySize   = 1 << 20
 )
 
+var (
+	policy  = usecase.PasswordPolicy{HistorySize: 3, MaxAge: 90 * 24 * time.Hour}
+	lockout = usecase.LoginPolicy{
+		MaxFailures:     3,
+		PeerMaxFailures: 10,
+		LockoutBase:     time.Minute,
+		LockoutMax:      time.Hour,
+		Window:          24 * time.Hour,
+	}
+	// firstPage выборка первой страницы списка по умолчанию
+	firstPage = entity.ListQuery{Limit: usecase.DefaultPageSize + 1}
+)
+
+// expectUnlocked ожидает проверку блокировок логина и адреса (блокировок нет).
+func expectUnlocked() {
+	serverMock.repo.EXPECT().GetLoginLock(context.Background(), "login:"+login, "peer:"+peer).Return(time.Time{}, nil)
+}
+
+// expectPasswordChecked ожидает успешную проверку пароля пользователя user.
+func expectPasswordChecked(user entity.UserDAO) {
+	expectUnlocked()
+	serverMock.repo.EXPECT().GetUser(context.Background(), login).Return(user, nil)
+	serverMock.hasher.EXPECT().Check(password, user.PasswordHash).Return(nil)
+}
+
+// expectAuthenticated ожидает завершение входа пользователя userID без второго фактора.
+func expectAuthenticated(userID int) {
+	serverMock.repo.EXPECT().GetTOTP(context.Background(), userID).Return(entity.TOTPDAO{}, nil)
+	serverMock.repo.EXPECT().ResetLoginFailures(context.Background(), "login:"+login).Return(nil)
+}
+
+// expectFailureCounted ожидает учёт неудачной попытки (порог не достигнут).
+func expectFailureCounted() {
+	serverMock.repo.EXPECT().AddLoginFailure(gomock.Any(), "login:"+login, lockout.Window).Return(1, nil)
+	serverMock.repo.EXPECT().AddLoginFailure(gomock.Any(), "peer:"+peer, lockout.Window).Return(1, nil)
+}
+
 func TestAuthorization_RegisterUser(t *testing.T) {
 	t.Run("proper create new user", func(t *testing.T) {
 		userID := 1
 		serverMock.hasher.EXPECT().Hash(password).Return(hashedPassword, nil)
-		serverMock.repo.EXPECT().CreateUser(login, hashedPassword).Return(userID, nil)
-		serverMock.maker.EXPECT().Create(userID, 12*time.Hour).Return("token", nil)
-		token, err := serverMock.uc.RegisterUser(login, password)
+		serverMock.repo.EXPECT().CreateUser(context.Background(), login, hashedPassword).Return(userID, nil)
+		serverMock.repo.EXPECT().CreateSession(context.Background(), gomock.Any()).Return(nil)
+		serverMock.maker.EXPECT().Create(userID, gomock.Any(), accessDuration).Return("token", nil)
+		tokens, err := serverMock.uc.RegisterUser(context.Background(), login, password)
 		require.NoError(t, err)
-		require.NotEmpty(t, token)
-		assert.Equal(t, "token", token)
+		assert.Equal(t, "token", tokens.AccessToken)
+		assert.NotEmpty(t, tokens.RefreshToken)
+		assert.Equal(t, accessDuration, tokens.ExpiresIn)
 	})
 
 	t.Run("duplicate user", func(t *testing.T) {
 		serverMock.hasher.EXPECT().Hash(password).Return(hashedPassword, nil)
-		serverMock.repo.EXPECT().CreateUser(login, hashedPassword).Return(0, repo.ErrUserExist)
-		token, err := serverMock.uc.RegisterUser(login, password)
+		serverMock.repo.EXPECT().CreateUser(context.Background(), login, hashedPassword).Return(0, repo.ErrUserExist)
+		tokens, err := serverMock.uc.RegisterUser(context.Background(), login, password)
 		require.ErrorIs(t, err, repo.ErrUserExist)
-		require.Empty(t, token)
+		require.Empty(t, tokens)
 	})
 
 	t.Run("invalid password hash", func(t *testing.T) {
 		serverMock.hasher.EXPECT().Hash(password).Return("", errors.New("invalid password hash"))
-		_, err := serverMock.uc.RegisterUser(login, password)
+		_, err := serverMock.uc.RegisterUser(context.Background(), login, password)
 		require.Error(t, err)
 	})
 
 	t.Run("error from Create", func(t *testing.T) {
 		userID := 1
 		serverMock.hasher.EXPECT().Hash(password).Return(hashedPassword, nil)
-		serverMock.repo.EXPECT().CreateUser(login, hashedPassword).Return(userID, nil)
-		serverMock.maker.EXPECT().Create(userID, 12*time.Hour).Return("", errors.New("token create error"))
-		_, err := serverMock.uc.RegisterUser(login, password)
+		serverMock.repo.EXPECT().CreateUser(context.Background(), login, hashedPassword).Return(userID, nil)
+		serverMock.repo.EXPECT().CreateSession(context.Background(), gomock.Any()).Return(nil)
+		serverMock.maker.EXPECT().Create(userID, gomock.Any(), accessDuration).Return("", errors.New("token create error"))
+		_, err := serverMock.uc.RegisterUser(context.Background(), login, password)
 		require.Error(t, err)
 	})
 }
 
 func TestAuthorization_LoginUser(t *testing.T) {
 	user := entity.UserDAO{
-		ID:           1,
-		Login:        login,
-		PasswordHash: hashedPassword,
+		ID:                1,
+		Login:             login,
+		PasswordHash:      hashedPassword,
+		PasswordChangedAt: time.Now(),
 	}
 
 	t.Run("proper login new user", func(t *testing.T) {
 		testToken := "token"
 
-		serverMock.repo.EXPECT().GetUser(login).Return(user, nil)
-		serverMock.hasher.EXPECT().Check(password, hashedPassword).Return(nil)
-		serverMock.maker.EXPECT().Create(user.ID, 12*time.Hour).Return(testToken, nil)
-		token, err := serverMock.uc.LoginUser(login, password)
+		expectPasswordChecked(user)
+		serverMock.hasher.EXPECT().NeedsRehash(hashedPassword).Return(false)
+		expectAuthenticated(user.ID)
+		serverMock.repo.EXPECT().CreateSession(context.Background(), gomock.Any()).Return(nil)
+		serverMock.maker.EXPECT().Create(user.ID, gomock.Any(), accessDuration).Return(testToken, nil)
+		tokens, err := serverMock.uc.LoginUser(context.Background(), login, password, peer)
 		require.NoError(t, err)
-		require.Equal(t, testToken, token)
+		require.Equal(t, testToken, tokens.AccessToken)
 	})
 
 	t.Run("login not exist", func(t *testing.T) {
-		serverMock.repo.EXPECT().GetUser(login).Return(entity.UserDAO{}, errors.New("login not exist"))
-		token, err := serverMock.uc.LoginUser(login, password)
-		require.Error(t, err)
-		require.Empty(t, token)
+		expectUnlocked()
+		serverMock.repo.EXPECT().GetUser(context.Background(), login).Return(entity.UserDAO{}, errors.New("login not exist"))
+		// пароль проверяется по хэшу-заглушке, вычисленному при создании сервиса
+		serverMock.hasher.EXPECT().Check(password, "dummy_hash").Return(errors.New("mismatch"))
+		expectFailureCounted()
+		tokens, err := serverMock.uc.LoginUser(context.Background(), login, password, peer)
+		require.ErrorIs(t, err, usecase.ErrInvalidCredentials)
+		require.Empty(t, tokens)
 	})
 
 	t.Run("invalid hash check", func(t *testing.T) {
-		serverMock.repo.EXPECT().GetUser(login).Return(user, nil)
+		expectUnlocked()
+		serverMock.repo.EXPECT().GetUser(context.Background(), login).Return(user, nil)
 		serverMock.hasher.EXPECT().Check(password, hashedPassword).Return(errors.New("invalid hash"))
-		token, err := serverMock.uc.LoginUser(login, password)
+		expectFailureCounted()
+		tokens, err := serverMock.uc.LoginUser(context.Background(), login, password, peer)
+		require.ErrorIs(t, err, usecase.ErrInvalidCredentials)
+		require.Empty(t, tokens)
+	})
+
+	t.Run("login locked after failures", func(t *testing.T) {
+		var event entity.SecurityEventDAO
+		var lockedUntil time.Time
+		expectUnlocked()
+		serverMock.repo.EXPECT().GetUser(context.Background(), login).Return(user, nil)
+		serverMock.hasher.EXPECT().Check(password, hashedPassword).Return(errors.New("mismatch"))
+		// третья неудача сверх порога - срок блокировки удваивается дважды
+		serverMock.repo.EXPECT().AddLoginFailure(gomock.Any(), "login:"+login, lockout.Window).Return(lockout.MaxFailures+2, nil)
+		serverMock.repo.EXPECT().LockLogin(gomock.Any(), "login:"+login, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, until time.Time) error {
+				lockedUntil = until
+				return nil
+			})
+		serverMock.repo.EXPECT().CreateSecurityEvent(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, e entity.SecurityEventDAO) error {
+				event = e
+				return nil
+			})
+		serverMock.repo.EXPECT().AddLoginFailure(gomock.Any(), "peer:"+peer, lockout.Window).Return(1, nil)
+		_, err := serverMock.uc.LoginUser(context.Background(), login, password, peer)
+		require.ErrorIs(t, err, usecase.ErrInvalidCredentials)
+		assert.WithinDuration(t, time.Now().Add(4*lockout.LockoutBase), lockedUntil, time.Second)
+		assert.Equal(t, user.ID, event.UserID)
+		assert.Equal(t, entity.SecurityEventLoginLocked, event.Kind)
+		assert.Equal(t, peer, event.Peer)
+		assert.Equal(t, lockedUntil, event.LockedUntil)
+	})
+
+	t.Run("peer locked with capped delay", func(t *testing.T) {
+		var lockedUntil time.Time
+		expectUnlocked()
+		serverMock.repo.EXPECT().GetUser(context.Background(), login).Return(entity.UserDAO{}, errors.New("login not exist"))
+		// хэш-заглушка уже вычислен
+		serverMock.hasher.EXPECT().Check(password, "dummy_hash").Return(errors.New("mismatch"))
+		serverMock.repo.EXPECT().AddLoginFailure(gomock.Any(), "login:"+login, lockout.Window).Return(1, nil)
+		serverMock.repo.EXPECT().AddLoginFailure(gomock.Any(), "peer:"+peer, lockout.Window).Return(lockout.PeerMaxFailures+20, nil)
+		serverMock.repo.EXPECT().LockLogin(gomock.Any(), "peer:"+peer, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, until time.Time) error {
+				lockedUntil = until
+				return nil
+			})
+		_, err := serverMock.uc.LoginUser(context.Background(), login, password, peer)
+		require.ErrorIs(t, err, usecase.ErrInvalidCredentials)
+		assert.WithinDuration(t, time.Now().Add(lockout.LockoutMax), lockedUntil, time.Second)
+	})
+
+	t.Run("failure counted after request canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		notCanceled := func(ctx context.Context, _ string, _ time.Duration) (int, error) {
+			require.NoError(t, ctx.Err())
+			return 1, nil
+		}
+		serverMock.repo.EXPECT().GetLoginLock(ctx, "login:"+login, "peer:"+peer).Return(time.Time{}, nil)
+		serverMock.repo.EXPECT().GetUser(ctx, login).Return(user, nil)
+		serverMock.hasher.EXPECT().Check(password, hashedPassword).Return(errors.New("mismatch"))
+		serverMock.repo.EXPECT().AddLoginFailure(gomock.Any(), "login:"+login, lockout.Window).DoAndReturn(notCanceled)
+		serverMock.repo.EXPECT().AddLoginFailure(gomock.Any(), "peer:"+peer, lockout.Window).DoAndReturn(notCanceled)
+		_, err := serverMock.uc.LoginUser(ctx, login, password, peer)
+		require.ErrorIs(t, err, usecase.ErrInvalidCredentials)
+	})
+
+	t.Run("login locked", func(t *testing.T) {
+		serverMock.repo.EXPECT().GetLoginLock(context.Background(), "login:"+login, "peer:"+peer).
+			Return(time.Now().Add(time.Minute), nil)
+		tokens, err := serverMock.uc.LoginUser(context.Background(), login, password, peer)
+		require.ErrorIs(t, err, usecase.ErrTooManyAttempts)
+		require.Empty(t, tokens)
+	})
+
+	t.Run("security events", func(t *testing.T) {
+		now := time.Now()
+		serverMock.repo.EXPECT().GetSecurityEvents(context.Background(), user.ID, gomock.Any()).
+			Return([]entity.SecurityEventDAO{{ID: 1, UserID: user.ID, Kind: entity.SecurityEventLoginLocked, Peer: peer, LockedUntil: now, CreatedAt: now}}, nil)
+		events, err := serverMock.uc.SecurityEvents(context.Background(), user.ID)
+		require.NoError(t, err)
+		require.Equal(t, []entity.SecurityEventDTO{{Kind: entity.SecurityEventLoginLocked, Peer: peer, LockedUntil: now, CreatedAt: now}}, events)
+	})
+
+	t.Run("password expired", func(t *testing.T) {
+		expired := user
+		expired.PasswordChangedAt = time.Now().Add(-policy.MaxAge - time.Hour)
+		expectPasswordChecked(expired)
+		tokens, err := serverMock.uc.LoginUser(context.Background(), login, password, peer)
+		require.ErrorIs(t, err, usecase.ErrPasswordExpired)
+		require.Empty(t, tokens)
+	})
+
+	t.Run("rehash outdated password hash", func(t *testing.T) {
+		newHash := "new_hashed_password"
+		expectPasswordChecked(user)
+		serverMock.hasher.EXPECT().NeedsRehash(hashedPassword).Return(true)
+		serverMock.hasher.EXPECT().Hash(password).Return(newHash, nil)
+		serverMock.repo.EXPECT().UpdatePasswordHash(context.Background(), user.ID, hashedPassword, newHash).Return(nil)
+		expectAuthenticated(user.ID)
+		serverMock.repo.EXPECT().CreateSession(context.Background(), gomock.Any()).Return(nil)
+		serverMock.maker.EXPECT().Create(user.ID, gomock.Any(), accessDuration).Return("token", nil)
+		tokens, err := serverMock.uc.LoginUser(context.Background(), login, password, peer)
+		require.NoError(t, err)
+		require.Equal(t, "token", tokens.AccessToken)
+	})
+
+	t.Run("rehash error does not block login", func(t *testing.T) {
+		expectPasswordChecked(user)
+		serverMock.hasher.EXPECT().NeedsRehash(hashedPassword).Return(true)
+		serverMock.hasher.EXPECT().Hash(password).Return("new_hashed_password", nil)
+		serverMock.repo.EXPECT().UpdatePasswordHash(context.Background(), user.ID, hashedPassword, gomock.Any()).
+			Return(errors.New("db error"))
+		expectAuthenticated(user.ID)
+		serverMock.repo.EXPECT().CreateSession(context.Background(), gomock.Any()).Return(nil)
+		serverMock.maker.EXPECT().Create(user.ID, gomock.Any(), accessDuration).Return("token", nil)
+		tokens, err := serverMock.uc.LoginUser(context.Background(), login, password, peer)
+		require.NoError(t, err)
+		require.Equal(t, "token", tokens.AccessToken)
+	})
+
+	t.Run("session create error", func(t *testing.T) {
+		expectPasswordChecked(user)
+		serverMock.hasher.EXPECT().NeedsRehash(hashedPassword).Return(false)
+		expectAuthenticated(user.ID)
+		serverMock.repo.EXPECT().CreateSession(context.Background(), gomock.Any()).Return(errors.New("db error"))
+		tokens, err := serverMock.uc.LoginUser(context.Background(), login, password, peer)
 		require.Error(t, err)
-		require.Empty(t, token)
+		require.Empty(t, tokens)
 	})
 
 	t.Run("invalid token create", func(t *testing.T) {
-		serverMock.repo.EXPECT().GetUser(login).Return(user, nil)
-		serverMock.hasher.EXPECT().Check(password, hashedPassword).Return(nil)
-		serverMock.maker.EXPECT().Create(user.ID, 12*time.Hour).Return("", errors.New("invalid token"))
-		token, err := serverMock.uc.LoginUser(login, password)
+		expectPasswordChecked(user)
+		serverMock.hasher.EXPECT().NeedsRehash(hashedPassword).Return(false)
+		expectAuthenticated(user.ID)
+		serverMock.repo.EXPECT().CreateSession(context.Background(), gomock.Any()).Return(nil)
+		serverMock.maker.EXPECT().Create(user.ID, gomock.Any(), accessDuration).Return("", errors.New("invalid token"))
+		tokens, err := serverMock.uc.LoginUser(context.Background(), login, password, peer)
 		require.Error(t, err)
-		require.Empty(t, token)
+		require.Empty(t, tokens)
+	})
+}
+
+func TestAuthorization_ChangePassword(t *testing.T) {
+	user := entity.UserDAO{
+		ID:           1,
+		Login:        login,
+		PasswordHash: hashedPassword,
+	}
+	newPassword, newHash := "new_password", "new_hash"
+	history := []string{"old_hash_1", "old_hash_2"}
+
+	t.Run("proper change password", func(t *testing.T) {
+		expectPasswordChecked(user)
+		expectAuthenticated(user.ID)
+		serverMock.repo.EXPECT().GetPasswordHistory(context.Background(), user.ID, policy.HistorySize-1).Return(history, nil)
+		for _, hash := range append([]string{hashedPassword}, history...) {
+			serverMock.hasher.EXPECT().Check(newPassword, hash).Return(errors.New("mismatch"))
+		}
+		serverMock.hasher.EXPECT().Hash(newPassword).Return(newHash, nil)
+		serverMock.repo.EXPECT().ChangePassword(context.Background(), user.ID, hashedPassword, newHash).Return(nil)
+		serverMock.repo.EXPECT().CreateSession(context.Background(), gomock.Any()).Return(nil)
+		serverMock.maker.EXPECT().Create(user.ID, gomock.Any(), accessDuration).Return("token", nil)
+		tokens, err := serverMock.uc.ChangePassword(context.Background(), login, password, newPassword, "", peer)
+		require.NoError(t, err)
+		require.Equal(t, "token", tokens.AccessToken)
+	})
+
+	t.Run("recent password reused", func(t *testing.T) {
+		expectPasswordChecked(user)
+		expectAuthenticated(user.ID)
+		serverMock.repo.EXPECT().GetPasswordHistory(context.Background(), user.ID, policy.HistorySize-1).Return(history, nil)
+		serverMock.hasher.EXPECT().Check(newPassword, hashedPassword).Return(errors.New("mismatch"))
+		serverMock.hasher.EXPECT().Check(newPassword, history[0]).Return(nil)
+		tokens, err := serverMock.uc.ChangePassword(context.Background(), login, password, newPassword, "", peer)
+		require.ErrorIs(t, err, usecase.ErrPasswordReused)
+		require.Empty(t, tokens)
+	})
+
+	t.Run("wrong current password", func(t *testing.T) {
+		expectUnlocked()
+		serverMock.repo.EXPECT().GetUser(context.Background(), login).Return(user, nil)
+		serverMock.hasher.EXPECT().Check("wrong", hashedPassword).Return(errors.New("mismatch"))
+		expectFailureCounted()
+		tokens, err := serverMock.uc.ChangePassword(context.Background(), login, "wrong", newPassword, "", peer)
+		require.ErrorIs(t, err, usecase.ErrInvalidCredentials)
+		require.Empty(t, tokens)
+	})
+
+	t.Run("password changed concurrently", func(t *testing.T) {
+		expectPasswordChecked(user)
+		expectAuthenticated(user.ID)
+		serverMock.repo.EXPECT().GetPasswordHistory(context.Background(), user.ID, policy.HistorySize-1).Return(nil, nil)
+		serverMock.hasher.EXPECT().Check(newPassword, hashedPassword).Return(errors.New("mismatch"))
+		serverMock.hasher.EXPECT().Hash(newPassword).Return(newHash, nil)
+		serverMock.repo.EXPECT().ChangePassword(context.Background(), user.ID, hashedPassword, newHash).Return(repo.ErrNotFound)
+		_, err := serverMock.uc.ChangePassword(context.Background(), login, password, newPassword, "", peer)
+		require.ErrorIs(t, err, repo.ErrNotFound)
+	})
+}
+
+// currentCode возвращает текущий код аутентификатора с секретом secret.
+func currentCode(t *testing.T, secret string) string {
+	code, err := otp.TOTP(secret, time.Now(), otp.DefaultPeriod, otp.AlgorithmSHA1, otp.DefaultDigits)
+	require.NoError(t, err)
+	return code
+}
+
+func TestAuthorization_SecondFactor(t *testing.T) {
+	user := entity.UserDAO{
+		ID:                1,
+		Login:             login,
+		PasswordHash:      hashedPassword,
+		PasswordChangedAt: time.Now(),
+	}
+	secret := "JBSWY3DPEHPK3PXP"
+	totp := entity.TOTPDAO{UserID: user.ID, Secret: secret}
+
+	// незавершённый вход, созданный после проверки пароля
+	var challenge entity.ChallengeDAO
+	expectPasswordChecked(user)
+	serverMock.hasher.EXPECT().NeedsRehash(hashedPassword).Return(false)
+	serverMock.repo.EXPECT().GetTOTP(context.Background(), user.ID).Return(totp, nil)
+	serverMock.repo.EXPECT().CreateChallenge(context.Background(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, ch entity.ChallengeDAO) error {
+			challenge = ch
+			return nil
+		})
+	pending, err := serverMock.uc.LoginUser(context.Background(), login, password, peer)
+	require.NoError(t, err)
+	require.Empty(t, pending.AccessToken)
+	require.Empty(t, pending.RefreshToken)
+	require.NotEmpty(t, pending.Challenge)
+	require.Equal(t, user.ID, challenge.UserID)
+	challenge.Login = login
+
+	// expectChallenge ожидает проверку незавершённого входа и настроек второго фактора
+	expectChallenge := func() {
+		serverMock.repo.EXPECT().GetChallenge(context.Background(), challenge.ID).Return(challenge, nil)
+		expectUnlocked()
+		serverMock.repo.EXPECT().GetTOTP(context.Background(), user.ID).Return(totp, nil)
+	}
+
+	t.Run("wrong code", func(t *testing.T) {
+		expectChallenge()
+		expectFailureCounted()
+		tokens, err := serverMock.uc.VerifySecondFactor(context.Background(), pending.Challenge, "000000", peer)
+		require.ErrorIs(t, err, usecase.ErrInvalidCode)
+		require.Empty(t, tokens)
+	})
+
+	t.Run("code already used", func(t *testing.T) {
+		expectChallenge()
+		serverMock.repo.EXPECT().UseTOTPStep(context.Background(), user.ID, gomock.Any()).Return(repo.ErrNotFound)
+		_, err := serverMock.uc.VerifySecondFactor(context.Background(), pending.Challenge, currentCode(t, secret), peer)
+		require.ErrorIs(t, err, usecase.ErrInvalidCode)
+	})
+
+	t.Run("proper code", func(t *testing.T) {
+		expectChallenge()
+		serverMock.repo.EXPECT().UseTOTPStep(context.Background(), user.ID, gomock.Any()).Return(nil)
+		serverMock.repo.EXPECT().DeleteChallenge(context.Background(), challenge.ID).Return(nil)
+		serverMock.repo.EXPECT().ResetLoginFailures(context.Background(), "login:"+login).Return(nil)
+		serverMock.repo.EXPECT().CreateSession(context.Background(), gomock.Any()).Return(nil)
+		serverMock.maker.EXPECT().Create(user.ID, gomock.Any(), accessDuration).Return("token", nil)
+		tokens, err := serverMock.uc.VerifySecondFactor(context.Background(), pending.Challenge, currentCode(t, secret), peer)
+		require.NoError(t, err)
+		require.Equal(t, "token", tokens.AccessToken)
+		require.NotEmpty(t, tokens.RefreshToken)
+	})
+
+	t.Run("wrong challenge secret", func(t *testing.T) {
+		serverMock.repo.EXPECT().GetChallenge(context.Background(), challenge.ID).Return(challenge, nil)
+		_, err := serverMock.uc.VerifySecondFactor(context.Background(), challenge.ID+".wrong", currentCode(t, secret), peer)
+		require.ErrorIs(t, err, usecase.ErrInvalidChallenge)
+	})
+
+	t.Run("expired challenge", func(t *testing.T) {
+		expired := challenge
+		expired.ExpiresAt = time.Now().Add(-time.Second)
+		serverMock.repo.EXPECT().GetChallenge(context.Background(), challenge.ID).Return(expired, nil)
+		_, err := serverMock.uc.VerifySecondFactor(context.Background(), pending.Challenge, currentCode(t, secret), peer)
+		require.ErrorIs(t, err, usecase.ErrInvalidChallenge)
+	})
+
+	t.Run("malformed challenge", func(t *testing.T) {
+		_, err := serverMock.uc.VerifySecondFactor(context.Background(), "malformed", "123456", peer)
+		require.ErrorIs(t, err, usecase.ErrInvalidChallenge)
+	})
+
+	t.Run("change password requires code", func(t *testing.T) {
+		expectPasswordChecked(user)
+		serverMock.repo.EXPECT().GetTOTP(context.Background(), user.ID).Return(totp, nil)
+		tokens, err := serverMock.uc.ChangePassword(context.Background(), login, password, "new_password", "", peer)
+		require.ErrorIs(t, err, usecase.ErrSecondFactorRequired)
+		require.Empty(t, tokens)
+	})
+}
+
+func TestAuthorization_TOTPEnrollment(t *testing.T) {
+	userID := 1
+	key := "totp:1"
+	user := entity.UserDAO{ID: userID, Login: login, PasswordHash: hashedPassword}
+
+	var secret string
+	serverMock.repo.EXPECT().GetUserByID(context.Background(), userID).Return(user, nil)
+	expectPasswordChecked(user)
+	expectAuthenticated(userID)
+	serverMock.repo.EXPECT().SaveTOTPPending(context.Background(), userID, gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ int, s string) error {
+			secret = s
+			return nil
+		})
+	enrollment, err := serverMock.uc.EnrollTOTP(context.Background(), userID, password, "", peer)
+	require.NoError(t, err)
+	require.Equal(t, secret, enrollment.Secret)
+	require.Equal(t, otp.AlgorithmSHA1, enrollment.Algorithm)
+	require.Equal(t, otp.DefaultDigits, enrollment.Digits)
+	require.Equal(t, otp.DefaultPeriod, enrollment.Period)
+
+	// expectTOTP ожидает проверку блокировки и чтение настроек второго фактора
+	expectTOTP := func(totp entity.TOTPDAO) {
+		serverMock.repo.EXPECT().GetLoginLock(context.Background(), key).Return(time.Time{}, nil)
+		serverMock.repo.EXPECT().GetTOTP(context.Background(), userID).Return(totp, nil)
+	}
+
+	t.Run("confirm wrong code", func(t *testing.T) {
+		expectTOTP(entity.TOTPDAO{UserID: userID, PendingSecret: secret})
+		serverMock.repo.EXPECT().AddLoginFailure(gomock.Any(), key, lockout.Window).Return(1, nil)
+		err := serverMock.uc.ConfirmTOTP(context.Background(), userID, "000000")
+		require.ErrorIs(t, err, usecase.ErrInvalidCode)
+	})
+
+	t.Run("confirm without enrollment", func(t *testing.T) {
+		expectTOTP(entity.TOTPDAO{})
+		err := serverMock.uc.ConfirmTOTP(context.Background(), userID, currentCode(t, secret))
+		require.ErrorIs(t, err, usecase.ErrTOTPNotEnrolled)
+	})
+
+	t.Run("proper confirm", func(t *testing.T) {
+		expectTOTP(entity.TOTPDAO{UserID: userID, PendingSecret: secret})
+		serverMock.repo.EXPECT().ConfirmTOTP(context.Background(), userID, gomock.Any()).Return(nil)
+		serverMock.repo.EXPECT().ResetLoginFailures(context.Background(), key).Return(nil)
+		err := serverMock.uc.ConfirmTOTP(context.Background(), userID, currentCode(t, secret))
+		require.NoError(t, err)
+	})
+
+	t.Run("codes locked", func(t *testing.T) {
+		serverMock.repo.EXPECT().GetLoginLock(context.Background(), key).Return(time.Now().Add(time.Minute), nil)
+		err := serverMock.uc.DisableTOTP(context.Background(), userID, currentCode(t, secret))
+		require.ErrorIs(t, err, usecase.ErrTooManyAttempts)
+	})
+
+	t.Run("disable wrong code", func(t *testing.T) {
+		expectTOTP(entity.TOTPDAO{UserID: userID, Secret: secret})
+		serverMock.repo.EXPECT().AddLoginFailure(gomock.Any(), key, lockout.Window).Return(1, nil)
+		err := serverMock.uc.DisableTOTP(context.Background(), userID, "000000")
+		require.ErrorIs(t, err, usecase.ErrInvalidCode)
+	})
+
+	t.Run("disable with used code", func(t *testing.T) {
+		// код уже принят другим запросом (например, входом) - второй фактор не отключается
+		expectTOTP(entity.TOTPDAO{UserID: userID, Secret: secret})
+		serverMock.repo.EXPECT().UseTOTPStep(context.Background(), userID, gomock.Any()).Return(repo.ErrNotFound)
+		err := serverMock.uc.DisableTOTP(context.Background(), userID, currentCode(t, secret))
+		require.ErrorIs(t, err, usecase.ErrInvalidCode)
+	})
+
+	t.Run("proper disable", func(t *testing.T) {
+		expectTOTP(entity.TOTPDAO{UserID: userID, Secret: secret})
+		serverMock.repo.EXPECT().UseTOTPStep(context.Background(), userID, gomock.Any()).Return(nil)
+		serverMock.repo.EXPECT().DeleteTOTP(context.Background(), userID).Return(nil)
+		serverMock.repo.EXPECT().ResetLoginFailures(context.Background(), key).Return(nil)
+		err := serverMock.uc.DisableTOTP(context.Background(), userID, currentCode(t, secret))
+		require.NoError(t, err)
+	})
+
+	// замена действующего аутентификатора: без пароля и его кода новый секрет не создаётся
+	totp := entity.TOTPDAO{UserID: userID, Secret: secret}
+
+	t.Run("re-enroll wrong password", func(t *testing.T) {
+		serverMock.repo.EXPECT().GetUserByID(context.Background(), userID).Return(user, nil)
+		expectUnlocked()
+		serverMock.repo.EXPECT().GetUser(context.Background(), login).Return(user, nil)
+		serverMock.hasher.EXPECT().Check("wrong", hashedPassword).Return(errors.New("mismatch"))
+		expectFailureCounted()
+		_, err := serverMock.uc.EnrollTOTP(context.Background(), userID, "wrong", currentCode(t, secret), peer)
+		require.ErrorIs(t, err, usecase.ErrMismatchPassword)
+	})
+
+	t.Run("re-enroll without current code", func(t *testing.T) {
+		serverMock.repo.EXPECT().GetUserByID(context.Background(), userID).Return(user, nil)
+		expectPasswordChecked(user)
+		serverMock.repo.EXPECT().GetTOTP(context.Background(), userID).Return(totp, nil)
+		_, err := serverMock.uc.EnrollTOTP(context.Background(), userID, password, "", peer)
+		require.ErrorIs(t, err, usecase.ErrSecondFactorRequired)
+	})
+
+	t.Run("re-enroll wrong current code", func(t *testing.T) {
+		serverMock.repo.EXPECT().GetUserByID(context.Background(), userID).Return(user, nil)
+		expectPasswordChecked(user)
+		serverMock.repo.EXPECT().GetTOTP(context.Background(), userID).Return(totp, nil)
+		expectFailureCounted()
+		_, err := serverMock.uc.EnrollTOTP(context.Background(), userID, password, "000000", peer)
+		require.ErrorIs(t, err, usecase.ErrInvalidCode)
+	})
+
+	t.Run("re-enroll locked out", func(t *testing.T) {
+		serverMock.repo.EXPECT().GetUserByID(context.Background(), userID).Return(user, nil)
+		serverMock.repo.EXPECT().GetLoginLock(context.Background(), "login:"+login, "peer:"+peer).
+			Return(time.Now().Add(time.Minute), nil)
+		_, err := serverMock.uc.EnrollTOTP(context.Background(), userID, password, currentCode(t, secret), peer)
+		require.ErrorIs(t, err, usecase.ErrTooManyAttempts)
+	})
+
+	t.Run("re-enroll with current code", func(t *testing.T) {
+		serverMock.repo.EXPECT().GetUserByID(context.Background(), userID).Return(user, nil)
+		expectPasswordChecked(user)
+		serverMock.repo.EXPECT().GetTOTP(context.Background(), userID).Return(totp, nil)
+		serverMock.repo.EXPECT().UseTOTPStep(context.Background(), userID, gomock.Any()).Return(nil)
+		serverMock.repo.EXPECT().ResetLoginFailures(context.Background(), "login:"+login).Return(nil)
+		serverMock.repo.EXPECT().SaveTOTPPending(context.Background(), userID, gomock.Any()).Return(nil)
+		enrollment, err := serverMock.uc.EnrollTOTP(context.Background(), userID, password, currentCode(t, secret), peer)
+		require.NoError(t, err)
+		require.NotEqual(t, secret, enrollment.Secret)
+	})
+}
+
+// Сценарий целиком на хранилище в памяти (без ожиданий gomock).
+func TestAuthorization_MemoryRepo(t *testing.T) {
+	maker, err := token.NewPasetoMaker("12345678901234567890123456789012")
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	store := memory.New()
+	auth := usecase.NewAuthService(store, passwordhash.New(), maker, accessDuration, refreshDuration, policy, lockout)
+
+	tokens, err := auth.RegisterUser(ctx, login, password)
+	require.NoError(t, err)
+	require.NotEmpty(t, tokens.AccessToken)
+
+	_, err = auth.RegisterUser(ctx, login, password)
+	require.ErrorIs(t, err, repo.ErrUserExist)
+
+	t.Run("login", func(t *testing.T) {
+		tokens, err := auth.LoginUser(ctx, login, password, peer)
+		require.NoError(t, err)
+
+		userID, _, err := auth.ParseToken(ctx, tokens.AccessToken)
+		require.NoError(t, err)
+
+		user, err := store.GetUser(ctx, login)
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, userID)
+	})
+
+	t.Run("change password revokes sessions", func(t *testing.T) {
+		changed, err := auth.ChangePassword(ctx, login, password, "new_password", "", peer)
+		require.NoError(t, err)
+
+		_, _, err = auth.ParseToken(ctx, tokens.AccessToken)
+		require.ErrorIs(t, err, usecase.ErrInvalidSession)
+		_, _, err = auth.ParseToken(ctx, changed.AccessToken)
+		require.NoError(t, err)
+
+		_, err = auth.ChangePassword(ctx, login, "new_password", password, "", peer)
+		require.ErrorIs(t, err, usecase.ErrPasswordReused)
+	})
+
+	t.Run("lockout", func(t *testing.T) {
+		for i := 0; i < lockout.MaxFailures; i++ {
+			_, err := auth.LoginUser(ctx, login, "wrong", peer)
+			require.ErrorIs(t, err, usecase.ErrInvalidCredentials)
+		}
+
+		_, err := auth.LoginUser(ctx, login, "new_password", peer)
+		require.ErrorIs(t, err, usecase.ErrTooManyAttempts)
+	})
+}
+
+func TestAuthorization_Sessions(t *testing.T) {
+	userID := 1
+
+	// сессия, открытая при входе: её refresh-токен и сохранённое в БД состояние
+	var session entity.SessionDAO
+	expectPasswordChecked(entity.UserDAO{ID: userID, Login: login, PasswordHash: hashedPassword, PasswordChangedAt: time.Now()})
+	serverMock.hasher.EXPECT().NeedsRehash(hashedPassword).Return(false)
+	expectAuthenticated(userID)
+	serverMock.repo.EXPECT().CreateSession(context.Background(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, s entity.SessionDAO) error {
+			session = s
+			return nil
+		})
+	serverMock.maker.EXPECT().Create(userID, gomock.Any(), accessDuration).Return("token", nil)
+	tokens, err := serverMock.uc.LoginUser(context.Background(), login, password, peer)
+	require.NoError(t, err)
+	require.Equal(t, userID, session.UserID)
+
+	t.Run("proper refresh", func(t *testing.T) {
+		serverMock.repo.EXPECT().GetSession(context.Background(), session.ID).Return(session, nil)
+		serverMock.repo.EXPECT().RotateSession(context.Background(), session.ID, session.RefreshHash, gomock.Any(), gomock.Any()).Return(nil)
+		serverMock.maker.EXPECT().Create(userID, session.ID, accessDuration).Return("new_token", nil)
+		refreshed, err := serverMock.uc.Refresh(context.Background(), tokens.RefreshToken)
+		require.NoError(t, err)
+		assert.Equal(t, "new_token", refreshed.AccessToken)
+		assert.NotEqual(t, tokens.RefreshToken, refreshed.RefreshToken)
+	})
+
+	t.Run("reused refresh token revokes session", func(t *testing.T) {
+		rotated := session
+		rotated.RefreshHash = "another_hash"
+		serverMock.repo.EXPECT().GetSession(context.Background(), session.ID).Return(rotated, nil)
+		serverMock.repo.EXPECT().RevokeSession(context.Background(), session.ID).Return(nil)
+		_, err := serverMock.uc.Refresh(context.Background(), tokens.RefreshToken)
+		require.ErrorIs(t, err, usecase.ErrInvalidSession)
+	})
+
+	t.Run("refresh of revoked session", func(t *testing.T) {
+		revoked := session
+		now := time.Now()
+		revoked.RevokedAt = &now
+		serverMock.repo.EXPECT().GetSession(context.Background(), session.ID).Return(revoked, nil)
+		_, err := serverMock.uc.Refresh(context.Background(), tokens.RefreshToken)
+		require.ErrorIs(t, err, usecase.ErrInvalidSession)
+	})
+
+	t.Run("malformed refresh token", func(t *testing.T) {
+		_, err := serverMock.uc.Refresh(context.Background(), "malformed")
+		require.ErrorIs(t, err, usecase.ErrInvalidSession)
+	})
+
+	t.Run("parse token of active session", func(t *testing.T) {
+		serverMock.maker.EXPECT().Verify("token").Return(&token.Payload{UserID: userID, SessionID: session.ID}, nil)
+		serverMock.repo.EXPECT().GetSession(context.Background(), session.ID).Return(session, nil)
+		id, sessionID, err := serverMock.uc.ParseToken(context.Background(), "token")
+		require.NoError(t, err)
+		assert.Equal(t, userID, id)
+		assert.Equal(t, session.ID, sessionID)
+	})
+
+	t.Run("parse token of revoked session", func(t *testing.T) {
+		revoked := session
+		now := time.Now()
+		revoked.RevokedAt = &now
+		serverMock.maker.EXPECT().Verify("token").Return(&token.Payload{UserID: userID, SessionID: session.ID}, nil)
+		serverMock.repo.EXPECT().GetSession(context.Background(), session.ID).Return(revoked, nil)
+		_, _, err := serverMock.uc.ParseToken(context.Background(), "token")
+		require.ErrorIs(t, err, usecase.ErrInvalidSession)
+	})
+
+	t.Run("logout", func(t *testing.T) {
+		serverMock.repo.EXPECT().RevokeSession(context.Background(), session.ID).Return(nil)
+		require.NoError(t, serverMock.uc.Logout(context.Background(), session.ID))
 	})
 }
 
@@ -168,21 +776,93 @@ tag #2: test-2_2;`,
 	}
 
 	t.Run("get pairs from exist user", func(t *testing.T) {
-		serverMock.repo.EXPECT().GetAllPairs(context.Background(), userID).Return(testPairs, nil)
-		pairs, err := serverMock.uc.ViewAllPairs(userID)
+		serverMock.repo.EXPECT().ListPairs(context.Background(), userID, firstPage).Return(testPairs, nil)
+		pairs, _, err := serverMock.uc.ViewPairs(context.Background(), userID, entity.PageRequest{})
 		require.NoError(t, err)
 		require.NotEmpty(t, pairs)
 		require.IsType(t, []entity.PairDTO{}, pairs)
 	})
 
 	t.Run("get pairs from not exist user", func(t *testing.T) {
-		serverMock.repo.EXPECT().GetAllPairs(context.Background(), userID).Return(nil, errors.New("user_id not exist"))
-		pairs, err := serverMock.uc.ViewAllPairs(userID)
+		serverMock.repo.EXPECT().ListPairs(context.Background(), userID, firstPage).Return(nil, errors.New("user_id not exist"))
+		pairs, _, err := serverMock.uc.ViewPairs(context.Background(), userID, entity.PageRequest{})
 		require.Error(t, err)
 		require.Empty(t, pairs)
 	})
 }
 
+func TestPaging(t *testing.T) {
+	userID := 1
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	notes := []entity.TextDAO{
+		{ID: 1, UserID: userID, Note: "first", CreatedAt: created},
+		{ID: 2, UserID: userID, Note: "second", CreatedAt: created.Add(time.Second)},
+		{ID: 3, UserID: userID, Note: "third", CreatedAt: created.Add(2 * time.Second)},
+	}
+
+	var token string
+	t.Run("first page", func(t *testing.T) {
+		serverMock.repo.EXPECT().ListNotes(context.Background(), userID, entity.ListQuery{Limit: 3}).Return(notes, nil)
+		page, next, err := serverMock.uc.ViewNotes(context.Background(), userID, entity.PageRequest{Size: 2})
+		require.NoError(t, err)
+		require.Len(t, page, 2)
+		assert.Equal(t, 2, page[1].ID)
+		require.NotEmpty(t, next)
+		token = next
+	})
+
+	t.Run("next page", func(t *testing.T) {
+		after := entity.Cursor{CreatedAt: notes[1].CreatedAt, ID: notes[1].ID}
+		serverMock.repo.EXPECT().
+			ListNotes(context.Background(), userID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ int, query entity.ListQuery) ([]entity.TextDAO, error) {
+				require.NotNil(t, query.After)
+				assert.True(t, after.CreatedAt.Equal(query.After.CreatedAt))
+				assert.Equal(t, after.ID, query.After.ID)
+				return notes[2:], nil
+			})
+		page, next, err := serverMock.uc.ViewNotes(context.Background(), userID, entity.PageRequest{Size: 2, Token: token})
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+		assert.Empty(t, next)
+	})
+
+	t.Run("page size limits", func(t *testing.T) {
+		serverMock.repo.EXPECT().
+			ListNotes(context.Background(), userID, entity.ListQuery{Limit: usecase.MaxPageSize + 1}).
+			Return(nil, nil)
+		_, _, err := serverMock.uc.ViewNotes(context.Background(), userID, entity.PageRequest{Size: usecase.MaxPageSize * 2})
+		require.NoError(t, err)
+	})
+
+	t.Run("label filter", func(t *testing.T) {
+		folderID := 4
+		filter := entity.LabelFilter{FolderID: &folderID, TagID: 2}
+		serverMock.repo.EXPECT().
+			ListNotes(context.Background(), userID, entity.ListQuery{Limit: 3, Filter: filter}).
+			Return(notes[:1], nil)
+		page, _, err := serverMock.uc.ViewNotes(context.Background(), userID, entity.PageRequest{Size: 2, Filter: filter})
+		require.NoError(t, err)
+		require.Len(t, page, 1)
+	})
+
+	t.Run("invalid page", func(t *testing.T) {
+		negative := -1
+		for name, page := range map[string]entity.PageRequest{
+			"negative folder": {Filter: entity.LabelFilter{FolderID: &negative}},
+			"negative tag":    {Filter: entity.LabelFilter{TagID: -1}},
+			"negative size":   {Size: -1},
+			"unknown order":   {Order: 7},
+			"empty range":     {CreatedFrom: created, CreatedTo: created},
+			"malformed token": {Token: "not a token"},
+			"another order":   {Token: token, Order: entity.SortCreatedDesc},
+		} {
+			_, _, err := serverMock.uc.ViewNotes(context.Background(), userID, page)
+			require.ErrorIs(t, err, usecase.ErrInvalidPage, name)
+		}
+	})
+}
+
 func TestBank_GetAll(t *testing.T) {
 	userID := 1
 	testCards := []entity.BankDAO{
@@ -205,17 +885,503 @@ tag #2: test bank 2-2;`,
 	}
 
 	t.Run("get cards from exist user", func(t *testing.T) {
-		serverMock.repo.EXPECT().GetAllCards(context.Background(), userID).Return(testCards, nil)
-		cards, err := serverMock.uc.ViewAllCards(userID)
+		serverMock.repo.EXPECT().ListCards(context.Background(), userID, firstPage).Return(testCards, nil)
+		cards, _, err := serverMock.uc.ViewCards(context.Background(), userID, entity.PageRequest{})
 		require.NoError(t, err)
 		require.NotEmpty(t, cards)
 		require.IsType(t, []entity.BankDTO{}, cards)
 	})
 
 	t.Run("get cards from not exist user", func(t *testing.T) {
-		serverMock.repo.EXPECT().GetAllCards(context.Background(), userID).Return(nil, errors.New("user_id not exist"))
-		cards, err := serverMock.uc.ViewAllCards(userID)
+		serverMock.repo.EXPECT().ListCards(context.Background(), userID, firstPage).Return(nil, errors.New("user_id not exist"))
+		cards, _, err := serverMock.uc.ViewCards(context.Background(), userID, entity.PageRequest{})
 		require.Error(t, err)
 		require.Empty(t, cards)
 	})
 }
+
+func TestPairs_Modify(t *testing.T) {
+	userID := 1
+	pair := entity.PairDTO{
+		ID:       10,
+		Login:    "pairTest",
+		Password: "pairPass",
+		Metadata: "tag #1: test-1;",
+	}
+	pairDAO := entity.PairDAO{
+		ID:       pair.ID,
+		UserID:   userID,
+		Login:    pair.Login,
+		Password: pair.Password,
+		Metadata: pair.Metadata,
+	}
+
+	t.Run("create pair", func(t *testing.T) {
+		newPair := pairDAO
+		newPair.ID = 0
+		serverMock.repo.EXPECT().CreatePair(context.Background(), newPair).Return(pair.ID, nil)
+		id, err := serverMock.uc.CreatePair(context.Background(), userID, pair)
+		require.NoError(t, err)
+		assert.Equal(t, pair.ID, id)
+	})
+
+	t.Run("update pair", func(t *testing.T) {
+		serverMock.repo.EXPECT().UpdatePair(context.Background(), pairDAO).Return(nil)
+		err := serverMock.uc.UpdatePair(context.Background(), userID, pair)
+		require.NoError(t, err)
+	})
+
+	t.Run("update not exist pair", func(t *testing.T) {
+		serverMock.repo.EXPECT().UpdatePair(context.Background(), pairDAO).Return(repo.ErrNotFound)
+		err := serverMock.uc.UpdatePair(context.Background(), userID, pair)
+		require.ErrorIs(t, err, repo.ErrNotFound)
+	})
+
+	t.Run("delete pair", func(t *testing.T) {
+		serverMock.repo.EXPECT().DeletePair(context.Background(), userID, pair.ID).Return(nil)
+		err := serverMock.uc.DeletePair(context.Background(), userID, pair.ID)
+		require.NoError(t, err)
+	})
+
+	t.Run("delete not exist pair", func(t *testing.T) {
+		serverMock.repo.EXPECT().DeletePair(context.Background(), userID, pair.ID).Return(repo.ErrNotFound)
+		err := serverMock.uc.DeletePair(context.Background(), userID, pair.ID)
+		require.ErrorIs(t, err, repo.ErrNotFound)
+	})
+}
+
+func TestBank_Modify(t *testing.T) {
+	userID := 1
+	card := entity.BankDTO{
+		ID:             20,
+		CardHolder:     "Ivanov Ivan",
+		Number:         "1234 0987 5678 6543",
+		ExpirationDate: "09/99",
+		Metadata:       "tag #1: test bank;",
+	}
+	cardDAO := entity.BankDAO{
+		ID:             card.ID,
+		UserID:         userID,
+		CardHolder:     card.CardHolder,
+		Number:         card.Number,
+		ExpirationDate: card.ExpirationDate,
+		Metadata:       card.Metadata,
+	}
+
+	t.Run("create card", func(t *testing.T) {
+		newCard := cardDAO
+		newCard.ID = 0
+		serverMock.repo.EXPECT().CreateCard(context.Background(), newCard).Return(card.ID, nil)
+		id, err := serverMock.uc.CreateCard(context.Background(), userID, card)
+		require.NoError(t, err)
+		assert.Equal(t, card.ID, id)
+	})
+
+	t.Run("update card", func(t *testing.T) {
+		serverMock.repo.EXPECT().UpdateCard(context.Background(), cardDAO).Return(nil)
+		err := serverMock.uc.UpdateCard(context.Background(), userID, card)
+		require.NoError(t, err)
+	})
+
+	t.Run("delete not exist card", func(t *testing.T) {
+		serverMock.repo.EXPECT().DeleteCard(context.Background(), userID, card.ID).Return(repo.ErrNotFound)
+		err := serverMock.uc.DeleteCard(context.Background(), userID, card.ID)
+		require.ErrorIs(t, err, repo.ErrNotFound)
+	})
+}
+
+func TestText_Modify(t *testing.T) {
+	userID := 1
+	note := entity.TextDTO{
+		ID:       30,
+		Note:     "some text from user",
+		Metadata: "tag #1: test text;",
+	}
+	noteDAO := entity.TextDAO{
+		ID:       note.ID,
+		UserID:   userID,
+		Note:     note.Note,
+		Metadata: note.Metadata,
+	}
+
+	t.Run("create note", func(t *testing.T) {
+		newNote := noteDAO
+		newNote.ID = 0
+		serverMock.repo.EXPECT().CreateNote(context.Background(), newNote).Return(note.ID, nil)
+		id, err := serverMock.uc.CreateNote(context.Background(), userID, note)
+		require.NoError(t, err)
+		assert.Equal(t, note.ID, id)
+	})
+
+	t.Run("update note", func(t *testing.T) {
+		serverMock.repo.EXPECT().UpdateNote(context.Background(), noteDAO).Return(nil)
+		err := serverMock.uc.UpdateNote(context.Background(), userID, note)
+		require.NoError(t, err)
+	})
+
+	t.Run("delete note", func(t *testing.T) {
+		serverMock.repo.EXPECT().DeleteNote(context.Background(), userID, note.ID).Return(nil)
+		err := serverMock.uc.DeleteNote(context.Background(), userID, note.ID)
+		require.NoError(t, err)
+	})
+}
+
+func TestBinary(t *testing.T) {
+	userID := 1
+	binary := entity.BinaryDTO{
+		ID:       40,
+		Filename: "test.bin",
+		Size:     2,
+		Data:     []byte{0x01, 0x02, 0x03},
+		Metadata: "tag #1: test binary;",
+	}
+
+	t.Run("create binary", func(t *testing.T) {
+		serverMock.repo.EXPECT().CreateBinary(context.Background(), entity.BinaryDAO{
+			UserID:   userID,
+			Filename: binary.Filename,
+			Size:     binary.Size,
+			Data:     binary.Data,
+			Metadata: binary.Metadata,
+		}).Return(binary.ID, nil)
+		id, err := serverMock.uc.CreateBinary(context.Background(), userID, binary)
+		require.NoError(t, err)
+		assert.Equal(t, binary.ID, id)
+	})
+
+	t.Run("create too large binary", func(t *testing.T) {
+		large := binary
+		large.Data = make([]byte, maxBinarySize+encryption.Overhead+1)
+		require.Equal(t, len(large.Data)-1, serverMock.uc.MaxStoredSize())
+		_, err := serverMock.uc.CreateBinary(context.Background(), userID, large)
+		require.ErrorIs(t, err, usecase.ErrBinaryTooLarge)
+	})
+
+	t.Run("create binary with too large declared size", func(t *testing.T) {
+		large := binary
+		large.Size = maxBinarySize + 1
+		_, err := serverMock.uc.CreateBinary(context.Background(), userID, large)
+		require.ErrorIs(t, err, usecase.ErrBinaryTooLarge)
+	})
+
+	t.Run("get all binaries", func(t *testing.T) {
+		serverMock.repo.EXPECT().ListBinaries(context.Background(), userID, firstPage).Return([]entity.BinaryDAO{
+			{ID: binary.ID, UserID: userID, Filename: binary.Filename, Size: 3, Metadata: binary.Metadata},
+		}, nil)
+		binaries, _, err := serverMock.uc.ViewBinaries(context.Background(), userID, entity.PageRequest{})
+		require.NoError(t, err)
+		require.Len(t, binaries, 1)
+		assert.Equal(t, binary.Filename, binaries[0].Filename)
+		assert.Empty(t, binaries[0].Data)
+	})
+
+	t.Run("get binary", func(t *testing.T) {
+		serverMock.repo.EXPECT().GetBinary(context.Background(), userID, binary.ID).Return(entity.BinaryDAO{
+			ID: binary.ID, UserID: userID, Filename: binary.Filename, Size: 3, Data: binary.Data,
+		}, nil)
+		got, err := serverMock.uc.GetBinary(context.Background(), userID, binary.ID)
+		require.NoError(t, err)
+		assert.Equal(t, binary.Data, got.Data)
+	})
+
+	t.Run("get not exist binary", func(t *testing.T) {
+		serverMock.repo.EXPECT().GetBinary(context.Background(), userID, binary.ID).Return(entity.BinaryDAO{}, repo.ErrNotFound)
+		_, err := serverMock.uc.GetBinary(context.Background(), userID, binary.ID)
+		require.ErrorIs(t, err, repo.ErrNotFound)
+	})
+
+	t.Run("delete binary", func(t *testing.T) {
+		serverMock.repo.EXPECT().DeleteBinary(context.Background(), userID, binary.ID).Return(nil)
+		err := serverMock.uc.DeleteBinary(context.Background(), userID, binary.ID)
+		require.NoError(t, err)
+	})
+}
+
+func TestOTP(t *testing.T) {
+	userID := 1
+	item := entity.OTPDTO{
+		ID:       50,
+		Secret:   "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ",
+		Issuer:   "example.com",
+		Metadata: "tag #1: test otp;",
+	}
+
+	t.Run("create otp with defaults", func(t *testing.T) {
+		serverMock.repo.EXPECT().CreateOTP(context.Background(), entity.OTPDAO{
+			ID:        item.ID,
+			UserID:    userID,
+			Kind:      "totp",
+			Secret:    item.Secret,
+			Algorithm: "SHA1",
+			Digits:    6,
+			Period:    30,
+			Issuer:    item.Issuer,
+			Metadata:  item.Metadata,
+		}).Return(item.ID, nil)
+		id, err := serverMock.uc.CreateOTP(context.Background(), userID, item)
+		require.NoError(t, err)
+		assert.Equal(t, item.ID, id)
+	})
+
+	t.Run("create otp with invalid secret", func(t *testing.T) {
+		invalid := item
+		invalid.Secret = "not base32!"
+		_, err := serverMock.uc.CreateOTP(context.Background(), userID, invalid)
+		require.ErrorIs(t, err, usecase.ErrInvalidOTP)
+	})
+
+	t.Run("create otp with client-side encrypted secret", func(t *testing.T) {
+		encrypted := item
+		encrypted.Secret = encryption.Prefix + "R0sBAAAAAA"
+		serverMock.repo.EXPECT().CreateOTP(context.Background(), gomock.Any()).Return(item.ID, nil)
+		_, err := serverMock.uc.CreateOTP(context.Background(), userID, encrypted)
+		require.NoError(t, err)
+
+		encrypted.Digits = 12
+		_, err = serverMock.uc.CreateOTP(context.Background(), userID, encrypted)
+		require.ErrorIs(t, err, usecase.ErrInvalidOTP)
+	})
+
+	t.Run("create otp with unknown algorithm", func(t *testing.T) {
+		invalid := item
+		invalid.Algorithm = "md5"
+		_, err := serverMock.uc.CreateOTP(context.Background(), userID, invalid)
+		require.ErrorIs(t, err, usecase.ErrInvalidOTP)
+	})
+
+	t.Run("update hotp counter", func(t *testing.T) {
+		hotp := item
+		hotp.Kind = "HOTP"
+		hotp.Counter = 5
+		serverMock.repo.EXPECT().UpdateOTP(context.Background(), entity.OTPDAO{
+			ID:        item.ID,
+			UserID:    userID,
+			Kind:      "hotp",
+			Secret:    item.Secret,
+			Algorithm: "SHA1",
+			Digits:    6,
+			Counter:   5,
+			Issuer:    item.Issuer,
+			Metadata:  item.Metadata,
+		}).Return(nil)
+		err := serverMock.uc.UpdateOTP(context.Background(), userID, hotp)
+		require.NoError(t, err)
+	})
+
+	t.Run("get all otps", func(t *testing.T) {
+		serverMock.repo.EXPECT().ListOTPs(context.Background(), userID, firstPage).Return([]entity.OTPDAO{
+			{ID: item.ID, UserID: userID, Kind: "totp", Secret: item.Secret, Algorithm: "SHA1", Digits: 6, Period: 30, Issuer: item.Issuer},
+		}, nil)
+		otps, _, err := serverMock.uc.ViewOTPs(context.Background(), userID, entity.PageRequest{})
+		require.NoError(t, err)
+		require.Len(t, otps, 1)
+		assert.Equal(t, item.Issuer, otps[0].Issuer)
+	})
+
+	t.Run("delete otp", func(t *testing.T) {
+		serverMock.repo.EXPECT().DeleteOTP(context.Background(), userID, item.ID).Return(nil)
+		err := serverMock.uc.DeleteOTP(context.Background(), userID, item.ID)
+		require.NoError(t, err)
+	})
+}
+
+func TestSync(t *testing.T) {
+	userID := 1
+	changes := entity.ChangesDAO{
+		Revision: 7,
+		Pairs: []entity.PairDAO{
+			{ID: 1, UserID: userID, Login: "login", Password: "pass", Revision: 6},
+		},
+		Deleted: []entity.Tombstone{
+			{Kind: entity.NoteKind, ID: 3, Revision: 7},
+		},
+	}
+
+	t.Run("full sync from zero revision", func(t *testing.T) {
+		serverMock.repo.EXPECT().GetChanges(context.Background(), userID, int64(-1)).Return(changes, nil)
+		result, err := serverMock.uc.Sync(context.Background(), userID, 0)
+		require.NoError(t, err)
+		assert.True(t, result.Full)
+		assert.Equal(t, int64(7), result.Revision)
+		assert.Equal(t, []entity.PairDTO{{ID: 1, Login: "login", Password: "pass"}}, result.Pairs)
+		assert.Empty(t, result.Deleted)
+	})
+
+	t.Run("delta since known revision", func(t *testing.T) {
+		serverMock.repo.EXPECT().GetChanges(context.Background(), userID, int64(5)).Return(changes, nil)
+		result, err := serverMock.uc.Sync(context.Background(), userID, 5)
+		require.NoError(t, err)
+		assert.False(t, result.Full)
+		assert.Len(t, result.Pairs, 1)
+		assert.Equal(t, changes.Deleted, result.Deleted)
+	})
+
+	t.Run("client ahead of server", func(t *testing.T) {
+		gomock.InOrder(
+			serverMock.repo.EXPECT().GetChanges(context.Background(), userID, int64(10)).
+				Return(entity.ChangesDAO{Revision: 7}, nil),
+			serverMock.repo.EXPECT().GetChanges(context.Background(), userID, int64(-1)).Return(changes, nil),
+		)
+		result, err := serverMock.uc.Sync(context.Background(), userID, 10)
+		require.NoError(t, err)
+		assert.True(t, result.Full)
+		assert.Len(t, result.Pairs, 1)
+		assert.Empty(t, result.Deleted)
+	})
+
+	t.Run("repo error", func(t *testing.T) {
+		serverMock.repo.EXPECT().GetChanges(context.Background(), userID, int64(5)).
+			Return(entity.ChangesDAO{}, errors.New("db down"))
+		_, err := serverMock.uc.Sync(context.Background(), userID, 5)
+		require.Error(t, err)
+	})
+}
+
+func TestAccount(t *testing.T) {
+	user := entity.UserDAO{ID: 1, Login: login, PasswordHash: hashedPassword}
+
+	t.Run("export account", func(t *testing.T) {
+		serverMock.repo.EXPECT().ExportAccount(context.Background(), user.ID).Return(entity.AccountDAO{
+			User:     user,
+			Notes:    []entity.TextDAO{{ID: 1, UserID: user.ID, Note: "note"}},
+			Binaries: []entity.BinaryDAO{{ID: 2, UserID: user.ID, Filename: "file", Size: 1, Data: []byte{1}}},
+		}, nil)
+		account, err := serverMock.uc.ExportAccount(context.Background(), user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, login, account.Login)
+		require.Len(t, account.Notes, 1)
+		require.Len(t, account.Binaries, 1)
+		assert.Equal(t, []byte{1}, account.Binaries[0].Data)
+		assert.False(t, account.ExportedAt.IsZero())
+	})
+
+	t.Run("delete account", func(t *testing.T) {
+		serverMock.repo.EXPECT().GetUserByID(context.Background(), user.ID).Return(user, nil)
+		expectPasswordChecked(user)
+		expectAuthenticated(user.ID)
+		serverMock.repo.EXPECT().DeleteAccount(context.Background(), user.ID, hashedPassword).Return(nil)
+		require.NoError(t, serverMock.uc.DeleteAccount(context.Background(), user.ID, password, "", peer))
+	})
+
+	t.Run("delete account with wrong password", func(t *testing.T) {
+		serverMock.repo.EXPECT().GetUserByID(context.Background(), user.ID).Return(user, nil)
+		expectUnlocked()
+		serverMock.repo.EXPECT().GetUser(context.Background(), login).Return(user, nil)
+		serverMock.hasher.EXPECT().Check("wrong", hashedPassword).Return(errors.New("mismatch"))
+		expectFailureCounted()
+		err := serverMock.uc.DeleteAccount(context.Background(), user.ID, "wrong", "", peer)
+		require.ErrorIs(t, err, usecase.ErrMismatchPassword)
+	})
+
+	t.Run("delete account locked out", func(t *testing.T) {
+		serverMock.repo.EXPECT().GetUserByID(context.Background(), user.ID).Return(user, nil)
+		serverMock.repo.EXPECT().GetLoginLock(context.Background(), "login:"+login, "peer:"+peer).
+			Return(time.Now().Add(time.Minute), nil)
+		err := serverMock.uc.DeleteAccount(context.Background(), user.ID, password, "", peer)
+		require.ErrorIs(t, err, usecase.ErrTooManyAttempts)
+	})
+
+	secret := "JBSWY3DPEHPK3PXP"
+	totp := entity.TOTPDAO{UserID: user.ID, Secret: secret}
+
+	t.Run("delete account requires code", func(t *testing.T) {
+		serverMock.repo.EXPECT().GetUserByID(context.Background(), user.ID).Return(user, nil)
+		expectPasswordChecked(user)
+		serverMock.repo.EXPECT().GetTOTP(context.Background(), user.ID).Return(totp, nil)
+		err := serverMock.uc.DeleteAccount(context.Background(), user.ID, password, "", peer)
+		require.ErrorIs(t, err, usecase.ErrSecondFactorRequired)
+	})
+
+	t.Run("delete account with wrong code", func(t *testing.T) {
+		serverMock.repo.EXPECT().GetUserByID(context.Background(), user.ID).Return(user, nil)
+		expectPasswordChecked(user)
+		serverMock.repo.EXPECT().GetTOTP(context.Background(), user.ID).Return(totp, nil)
+		expectFailureCounted()
+		err := serverMock.uc.DeleteAccount(context.Background(), user.ID, password, "000000", peer)
+		require.ErrorIs(t, err, usecase.ErrInvalidCode)
+	})
+
+	t.Run("delete account with code", func(t *testing.T) {
+		serverMock.repo.EXPECT().GetUserByID(context.Background(), user.ID).Return(user, nil)
+		expectPasswordChecked(user)
+		serverMock.repo.EXPECT().GetTOTP(context.Background(), user.ID).Return(totp, nil)
+		serverMock.repo.EXPECT().UseTOTPStep(context.Background(), user.ID, gomock.Any()).Return(nil)
+		serverMock.repo.EXPECT().ResetLoginFailures(context.Background(), "login:"+login).Return(nil)
+		serverMock.repo.EXPECT().DeleteAccount(context.Background(), user.ID, hashedPassword).Return(nil)
+		require.NoError(t, serverMock.uc.DeleteAccount(context.Background(), user.ID, password, currentCode(t, secret), peer))
+	})
+}
+
+func TestLabels(t *testing.T) {
+	userID := 1
+
+	t.Run("create folder", func(t *testing.T) {
+		serverMock.repo.EXPECT().
+			CreateFolder(context.Background(), entity.FolderDAO{UserID: userID, ParentID: 3, Name: "work"}).
+			Return(5, nil)
+		id, err := serverMock.uc.CreateFolder(context.Background(), userID, entity.FolderDTO{ParentID: 3, Name: " work "})
+		require.NoError(t, err)
+		assert.Equal(t, 5, id)
+	})
+
+	t.Run("create tag", func(t *testing.T) {
+		serverMock.repo.EXPECT().CreateTag(context.Background(), entity.TagDAO{UserID: userID, Name: "vpn"}).Return(7, nil)
+		id, err := serverMock.uc.CreateTag(context.Background(), userID, entity.TagDTO{Name: "vpn"})
+		require.NoError(t, err)
+		assert.Equal(t, 7, id)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		serverMock.repo.EXPECT().ListFolders(context.Background(), userID).
+			Return([]entity.FolderDAO{{ID: 5, UserID: userID, ParentID: 3, Name: "work"}}, nil)
+		folders, err := serverMock.uc.ListFolders(context.Background(), userID)
+		require.NoError(t, err)
+		assert.Equal(t, []entity.FolderDTO{{ID: 5, ParentID: 3, Name: "work"}}, folders)
+
+		serverMock.repo.EXPECT().ListTags(context.Background(), userID).
+			Return([]entity.TagDAO{{ID: 7, UserID: userID, Name: "vpn"}}, nil)
+		tags, err := serverMock.uc.ListTags(context.Background(), userID)
+		require.NoError(t, err)
+		assert.Equal(t, []entity.TagDTO{{ID: 7, Name: "vpn"}}, tags)
+	})
+
+	t.Run("rename and delete", func(t *testing.T) {
+		serverMock.repo.EXPECT().RenameFolder(context.Background(), userID, 5, "home").Return(nil)
+		require.NoError(t, serverMock.uc.RenameFolder(context.Background(), userID, 5, "home"))
+
+		serverMock.repo.EXPECT().DeleteTag(context.Background(), userID, 7).Return(repo.ErrNotFound)
+		require.ErrorIs(t, serverMock.uc.DeleteTag(context.Background(), userID, 7), repo.ErrNotFound)
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		_, err := serverMock.uc.CreateFolder(context.Background(), userID, entity.FolderDTO{Name: "  "})
+		require.ErrorIs(t, err, usecase.ErrInvalidLabel)
+		_, err = serverMock.uc.CreateFolder(context.Background(), userID, entity.FolderDTO{ParentID: -1, Name: "work"})
+		require.ErrorIs(t, err, usecase.ErrInvalidLabel)
+		err = serverMock.uc.RenameTag(context.Background(), userID, 7, strings.Repeat("a", usecase.MaxLabelNameLen+1))
+		require.ErrorIs(t, err, usecase.ErrInvalidLabel)
+	})
+
+	t.Run("record labels", func(t *testing.T) {
+		pair := entity.PairDTO{Login: "vpn", Labels: entity.Labels{FolderID: 5, TagIDs: entity.IDs{9, 7, 9}}}
+		serverMock.repo.EXPECT().
+			CreatePair(context.Background(), entity.PairDAO{UserID: userID, Login: "vpn",
+				Labels: entity.Labels{FolderID: 5, TagIDs: entity.IDs{7, 9}}}).
+			Return(1, nil)
+		_, err := serverMock.uc.CreatePair(context.Background(), userID, pair)
+		require.NoError(t, err)
+
+		manyTags := make(entity.IDs, usecase.MaxTagsPerItem+1)
+		for i := range manyTags {
+			manyTags[i] = i + 1
+		}
+		for name, labels := range map[string]entity.Labels{
+			"negative folder": {FolderID: -1},
+			"zero tag":        {TagIDs: entity.IDs{0}},
+			"too many tags":   {TagIDs: manyTags},
+		} {
+			_, err := serverMock.uc.CreateNote(context.Background(), userID, entity.TextDTO{Note: "note", Labels: labels})
+			require.ErrorIs(t, err, usecase.ErrInvalidLabel, name)
+		}
+	})
+}
@@ -0,0 +1,266 @@
+// Package encryption содержит реализацию шифрования данных пользователя.
+//
+// Сквозное (end-to-end) шифрование на клиенте: ключ выводится из мастер-пароля функцией Argon2id,
+// каждое значение шифруется AEAD XChaCha20-Poly1305. Зашифрованное значение самоописываемое:
+// заголовок содержит версию формата и идентификатор ключа, поэтому сервер хранит и возвращает
+// только непрозрачный шифротекст.
+//
+// Шифрование хранимых данных на сервере (envelope encryption) использует тот же формат
+// с отдельным признаком (RestPrefix), ключи шифрования ключей (KEK) предоставляет KeyProvider.
+package encryption
+
+import (
+	"bytes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+// Параметры Argon2id для вывода ключа из мастер-пароля.
+const (
+	kdfTime    = 3
+	kdfMemory  = 64 * 1024
+	kdfThreads = 4
+)
+
+const (
+	// Version текущая версия формата шифротекста.
+	Version byte = 1
+
+	// KeyIDSize размер идентификатора ключа в заголовке.
+	KeyIDSize = 4
+
+	// Prefix признак зашифрованного на клиенте строкового значения (за ним следует шифротекст в base64).
+	Prefix = "gk:"
+
+	// RestPrefix признак строкового значения, зашифрованного сервером при сохранении.
+	RestPrefix = "gks:"
+
+	// RestMagic признак бинарного значения, зашифрованного сервером при сохранении.
+	RestMagic = "GKS"
+)
+
+// Признаки зашифрованных бинарных значений (на клиенте и на сервере соответственно).
+var (
+	magic     = []byte("GK")
+	restMagic = []byte(RestMagic)
+)
+
+// Overhead увеличение размера данных при шифровании на клиенте (заголовок, nonce и тег аутентификации).
+const Overhead = 2 + 1 + KeyIDSize + chacha20poly1305.NonceSizeX + chacha20poly1305.Overhead
+
+var (
+	ErrMalformed      = errors.New("encryption: malformed ciphertext")
+	ErrUnknownVersion = errors.New("encryption: unknown ciphertext version")
+	ErrKeyMismatch    = errors.New("encryption: data encrypted with another key (wrong master password?)")
+	ErrDecrypt        = errors.New("encryption: message authentication failed")
+	ErrNotEncrypted   = errors.New("encryption: value is not encrypted")
+)
+
+// Cipher шифрует и расшифровывает данные ключом пользователя.
+type Cipher struct {
+	aead   cipher.AEAD
+	keyID  [KeyIDSize]byte
+	prefix string
+	magic  []byte
+}
+
+// DeriveKey выводит ключ шифрования из мастер-пароля.
+//
+// Соль определяется логином пользователя, поэтому на любом устройстве
+// для одной и той же пары логин/мастер-пароль получается один и тот же ключ.
+func DeriveKey(login, masterPassword string) []byte {
+	salt := sha256.Sum256([]byte("gophkeeper/e2e/" + login))
+	return argon2.IDKey([]byte(masterPassword), salt[:], kdfTime, kdfMemory, kdfThreads, chacha20poly1305.KeySize)
+}
+
+// NewCipher создаёт объект Cipher для переданного ключа (шифрование на клиенте).
+func NewCipher(key []byte) (*Cipher, error) {
+	return newCipher(key, Prefix, magic)
+}
+
+// NewRestCipher создаёт объект Cipher для шифрования хранимых данных на сервере.
+//
+// Шифротекст отличается признаком, поэтому данные, уже зашифрованные на клиенте, не путаются с ним.
+func NewRestCipher(key []byte) (*Cipher, error) {
+	return newCipher(key, RestPrefix, restMagic)
+}
+
+func newCipher(key []byte, prefix string, magic []byte) (*Cipher, error) {
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, fmt.Errorf("encryption: %w", err)
+	}
+
+	c := &Cipher{aead: aead, prefix: prefix, magic: magic}
+	sum := sha256.Sum256(append([]byte("gophkeeper/key-id/"), key...))
+	copy(c.keyID[:], sum[:KeyIDSize])
+
+	return c, nil
+}
+
+// Encrypt шифрует данные. Результат: заголовок (признак, версия, id ключа), nonce и шифротекст с тегом.
+func (c *Cipher) Encrypt(plain []byte) ([]byte, error) {
+	headerSize := c.headerSize()
+
+	out := make([]byte, headerSize+chacha20poly1305.NonceSizeX, headerSize+len(plain)+
+		chacha20poly1305.NonceSizeX+chacha20poly1305.Overhead)
+	copy(out, c.magic)
+	out[len(c.magic)] = Version
+	copy(out[len(c.magic)+1:headerSize], c.keyID[:])
+
+	nonce := out[headerSize:]
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("encryption: %w", err)
+	}
+
+	// заголовок аутентифицируется вместе с данными
+	return c.aead.Seal(out, nonce, plain, out[:headerSize]), nil
+}
+
+// Decrypt расшифровывает данные, полученные Encrypt.
+//
+// Данные без заголовка отклоняются (ErrNotEncrypted), пустые данные возвращаются без изменений.
+func (c *Cipher) Decrypt(data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return data, nil
+	}
+	if !c.isEncryptedBytes(data) {
+		return nil, ErrNotEncrypted
+	}
+
+	headerSize := c.headerSize()
+	if len(data) < headerSize+chacha20poly1305.NonceSizeX+chacha20poly1305.Overhead {
+		return nil, ErrMalformed
+	}
+	if data[len(c.magic)] != Version {
+		return nil, ErrUnknownVersion
+	}
+	if !bytes.Equal(data[len(c.magic)+1:headerSize], c.keyID[:]) {
+		return nil, ErrKeyMismatch
+	}
+
+	nonce := data[headerSize : headerSize+chacha20poly1305.NonceSizeX]
+	plain, err := c.aead.Open(nil, nonce, data[headerSize+chacha20poly1305.NonceSizeX:], data[:headerSize])
+	if err != nil {
+		return nil, ErrDecrypt
+	}
+
+	return plain, nil
+}
+
+// EncryptString шифрует строковое значение (результат - признак и шифротекст в base64).
+func (c *Cipher) EncryptString(plain string) (string, error) {
+	data, err := c.Encrypt([]byte(plain))
+	if err != nil {
+		return "", err
+	}
+
+	return c.prefix + base64.RawStdEncoding.EncodeToString(data), nil
+}
+
+// DecryptString расшифровывает строковое значение, полученное EncryptString.
+//
+// Значения без признака отклоняются (ErrNotEncrypted), пустая строка возвращается без изменений.
+func (c *Cipher) DecryptString(in string) (string, error) {
+	if in == "" {
+		return in, nil
+	}
+	if !strings.HasPrefix(in, c.prefix) {
+		return "", ErrNotEncrypted
+	}
+
+	data, err := base64.RawStdEncoding.DecodeString(strings.TrimPrefix(in, c.prefix))
+	if err != nil || !c.isEncryptedBytes(data) {
+		return "", ErrMalformed
+	}
+
+	plain, err := c.Decrypt(data)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plain), nil
+}
+
+// DecryptLegacy расшифровывает данные, которые могли быть сохранены до включения шифрования
+// (только для однократной миграции таких данных).
+//
+// Данные без заголовка или с заголовком другого формата либо ключа (например, файл, случайно начинающийся
+// с "GK") возвращаются без изменений с признаком legacy. Ошибка аутентификации шифротекста возвращается.
+func (c *Cipher) DecryptLegacy(data []byte) (plain []byte, legacy bool, err error) {
+	plain, err = c.Decrypt(data)
+	switch {
+	case errors.Is(err, ErrNotEncrypted), errors.Is(err, ErrMalformed),
+		errors.Is(err, ErrUnknownVersion), errors.Is(err, ErrKeyMismatch):
+		return data, true, nil
+	case err != nil:
+		return nil, false, err
+	}
+
+	return plain, false, nil
+}
+
+// DecryptStringLegacy расшифровывает строковое значение, которое могло быть сохранено до включения шифрования
+// (только для однократной миграции таких данных).
+//
+// Значение без признака или с искажённым шифротекстом (открытый текст, случайно начинающийся с признака)
+// возвращается без изменений с признаком legacy.
+func (c *Cipher) DecryptStringLegacy(in string) (plain string, legacy bool, err error) {
+	plain, err = c.DecryptString(in)
+	switch {
+	case errors.Is(err, ErrNotEncrypted), errors.Is(err, ErrMalformed):
+		return in, true, nil
+	case err != nil:
+		return "", false, err
+	}
+
+	return plain, false, nil
+}
+
+// IsEncrypted проверяет, является ли строковое значение шифротекстом клиента.
+func IsEncrypted(in string) bool {
+	return strings.HasPrefix(in, Prefix)
+}
+
+// IsEncryptedBytes проверяет, является ли бинарное значение шифротекстом клиента.
+func IsEncryptedBytes(data []byte) bool {
+	return bytes.HasPrefix(data, magic) && !bytes.HasPrefix(data, restMagic)
+}
+
+// Owns проверяет, что данные - шифротекст этого шифра: признак, версия формата и идентификатор ключа.
+func (c *Cipher) Owns(data []byte) bool {
+	headerSize := c.headerSize()
+
+	return c.isEncryptedBytes(data) && len(data) >= headerSize &&
+		data[len(c.magic)] == Version && bytes.Equal(data[len(c.magic)+1:headerSize], c.keyID[:])
+}
+
+// IsRestEncrypted проверяет, является ли строковое значение шифротекстом сервера.
+func IsRestEncrypted(in string) bool {
+	return strings.HasPrefix(in, RestPrefix)
+}
+
+// IsRestEncryptedBytes проверяет, является ли бинарное значение шифротекстом сервера.
+func IsRestEncryptedBytes(data []byte) bool {
+	return bytes.HasPrefix(data, restMagic)
+}
+
+func (c *Cipher) isEncryptedBytes(data []byte) bool {
+	if c.prefix == Prefix {
+		return IsEncryptedBytes(data)
+	}
+
+	return bytes.HasPrefix(data, c.magic)
+}
+
+func (c *Cipher) headerSize() int {
+	return len(c.magic) + 1 + KeyIDSize
+}
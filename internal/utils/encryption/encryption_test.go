@@ -0,0 +1,129 @@
+package encryption_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulYakow/gophkeeper/internal/utils/encryption"
+)
+
+func newCipher(t *testing.T, login, master string) *encryption.Cipher {
+	t.Helper()
+
+	c, err := encryption.NewCipher(encryption.DeriveKey(login, master))
+	require.NoError(t, err)
+	return c
+}
+
+func TestDeriveKey(t *testing.T) {
+	require.Equal(t, encryption.DeriveKey("user", "master"), encryption.DeriveKey("user", "master"))
+	require.NotEqual(t, encryption.DeriveKey("user", "master"), encryption.DeriveKey("other", "master"))
+	require.NotEqual(t, encryption.DeriveKey("user", "master"), encryption.DeriveKey("user", "other"))
+}
+
+func TestCipher(t *testing.T) {
+	c := newCipher(t, "user", "master")
+
+	t.Run("string round trip", func(t *testing.T) {
+		enc, err := c.EncryptString("secret password")
+		require.NoError(t, err)
+		require.True(t, encryption.IsEncrypted(enc))
+		require.NotContains(t, enc, "secret")
+
+		dec, err := c.DecryptString(enc)
+		require.NoError(t, err)
+		require.Equal(t, "secret password", dec)
+	})
+
+	t.Run("random nonce", func(t *testing.T) {
+		first, err := c.EncryptString("same")
+		require.NoError(t, err)
+		second, err := c.EncryptString("same")
+		require.NoError(t, err)
+		require.NotEqual(t, first, second)
+	})
+
+	t.Run("bytes round trip", func(t *testing.T) {
+		plain := []byte{0x00, 0x01, 0x02, 0xFF}
+		enc, err := c.Encrypt(plain)
+		require.NoError(t, err)
+		require.Len(t, enc, len(plain)+encryption.Overhead)
+
+		dec, err := c.Decrypt(enc)
+		require.NoError(t, err)
+		require.Equal(t, plain, dec)
+	})
+
+	t.Run("plaintext rejected", func(t *testing.T) {
+		_, err := c.DecryptString("stored before encryption")
+		require.ErrorIs(t, err, encryption.ErrNotEncrypted)
+
+		_, err = c.Decrypt([]byte("stored before encryption"))
+		require.ErrorIs(t, err, encryption.ErrNotEncrypted)
+
+		dec, err := c.DecryptString("")
+		require.NoError(t, err)
+		require.Empty(t, dec)
+	})
+
+	t.Run("legacy plaintext", func(t *testing.T) {
+		dec, legacy, err := c.DecryptStringLegacy("stored before encryption")
+		require.NoError(t, err)
+		require.True(t, legacy)
+		require.Equal(t, "stored before encryption", dec)
+
+		enc, err := c.EncryptString("secret")
+		require.NoError(t, err)
+		dec, legacy, err = c.DecryptStringLegacy(enc)
+		require.NoError(t, err)
+		require.False(t, legacy)
+		require.Equal(t, "secret", dec)
+
+		// файл, случайно начинающийся с признака шифротекста
+		file := []byte("GKIF89a legacy image")
+		data, legacy, err := c.DecryptLegacy(file)
+		require.NoError(t, err)
+		require.True(t, legacy)
+		require.Equal(t, file, data)
+
+		tampered, err := c.Encrypt([]byte("secret"))
+		require.NoError(t, err)
+		tampered[len(tampered)-1] ^= 0x01
+		_, _, err = c.DecryptLegacy(tampered)
+		require.ErrorIs(t, err, encryption.ErrDecrypt)
+	})
+
+	t.Run("wrong master password", func(t *testing.T) {
+		enc, err := c.EncryptString("secret")
+		require.NoError(t, err)
+
+		_, err = newCipher(t, "user", "wrong").DecryptString(enc)
+		require.ErrorIs(t, err, encryption.ErrKeyMismatch)
+	})
+
+	t.Run("tampered ciphertext", func(t *testing.T) {
+		enc, err := c.Encrypt([]byte("secret"))
+		require.NoError(t, err)
+
+		enc[len(enc)-1] ^= 0x01
+		_, err = c.Decrypt(enc)
+		require.ErrorIs(t, err, encryption.ErrDecrypt)
+	})
+
+	t.Run("owns", func(t *testing.T) {
+		enc, err := c.Encrypt([]byte("key"))
+		require.NoError(t, err)
+		require.True(t, c.Owns(enc))
+		require.False(t, newCipher(t, "user", "wrong").Owns(enc))
+		require.False(t, c.Owns([]byte("raw key without header")))
+	})
+
+	t.Run("malformed ciphertext", func(t *testing.T) {
+		_, err := c.DecryptString(encryption.Prefix + "!!!")
+		require.ErrorIs(t, err, encryption.ErrMalformed)
+
+		_, err = c.Decrypt([]byte("GK"))
+		require.ErrorIs(t, err, encryption.ErrMalformed)
+	})
+}
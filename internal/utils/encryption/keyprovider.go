@@ -0,0 +1,134 @@
+package encryption
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+var (
+	ErrNoKeys      = errors.New("encryption: no key-encryption keys")
+	ErrUnknownKEK  = errors.New("encryption: unknown key-encryption key")
+	ErrInvalidKeys = errors.New("encryption: invalid key-encryption keys")
+)
+
+// KeyProvider источник ключей шифрования ключей (KEK) для шифрования хранимых данных на сервере.
+type KeyProvider interface {
+	// CurrentKey возвращает id и значение актуального KEK (им оборачиваются ключи данных).
+	CurrentKey() (id string, key []byte, err error)
+
+	// Key возвращает KEK по его id (в том числе предыдущие - для ключей данных, ещё не обёрнутых заново).
+	Key(id string) ([]byte, error)
+}
+
+// Reloader источник ключей, который перечитывает KEK перед ротацией (например, из обновлённого файла).
+type Reloader interface {
+	Reload() error
+}
+
+// LocalKeyProvider реализация KeyProvider с ключами из файла или переменной окружения.
+//
+// Ключи задаются записями вида "id:base64(32 байта)" (в файле - по одной на строку,
+// в переменной окружения - через запятую). Актуальным считается последний ключ,
+// поэтому для ротации новый ключ добавляется в конец, а старые остаются до завершения RotateKEK.
+type LocalKeyProvider struct {
+	mu      sync.RWMutex
+	path    string
+	keys    map[string][]byte
+	current string
+}
+
+// NewFileKeyProvider создаёт LocalKeyProvider с ключами из файла path.
+func NewFileKeyProvider(path string) (*LocalKeyProvider, error) {
+	p := &LocalKeyProvider{path: path}
+	if err := p.Reload(); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// NewEnvKeyProvider создаёт LocalKeyProvider с ключами из строки spec (значение переменной окружения).
+func NewEnvKeyProvider(spec string) (*LocalKeyProvider, error) {
+	p := &LocalKeyProvider{}
+	if err := p.load(strings.Split(spec, ",")); err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// Reload перечитывает файл ключей (для ключей из переменной окружения ничего не делает).
+func (p *LocalKeyProvider) Reload() error {
+	if p.path == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(p.path)
+	if err != nil {
+		return fmt.Errorf("encryption: read keys file: %w", err)
+	}
+
+	return p.load(strings.Split(string(data), "\n"))
+}
+
+// CurrentKey возвращает id и значение актуального KEK.
+func (p *LocalKeyProvider) CurrentKey() (string, []byte, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.current, p.keys[p.current], nil
+}
+
+// Key возвращает KEK по его id.
+func (p *LocalKeyProvider) Key(id string) ([]byte, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	key, ok := p.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKEK, id)
+	}
+
+	return key, nil
+}
+
+func (p *LocalKeyProvider) load(records []string) error {
+	keys := make(map[string][]byte)
+	var current string
+
+	for _, record := range records {
+		record = strings.TrimSpace(record)
+		if record == "" || strings.HasPrefix(record, "#") {
+			continue
+		}
+
+		id, encoded, ok := strings.Cut(record, ":")
+		if !ok || id == "" {
+			return fmt.Errorf("%w: record must be \"id:base64key\"", ErrInvalidKeys)
+		}
+
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil || len(key) != chacha20poly1305.KeySize {
+			return fmt.Errorf("%w: key %q must be %d bytes in base64", ErrInvalidKeys, id, chacha20poly1305.KeySize)
+		}
+
+		keys[id] = key
+		current = id
+	}
+
+	if current == "" {
+		return ErrNoKeys
+	}
+
+	p.mu.Lock()
+	p.keys, p.current = keys, current
+	p.mu.Unlock()
+
+	return nil
+}
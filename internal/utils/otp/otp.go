@@ -0,0 +1,128 @@
+// Package otp содержит реализацию генерации одноразовых паролей HOTP (RFC 4226) и TOTP (RFC 6238).
+package otp
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+	"time"
+)
+
+// Типы одноразовых паролей.
+const (
+	KindTOTP = "totp"
+	KindHOTP = "hotp"
+)
+
+// Алгоритмы HMAC.
+const (
+	AlgorithmSHA1   = "SHA1"
+	AlgorithmSHA256 = "SHA256"
+	AlgorithmSHA512 = "SHA512"
+)
+
+// Значения параметров по умолчанию.
+const (
+	DefaultDigits = 6
+	DefaultPeriod = 30
+)
+
+var (
+	ErrInvalidSecret    = errors.New("otp: invalid base32 secret")
+	ErrInvalidAlgorithm = errors.New("otp: unknown algorithm")
+	ErrInvalidDigits    = errors.New("otp: digits must be between 6 and 8")
+	ErrInvalidPeriod    = errors.New("otp: period must be positive")
+)
+
+var powers = [...]uint32{1e6, 1e7, 1e8}
+
+// HOTP вычисляет одноразовый пароль для счётчика counter (RFC 4226).
+//
+// secret - секрет в кодировке base32 (регистр и пробелы не учитываются).
+func HOTP(secret string, counter uint64, algorithm string, digits int) (string, error) {
+	key, err := DecodeSecret(secret)
+	if err != nil {
+		return "", err
+	}
+
+	newHash, err := hashFunc(algorithm)
+	if err != nil {
+		return "", err
+	}
+
+	if digits < 6 || digits > 8 {
+		return "", ErrInvalidDigits
+	}
+
+	msg := make([]byte, 8)
+	binary.BigEndian.PutUint64(msg, counter)
+
+	mac := hmac.New(newHash, key)
+	mac.Write(msg)
+	sum := mac.Sum(nil)
+
+	// динамическое усечение (RFC 4226, раздел 5.3)
+	offset := sum[len(sum)-1] & 0x0f
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	code %= powers[digits-6]
+
+	return fmt.Sprintf("%0*d", digits, code), nil
+}
+
+// TOTP вычисляет одноразовый пароль для момента времени t и длительности шага period в секундах (RFC 6238).
+func TOTP(secret string, t time.Time, period int, algorithm string, digits int) (string, error) {
+	if period <= 0 {
+		return "", ErrInvalidPeriod
+	}
+
+	return HOTP(secret, uint64(t.Unix())/uint64(period), algorithm, digits)
+}
+
+// Remaining возвращает время до смены TOTP-пароля.
+func Remaining(t time.Time, period int) time.Duration {
+	if period <= 0 {
+		return 0
+	}
+
+	step := int64(period)
+	return time.Duration(step-t.Unix()%step) * time.Second
+}
+
+// DecodeSecret декодирует секрет из base32 (регистр, пробелы и отсутствие выравнивания '=' допускаются).
+func DecodeSecret(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+	secret = strings.TrimRight(secret, "=")
+
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil || len(key) == 0 {
+		return nil, ErrInvalidSecret
+	}
+
+	return key, nil
+}
+
+// ValidAlgorithm проверяет, поддерживается ли алгоритм.
+func ValidAlgorithm(algorithm string) bool {
+	_, err := hashFunc(algorithm)
+	return err == nil
+}
+
+func hashFunc(algorithm string) (func() hash.Hash, error) {
+	switch strings.ToUpper(algorithm) {
+	case AlgorithmSHA1:
+		return sha1.New, nil
+	case AlgorithmSHA256:
+		return sha256.New, nil
+	case AlgorithmSHA512:
+		return sha512.New, nil
+	default:
+		return nil, ErrInvalidAlgorithm
+	}
+}
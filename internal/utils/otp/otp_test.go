@@ -0,0 +1,98 @@
+package otp_test
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulYakow/gophkeeper/internal/utils/otp"
+)
+
+var (
+	seedSHA1   = base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+	seedSHA256 = base32.StdEncoding.EncodeToString([]byte("12345678901234567890123456789012"))
+	seedSHA512 = base32.StdEncoding.EncodeToString(
+		[]byte("1234567890123456789012345678901234567890123456789012345678901234"))
+)
+
+// Тестовые значения из RFC 4226 (приложение D).
+func TestHOTP(t *testing.T) {
+	expected := []string{
+		"755224", "287082", "359152", "969429", "338314",
+		"254676", "287922", "162583", "399871", "520489",
+	}
+
+	for counter, want := range expected {
+		code, err := otp.HOTP(seedSHA1, uint64(counter), otp.AlgorithmSHA1, 6)
+		require.NoError(t, err)
+		require.Equal(t, want, code, "counter %d", counter)
+	}
+}
+
+// Тестовые значения из RFC 6238 (приложение B).
+func TestTOTP(t *testing.T) {
+	tests := []struct {
+		unix   int64
+		sha1   string
+		sha256 string
+		sha512 string
+	}{
+		{59, "94287082", "46119246", "90693936"},
+		{1111111109, "07081804", "68084774", "25091201"},
+		{1111111111, "14050471", "67062674", "99943326"},
+		{1234567890, "89005924", "91819424", "93441116"},
+		{2000000000, "69279037", "90698825", "38618901"},
+		{20000000000, "65353130", "77737706", "47863826"},
+	}
+
+	for _, tt := range tests {
+		moment := time.Unix(tt.unix, 0)
+
+		code, err := otp.TOTP(seedSHA1, moment, 30, otp.AlgorithmSHA1, 8)
+		require.NoError(t, err)
+		require.Equal(t, tt.sha1, code)
+
+		code, err = otp.TOTP(seedSHA256, moment, 30, otp.AlgorithmSHA256, 8)
+		require.NoError(t, err)
+		require.Equal(t, tt.sha256, code)
+
+		code, err = otp.TOTP(seedSHA512, moment, 30, otp.AlgorithmSHA512, 8)
+		require.NoError(t, err)
+		require.Equal(t, tt.sha512, code)
+	}
+}
+
+func TestInvalidParams(t *testing.T) {
+	t.Run("invalid secret", func(t *testing.T) {
+		_, err := otp.HOTP("not base32!", 0, otp.AlgorithmSHA1, 6)
+		require.ErrorIs(t, err, otp.ErrInvalidSecret)
+	})
+
+	t.Run("invalid algorithm", func(t *testing.T) {
+		_, err := otp.HOTP(seedSHA1, 0, "MD5", 6)
+		require.ErrorIs(t, err, otp.ErrInvalidAlgorithm)
+	})
+
+	t.Run("invalid digits", func(t *testing.T) {
+		_, err := otp.HOTP(seedSHA1, 0, otp.AlgorithmSHA1, 9)
+		require.ErrorIs(t, err, otp.ErrInvalidDigits)
+	})
+
+	t.Run("invalid period", func(t *testing.T) {
+		_, err := otp.TOTP(seedSHA1, time.Now(), 0, otp.AlgorithmSHA1, 6)
+		require.ErrorIs(t, err, otp.ErrInvalidPeriod)
+	})
+
+	t.Run("lower case secret without padding", func(t *testing.T) {
+		code, err := otp.HOTP("gezdgnbvgy3tqojqgezdgnbvgy3tqojq", 0, "sha1", 6)
+		require.NoError(t, err)
+		require.Equal(t, "755224", code)
+	})
+}
+
+func TestRemaining(t *testing.T) {
+	require.Equal(t, 30*time.Second, otp.Remaining(time.Unix(60, 0), 30))
+	require.Equal(t, time.Second, otp.Remaining(time.Unix(89, 0), 30))
+}
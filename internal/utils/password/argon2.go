@@ -0,0 +1,143 @@
+package password
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	argon2Prefix  = "$argon2id$"
+	argon2SaltLen = 16
+	argon2KeyLen  = 32
+)
+
+// ErrMismatchedHashAndPassword ошибка проверки пароля, не соответствующего хешу.
+var ErrMismatchedHashAndPassword = bcrypt.ErrMismatchedHashAndPassword
+
+// ErrUnknownHash ошибка проверки хеша неизвестного формата.
+var ErrUnknownHash = errors.New("unknown password hash format")
+
+// Argon2Params параметры Argon2id.
+type Argon2Params struct {
+	// Memory объём памяти (КиБ).
+	Memory uint32
+	// Time количество проходов.
+	Time uint32
+	// Threads степень параллелизма.
+	Threads uint8
+}
+
+// Argon2 обеспечивает хеширование паролей по Argon2id и проверку хешей Argon2id и bcrypt.
+//
+// Хеш сохраняется в формате PHC ($argon2id$v=19$m=...,t=...,p=...$соль$хеш) и содержит все параметры,
+// поэтому хеши с прежними параметрами проверяются и после их изменения. Если задан pepper (секрет сервера,
+// не хранится в БД), пароль перед хешированием заменяется на HMAC-SHA256(pepper, пароль); смена pepper
+// делает недействительными все хеши Argon2id. Хеши bcrypt (созданные до перехода на Argon2id, без pepper)
+// только проверяются.
+type Argon2 struct {
+	params Argon2Params
+	pepper []byte
+}
+
+// NewArgon2 создаёт объект Argon2 с параметрами params и секретом pepper (пустая строка - без секрета).
+func NewArgon2(params Argon2Params, pepper string) *Argon2 {
+	return &Argon2{
+		params: params,
+		pepper: []byte(pepper),
+	}
+}
+
+// Hash - хеширование пароля с текущими параметрами.
+func (a *Argon2) Hash(password string) (string, error) {
+	salt := make([]byte, argon2SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	key := argon2.IDKey(a.peppered(password), salt, a.params.Time, a.params.Memory, a.params.Threads, argon2KeyLen)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", argon2Prefix, argon2.Version,
+		a.params.Memory, a.params.Time, a.params.Threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Check - проверка переданного пароля и оригинального хеша (Argon2id или bcrypt) на соответствие.
+func (a *Argon2) Check(password, hashedPassword string) error {
+	if !strings.HasPrefix(hashedPassword, argon2Prefix) {
+		return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+	}
+
+	params, salt, key, err := parseArgon2(hashedPassword)
+	if err != nil {
+		return err
+	}
+
+	other := argon2.IDKey(a.peppered(password), salt, params.Time, params.Memory, params.Threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(key, other) != 1 {
+		return ErrMismatchedHashAndPassword
+	}
+
+	return nil
+}
+
+// NeedsRehash - проверка, создан ли хеш по устаревшей схеме (bcrypt) или с параметрами, отличными от текущих.
+func (a *Argon2) NeedsRehash(hashedPassword string) bool {
+	params, _, key, err := parseArgon2(hashedPassword)
+	if err != nil {
+		return true
+	}
+
+	return params != a.params || len(key) != argon2KeyLen
+}
+
+func (a *Argon2) peppered(password string) []byte {
+	if len(a.pepper) == 0 {
+		return []byte(password)
+	}
+
+	mac := hmac.New(sha256.New, a.pepper)
+	mac.Write([]byte(password))
+	return mac.Sum(nil)
+}
+
+// parseArgon2 разбирает хеш Argon2id в формате PHC.
+func parseArgon2(hashedPassword string) (Argon2Params, []byte, []byte, error) {
+	var params Argon2Params
+
+	// "", "argon2id", "v=19", "m=...,t=...,p=...", соль, хеш
+	parts := strings.Split(hashedPassword, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return params, nil, nil, ErrUnknownHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return params, nil, nil, ErrUnknownHash
+	}
+
+	_, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Time, &params.Threads)
+	if err != nil {
+		return params, nil, nil, ErrUnknownHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, ErrUnknownHash
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, ErrUnknownHash
+	}
+
+	return params, salt, key, nil
+}
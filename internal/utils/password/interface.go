@@ -9,4 +9,7 @@ type IPasswordHash interface {
 
 	// Check - проверка переданного пароля и оригинального хеша на соответствие.
 	Check(password, hashedPassword string) error
+
+	// NeedsRehash - проверка, нужно ли пересчитать хеш по текущей схеме (после успешной проверки пароля).
+	NeedsRehash(hashedPassword string) bool
 }
@@ -8,7 +8,12 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
-// Password обеспечивает хеширование и проверку паролей.
+// bcryptCost стоимость хеширования bcrypt.
+const bcryptCost = 7
+
+// Password обеспечивает хеширование и проверку паролей (bcrypt).
+//
+// Для сервера используется Argon2 - Password остаётся для совместимости.
 type Password struct{}
 
 // New создаёт объект Password.
@@ -18,7 +23,7 @@ func New() Password {
 
 // Hash - хеширование пароля.
 func (p Password) Hash(password string) (string, error) {
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), 7)
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcryptCost)
 	if err != nil {
 		return "", fmt.Errorf("failed to hash password: %w", err)
 	}
@@ -30,3 +35,9 @@ func (p Password) Hash(password string) (string, error) {
 func (p Password) Check(password, hashedPassword string) error {
 	return bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 }
+
+// NeedsRehash - проверка, создан ли хеш со стоимостью ниже текущей.
+func (p Password) NeedsRehash(hashedPassword string) bool {
+	cost, err := bcrypt.Cost([]byte(hashedPassword))
+	return err != nil || cost < bcryptCost
+}
@@ -1,6 +1,7 @@
 package password_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -42,3 +43,51 @@ func TestPassword(t *testing.T) {
 		require.NotEqual(t, hashedPassword1, hashedPassword2)
 	})
 }
+
+func TestArgon2(t *testing.T) {
+	params := password.Argon2Params{Memory: 1024, Time: 1, Threads: 1}
+	pass := password.NewArgon2(params, "pepper")
+
+	rndPassword := test.RandomPassword()
+	hashedPassword, err := pass.Hash(rndPassword)
+
+	t.Run("correct hashing", func(t *testing.T) {
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(hashedPassword, "$argon2id$v=19$m=1024,t=1,p=1$"))
+		require.False(t, pass.NeedsRehash(hashedPassword))
+	})
+
+	t.Run("correct check", func(t *testing.T) {
+		require.NoError(t, pass.Check(rndPassword, hashedPassword))
+	})
+
+	t.Run("wrong password check", func(t *testing.T) {
+		err := pass.Check(test.RandomPassword(), hashedPassword)
+		require.ErrorIs(t, err, password.ErrMismatchedHashAndPassword)
+	})
+
+	t.Run("wrong pepper check", func(t *testing.T) {
+		other := password.NewArgon2(params, "other")
+		err := other.Check(rndPassword, hashedPassword)
+		require.ErrorIs(t, err, password.ErrMismatchedHashAndPassword)
+	})
+
+	t.Run("hash with previous params", func(t *testing.T) {
+		stronger := password.NewArgon2(password.Argon2Params{Memory: 2048, Time: 2, Threads: 1}, "pepper")
+		require.NoError(t, stronger.Check(rndPassword, hashedPassword))
+		require.True(t, stronger.NeedsRehash(hashedPassword))
+	})
+
+	t.Run("legacy bcrypt hash", func(t *testing.T) {
+		legacy, err := password.New().Hash(rndPassword)
+		require.NoError(t, err)
+		require.NoError(t, pass.Check(rndPassword, legacy))
+		require.ErrorIs(t, pass.Check(test.RandomPassword(), legacy), password.ErrMismatchedHashAndPassword)
+		require.True(t, pass.NeedsRehash(legacy))
+	})
+
+	t.Run("malformed hash", func(t *testing.T) {
+		err := pass.Check(rndPassword, "$argon2id$v=19$m=1024$bad")
+		require.ErrorIs(t, err, password.ErrUnknownHash)
+	})
+}
@@ -0,0 +1,148 @@
+// Package textsearch содержит простой полнотекстовый поиск по словам в памяти.
+//
+// Слова сравниваются без учёта регистра и без приведения к основе, должны встретиться все слова
+// запроса, слова с '-' в начале встречаться не должны.
+package textsearch
+
+import (
+	"strings"
+	"unicode"
+)
+
+const (
+	// snippetBefore, snippetAfter количество слов фрагмента до и после первого найденного слова.
+	snippetBefore = 4
+	snippetAfter  = 8
+	// StartSel, StopSel выделение найденных слов во фрагменте.
+	StartSel = "«"
+	StopSel  = "»"
+)
+
+// Query разобранный поисковый запрос.
+type Query struct {
+	include []string
+	exclude []string
+}
+
+// Parse разбирает запрос: слова через пробел (кавычки и "or" не учитываются), '-' перед словом - исключение.
+func Parse(query string) Query {
+	var q Query
+	for _, field := range strings.Fields(query) {
+		exclude := strings.HasPrefix(field, "-")
+		for _, word := range words(field) {
+			switch {
+			case word == "or":
+			case exclude:
+				q.exclude = append(q.exclude, word)
+			default:
+				q.include = append(q.include, word)
+			}
+		}
+	}
+
+	return q
+}
+
+// Empty - в запросе нет искомых слов.
+func (q Query) Empty() bool {
+	return len(q.include) == 0
+}
+
+// Match проверяет поля записи (в порядке убывания веса) на соответствие запросу.
+//
+// Возвращает ранг (чем больше, тем лучше совпадение) и фрагмент первого подходящего поля
+// с выделенными словами запроса.
+func (q Query) Match(fields ...string) (rank float64, snippet string, ok bool) {
+	if q.Empty() {
+		return 0, "", false
+	}
+
+	found := make(map[string]bool, len(q.include))
+	for i, field := range fields {
+		matches := 0
+		for _, word := range words(field) {
+			if contains(q.exclude, word) {
+				return 0, "", false
+			}
+			if contains(q.include, word) {
+				found[word] = true
+				matches++
+			}
+		}
+
+		if matches > 0 {
+			rank += float64(matches) / float64(i+1)
+			if snippet == "" {
+				snippet = q.snippet(field)
+			}
+		}
+	}
+
+	for _, word := range q.include {
+		if !found[word] {
+			return 0, "", false
+		}
+	}
+
+	return rank, snippet, true
+}
+
+// snippet фрагмент поля вокруг первого найденного слова.
+func (q Query) snippet(field string) string {
+	tokens := strings.Fields(field)
+
+	first := -1
+	for i, token := range tokens {
+		if q.matchToken(token) {
+			first = i
+			break
+		}
+	}
+	if first < 0 {
+		return ""
+	}
+
+	from, to := first-snippetBefore, first+snippetAfter+1
+	if from < 0 {
+		from = 0
+	}
+	if to > len(tokens) {
+		to = len(tokens)
+	}
+
+	parts := make([]string, 0, to-from)
+	for _, token := range tokens[from:to] {
+		if q.matchToken(token) {
+			token = StartSel + token + StopSel
+		}
+		parts = append(parts, token)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// matchToken - слово текста (с возможными знаками препинания) есть в запросе.
+func (q Query) matchToken(token string) bool {
+	for _, word := range words(token) {
+		if contains(q.include, word) {
+			return true
+		}
+	}
+	return false
+}
+
+// words разбивает текст на слова (буквы и цифры) в нижнем регистре.
+func words(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func contains(list []string, word string) bool {
+	for _, item := range list {
+		if item == word {
+			return true
+		}
+	}
+	return false
+}
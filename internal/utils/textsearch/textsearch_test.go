@@ -0,0 +1,57 @@
+package textsearch_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulYakow/gophkeeper/internal/utils/textsearch"
+)
+
+func TestMatch(t *testing.T) {
+	note := "Настройки VPN для ноутбука: сервер vpn.example.com, протокол WireGuard."
+	metadata := "work, laptop"
+
+	tests := []struct {
+		name    string
+		query   string
+		ok      bool
+		snippet string
+	}{
+		{name: "case insensitive", query: "vpn", ok: true, snippet: "Настройки «VPN» для ноутбука: сервер «vpn.example.com,» протокол WireGuard."},
+		{name: "all words", query: "vpn laptop", ok: true, snippet: "Настройки «VPN» для ноутбука: сервер «vpn.example.com,» протокол WireGuard."},
+		{name: "metadata only", query: "work", ok: true, snippet: "«work,» laptop"},
+		{name: "missing word", query: "vpn home", ok: false},
+		{name: "excluded word", query: "vpn -wireguard", ok: false},
+		{name: "no prefix match", query: "wire", ok: false},
+		{name: "empty", query: "  -vpn ", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rank, snippet, ok := textsearch.Parse(tt.query).Match(note, metadata)
+			require.Equal(t, tt.ok, ok)
+			if tt.ok {
+				require.Positive(t, rank)
+				require.Equal(t, tt.snippet, snippet)
+			}
+		})
+	}
+}
+
+func TestRank(t *testing.T) {
+	q := textsearch.Parse("vpn")
+
+	inNote, _, ok := q.Match("vpn", "")
+	require.True(t, ok)
+	inMetadata, _, ok := q.Match("", "vpn")
+	require.True(t, ok)
+	require.Greater(t, inNote, inMetadata)
+}
+
+func TestSnippetWindow(t *testing.T) {
+	_, snippet, ok := textsearch.Parse("key").
+		Match("one two three four five six seven key eight nine ten eleven twelve thirteen fourteen")
+	require.True(t, ok)
+	require.Equal(t, "four five six seven «key» eight nine ten eleven twelve thirteen fourteen", snippet)
+}
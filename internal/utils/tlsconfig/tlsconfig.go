@@ -0,0 +1,87 @@
+// Package tlsconfig содержит формирование настроек TLS (в том числе взаимного - mTLS) для gRPC-соединения.
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+var (
+	ErrMissingCert = errors.New("tls: certificate and key files are required (or enable insecure mode explicitly)")
+	ErrInvalidCA   = errors.New("tls: no certificates found in CA file")
+)
+
+// Server формирует настройки TLS сервера.
+//
+// Если задан clientCAFile, включается взаимный TLS: клиент обязан предъявить сертификат,
+// подписанный одним из центров сертификации из этого файла.
+func Server(certFile, keyFile, clientCAFile string) (*tls.Config, error) {
+	if certFile == "" || keyFile == "" {
+		return nil, ErrMissingCert
+	}
+
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("tls: load server certificate: %w", err)
+	}
+
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if clientCAFile != "" {
+		if cfg.ClientCAs, err = loadPool(clientCAFile); err != nil {
+			return nil, err
+		}
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return cfg, nil
+}
+
+// Client формирует настройки TLS клиента.
+//
+// caFile - центр сертификации для проверки сервера (пусто - системные корневые сертификаты),
+// certFile/keyFile - сертификат клиента для взаимного TLS (необязательны),
+// serverName - имя сервера для проверки сертификата (пусто - по адресу подключения).
+func Client(caFile, certFile, keyFile, serverName string) (*tls.Config, error) {
+	cfg := &tls.Config{
+		ServerName: serverName,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	var err error
+	if caFile != "" {
+		if cfg.RootCAs, err = loadPool(caFile); err != nil {
+			return nil, err
+		}
+	}
+
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("tls: load client certificate: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+
+	return cfg, nil
+}
+
+func loadPool(path string) (*x509.CertPool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("tls: read CA file: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(data) {
+		return nil, ErrInvalidCA
+	}
+
+	return pool, nil
+}
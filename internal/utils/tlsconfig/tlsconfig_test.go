@@ -0,0 +1,184 @@
+package tlsconfig_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/PaulYakow/gophkeeper/internal/utils/tlsconfig"
+)
+
+type certFiles struct {
+	cert, key string
+}
+
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	file string
+}
+
+func newCA(t *testing.T, dir, name string) *testCA {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	file := filepath.Join(dir, name+".crt")
+	writePEM(t, file, "CERTIFICATE", der)
+
+	return &testCA{cert: cert, key: key, file: file}
+}
+
+func (ca *testCA) issue(t *testing.T, dir, name string, usage x509.ExtKeyUsage) certFiles {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	files := certFiles{
+		cert: filepath.Join(dir, name+".crt"),
+		key:  filepath.Join(dir, name+".key"),
+	}
+	writePEM(t, files.cert, "CERTIFICATE", der)
+	writePEM(t, files.key, "EC PRIVATE KEY", keyDER)
+
+	return files
+}
+
+func writePEM(t *testing.T, path, kind string, der []byte) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: kind, Bytes: der}), 0o600))
+}
+
+// handshake выполняет TLS-рукопожатие между клиентом и сервером и возвращает первую из ошибок.
+func handshake(t *testing.T, server, client *tls.Config) error {
+	t.Helper()
+
+	srvConn, cliConn := net.Pipe()
+	defer srvConn.Close()
+	defer cliConn.Close()
+
+	deadline := time.Now().Add(5 * time.Second)
+	require.NoError(t, srvConn.SetDeadline(deadline))
+	require.NoError(t, cliConn.SetDeadline(deadline))
+
+	done := make(chan error, 1)
+	go func() {
+		done <- tls.Server(srvConn, server).Handshake()
+	}()
+
+	conn := tls.Client(cliConn, client)
+	if err := conn.Handshake(); err != nil {
+		return err
+	}
+
+	// в TLS 1.3 сервер проверяет сертификат клиента после завершения рукопожатия на стороне клиента,
+	// поэтому ответ сервера (в том числе alert) нужно дочитать
+	go func() {
+		_, _ = conn.Read(make([]byte, 1))
+	}()
+
+	return <-done
+}
+
+func TestTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newCA(t, dir, "ca")
+	server := ca.issue(t, dir, "localhost", x509.ExtKeyUsageServerAuth)
+	client := ca.issue(t, dir, "client", x509.ExtKeyUsageClientAuth)
+
+	foreign := newCA(t, dir, "foreign")
+	foreignClient := foreign.issue(t, dir, "intruder", x509.ExtKeyUsageClientAuth)
+
+	t.Run("server requires certificate", func(t *testing.T) {
+		_, err := tlsconfig.Server("", "", "")
+		require.ErrorIs(t, err, tlsconfig.ErrMissingCert)
+	})
+
+	t.Run("invalid CA file", func(t *testing.T) {
+		_, err := tlsconfig.Client(server.key, "", "", "")
+		require.ErrorIs(t, err, tlsconfig.ErrInvalidCA)
+	})
+
+	t.Run("tls", func(t *testing.T) {
+		srvCfg, err := tlsconfig.Server(server.cert, server.key, "")
+		require.NoError(t, err)
+
+		cliCfg, err := tlsconfig.Client(ca.file, "", "", "localhost")
+		require.NoError(t, err)
+
+		require.NoError(t, handshake(t, srvCfg, cliCfg))
+	})
+
+	t.Run("untrusted server", func(t *testing.T) {
+		srvCfg, err := tlsconfig.Server(server.cert, server.key, "")
+		require.NoError(t, err)
+
+		cliCfg, err := tlsconfig.Client(foreign.file, "", "", "localhost")
+		require.NoError(t, err)
+
+		require.Error(t, handshake(t, srvCfg, cliCfg))
+	})
+
+	t.Run("mtls", func(t *testing.T) {
+		srvCfg, err := tlsconfig.Server(server.cert, server.key, ca.file)
+		require.NoError(t, err)
+
+		cliCfg, err := tlsconfig.Client(ca.file, client.cert, client.key, "localhost")
+		require.NoError(t, err)
+		require.NoError(t, handshake(t, srvCfg, cliCfg))
+
+		noCert, err := tlsconfig.Client(ca.file, "", "", "localhost")
+		require.NoError(t, err)
+		require.Error(t, handshake(t, srvCfg, noCert))
+
+		foreignCert, err := tlsconfig.Client(ca.file, foreignClient.cert, foreignClient.key, "localhost")
+		require.NoError(t, err)
+		require.Error(t, handshake(t, srvCfg, foreignCert))
+	})
+}
@@ -8,8 +8,8 @@ import "time"
 
 // IMaker абстракция для управления токенами.
 type IMaker interface {
-	// Create создаёт токен для переданных id пользователя и продолжительности.
-	Create(userID int, duration time.Duration) (string, error)
+	// Create создаёт токен для переданных id пользователя, id сессии и продолжительности.
+	Create(userID int, sessionID string, duration time.Duration) (string, error)
 
 	// Verify проверяет, является ли токен действительным.
 	Verify(in string) (*Payload, error)
@@ -26,9 +26,9 @@ func NewPasetoMaker(symmetricKey string) (IMaker, error) {
 	}, nil
 }
 
-// Create создаёт токен для переданных id пользователя и продолжительности.
-func (m *PasetoMaker) Create(userID int, duration time.Duration) (string, error) {
-	payload, err := NewPayload(userID, duration)
+// Create создаёт токен для переданных id пользователя, id сессии и продолжительности.
+func (m *PasetoMaker) Create(userID int, sessionID string, duration time.Duration) (string, error) {
+	payload, err := NewPayload(userID, sessionID, duration)
 	if err != nil {
 		return "", err
 	}
@@ -12,6 +12,7 @@ import (
 
 func TestPasetoMaker(t *testing.T) {
 	userID := test.RandomInt(1, 100)
+	sessionID := test.RandomString(32)
 	duration := time.Minute
 
 	issuedAt := time.Now()
@@ -26,7 +27,7 @@ func TestPasetoMaker(t *testing.T) {
 
 	var token string
 	t.Run("create token", func(t *testing.T) {
-		token, err = maker.Create(userID, duration)
+		token, err = maker.Create(userID, sessionID, duration)
 		require.NoError(t, err)
 		require.NotEmpty(t, token)
 	})
@@ -37,6 +38,7 @@ func TestPasetoMaker(t *testing.T) {
 		require.NotEmpty(t, payload)
 
 		require.Equal(t, userID, payload.UserID)
+		require.Equal(t, sessionID, payload.SessionID)
 		require.WithinDuration(t, issuedAt, payload.IssuedAt, time.Second)
 		require.WithinDuration(t, expiredAt, payload.ExpiredAt, time.Second)
 	})
@@ -50,7 +52,7 @@ func TestExpiredPasetoToken(t *testing.T) {
 
 	var token string
 	t.Run("create expired token", func(t *testing.T) {
-		token, err = maker.Create(test.RandomInt(1, 100), -time.Minute)
+		token, err = maker.Create(test.RandomInt(1, 100), test.RandomString(32), -time.Minute)
 		require.NoError(t, err)
 		require.NotEmpty(t, token)
 	})
@@ -15,14 +15,16 @@ var (
 // Payload содержит полезную нагрузку для токена.
 type Payload struct {
 	UserID    int       `json:"user_id"`
+	SessionID string    `json:"session_id"`
 	IssuedAt  time.Time `json:"issued_at"`
 	ExpiredAt time.Time `json:"expired_at"`
 }
 
 // NewPayload создаёт объект Payload.
-func NewPayload(userID int, duration time.Duration) (*Payload, error) {
+func NewPayload(userID int, sessionID string, duration time.Duration) (*Payload, error) {
 	return &Payload{
 		UserID:    userID,
+		SessionID: sessionID,
 		IssuedAt:  time.Now(),
 		ExpiredAt: time.Now().Add(duration),
 	}, nil
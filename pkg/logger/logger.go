@@ -22,6 +22,7 @@ type ILogger interface {
 	Error(message error, args ...interface{})
 	Fatal(message error, args ...interface{})
 	Named(s string) ILogger
+	With(keysAndValues ...interface{}) ILogger
 }
 
 type Logger struct {
@@ -77,6 +78,11 @@ func (l *Logger) Named(s string) ILogger {
 	return &Logger{logger: l.logger.Named(s)}
 }
 
+// With возвращает логгер, добавляющий к записям поля (пары ключ - значение).
+func (l *Logger) With(keysAndValues ...interface{}) ILogger {
+	return &Logger{logger: l.logger.Sugar().With(keysAndValues...).Desugar()}
+}
+
 func (l *Logger) Debug(message string, args ...interface{}) {
 	l.logger.Log(zap.DebugLevel, fmt.Sprintf(message, args...))
 }
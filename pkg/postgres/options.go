@@ -46,3 +46,10 @@ func ConnTimeout(timeout time.Duration) Option {
 		p.connTimeout = timeout
 	}
 }
+
+// QueryTimeout задаёт максимальное время выполнения запроса (см. WithTimeout).
+func QueryTimeout(timeout time.Duration) Option {
+	return func(p *Postgres) {
+		p.queryTimeout = timeout
+	}
+}
@@ -2,6 +2,7 @@
 package postgres
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
@@ -18,6 +19,8 @@ const (
 
 	defaultConnAttempts = 10
 	defaultConnTimeout  = time.Second
+
+	defaultQueryTimeout = time.Second
 )
 
 // Postgres структура с настройками подключения к БД и доступом к текущему соединению.
@@ -30,6 +33,7 @@ type Postgres struct {
 	maxConnIdleTime time.Duration
 	maxConnLifetime time.Duration
 	connTimeout     time.Duration
+	queryTimeout    time.Duration
 }
 
 // New создаёт объект Postgres с заданными параметрами и подключается к БД.
@@ -41,6 +45,7 @@ func New(dsn string, opts ...Option) (*Postgres, error) {
 		maxConnLifetime: defaultMaxConnLifetime,
 		connAttempts:    defaultConnAttempts,
 		connTimeout:     defaultConnTimeout,
+		queryTimeout:    defaultQueryTimeout,
 	}
 
 	for _, opt := range opts {
@@ -73,6 +78,13 @@ func New(dsn string, opts ...Option) (*Postgres, error) {
 	return pg, nil
 }
 
+// WithTimeout возвращает контекст запроса к БД: ctx, ограниченный таймаутом запроса (QueryTimeout).
+//
+// Отмена ctx (например, клиент прервал вызов) прерывает и запрос.
+func (pg *Postgres) WithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, pg.queryTimeout)
+}
+
 // Shutdown дожидается завершения запросов и закрывает все открытые соединения.
 func (pg *Postgres) Shutdown() error {
 	return pg.Close()
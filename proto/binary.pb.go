@@ -0,0 +1,852 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.12
+// source: proto/binary.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type GetAllBinariesRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Token string       `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Page  *PageRequest `protobuf:"bytes,2,opt,name=page,proto3" json:"page,omitempty"`
+}
+
+func (x *GetAllBinariesRequest) Reset() {
+	*x = GetAllBinariesRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_binary_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAllBinariesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllBinariesRequest) ProtoMessage() {}
+
+func (x *GetAllBinariesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_binary_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllBinariesRequest.ProtoReflect.Descriptor instead.
+func (*GetAllBinariesRequest) Descriptor() ([]byte, []int) {
+	return file_proto_binary_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *GetAllBinariesRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *GetAllBinariesRequest) GetPage() *PageRequest {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type BinaryMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id       int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Filename string `protobuf:"bytes,2,opt,name=filename,proto3" json:"filename,omitempty"`
+	// размер файла до шифрования (при загрузке сообщает клиент)
+	Size     int64  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Metadata string `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// папка записи (0 - вне папок) и метки записи
+	FolderId int64   `protobuf:"varint,5,opt,name=folder_id,json=folderId,proto3" json:"folder_id,omitempty"`
+	TagIds   []int64 `protobuf:"varint,6,rep,packed,name=tag_ids,json=tagIds,proto3" json:"tag_ids,omitempty"`
+}
+
+func (x *BinaryMsg) Reset() {
+	*x = BinaryMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_binary_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BinaryMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BinaryMsg) ProtoMessage() {}
+
+func (x *BinaryMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_binary_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BinaryMsg.ProtoReflect.Descriptor instead.
+func (*BinaryMsg) Descriptor() ([]byte, []int) {
+	return file_proto_binary_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *BinaryMsg) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *BinaryMsg) GetFilename() string {
+	if x != nil {
+		return x.Filename
+	}
+	return ""
+}
+
+func (x *BinaryMsg) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *BinaryMsg) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+func (x *BinaryMsg) GetFolderId() int64 {
+	if x != nil {
+		return x.FolderId
+	}
+	return 0
+}
+
+func (x *BinaryMsg) GetTagIds() []int64 {
+	if x != nil {
+		return x.TagIds
+	}
+	return nil
+}
+
+type GetAllBinariesResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Binaries []*BinaryMsg `protobuf:"bytes,1,rep,name=binaries,proto3" json:"binaries,omitempty"`
+	Error    string       `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+	// токен следующей страницы (пустой - страница последняя)
+	NextPageToken string `protobuf:"bytes,3,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+}
+
+func (x *GetAllBinariesResponse) Reset() {
+	*x = GetAllBinariesResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_binary_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GetAllBinariesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAllBinariesResponse) ProtoMessage() {}
+
+func (x *GetAllBinariesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_binary_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAllBinariesResponse.ProtoReflect.Descriptor instead.
+func (*GetAllBinariesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_binary_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *GetAllBinariesResponse) GetBinaries() []*BinaryMsg {
+	if x != nil {
+		return x.Binaries
+	}
+	return nil
+}
+
+func (x *GetAllBinariesResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *GetAllBinariesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Первое сообщение потока - описание файла (info), далее - данные файла частями (chunk).
+type UploadBinaryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Data:
+	//	*UploadBinaryRequest_Info
+	//	*UploadBinaryRequest_Chunk
+	Data isUploadBinaryRequest_Data `protobuf_oneof:"data"`
+}
+
+func (x *UploadBinaryRequest) Reset() {
+	*x = UploadBinaryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_binary_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadBinaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadBinaryRequest) ProtoMessage() {}
+
+func (x *UploadBinaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_binary_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadBinaryRequest.ProtoReflect.Descriptor instead.
+func (*UploadBinaryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_binary_proto_rawDescGZIP(), []int{3}
+}
+
+func (m *UploadBinaryRequest) GetData() isUploadBinaryRequest_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (x *UploadBinaryRequest) GetInfo() *BinaryMsg {
+	if x, ok := x.GetData().(*UploadBinaryRequest_Info); ok {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *UploadBinaryRequest) GetChunk() []byte {
+	if x, ok := x.GetData().(*UploadBinaryRequest_Chunk); ok {
+		return x.Chunk
+	}
+	return nil
+}
+
+type isUploadBinaryRequest_Data interface {
+	isUploadBinaryRequest_Data()
+}
+
+type UploadBinaryRequest_Info struct {
+	Info *BinaryMsg `protobuf:"bytes,1,opt,name=info,proto3,oneof"`
+}
+
+type UploadBinaryRequest_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*UploadBinaryRequest_Info) isUploadBinaryRequest_Data() {}
+
+func (*UploadBinaryRequest_Chunk) isUploadBinaryRequest_Data() {}
+
+type UploadBinaryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id    int64  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Error string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *UploadBinaryResponse) Reset() {
+	*x = UploadBinaryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_binary_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *UploadBinaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadBinaryResponse) ProtoMessage() {}
+
+func (x *UploadBinaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_binary_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadBinaryResponse.ProtoReflect.Descriptor instead.
+func (*UploadBinaryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_binary_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *UploadBinaryResponse) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UploadBinaryResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type DownloadBinaryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *DownloadBinaryRequest) Reset() {
+	*x = DownloadBinaryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_binary_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DownloadBinaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadBinaryRequest) ProtoMessage() {}
+
+func (x *DownloadBinaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_binary_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadBinaryRequest.ProtoReflect.Descriptor instead.
+func (*DownloadBinaryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_binary_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *DownloadBinaryRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// Первое сообщение потока - описание файла (info), далее - данные файла частями (chunk).
+type DownloadBinaryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Data:
+	//	*DownloadBinaryResponse_Info
+	//	*DownloadBinaryResponse_Chunk
+	Data isDownloadBinaryResponse_Data `protobuf_oneof:"data"`
+}
+
+func (x *DownloadBinaryResponse) Reset() {
+	*x = DownloadBinaryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_binary_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DownloadBinaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DownloadBinaryResponse) ProtoMessage() {}
+
+func (x *DownloadBinaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_binary_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DownloadBinaryResponse.ProtoReflect.Descriptor instead.
+func (*DownloadBinaryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_binary_proto_rawDescGZIP(), []int{6}
+}
+
+func (m *DownloadBinaryResponse) GetData() isDownloadBinaryResponse_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (x *DownloadBinaryResponse) GetInfo() *BinaryMsg {
+	if x, ok := x.GetData().(*DownloadBinaryResponse_Info); ok {
+		return x.Info
+	}
+	return nil
+}
+
+func (x *DownloadBinaryResponse) GetChunk() []byte {
+	if x, ok := x.GetData().(*DownloadBinaryResponse_Chunk); ok {
+		return x.Chunk
+	}
+	return nil
+}
+
+type isDownloadBinaryResponse_Data interface {
+	isDownloadBinaryResponse_Data()
+}
+
+type DownloadBinaryResponse_Info struct {
+	Info *BinaryMsg `protobuf:"bytes,1,opt,name=info,proto3,oneof"`
+}
+
+type DownloadBinaryResponse_Chunk struct {
+	Chunk []byte `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*DownloadBinaryResponse_Info) isDownloadBinaryResponse_Data() {}
+
+func (*DownloadBinaryResponse_Chunk) isDownloadBinaryResponse_Data() {}
+
+type DeleteBinaryRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *DeleteBinaryRequest) Reset() {
+	*x = DeleteBinaryRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_binary_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteBinaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBinaryRequest) ProtoMessage() {}
+
+func (x *DeleteBinaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_binary_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBinaryRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBinaryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_binary_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *DeleteBinaryRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteBinaryResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Error string `protobuf:"bytes,1,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *DeleteBinaryResponse) Reset() {
+	*x = DeleteBinaryResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_binary_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DeleteBinaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBinaryResponse) ProtoMessage() {}
+
+func (x *DeleteBinaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_binary_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBinaryResponse.ProtoReflect.Descriptor instead.
+func (*DeleteBinaryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_binary_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteBinaryResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+var File_proto_binary_proto protoreflect.FileDescriptor
+
+var file_proto_binary_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x10, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2f, 0x70, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x55, 0x0a,
+	0x15, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x69, 0x65, 0x73, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x12, 0x26, 0x0a, 0x04,
+	0x70, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x50, 0x61, 0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x52, 0x04,
+	0x70, 0x61, 0x67, 0x65, 0x22, 0x9d, 0x01, 0x0a, 0x09, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x4d,
+	0x73, 0x67, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x69, 0x6c, 0x65, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x73, 0x69,
+	0x7a, 0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x6d, 0x65, 0x74, 0x61, 0x64, 0x61, 0x74, 0x61, 0x12, 0x1b,
+	0x0a, 0x09, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x03, 0x52, 0x08, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x17, 0x0a, 0x07, 0x74,
+	0x61, 0x67, 0x5f, 0x69, 0x64, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x03, 0x52, 0x06, 0x74, 0x61,
+	0x67, 0x49, 0x64, 0x73, 0x22, 0x84, 0x01, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x42,
+	0x69, 0x6e, 0x61, 0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12,
+	0x2c, 0x0a, 0x08, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x69, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79,
+	0x4d, 0x73, 0x67, 0x52, 0x08, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x14, 0x0a,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72,
+	0x72, 0x6f, 0x72, 0x12, 0x26, 0x0a, 0x0f, 0x6e, 0x65, 0x78, 0x74, 0x5f, 0x70, 0x61, 0x67, 0x65,
+	0x5f, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6e, 0x65,
+	0x78, 0x74, 0x50, 0x61, 0x67, 0x65, 0x54, 0x6f, 0x6b, 0x65, 0x6e, 0x22, 0x5d, 0x0a, 0x13, 0x55,
+	0x70, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x26, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x4d,
+	0x73, 0x67, 0x48, 0x00, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x0a, 0x05, 0x63, 0x68,
+	0x75, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00, 0x52, 0x05, 0x63, 0x68, 0x75,
+	0x6e, 0x6b, 0x42, 0x06, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x22, 0x3c, 0x0a, 0x14, 0x55, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02,
+	0x69, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x27, 0x0a, 0x15, 0x44, 0x6f, 0x77, 0x6e,
+	0x6c, 0x6f, 0x61, 0x64, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69,
+	0x64, 0x22, 0x60, 0x0a, 0x16, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x69, 0x6e,
+	0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x26, 0x0a, 0x04, 0x69,
+	0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x4d, 0x73, 0x67, 0x48, 0x00, 0x52, 0x04, 0x69,
+	0x6e, 0x66, 0x6f, 0x12, 0x16, 0x0a, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x0c, 0x48, 0x00, 0x52, 0x05, 0x63, 0x68, 0x75, 0x6e, 0x6b, 0x42, 0x06, 0x0a, 0x04, 0x64,
+	0x61, 0x74, 0x61, 0x22, 0x25, 0x0a, 0x13, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42, 0x69, 0x6e,
+	0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x2c, 0x0a, 0x14, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x32, 0xa2, 0x02, 0x0a, 0x06, 0x42, 0x69, 0x6e,
+	0x61, 0x72, 0x79, 0x12, 0x45, 0x0a, 0x06, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x12, 0x1c, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x42, 0x69, 0x6e, 0x61,
+	0x72, 0x69, 0x65, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x47, 0x65, 0x74, 0x41, 0x6c, 0x6c, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x69,
+	0x65, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x43, 0x0a, 0x06, 0x55, 0x70,
+	0x6c, 0x6f, 0x61, 0x64, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x70, 0x6c,
+	0x6f, 0x61, 0x64, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x55, 0x70, 0x6c, 0x6f, 0x61, 0x64, 0x42,
+	0x69, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x28, 0x01, 0x12,
+	0x49, 0x0a, 0x08, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x1c, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x69, 0x6e, 0x61,
+	0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1d, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x44, 0x6f, 0x77, 0x6e, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x41, 0x0a, 0x06, 0x44, 0x65,
+	0x6c, 0x65, 0x74, 0x65, 0x12, 0x1a, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x6c,
+	0x65, 0x74, 0x65, 0x42, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x44, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x42,
+	0x69, 0x6e, 0x61, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x12, 0x5a,
+	0x10, 0x67, 0x6f, 0x70, 0x68, 0x6b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_binary_proto_rawDescOnce sync.Once
+	file_proto_binary_proto_rawDescData = file_proto_binary_proto_rawDesc
+)
+
+func file_proto_binary_proto_rawDescGZIP() []byte {
+	file_proto_binary_proto_rawDescOnce.Do(func() {
+		file_proto_binary_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_binary_proto_rawDescData)
+	})
+	return file_proto_binary_proto_rawDescData
+}
+
+var file_proto_binary_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_proto_binary_proto_goTypes = []interface{}{
+	(*GetAllBinariesRequest)(nil),  // 0: proto.GetAllBinariesRequest
+	(*BinaryMsg)(nil),              // 1: proto.BinaryMsg
+	(*GetAllBinariesResponse)(nil), // 2: proto.GetAllBinariesResponse
+	(*UploadBinaryRequest)(nil),    // 3: proto.UploadBinaryRequest
+	(*UploadBinaryResponse)(nil),   // 4: proto.UploadBinaryResponse
+	(*DownloadBinaryRequest)(nil),  // 5: proto.DownloadBinaryRequest
+	(*DownloadBinaryResponse)(nil), // 6: proto.DownloadBinaryResponse
+	(*DeleteBinaryRequest)(nil),    // 7: proto.DeleteBinaryRequest
+	(*DeleteBinaryResponse)(nil),   // 8: proto.DeleteBinaryResponse
+	(*PageRequest)(nil),            // 9: proto.PageRequest
+}
+var file_proto_binary_proto_depIdxs = []int32{
+	9, // 0: proto.GetAllBinariesRequest.page:type_name -> proto.PageRequest
+	1, // 1: proto.GetAllBinariesResponse.binaries:type_name -> proto.BinaryMsg
+	1, // 2: proto.UploadBinaryRequest.info:type_name -> proto.BinaryMsg
+	1, // 3: proto.DownloadBinaryResponse.info:type_name -> proto.BinaryMsg
+	0, // 4: proto.Binary.GetAll:input_type -> proto.GetAllBinariesRequest
+	3, // 5: proto.Binary.Upload:input_type -> proto.UploadBinaryRequest
+	5, // 6: proto.Binary.Download:input_type -> proto.DownloadBinaryRequest
+	7, // 7: proto.Binary.Delete:input_type -> proto.DeleteBinaryRequest
+	2, // 8: proto.Binary.GetAll:output_type -> proto.GetAllBinariesResponse
+	4, // 9: proto.Binary.Upload:output_type -> proto.UploadBinaryResponse
+	6, // 10: proto.Binary.Download:output_type -> proto.DownloadBinaryResponse
+	8, // 11: proto.Binary.Delete:output_type -> proto.DeleteBinaryResponse
+	8, // [8:12] is the sub-list for method output_type
+	4, // [4:8] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_proto_binary_proto_init() }
+func file_proto_binary_proto_init() {
+	if File_proto_binary_proto != nil {
+		return
+	}
+	file_proto_page_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_proto_binary_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAllBinariesRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_binary_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BinaryMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_binary_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*GetAllBinariesResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_binary_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadBinaryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_binary_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*UploadBinaryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_binary_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DownloadBinaryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_binary_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DownloadBinaryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_binary_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteBinaryRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_binary_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DeleteBinaryResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_proto_binary_proto_msgTypes[3].OneofWrappers = []interface{}{
+		(*UploadBinaryRequest_Info)(nil),
+		(*UploadBinaryRequest_Chunk)(nil),
+	}
+	file_proto_binary_proto_msgTypes[6].OneofWrappers = []interface{}{
+		(*DownloadBinaryResponse_Info)(nil),
+		(*DownloadBinaryResponse_Chunk)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_binary_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   9,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_binary_proto_goTypes,
+		DependencyIndexes: file_proto_binary_proto_depIdxs,
+		MessageInfos:      file_proto_binary_proto_msgTypes,
+	}.Build()
+	File_proto_binary_proto = out.File
+	file_proto_binary_proto_rawDesc = nil
+	file_proto_binary_proto_goTypes = nil
+	file_proto_binary_proto_depIdxs = nil
+}
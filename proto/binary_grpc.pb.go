@@ -0,0 +1,275 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.12
+// source: proto/binary.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// BinaryClient is the client API for Binary service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BinaryClient interface {
+	GetAll(ctx context.Context, in *GetAllBinariesRequest, opts ...grpc.CallOption) (*GetAllBinariesResponse, error)
+	Upload(ctx context.Context, opts ...grpc.CallOption) (Binary_UploadClient, error)
+	Download(ctx context.Context, in *DownloadBinaryRequest, opts ...grpc.CallOption) (Binary_DownloadClient, error)
+	Delete(ctx context.Context, in *DeleteBinaryRequest, opts ...grpc.CallOption) (*DeleteBinaryResponse, error)
+}
+
+type binaryClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBinaryClient(cc grpc.ClientConnInterface) BinaryClient {
+	return &binaryClient{cc}
+}
+
+func (c *binaryClient) GetAll(ctx context.Context, in *GetAllBinariesRequest, opts ...grpc.CallOption) (*GetAllBinariesResponse, error) {
+	out := new(GetAllBinariesResponse)
+	err := c.cc.Invoke(ctx, "/proto.Binary/GetAll", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *binaryClient) Upload(ctx context.Context, opts ...grpc.CallOption) (Binary_UploadClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Binary_ServiceDesc.Streams[0], "/proto.Binary/Upload", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &binaryUploadClient{stream}
+	return x, nil
+}
+
+type Binary_UploadClient interface {
+	Send(*UploadBinaryRequest) error
+	CloseAndRecv() (*UploadBinaryResponse, error)
+	grpc.ClientStream
+}
+
+type binaryUploadClient struct {
+	grpc.ClientStream
+}
+
+func (x *binaryUploadClient) Send(m *UploadBinaryRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *binaryUploadClient) CloseAndRecv() (*UploadBinaryResponse, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(UploadBinaryResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *binaryClient) Download(ctx context.Context, in *DownloadBinaryRequest, opts ...grpc.CallOption) (Binary_DownloadClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Binary_ServiceDesc.Streams[1], "/proto.Binary/Download", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &binaryDownloadClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Binary_DownloadClient interface {
+	Recv() (*DownloadBinaryResponse, error)
+	grpc.ClientStream
+}
+
+type binaryDownloadClient struct {
+	grpc.ClientStream
+}
+
+func (x *binaryDownloadClient) Recv() (*DownloadBinaryResponse, error) {
+	m := new(DownloadBinaryResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *binaryClient) Delete(ctx context.Context, in *DeleteBinaryRequest, opts ...grpc.CallOption) (*DeleteBinaryResponse, error) {
+	out := new(DeleteBinaryResponse)
+	err := c.cc.Invoke(ctx, "/proto.Binary/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BinaryServer is the server API for Binary service.
+// All implementations must embed UnimplementedBinaryServer
+// for forward compatibility
+type BinaryServer interface {
+	GetAll(context.Context, *GetAllBinariesRequest) (*GetAllBinariesResponse, error)
+	Upload(Binary_UploadServer) error
+	Download(*DownloadBinaryRequest, Binary_DownloadServer) error
+	Delete(context.Context, *DeleteBinaryRequest) (*DeleteBinaryResponse, error)
+	mustEmbedUnimplementedBinaryServer()
+}
+
+// UnimplementedBinaryServer must be embedded to have forward compatible implementations.
+type UnimplementedBinaryServer struct {
+}
+
+func (UnimplementedBinaryServer) GetAll(context.Context, *GetAllBinariesRequest) (*GetAllBinariesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAll not implemented")
+}
+func (UnimplementedBinaryServer) Upload(Binary_UploadServer) error {
+	return status.Errorf(codes.Unimplemented, "method Upload not implemented")
+}
+func (UnimplementedBinaryServer) Download(*DownloadBinaryRequest, Binary_DownloadServer) error {
+	return status.Errorf(codes.Unimplemented, "method Download not implemented")
+}
+func (UnimplementedBinaryServer) Delete(context.Context, *DeleteBinaryRequest) (*DeleteBinaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedBinaryServer) mustEmbedUnimplementedBinaryServer() {}
+
+// UnsafeBinaryServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BinaryServer will
+// result in compilation errors.
+type UnsafeBinaryServer interface {
+	mustEmbedUnimplementedBinaryServer()
+}
+
+func RegisterBinaryServer(s grpc.ServiceRegistrar, srv BinaryServer) {
+	s.RegisterService(&Binary_ServiceDesc, srv)
+}
+
+func _Binary_GetAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAllBinariesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BinaryServer).GetAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Binary/GetAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BinaryServer).GetAll(ctx, req.(*GetAllBinariesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Binary_Upload_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(BinaryServer).Upload(&binaryUploadServer{stream})
+}
+
+type Binary_UploadServer interface {
+	SendAndClose(*UploadBinaryResponse) error
+	Recv() (*UploadBinaryRequest, error)
+	grpc.ServerStream
+}
+
+type binaryUploadServer struct {
+	grpc.ServerStream
+}
+
+func (x *binaryUploadServer) SendAndClose(m *UploadBinaryResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *binaryUploadServer) Recv() (*UploadBinaryRequest, error) {
+	m := new(UploadBinaryRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Binary_Download_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DownloadBinaryRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BinaryServer).Download(m, &binaryDownloadServer{stream})
+}
+
+type Binary_DownloadServer interface {
+	Send(*DownloadBinaryResponse) error
+	grpc.ServerStream
+}
+
+type binaryDownloadServer struct {
+	grpc.ServerStream
+}
+
+func (x *binaryDownloadServer) Send(m *DownloadBinaryResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Binary_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBinaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BinaryServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Binary/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BinaryServer).Delete(ctx, req.(*DeleteBinaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Binary_ServiceDesc is the grpc.ServiceDesc for Binary service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Binary_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.Binary",
+	HandlerType: (*BinaryServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAll",
+			Handler:    _Binary_GetAll_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _Binary_Delete_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Upload",
+			Handler:       _Binary_Upload_Handler,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "Download",
+			Handler:       _Binary_Download_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/binary.proto",
+}
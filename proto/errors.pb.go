@@ -0,0 +1,230 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.12
+// source: proto/errors.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Домен ошибок сервера (поле domain в google.rpc.ErrorInfo).
+//
+// Ошибки сервера передаются статусом gRPC с деталями google.rpc.ErrorInfo: reason - имя значения
+// ErrorReason (например, "USER_EXISTS"), по нему клиент определяет причину независимо от текста ошибки.
+type ErrorReason int32
+
+const (
+	ErrorReason_ERROR_REASON_UNSPECIFIED ErrorReason = 0
+	// логин уже занят (регистрация)
+	ErrorReason_USER_EXISTS ErrorReason = 1
+	// запись не найдена (или принадлежит другому пользователю)
+	ErrorReason_NOT_FOUND ErrorReason = 2
+	// неверный логин или пароль
+	ErrorReason_INVALID_CREDENTIALS ErrorReason = 3
+	// неверный текущий пароль (подтверждение операции)
+	ErrorReason_PASSWORD_MISMATCH ErrorReason = 4
+	// срок действия пароля истёк, пароль необходимо сменить
+	ErrorReason_PASSWORD_EXPIRED ErrorReason = 5
+	// новый пароль недавно использовался
+	ErrorReason_PASSWORD_REUSED ErrorReason = 6
+	// вход заблокирован после серии неудачных попыток
+	ErrorReason_TOO_MANY_ATTEMPTS ErrorReason = 7
+	// требуется код аутентификатора
+	ErrorReason_SECOND_FACTOR_REQUIRED ErrorReason = 8
+	// неверный или уже использованный код аутентификатора
+	ErrorReason_INVALID_CODE ErrorReason = 9
+	// незавершённый вход не найден или истёк
+	ErrorReason_INVALID_CHALLENGE ErrorReason = 10
+	// аутентификатор не подключён
+	ErrorReason_TOTP_NOT_ENROLLED ErrorReason = 11
+	// токен не передан
+	ErrorReason_TOKEN_MISSING ErrorReason = 12
+	// срок действия access-токена истёк
+	ErrorReason_TOKEN_EXPIRED ErrorReason = 13
+	// сессия недействительна, истекла или отозвана
+	ErrorReason_SESSION_INVALID ErrorReason = 14
+	// файл превышает допустимый размер
+	ErrorReason_BINARY_TOO_LARGE ErrorReason = 15
+	// некорректные параметры одноразового пароля
+	ErrorReason_INVALID_OTP ErrorReason = 16
+	// внутренняя ошибка сервера
+	ErrorReason_INTERNAL ErrorReason = 17
+	// некорректные параметры страницы списка
+	ErrorReason_INVALID_PAGE ErrorReason = 18
+	// некорректная папка или метка
+	ErrorReason_INVALID_LABEL ErrorReason = 20
+)
+
+// Enum value maps for ErrorReason.
+var (
+	ErrorReason_name = map[int32]string{
+		0:  "ERROR_REASON_UNSPECIFIED",
+		1:  "USER_EXISTS",
+		2:  "NOT_FOUND",
+		3:  "INVALID_CREDENTIALS",
+		4:  "PASSWORD_MISMATCH",
+		5:  "PASSWORD_EXPIRED",
+		6:  "PASSWORD_REUSED",
+		7:  "TOO_MANY_ATTEMPTS",
+		8:  "SECOND_FACTOR_REQUIRED",
+		9:  "INVALID_CODE",
+		10: "INVALID_CHALLENGE",
+		11: "TOTP_NOT_ENROLLED",
+		12: "TOKEN_MISSING",
+		13: "TOKEN_EXPIRED",
+		14: "SESSION_INVALID",
+		15: "BINARY_TOO_LARGE",
+		16: "INVALID_OTP",
+		17: "INTERNAL",
+		18: "INVALID_PAGE",
+		20: "INVALID_LABEL",
+	}
+	ErrorReason_value = map[string]int32{
+		"ERROR_REASON_UNSPECIFIED": 0,
+		"USER_EXISTS":              1,
+		"NOT_FOUND":                2,
+		"INVALID_CREDENTIALS":      3,
+		"PASSWORD_MISMATCH":        4,
+		"PASSWORD_EXPIRED":         5,
+		"PASSWORD_REUSED":          6,
+		"TOO_MANY_ATTEMPTS":        7,
+		"SECOND_FACTOR_REQUIRED":   8,
+		"INVALID_CODE":             9,
+		"INVALID_CHALLENGE":        10,
+		"TOTP_NOT_ENROLLED":        11,
+		"TOKEN_MISSING":            12,
+		"TOKEN_EXPIRED":            13,
+		"SESSION_INVALID":          14,
+		"BINARY_TOO_LARGE":         15,
+		"INVALID_OTP":              16,
+		"INTERNAL":                 17,
+		"INVALID_PAGE":             18,
+		"INVALID_LABEL":            20,
+	}
+)
+
+func (x ErrorReason) Enum() *ErrorReason {
+	p := new(ErrorReason)
+	*p = x
+	return p
+}
+
+func (x ErrorReason) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ErrorReason) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_errors_proto_enumTypes[0].Descriptor()
+}
+
+func (ErrorReason) Type() protoreflect.EnumType {
+	return &file_proto_errors_proto_enumTypes[0]
+}
+
+func (x ErrorReason) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ErrorReason.Descriptor instead.
+func (ErrorReason) EnumDescriptor() ([]byte, []int) {
+	return file_proto_errors_proto_rawDescGZIP(), []int{0}
+}
+
+var File_proto_errors_proto protoreflect.FileDescriptor
+
+var file_proto_errors_proto_rawDesc = []byte{
+	0x0a, 0x12, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x73, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2a, 0xc3, 0x03, 0x0a, 0x0b,
+	0x45, 0x72, 0x72, 0x6f, 0x72, 0x52, 0x65, 0x61, 0x73, 0x6f, 0x6e, 0x12, 0x1c, 0x0a, 0x18, 0x45,
+	0x52, 0x52, 0x4f, 0x52, 0x5f, 0x52, 0x45, 0x41, 0x53, 0x4f, 0x4e, 0x5f, 0x55, 0x4e, 0x53, 0x50,
+	0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x53, 0x45,
+	0x52, 0x5f, 0x45, 0x58, 0x49, 0x53, 0x54, 0x53, 0x10, 0x01, 0x12, 0x0d, 0x0a, 0x09, 0x4e, 0x4f,
+	0x54, 0x5f, 0x46, 0x4f, 0x55, 0x4e, 0x44, 0x10, 0x02, 0x12, 0x17, 0x0a, 0x13, 0x49, 0x4e, 0x56,
+	0x41, 0x4c, 0x49, 0x44, 0x5f, 0x43, 0x52, 0x45, 0x44, 0x45, 0x4e, 0x54, 0x49, 0x41, 0x4c, 0x53,
+	0x10, 0x03, 0x12, 0x15, 0x0a, 0x11, 0x50, 0x41, 0x53, 0x53, 0x57, 0x4f, 0x52, 0x44, 0x5f, 0x4d,
+	0x49, 0x53, 0x4d, 0x41, 0x54, 0x43, 0x48, 0x10, 0x04, 0x12, 0x14, 0x0a, 0x10, 0x50, 0x41, 0x53,
+	0x53, 0x57, 0x4f, 0x52, 0x44, 0x5f, 0x45, 0x58, 0x50, 0x49, 0x52, 0x45, 0x44, 0x10, 0x05, 0x12,
+	0x13, 0x0a, 0x0f, 0x50, 0x41, 0x53, 0x53, 0x57, 0x4f, 0x52, 0x44, 0x5f, 0x52, 0x45, 0x55, 0x53,
+	0x45, 0x44, 0x10, 0x06, 0x12, 0x15, 0x0a, 0x11, 0x54, 0x4f, 0x4f, 0x5f, 0x4d, 0x41, 0x4e, 0x59,
+	0x5f, 0x41, 0x54, 0x54, 0x45, 0x4d, 0x50, 0x54, 0x53, 0x10, 0x07, 0x12, 0x1a, 0x0a, 0x16, 0x53,
+	0x45, 0x43, 0x4f, 0x4e, 0x44, 0x5f, 0x46, 0x41, 0x43, 0x54, 0x4f, 0x52, 0x5f, 0x52, 0x45, 0x51,
+	0x55, 0x49, 0x52, 0x45, 0x44, 0x10, 0x08, 0x12, 0x10, 0x0a, 0x0c, 0x49, 0x4e, 0x56, 0x41, 0x4c,
+	0x49, 0x44, 0x5f, 0x43, 0x4f, 0x44, 0x45, 0x10, 0x09, 0x12, 0x15, 0x0a, 0x11, 0x49, 0x4e, 0x56,
+	0x41, 0x4c, 0x49, 0x44, 0x5f, 0x43, 0x48, 0x41, 0x4c, 0x4c, 0x45, 0x4e, 0x47, 0x45, 0x10, 0x0a,
+	0x12, 0x15, 0x0a, 0x11, 0x54, 0x4f, 0x54, 0x50, 0x5f, 0x4e, 0x4f, 0x54, 0x5f, 0x45, 0x4e, 0x52,
+	0x4f, 0x4c, 0x4c, 0x45, 0x44, 0x10, 0x0b, 0x12, 0x11, 0x0a, 0x0d, 0x54, 0x4f, 0x4b, 0x45, 0x4e,
+	0x5f, 0x4d, 0x49, 0x53, 0x53, 0x49, 0x4e, 0x47, 0x10, 0x0c, 0x12, 0x11, 0x0a, 0x0d, 0x54, 0x4f,
+	0x4b, 0x45, 0x4e, 0x5f, 0x45, 0x58, 0x50, 0x49, 0x52, 0x45, 0x44, 0x10, 0x0d, 0x12, 0x13, 0x0a,
+	0x0f, 0x53, 0x45, 0x53, 0x53, 0x49, 0x4f, 0x4e, 0x5f, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44,
+	0x10, 0x0e, 0x12, 0x14, 0x0a, 0x10, 0x42, 0x49, 0x4e, 0x41, 0x52, 0x59, 0x5f, 0x54, 0x4f, 0x4f,
+	0x5f, 0x4c, 0x41, 0x52, 0x47, 0x45, 0x10, 0x0f, 0x12, 0x0f, 0x0a, 0x0b, 0x49, 0x4e, 0x56, 0x41,
+	0x4c, 0x49, 0x44, 0x5f, 0x4f, 0x54, 0x50, 0x10, 0x10, 0x12, 0x0c, 0x0a, 0x08, 0x49, 0x4e, 0x54,
+	0x45, 0x52, 0x4e, 0x41, 0x4c, 0x10, 0x11, 0x12, 0x10, 0x0a, 0x0c, 0x49, 0x4e, 0x56, 0x41, 0x4c,
+	0x49, 0x44, 0x5f, 0x50, 0x41, 0x47, 0x45, 0x10, 0x12, 0x12, 0x11, 0x0a, 0x0d, 0x49, 0x4e, 0x56,
+	0x41, 0x4c, 0x49, 0x44, 0x5f, 0x4c, 0x41, 0x42, 0x45, 0x4c, 0x10, 0x14, 0x22, 0x04, 0x08, 0x13,
+	0x10, 0x13, 0x2a, 0x0d, 0x49, 0x4e, 0x56, 0x41, 0x4c, 0x49, 0x44, 0x5f, 0x51, 0x55, 0x45, 0x52,
+	0x59, 0x42, 0x12, 0x5a, 0x10, 0x67, 0x6f, 0x70, 0x68, 0x6b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x2f,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_errors_proto_rawDescOnce sync.Once
+	file_proto_errors_proto_rawDescData = file_proto_errors_proto_rawDesc
+)
+
+func file_proto_errors_proto_rawDescGZIP() []byte {
+	file_proto_errors_proto_rawDescOnce.Do(func() {
+		file_proto_errors_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_errors_proto_rawDescData)
+	})
+	return file_proto_errors_proto_rawDescData
+}
+
+var file_proto_errors_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_errors_proto_goTypes = []interface{}{
+	(ErrorReason)(0), // 0: proto.ErrorReason
+}
+var file_proto_errors_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_proto_errors_proto_init() }
+func file_proto_errors_proto_init() {
+	if File_proto_errors_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_errors_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   0,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_errors_proto_goTypes,
+		DependencyIndexes: file_proto_errors_proto_depIdxs,
+		EnumInfos:         file_proto_errors_proto_enumTypes,
+	}.Build()
+	File_proto_errors_proto = out.File
+	file_proto_errors_proto_rawDesc = nil
+	file_proto_errors_proto_goTypes = nil
+	file_proto_errors_proto_depIdxs = nil
+}
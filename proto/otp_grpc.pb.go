@@ -0,0 +1,213 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.12
+// source: proto/otp.proto
+
+package proto
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// OTPClient is the client API for OTP service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type OTPClient interface {
+	GetAll(ctx context.Context, in *GetAllOTPsRequest, opts ...grpc.CallOption) (*GetAllOTPsResponse, error)
+	Create(ctx context.Context, in *CreateOTPRequest, opts ...grpc.CallOption) (*CreateOTPResponse, error)
+	Update(ctx context.Context, in *UpdateOTPRequest, opts ...grpc.CallOption) (*UpdateOTPResponse, error)
+	Delete(ctx context.Context, in *DeleteOTPRequest, opts ...grpc.CallOption) (*DeleteOTPResponse, error)
+}
+
+type oTPClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewOTPClient(cc grpc.ClientConnInterface) OTPClient {
+	return &oTPClient{cc}
+}
+
+func (c *oTPClient) GetAll(ctx context.Context, in *GetAllOTPsRequest, opts ...grpc.CallOption) (*GetAllOTPsResponse, error) {
+	out := new(GetAllOTPsResponse)
+	err := c.cc.Invoke(ctx, "/proto.OTP/GetAll", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oTPClient) Create(ctx context.Context, in *CreateOTPRequest, opts ...grpc.CallOption) (*CreateOTPResponse, error) {
+	out := new(CreateOTPResponse)
+	err := c.cc.Invoke(ctx, "/proto.OTP/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oTPClient) Update(ctx context.Context, in *UpdateOTPRequest, opts ...grpc.CallOption) (*UpdateOTPResponse, error) {
+	out := new(UpdateOTPResponse)
+	err := c.cc.Invoke(ctx, "/proto.OTP/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *oTPClient) Delete(ctx context.Context, in *DeleteOTPRequest, opts ...grpc.CallOption) (*DeleteOTPResponse, error) {
+	out := new(DeleteOTPResponse)
+	err := c.cc.Invoke(ctx, "/proto.OTP/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// OTPServer is the server API for OTP service.
+// All implementations must embed UnimplementedOTPServer
+// for forward compatibility
+type OTPServer interface {
+	GetAll(context.Context, *GetAllOTPsRequest) (*GetAllOTPsResponse, error)
+	Create(context.Context, *CreateOTPRequest) (*CreateOTPResponse, error)
+	Update(context.Context, *UpdateOTPRequest) (*UpdateOTPResponse, error)
+	Delete(context.Context, *DeleteOTPRequest) (*DeleteOTPResponse, error)
+	mustEmbedUnimplementedOTPServer()
+}
+
+// UnimplementedOTPServer must be embedded to have forward compatible implementations.
+type UnimplementedOTPServer struct {
+}
+
+func (UnimplementedOTPServer) GetAll(context.Context, *GetAllOTPsRequest) (*GetAllOTPsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAll not implemented")
+}
+func (UnimplementedOTPServer) Create(context.Context, *CreateOTPRequest) (*CreateOTPResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedOTPServer) Update(context.Context, *UpdateOTPRequest) (*UpdateOTPResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedOTPServer) Delete(context.Context, *DeleteOTPRequest) (*DeleteOTPResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
+func (UnimplementedOTPServer) mustEmbedUnimplementedOTPServer() {}
+
+// UnsafeOTPServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to OTPServer will
+// result in compilation errors.
+type UnsafeOTPServer interface {
+	mustEmbedUnimplementedOTPServer()
+}
+
+func RegisterOTPServer(s grpc.ServiceRegistrar, srv OTPServer) {
+	s.RegisterService(&OTP_ServiceDesc, srv)
+}
+
+func _OTP_GetAll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAllOTPsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OTPServer).GetAll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.OTP/GetAll",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OTPServer).GetAll(ctx, req.(*GetAllOTPsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OTP_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOTPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OTPServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.OTP/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OTPServer).Create(ctx, req.(*CreateOTPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OTP_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateOTPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OTPServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.OTP/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OTPServer).Update(ctx, req.(*UpdateOTPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OTP_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteOTPRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OTPServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.OTP/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OTPServer).Delete(ctx, req.(*DeleteOTPRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// OTP_ServiceDesc is the grpc.ServiceDesc for OTP service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var OTP_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "proto.OTP",
+	HandlerType: (*OTPServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetAll",
+			Handler:    _OTP_GetAll_Handler,
+		},
+		{
+			MethodName: "Create",
+			Handler:    _OTP_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _OTP_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _OTP_Delete_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/otp.proto",
+}
@@ -0,0 +1,267 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.12
+// source: proto/page.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Порядок записей в списке (по времени создания, при равенстве - по id).
+type SortOrder int32
+
+const (
+	// сначала старые записи
+	SortOrder_CREATED_ASC SortOrder = 0
+	// сначала новые записи
+	SortOrder_CREATED_DESC SortOrder = 1
+)
+
+// Enum value maps for SortOrder.
+var (
+	SortOrder_name = map[int32]string{
+		0: "CREATED_ASC",
+		1: "CREATED_DESC",
+	}
+	SortOrder_value = map[string]int32{
+		"CREATED_ASC":  0,
+		"CREATED_DESC": 1,
+	}
+)
+
+func (x SortOrder) Enum() *SortOrder {
+	p := new(SortOrder)
+	*p = x
+	return p
+}
+
+func (x SortOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_page_proto_enumTypes[0].Descriptor()
+}
+
+func (SortOrder) Type() protoreflect.EnumType {
+	return &file_proto_page_proto_enumTypes[0]
+}
+
+func (x SortOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortOrder.Descriptor instead.
+func (SortOrder) EnumDescriptor() ([]byte, []int) {
+	return file_proto_page_proto_rawDescGZIP(), []int{0}
+}
+
+// Запрос страницы списка записей.
+//
+// size - размер страницы (0 - по умолчанию), token - токен следующей страницы из предыдущего ответа
+// (пустой - первая страница), created_from/created_to - диапазон времени создания [from, to)
+// (unix-время в наносекундах, 0 - без ограничения), order - порядок записей,
+// folder_id - только записи папки (не задан - любой папки, 0 - записи вне папок),
+// tag_id - только записи с меткой (0 - без отбора по метке).
+// При продолжении списка по токену порядок и отбор должны совпадать с первой страницей.
+type PageRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Size        int32     `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	Token       string    `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	CreatedFrom int64     `protobuf:"varint,3,opt,name=created_from,json=createdFrom,proto3" json:"created_from,omitempty"`
+	CreatedTo   int64     `protobuf:"varint,4,opt,name=created_to,json=createdTo,proto3" json:"created_to,omitempty"`
+	Order       SortOrder `protobuf:"varint,5,opt,name=order,proto3,enum=proto.SortOrder" json:"order,omitempty"`
+	FolderId    *int64    `protobuf:"varint,6,opt,name=folder_id,json=folderId,proto3,oneof" json:"folder_id,omitempty"`
+	TagId       int64     `protobuf:"varint,7,opt,name=tag_id,json=tagId,proto3" json:"tag_id,omitempty"`
+}
+
+func (x *PageRequest) Reset() {
+	*x = PageRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_page_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *PageRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PageRequest) ProtoMessage() {}
+
+func (x *PageRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_page_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PageRequest.ProtoReflect.Descriptor instead.
+func (*PageRequest) Descriptor() ([]byte, []int) {
+	return file_proto_page_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PageRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *PageRequest) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *PageRequest) GetCreatedFrom() int64 {
+	if x != nil {
+		return x.CreatedFrom
+	}
+	return 0
+}
+
+func (x *PageRequest) GetCreatedTo() int64 {
+	if x != nil {
+		return x.CreatedTo
+	}
+	return 0
+}
+
+func (x *PageRequest) GetOrder() SortOrder {
+	if x != nil {
+		return x.Order
+	}
+	return SortOrder_CREATED_ASC
+}
+
+func (x *PageRequest) GetFolderId() int64 {
+	if x != nil && x.FolderId != nil {
+		return *x.FolderId
+	}
+	return 0
+}
+
+func (x *PageRequest) GetTagId() int64 {
+	if x != nil {
+		return x.TagId
+	}
+	return 0
+}
+
+var File_proto_page_proto protoreflect.FileDescriptor
+
+var file_proto_page_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x70, 0x61, 0x67, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xe8, 0x01, 0x0a, 0x0b, 0x50, 0x61,
+	0x67, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x12, 0x14, 0x0a,
+	0x05, 0x74, 0x6f, 0x6b, 0x65, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x74, 0x6f,
+	0x6b, 0x65, 0x6e, 0x12, 0x21, 0x0a, 0x0c, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x5f, 0x66,
+	0x72, 0x6f, 0x6d, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0b, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x64, 0x46, 0x72, 0x6f, 0x6d, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x64, 0x5f, 0x74, 0x6f, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x63, 0x72, 0x65, 0x61,
+	0x74, 0x65, 0x64, 0x54, 0x6f, 0x12, 0x26, 0x0a, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53, 0x6f, 0x72,
+	0x74, 0x4f, 0x72, 0x64, 0x65, 0x72, 0x52, 0x05, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x12, 0x20, 0x0a,
+	0x09, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x06, 0x20, 0x01, 0x28, 0x03,
+	0x48, 0x00, 0x52, 0x08, 0x66, 0x6f, 0x6c, 0x64, 0x65, 0x72, 0x49, 0x64, 0x88, 0x01, 0x01, 0x12,
+	0x15, 0x0a, 0x06, 0x74, 0x61, 0x67, 0x5f, 0x69, 0x64, 0x18, 0x07, 0x20, 0x01, 0x28, 0x03, 0x52,
+	0x05, 0x74, 0x61, 0x67, 0x49, 0x64, 0x42, 0x0c, 0x0a, 0x0a, 0x5f, 0x66, 0x6f, 0x6c, 0x64, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x2a, 0x2e, 0x0a, 0x09, 0x53, 0x6f, 0x72, 0x74, 0x4f, 0x72, 0x64, 0x65,
+	0x72, 0x12, 0x0f, 0x0a, 0x0b, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44, 0x5f, 0x41, 0x53, 0x43,
+	0x10, 0x00, 0x12, 0x10, 0x0a, 0x0c, 0x43, 0x52, 0x45, 0x41, 0x54, 0x45, 0x44, 0x5f, 0x44, 0x45,
+	0x53, 0x43, 0x10, 0x01, 0x42, 0x12, 0x5a, 0x10, 0x67, 0x6f, 0x70, 0x68, 0x6b, 0x65, 0x65, 0x70,
+	0x65, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_page_proto_rawDescOnce sync.Once
+	file_proto_page_proto_rawDescData = file_proto_page_proto_rawDesc
+)
+
+func file_proto_page_proto_rawDescGZIP() []byte {
+	file_proto_page_proto_rawDescOnce.Do(func() {
+		file_proto_page_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_page_proto_rawDescData)
+	})
+	return file_proto_page_proto_rawDescData
+}
+
+var file_proto_page_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_page_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_proto_page_proto_goTypes = []interface{}{
+	(SortOrder)(0),      // 0: proto.SortOrder
+	(*PageRequest)(nil), // 1: proto.PageRequest
+}
+var file_proto_page_proto_depIdxs = []int32{
+	0, // 0: proto.PageRequest.order:type_name -> proto.SortOrder
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_proto_page_proto_init() }
+func file_proto_page_proto_init() {
+	if File_proto_page_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_proto_page_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*PageRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_proto_page_proto_msgTypes[0].OneofWrappers = []interface{}{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_page_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_proto_page_proto_goTypes,
+		DependencyIndexes: file_proto_page_proto_depIdxs,
+		EnumInfos:         file_proto_page_proto_enumTypes,
+		MessageInfos:      file_proto_page_proto_msgTypes,
+	}.Build()
+	File_proto_page_proto = out.File
+	file_proto_page_proto_rawDesc = nil
+	file_proto_page_proto_goTypes = nil
+	file_proto_page_proto_depIdxs = nil
+}
@@ -23,6 +23,9 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type PairClient interface {
 	GetAll(ctx context.Context, in *GetAllPairsRequest, opts ...grpc.CallOption) (*GetAllPairsResponse, error)
+	Create(ctx context.Context, in *CreatePairRequest, opts ...grpc.CallOption) (*CreatePairResponse, error)
+	Update(ctx context.Context, in *UpdatePairRequest, opts ...grpc.CallOption) (*UpdatePairResponse, error)
+	Delete(ctx context.Context, in *DeletePairRequest, opts ...grpc.CallOption) (*DeletePairResponse, error)
 }
 
 type pairClient struct {
@@ -42,11 +45,41 @@ func (c *pairClient) GetAll(ctx context.Context, in *GetAllPairsRequest, opts ..
 	return out, nil
 }
 
+func (c *pairClient) Create(ctx context.Context, in *CreatePairRequest, opts ...grpc.CallOption) (*CreatePairResponse, error) {
+	out := new(CreatePairResponse)
+	err := c.cc.Invoke(ctx, "/proto.Pair/Create", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pairClient) Update(ctx context.Context, in *UpdatePairRequest, opts ...grpc.CallOption) (*UpdatePairResponse, error) {
+	out := new(UpdatePairResponse)
+	err := c.cc.Invoke(ctx, "/proto.Pair/Update", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pairClient) Delete(ctx context.Context, in *DeletePairRequest, opts ...grpc.CallOption) (*DeletePairResponse, error) {
+	out := new(DeletePairResponse)
+	err := c.cc.Invoke(ctx, "/proto.Pair/Delete", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PairServer is the server API for Pair service.
 // All implementations must embed UnimplementedPairServer
 // for forward compatibility
 type PairServer interface {
 	GetAll(context.Context, *GetAllPairsRequest) (*GetAllPairsResponse, error)
+	Create(context.Context, *CreatePairRequest) (*CreatePairResponse, error)
+	Update(context.Context, *UpdatePairRequest) (*UpdatePairResponse, error)
+	Delete(context.Context, *DeletePairRequest) (*DeletePairResponse, error)
 	mustEmbedUnimplementedPairServer()
 }
 
@@ -57,6 +90,15 @@ type UnimplementedPairServer struct {
 func (UnimplementedPairServer) GetAll(context.Context, *GetAllPairsRequest) (*GetAllPairsResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetAll not implemented")
 }
+func (UnimplementedPairServer) Create(context.Context, *CreatePairRequest) (*CreatePairResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Create not implemented")
+}
+func (UnimplementedPairServer) Update(context.Context, *UpdatePairRequest) (*UpdatePairResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Update not implemented")
+}
+func (UnimplementedPairServer) Delete(context.Context, *DeletePairRequest) (*DeletePairResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Delete not implemented")
+}
 func (UnimplementedPairServer) mustEmbedUnimplementedPairServer() {}
 
 // UnsafePairServer may be embedded to opt out of forward compatibility for this service.
@@ -88,6 +130,60 @@ func _Pair_GetAll_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Pair_Create_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePairRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PairServer).Create(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Pair/Create",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PairServer).Create(ctx, req.(*CreatePairRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pair_Update_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePairRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PairServer).Update(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Pair/Update",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PairServer).Update(ctx, req.(*UpdatePairRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Pair_Delete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePairRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PairServer).Delete(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/proto.Pair/Delete",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PairServer).Delete(ctx, req.(*DeletePairRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Pair_ServiceDesc is the grpc.ServiceDesc for Pair service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -99,6 +195,18 @@ var Pair_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAll",
 			Handler:    _Pair_GetAll_Handler,
 		},
+		{
+			MethodName: "Create",
+			Handler:    _Pair_Create_Handler,
+		},
+		{
+			MethodName: "Update",
+			Handler:    _Pair_Update_Handler,
+		},
+		{
+			MethodName: "Delete",
+			Handler:    _Pair_Delete_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/pair.proto",
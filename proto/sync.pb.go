@@ -0,0 +1,389 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.21.12
+// source: proto/sync.proto
+
+package proto
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Отметка об удалении записи (kind - тип записи: pair, card, note, binary, otp).
+type TombstoneMsg struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	Id   int64  `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *TombstoneMsg) Reset() {
+	*x = TombstoneMsg{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_sync_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TombstoneMsg) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TombstoneMsg) ProtoMessage() {}
+
+func (x *TombstoneMsg) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_sync_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TombstoneMsg.ProtoReflect.Descriptor instead.
+func (*TombstoneMsg) Descriptor() ([]byte, []int) {
+	return file_proto_sync_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *TombstoneMsg) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *TombstoneMsg) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// since - последняя известная клиенту ревизия (0 - получить все данные).
+type SyncRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Since int64 `protobuf:"varint,1,opt,name=since,proto3" json:"since,omitempty"`
+}
+
+func (x *SyncRequest) Reset() {
+	*x = SyncRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_sync_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyncRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncRequest) ProtoMessage() {}
+
+func (x *SyncRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_sync_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncRequest.ProtoReflect.Descriptor instead.
+func (*SyncRequest) Descriptor() ([]byte, []int) {
+	return file_proto_sync_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *SyncRequest) GetSince() int64 {
+	if x != nil {
+		return x.Since
+	}
+	return 0
+}
+
+// revision - текущая ревизия (курсор для следующего запроса).
+// full - передан полный набор данных, локальное состояние клиента следует заменить целиком.
+type SyncResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Revision int64           `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	Full     bool            `protobuf:"varint,2,opt,name=full,proto3" json:"full,omitempty"`
+	Pairs    []*PairMsg      `protobuf:"bytes,3,rep,name=pairs,proto3" json:"pairs,omitempty"`
+	Cards    []*CardMsg      `protobuf:"bytes,4,rep,name=cards,proto3" json:"cards,omitempty"`
+	Notes    []*NoteMsg      `protobuf:"bytes,5,rep,name=notes,proto3" json:"notes,omitempty"`
+	Binaries []*BinaryMsg    `protobuf:"bytes,6,rep,name=binaries,proto3" json:"binaries,omitempty"`
+	Otps     []*OTPMsg       `protobuf:"bytes,7,rep,name=otps,proto3" json:"otps,omitempty"`
+	Deleted  []*TombstoneMsg `protobuf:"bytes,8,rep,name=deleted,proto3" json:"deleted,omitempty"`
+	Error    string          `protobuf:"bytes,9,opt,name=error,proto3" json:"error,omitempty"`
+}
+
+func (x *SyncResponse) Reset() {
+	*x = SyncResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_proto_sync_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SyncResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SyncResponse) ProtoMessage() {}
+
+func (x *SyncResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_sync_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SyncResponse.ProtoReflect.Descriptor instead.
+func (*SyncResponse) Descriptor() ([]byte, []int) {
+	return file_proto_sync_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *SyncResponse) GetRevision() int64 {
+	if x != nil {
+		return x.Revision
+	}
+	return 0
+}
+
+func (x *SyncResponse) GetFull() bool {
+	if x != nil {
+		return x.Full
+	}
+	return false
+}
+
+func (x *SyncResponse) GetPairs() []*PairMsg {
+	if x != nil {
+		return x.Pairs
+	}
+	return nil
+}
+
+func (x *SyncResponse) GetCards() []*CardMsg {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+func (x *SyncResponse) GetNotes() []*NoteMsg {
+	if x != nil {
+		return x.Notes
+	}
+	return nil
+}
+
+func (x *SyncResponse) GetBinaries() []*BinaryMsg {
+	if x != nil {
+		return x.Binaries
+	}
+	return nil
+}
+
+func (x *SyncResponse) GetOtps() []*OTPMsg {
+	if x != nil {
+		return x.Otps
+	}
+	return nil
+}
+
+func (x *SyncResponse) GetDeleted() []*TombstoneMsg {
+	if x != nil {
+		return x.Deleted
+	}
+	return nil
+}
+
+func (x *SyncResponse) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+var File_proto_sync_proto protoreflect.FileDescriptor
+
+var file_proto_sync_proto_rawDesc = []byte{
+	0x0a, 0x10, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x73, 0x79, 0x6e, 0x63, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x12, 0x05, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x10, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x2f, 0x70, 0x61, 0x69, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x10, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2f, 0x62, 0x61, 0x6e, 0x6b, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x10, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x74, 0x65, 0x78, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a,
+	0x12, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x79, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x0f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2f, 0x6f, 0x74, 0x70, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0x32, 0x0a, 0x0c, 0x54, 0x6f, 0x6d, 0x62, 0x73, 0x74, 0x6f, 0x6e,
+	0x65, 0x4d, 0x73, 0x67, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x22, 0x23, 0x0a, 0x0b, 0x53, 0x79, 0x6e, 0x63,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x73, 0x69, 0x6e, 0x63, 0x65, 0x22, 0xc6, 0x02,
+	0x0a, 0x0c, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1a,
+	0x0a, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03,
+	0x52, 0x08, 0x72, 0x65, 0x76, 0x69, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x75,
+	0x6c, 0x6c, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x66, 0x75, 0x6c, 0x6c, 0x12, 0x24,
+	0x0a, 0x05, 0x70, 0x61, 0x69, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x50, 0x61, 0x69, 0x72, 0x4d, 0x73, 0x67, 0x52, 0x05, 0x70,
+	0x61, 0x69, 0x72, 0x73, 0x12, 0x24, 0x0a, 0x05, 0x63, 0x61, 0x72, 0x64, 0x73, 0x18, 0x04, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x43, 0x61, 0x72, 0x64,
+	0x4d, 0x73, 0x67, 0x52, 0x05, 0x63, 0x61, 0x72, 0x64, 0x73, 0x12, 0x24, 0x0a, 0x05, 0x6e, 0x6f,
+	0x74, 0x65, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x2e, 0x4e, 0x6f, 0x74, 0x65, 0x4d, 0x73, 0x67, 0x52, 0x05, 0x6e, 0x6f, 0x74, 0x65, 0x73,
+	0x12, 0x2c, 0x0a, 0x08, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x69, 0x65, 0x73, 0x18, 0x06, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x42, 0x69, 0x6e, 0x61, 0x72,
+	0x79, 0x4d, 0x73, 0x67, 0x52, 0x08, 0x62, 0x69, 0x6e, 0x61, 0x72, 0x69, 0x65, 0x73, 0x12, 0x21,
+	0x0a, 0x04, 0x6f, 0x74, 0x70, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x4f, 0x54, 0x50, 0x4d, 0x73, 0x67, 0x52, 0x04, 0x6f, 0x74, 0x70,
+	0x73, 0x12, 0x2d, 0x0a, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64, 0x18, 0x08, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x54, 0x6f, 0x6d, 0x62, 0x73,
+	0x74, 0x6f, 0x6e, 0x65, 0x4d, 0x73, 0x67, 0x52, 0x07, 0x64, 0x65, 0x6c, 0x65, 0x74, 0x65, 0x64,
+	0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x32, 0x37, 0x0a, 0x04, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x2f,
+	0x0a, 0x04, 0x53, 0x79, 0x6e, 0x63, 0x12, 0x12, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x2e, 0x53,
+	0x79, 0x6e, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x13, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x2e, 0x53, 0x79, 0x6e, 0x63, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42,
+	0x12, 0x5a, 0x10, 0x67, 0x6f, 0x70, 0x68, 0x6b, 0x65, 0x65, 0x70, 0x65, 0x72, 0x2f, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_proto_sync_proto_rawDescOnce sync.Once
+	file_proto_sync_proto_rawDescData = file_proto_sync_proto_rawDesc
+)
+
+func file_proto_sync_proto_rawDescGZIP() []byte {
+	file_proto_sync_proto_rawDescOnce.Do(func() {
+		file_proto_sync_proto_rawDescData = protoimpl.X.CompressGZIP(file_proto_sync_proto_rawDescData)
+	})
+	return file_proto_sync_proto_rawDescData
+}
+
+var file_proto_sync_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_proto_sync_proto_goTypes = []interface{}{
+	(*TombstoneMsg)(nil), // 0: proto.TombstoneMsg
+	(*SyncRequest)(nil),  // 1: proto.SyncRequest
+	(*SyncResponse)(nil), // 2: proto.SyncResponse
+	(*PairMsg)(nil),      // 3: proto.PairMsg
+	(*CardMsg)(nil),      // 4: proto.CardMsg
+	(*NoteMsg)(nil),      // 5: proto.NoteMsg
+	(*BinaryMsg)(nil),    // 6: proto.BinaryMsg
+	(*OTPMsg)(nil),       // 7: proto.OTPMsg
+}
+var file_proto_sync_proto_depIdxs = []int32{
+	3, // 0: proto.SyncResponse.pairs:type_name -> proto.PairMsg
+	4, // 1: proto.SyncResponse.cards:type_name -> proto.CardMsg
+	5, // 2: proto.SyncResponse.notes:type_name -> proto.NoteMsg
+	6, // 3: proto.SyncResponse.binaries:type_name -> proto.BinaryMsg
+	7, // 4: proto.SyncResponse.otps:type_name -> proto.OTPMsg
+	0, // 5: proto.SyncResponse.deleted:type_name -> proto.TombstoneMsg
+	1, // 6: proto.Sync.Sync:input_type -> proto.SyncRequest
+	2, // 7: proto.Sync.Sync:output_type -> proto.SyncResponse
+	7, // [7:8] is the sub-list for method output_type
+	6, // [6:7] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_proto_sync_proto_init() }
+func file_proto_sync_proto_init() {
+	if File_proto_sync_proto != nil {
+		return
+	}
+	file_proto_pair_proto_init()
+	file_proto_bank_proto_init()
+	file_proto_text_proto_init()
+	file_proto_binary_proto_init()
+	file_proto_otp_proto_init()
+	if !protoimpl.UnsafeEnabled {
+		file_proto_sync_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*TombstoneMsg); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_sync_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_proto_sync_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SyncResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_proto_sync_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_sync_proto_goTypes,
+		DependencyIndexes: file_proto_sync_proto_depIdxs,
+		MessageInfos:      file_proto_sync_proto_msgTypes,
+	}.Build()
+	File_proto_sync_proto = out.File
+	file_proto_sync_proto_rawDesc = nil
+	file_proto_sync_proto_goTypes = nil
+	file_proto_sync_proto_depIdxs = nil
+}